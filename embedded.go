@@ -15,6 +15,16 @@ var (
 	vulnerabilityReportsCRD []byte
 	//go:embed deploy/crd/clustervulnerabilityreports.crd.yaml
 	clusterVulnerabilityReportsCRD []byte
+	//go:embed deploy/crd/vulnerabilityexceptions.crd.yaml
+	vulnerabilityExceptionsCRD []byte
+	//go:embed deploy/crd/summaryhistories.crd.yaml
+	summaryHistoriesCRD []byte
+	//go:embed deploy/crd/namespacesecurityreports.crd.yaml
+	namespaceSecurityReportsCRD []byte
+	//go:embed deploy/crd/sbomreports.crd.yaml
+	sbomReportsCRD []byte
+	//go:embed deploy/crd/clustersbomreports.crd.yaml
+	clusterSbomReportsCRD []byte
 	//go:embed deploy/crd/configauditreports.crd.yaml
 	configAuditReportsCRD []byte
 	//go:embed deploy/crd/clusterconfigauditreports.crd.yaml
@@ -51,6 +61,26 @@ func GetClusterVulnerabilityReportsCRD() (apiextensionsv1.CustomResourceDefiniti
 	return getCRDFromBytes(clusterVulnerabilityReportsCRD)
 }
 
+func GetVulnerabilityExceptionsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(vulnerabilityExceptionsCRD)
+}
+
+func GetSummaryHistoriesCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(summaryHistoriesCRD)
+}
+
+func GetNamespaceSecurityReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(namespaceSecurityReportsCRD)
+}
+
+func GetSbomReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(sbomReportsCRD)
+}
+
+func GetClusterSbomReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(clusterSbomReportsCRD)
+}
+
 func GetConfigAuditReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
 	return getCRDFromBytes(configAuditReportsCRD)
 }
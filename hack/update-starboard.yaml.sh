@@ -6,6 +6,12 @@ CRD_DIR=$SCRIPT_ROOT/deploy/crd
 STATIC_DIR=$SCRIPT_ROOT/deploy/static
 
 cat $CRD_DIR/vulnerabilityreports.crd.yaml \
+  $CRD_DIR/clustervulnerabilityreports.crd.yaml \
+  $CRD_DIR/vulnerabilityexceptions.crd.yaml \
+  $CRD_DIR/summaryhistories.crd.yaml \
+  $CRD_DIR/namespacesecurityreports.crd.yaml \
+  $CRD_DIR/sbomreports.crd.yaml \
+  $CRD_DIR/clustersbomreports.crd.yaml \
   $CRD_DIR/configauditreports.crd.yaml \
   $CRD_DIR/clusterconfigauditreports.crd.yaml \
   $CRD_DIR/ciskubebenchreports.crd.yaml \
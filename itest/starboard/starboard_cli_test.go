@@ -86,6 +86,76 @@ var _ = Describe("Starboard CLI", func() {
 						"Scope": Equal(apiextensionsv1beta1.ClusterScoped),
 					}),
 				}),
+				"vulnerabilityexceptions.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Group":   Equal("aquasecurity.github.io"),
+						"Version": Equal("v1alpha1"),
+						"Names": Equal(apiextensionsv1beta1.CustomResourceDefinitionNames{
+							Plural:     "vulnerabilityexceptions",
+							Singular:   "vulnerabilityexception",
+							ShortNames: []string{"vulnexception", "vulnexceptions"},
+							Kind:       "VulnerabilityException",
+							ListKind:   "VulnerabilityExceptionList",
+						}),
+						"Scope": Equal(apiextensionsv1beta1.ClusterScoped),
+					}),
+				}),
+				"summaryhistories.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Group":   Equal("aquasecurity.github.io"),
+						"Version": Equal("v1alpha1"),
+						"Names": Equal(apiextensionsv1beta1.CustomResourceDefinitionNames{
+							Plural:     "summaryhistories",
+							Singular:   "summaryhistory",
+							ShortNames: []string{"sumhistory", "sumhistories"},
+							Kind:       "SummaryHistory",
+							ListKind:   "SummaryHistoryList",
+						}),
+						"Scope": Equal(apiextensionsv1beta1.NamespaceScoped),
+					}),
+				}),
+				"namespacesecurityreports.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Group":   Equal("aquasecurity.github.io"),
+						"Version": Equal("v1alpha1"),
+						"Names": Equal(apiextensionsv1beta1.CustomResourceDefinitionNames{
+							Plural:     "namespacesecurityreports",
+							Singular:   "namespacesecurityreport",
+							ShortNames: []string{"nsreport", "nsreports"},
+							Kind:       "NamespaceSecurityReport",
+							ListKind:   "NamespaceSecurityReportList",
+						}),
+						"Scope": Equal(apiextensionsv1beta1.NamespaceScoped),
+					}),
+				}),
+				"sbomreports.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Group":   Equal("aquasecurity.github.io"),
+						"Version": Equal("v1alpha1"),
+						"Names": Equal(apiextensionsv1beta1.CustomResourceDefinitionNames{
+							Plural:     "sbomreports",
+							Singular:   "sbomreport",
+							ShortNames: []string{"sbom", "sboms"},
+							Kind:       "SbomReport",
+							ListKind:   "SbomReportList",
+						}),
+						"Scope": Equal(apiextensionsv1beta1.NamespaceScoped),
+					}),
+				}),
+				"clustersbomreports.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Group":   Equal("aquasecurity.github.io"),
+						"Version": Equal("v1alpha1"),
+						"Names": Equal(apiextensionsv1beta1.CustomResourceDefinitionNames{
+							Plural:     "clustersbomreports",
+							Singular:   "clustersbomreport",
+							ShortNames: []string{"clustersbom", "clustersboms"},
+							Kind:       "ClusterSbomReport",
+							ListKind:   "ClusterSbomReportList",
+						}),
+						"Scope": Equal(apiextensionsv1beta1.ClusterScoped),
+					}),
+				}),
 				"clustercompliancereports.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
 					"Spec": MatchFields(IgnoreExtras, Fields{
 						"Group":   Equal("aquasecurity.github.io"),
@@ -0,0 +1,3 @@
+// Package admission provides a validating admission webhook, which denies
+// Kubernetes workloads based on existing security reports.
+package admission
@@ -0,0 +1,158 @@
+package admission
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// Mode determines what happens to a workload that violates a Policy.
+type Mode string
+
+const (
+	// ModeEnforce denies workloads that violate the policy.
+	ModeEnforce Mode = "enforce"
+	// ModeWarn admits workloads that violate the policy, but returns
+	// violations as warnings to the client.
+	ModeWarn Mode = "warn"
+	// ModeDisabled admits all workloads without evaluating the policy.
+	ModeDisabled Mode = "disabled"
+)
+
+// Policy defines conditions under which a workload is not admitted.
+type Policy struct {
+	// Mode determines whether violations are denied or reported as warnings.
+	Mode Mode
+
+	// MaxCriticalVulnerabilities is the maximum number of critical
+	// vulnerabilities in a container image. Nil means no limit.
+	MaxCriticalVulnerabilities *int
+
+	// MaxHighVulnerabilities is the maximum number of high vulnerabilities in
+	// a container image. Nil means no limit.
+	MaxHighVulnerabilities *int
+
+	// DeniedChecks are IDs of configuration checks, e.g. KSV017, that must not
+	// fail.
+	DeniedChecks []string
+
+	// TagFallback allows looking up the most recent VulnerabilityReport of an
+	// image tag if the tag cannot be resolved to a repo digest. Otherwise,
+	// vulnerabilities of such an image cannot be verified, which violates
+	// the policy.
+	TagFallback bool
+}
+
+// NewPolicy constructs a cluster-wide Policy from the given Starboard config.
+func NewPolicy(config starboard.ConfigData) (Policy, error) {
+	policy := Policy{
+		Mode: ModeEnforce,
+	}
+	if value, ok := config[starboard.KeyAdmissionTagFallback]; ok {
+		tagFallback, err := strconv.ParseBool(value)
+		if err != nil {
+			return Policy{}, fmt.Errorf("parsing %s: invalid boolean %q", starboard.KeyAdmissionTagFallback, value)
+		}
+		policy.TagFallback = tagFallback
+	}
+	return policy.override(map[string]string(config),
+		starboard.KeyAdmissionMode,
+		starboard.KeyAdmissionMaxCriticalVulns,
+		starboard.KeyAdmissionMaxHighVulns,
+		starboard.KeyAdmissionDeniedChecks)
+}
+
+// ForNamespace returns a copy of this policy overridden with the
+// starboard.AnnotationAdmissionMode,
+// starboard.AnnotationAdmissionMaxCriticalVulnerabilities,
+// starboard.AnnotationAdmissionMaxHighVulnerabilities, or
+// starboard.AnnotationAdmissionDeniedChecks annotations of a namespace.
+// Setting a threshold annotation to a blank string removes the limit.
+func (p Policy) ForNamespace(annotations map[string]string) (Policy, error) {
+	return p.override(annotations,
+		starboard.AnnotationAdmissionMode,
+		starboard.AnnotationAdmissionMaxCriticalVulnerabilities,
+		starboard.AnnotationAdmissionMaxHighVulnerabilities,
+		starboard.AnnotationAdmissionDeniedChecks)
+}
+
+func (p Policy) override(values map[string]string, modeKey, maxCriticalKey, maxHighKey, deniedChecksKey string) (Policy, error) {
+	if value, ok := values[modeKey]; ok {
+		mode := Mode(strings.ToLower(value))
+		switch mode {
+		case ModeEnforce, ModeWarn, ModeDisabled:
+			p.Mode = mode
+		default:
+			return Policy{}, fmt.Errorf("parsing %s: invalid mode %q, allowed modes are: %s,%s,%s",
+				modeKey, value, ModeEnforce, ModeWarn, ModeDisabled)
+		}
+	}
+	var err error
+	if value, ok := values[maxCriticalKey]; ok {
+		p.MaxCriticalVulnerabilities, err = parseThreshold(maxCriticalKey, value)
+		if err != nil {
+			return Policy{}, err
+		}
+	}
+	if value, ok := values[maxHighKey]; ok {
+		p.MaxHighVulnerabilities, err = parseThreshold(maxHighKey, value)
+		if err != nil {
+			return Policy{}, err
+		}
+	}
+	if value, ok := values[deniedChecksKey]; ok {
+		p.DeniedChecks = nil
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				p.DeniedChecks = append(p.DeniedChecks, id)
+			}
+		}
+	}
+	return p, nil
+}
+
+func parseThreshold(key, value string) (*int, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	threshold, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || threshold < 0 {
+		return nil, fmt.Errorf("parsing %s: invalid threshold %q", key, value)
+	}
+	return &threshold, nil
+}
+
+// ChecksVulnerabilities returns true if this policy limits the number of
+// vulnerabilities in container images.
+func (p Policy) ChecksVulnerabilities() bool {
+	return p.MaxCriticalVulnerabilities != nil || p.MaxHighVulnerabilities != nil
+}
+
+// IsCheckDenied returns true if the configuration check with the given ID
+// must not fail.
+func (p Policy) IsCheckDenied(id string) bool {
+	for _, denied := range p.DeniedChecks {
+		if strings.EqualFold(denied, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// EvalVulnerabilities returns violations of this policy by the given
+// vulnerability summary of a container image.
+func (p Policy) EvalVulnerabilities(summary v1alpha1.VulnerabilitySummary) []string {
+	var violations []string
+	if p.MaxCriticalVulnerabilities != nil && summary.CriticalCount > *p.MaxCriticalVulnerabilities {
+		violations = append(violations, fmt.Sprintf("%d critical vulnerabilities exceed the limit of %d",
+			summary.CriticalCount, *p.MaxCriticalVulnerabilities))
+	}
+	if p.MaxHighVulnerabilities != nil && summary.HighCount > *p.MaxHighVulnerabilities {
+		violations = append(violations, fmt.Sprintf("%d high vulnerabilities exceed the limit of %d",
+			summary.HighCount, *p.MaxHighVulnerabilities))
+	}
+	return violations
+}
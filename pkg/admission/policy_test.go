@@ -0,0 +1,109 @@
+package admission_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/admission"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+)
+
+func TestNewPolicy(t *testing.T) {
+	t.Run("Should enforce policy without limits by default", func(t *testing.T) {
+		policy, err := admission.NewPolicy(starboard.GetDefaultConfig())
+		require.NoError(t, err)
+		assert.Equal(t, admission.Policy{Mode: admission.ModeEnforce}, policy)
+		assert.False(t, policy.ChecksVulnerabilities())
+	})
+
+	t.Run("Should parse policy from config", func(t *testing.T) {
+		policy, err := admission.NewPolicy(starboard.ConfigData{
+			"admission.mode":                        "Warn",
+			"admission.vulnerabilities.maxCritical": "0",
+			"admission.vulnerabilities.maxHigh":     "10",
+			"admission.configAudit.deniedChecks":    "KSV017, KSV001",
+			"admission.vulnerabilities.tagFallback": "true",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, admission.Policy{
+			Mode:                       admission.ModeWarn,
+			MaxCriticalVulnerabilities: pointer.IntPtr(0),
+			MaxHighVulnerabilities:     pointer.IntPtr(10),
+			DeniedChecks:               []string{"KSV017", "KSV001"},
+			TagFallback:                true,
+		}, policy)
+	})
+
+	t.Run("Should return error when mode is invalid", func(t *testing.T) {
+		_, err := admission.NewPolicy(starboard.ConfigData{
+			"admission.mode": "audit",
+		})
+		assert.EqualError(t, err, `parsing admission.mode: invalid mode "audit", allowed modes are: enforce,warn,disabled`)
+	})
+
+	t.Run("Should return error when threshold is invalid", func(t *testing.T) {
+		_, err := admission.NewPolicy(starboard.ConfigData{
+			"admission.vulnerabilities.maxCritical": "-1",
+		})
+		assert.EqualError(t, err, `parsing admission.vulnerabilities.maxCritical: invalid threshold "-1"`)
+	})
+
+	t.Run("Should return error when tag fallback is invalid", func(t *testing.T) {
+		_, err := admission.NewPolicy(starboard.ConfigData{
+			"admission.vulnerabilities.tagFallback": "maybe",
+		})
+		assert.EqualError(t, err, `parsing admission.vulnerabilities.tagFallback: invalid boolean "maybe"`)
+	})
+}
+
+func TestPolicy_ForNamespace(t *testing.T) {
+	clusterPolicy := admission.Policy{
+		Mode:                       admission.ModeEnforce,
+		MaxCriticalVulnerabilities: pointer.IntPtr(0),
+		DeniedChecks:               []string{"KSV017"},
+	}
+
+	t.Run("Should return cluster policy when namespace is not annotated", func(t *testing.T) {
+		policy, err := clusterPolicy.ForNamespace(map[string]string{"foo": "bar"})
+		require.NoError(t, err)
+		assert.Equal(t, clusterPolicy, policy)
+	})
+
+	t.Run("Should override cluster policy with annotations", func(t *testing.T) {
+		policy, err := clusterPolicy.ForNamespace(map[string]string{
+			starboard.AnnotationAdmissionMode:                       "warn",
+			starboard.AnnotationAdmissionMaxCriticalVulnerabilities: "",
+			starboard.AnnotationAdmissionMaxHighVulnerabilities:     "5",
+			starboard.AnnotationAdmissionDeniedChecks:               "",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, admission.Policy{
+			Mode:                   admission.ModeWarn,
+			MaxHighVulnerabilities: pointer.IntPtr(5),
+		}, policy)
+		assert.Equal(t, pointer.IntPtr(0), clusterPolicy.MaxCriticalVulnerabilities, "cluster policy must not be modified")
+	})
+
+	t.Run("Should return error when annotation is invalid", func(t *testing.T) {
+		_, err := clusterPolicy.ForNamespace(map[string]string{
+			starboard.AnnotationAdmissionMaxHighVulnerabilities: "many",
+		})
+		assert.EqualError(t, err, `parsing starboard.aquasecurity.github.io/admission-max-high-vulnerabilities: invalid threshold "many"`)
+	})
+}
+
+func TestPolicy_EvalVulnerabilities(t *testing.T) {
+	policy := admission.Policy{
+		MaxCriticalVulnerabilities: pointer.IntPtr(0),
+		MaxHighVulnerabilities:     pointer.IntPtr(2),
+	}
+
+	assert.Empty(t, policy.EvalVulnerabilities(v1alpha1.VulnerabilitySummary{HighCount: 2, MediumCount: 10}))
+	assert.Equal(t, []string{
+		"1 critical vulnerabilities exceed the limit of 0",
+		"3 high vulnerabilities exceed the limit of 2",
+	}, policy.EvalVulnerabilities(v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 3}))
+}
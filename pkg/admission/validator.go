@@ -0,0 +1,547 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/policy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ValidateWorkloadsPath is the path at which the webhook server serves
+// admission reviews of Kubernetes workloads.
+const ValidateWorkloadsPath = "/validate-workloads"
+
+// Validator validates Kubernetes workloads against the admission Policy of
+// their namespace. Container images are checked against existing
+// VulnerabilityReports and ClusterVulnerabilityReports, whereas the workload
+// itself is evaluated with the built-in configuration audit policies.
+type Validator struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	vulnerabilityreport.Reader
+
+	// Scanner is the name of the primary vulnerability scanner, whose
+	// ClusterVulnerabilityReports are looked up by repo digest.
+	Scanner string
+
+	// ExceptionApplier applies VulnerabilityExceptions to reports cached by
+	// image digest, which are stored without exceptions applied.
+	ExceptionApplier *vulnerabilityreport.ExceptionApplier
+
+	// Policy is the cluster-wide admission policy, which can be overridden
+	// with annotations of a namespace.
+	Policy Policy
+
+	// PolicyLoader, if set, loads the bundle of config audit policies, which
+	// are evaluated in addition to policies of the starboard-policies-config
+	// ConfigMap.
+	PolicyLoader *policy.Loader
+
+	// PolicyCache, if set, holds queries prepared for config audit policies,
+	// so that policies are compiled only when they change.
+	PolicyCache *policy.Cache
+
+	// Inventory, if set, exposes cached objects to config audit policies as
+	// data.kubernetes.<kind>.
+	Inventory *kube.Inventory
+
+	decoder *admission.Decoder
+}
+
+func (v *Validator) SetupWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(ValidateWorkloadsPath, &webhook.Admission{Handler: v})
+	return nil
+}
+
+// InjectDecoder injects the decoder used to decode objects under review.
+func (v *Validator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
+
+// Handle admits or denies the workload under review.
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := v.Logger.WithValues("kind", req.Kind.Kind, "name", req.Name, "namespace", req.Namespace)
+
+	obj, err := v.decodeWorkload(req, req.Object)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if obj == nil {
+		return admission.Allowed("unsupported kind")
+	}
+	// Workloads created by controllers, such as Pods of a ReplicaSet, were
+	// already validated when their controller was admitted.
+	controlled, err := v.controlledByWorkload(ctx, obj)
+	if err != nil {
+		log.Error(err, "Getting controller")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if controlled {
+		return admission.Allowed("controlled by another workload")
+	}
+	if req.Operation == admissionv1.Update {
+		old, err := v.decodeWorkload(req, req.OldObject)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		changed, err := podSpecChanged(old, obj)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !changed {
+			return admission.Allowed("pod spec not changed")
+		}
+	}
+
+	nsPolicy, err := v.namespacePolicy(ctx, req.Namespace)
+	if err != nil {
+		log.Error(err, "Resolving admission policy")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if nsPolicy.Mode == ModeDisabled {
+		return admission.Allowed("admission policy disabled")
+	}
+
+	violations, err := v.evaluate(ctx, nsPolicy, obj)
+	if err != nil {
+		log.Error(err, "Evaluating admission policy")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(violations) == 0 {
+		return admission.Allowed("")
+	}
+	if nsPolicy.Mode == ModeWarn {
+		log.V(1).Info("Admitting workload violating admission policy", "violations", violations)
+		return admission.Allowed("").WithWarnings(violations...)
+	}
+	log.V(1).Info("Denying workload violating admission policy", "violations", violations)
+	return admission.Denied(strings.Join(violations, "; "))
+}
+
+// decodeWorkload decodes the given raw object into a workload of the kind
+// under review. It returns nil if the kind is not a workload.
+func (v *Validator) decodeWorkload(req admission.Request, raw runtime.RawExtension) (client.Object, error) {
+	obj := newWorkload(kube.Kind(req.Kind.Kind))
+	if obj == nil {
+		return nil, nil
+	}
+	err := v.decoder.DecodeRaw(raw, obj)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", req.Kind.Kind, err)
+	}
+	// Rego policies match resources by kind, which is not always set by the
+	// decoder.
+	obj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   req.Kind.Group,
+		Version: req.Kind.Version,
+		Kind:    req.Kind.Kind,
+	})
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(req.Namespace)
+	}
+	return obj, nil
+}
+
+// workloadGroups maps kinds of workloads validated by the webhook to their
+// API groups.
+var workloadGroups = map[kube.Kind]string{
+	kube.KindPod:                   corev1.GroupName,
+	kube.KindReplicationController: corev1.GroupName,
+	kube.KindDeployment:            appsv1.GroupName,
+	kube.KindReplicaSet:            appsv1.GroupName,
+	kube.KindStatefulSet:           appsv1.GroupName,
+	kube.KindDaemonSet:             appsv1.GroupName,
+	kube.KindCronJob:               batchv1.GroupName,
+	kube.KindJob:                   batchv1.GroupName,
+}
+
+// newWorkload returns an empty object of the given kind or nil if the kind is
+// not a workload validated by the webhook.
+func newWorkload(kind kube.Kind) client.Object {
+	switch kind {
+	case kube.KindPod:
+		return &corev1.Pod{}
+	case kube.KindDeployment:
+		return &appsv1.Deployment{}
+	case kube.KindReplicaSet:
+		return &appsv1.ReplicaSet{}
+	case kube.KindReplicationController:
+		return &corev1.ReplicationController{}
+	case kube.KindStatefulSet:
+		return &appsv1.StatefulSet{}
+	case kube.KindDaemonSet:
+		return &appsv1.DaemonSet{}
+	case kube.KindCronJob:
+		return &batchv1beta1.CronJob{}
+	case kube.KindJob:
+		return &batchv1.Job{}
+	}
+	return nil
+}
+
+// controlledByWorkload returns true if the controller of the given workload
+// exists and is a workload validated by the webhook. A controller reference
+// can be set by anyone who creates the workload, therefore it's not trusted
+// unless it refers to the UID of an existing workload, which was admitted.
+// Workloads controlled by objects of other kinds, e.g. custom resources, are
+// always evaluated.
+func (v *Validator) controlledByWorkload(ctx context.Context, obj client.Object) (bool, error) {
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil {
+		return false, nil
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false, nil
+	}
+	kind := kube.Kind(ref.Kind)
+	group, ok := workloadGroups[kind]
+	if !ok || kind == kube.KindPod || gv.Group != group {
+		return false, nil
+	}
+	controller := newWorkload(kind)
+	err = v.Client.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: ref.Name}, controller)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return controller.GetUID() == ref.UID, nil
+}
+
+func podSpecChanged(old, obj client.Object) (bool, error) {
+	oldHash, err := kube.ComputeSpecHash(old)
+	if err != nil {
+		return false, err
+	}
+	hash, err := kube.ComputeSpecHash(obj)
+	if err != nil {
+		return false, err
+	}
+	return oldHash != hash, nil
+}
+
+// namespacePolicy returns the cluster-wide policy overridden with annotations
+// of the given namespace.
+func (v *Validator) namespacePolicy(ctx context.Context, namespace string) (Policy, error) {
+	var ns corev1.Namespace
+	err := v.Client.Get(ctx, client.ObjectKey{Name: namespace}, &ns)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return v.Policy, nil
+		}
+		return Policy{}, fmt.Errorf("getting namespace from cache: %w", err)
+	}
+	return v.Policy.ForNamespace(ns.Annotations)
+}
+
+func (v *Validator) evaluate(ctx context.Context, p Policy, obj client.Object) ([]string, error) {
+	var violations []string
+	if p.ChecksVulnerabilities() {
+		vulnerabilityViolations, err := v.evaluateVulnerabilities(ctx, p, obj)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, vulnerabilityViolations...)
+	}
+	if len(p.DeniedChecks) > 0 {
+		checkViolations, err := v.evaluateChecks(ctx, p, obj)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, checkViolations...)
+	}
+	return violations, nil
+}
+
+// evaluateVulnerabilities returns violations of the given policy by container
+// images of the given workload. Images without VulnerabilityReports are
+// admitted, because they have not been scanned yet, unless they're referenced
+// by a tag that cannot be resolved to a repo digest and the policy does not
+// allow falling back to reports of the tag.
+func (v *Validator) evaluateVulnerabilities(ctx context.Context, p Policy, obj client.Object) ([]string, error) {
+	spec, err := kube.GetPodSpec(obj)
+	if err != nil {
+		return nil, err
+	}
+	images := kube.GetContainerImagesFromPodSpec(spec)
+
+	var reports []v1alpha1.VulnerabilityReport
+	var unresolved []string
+	for containerName, image := range images {
+		data, resolved, err := v.findReportData(ctx, obj.GetNamespace(), image, p.TagFallback)
+		if err != nil {
+			return nil, err
+		}
+		if !resolved {
+			unresolved = append(unresolved, containerName)
+			continue
+		}
+		if data == nil {
+			continue
+		}
+		reports = append(reports, v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{starboard.LabelContainerName: containerName},
+			},
+			Report: *data,
+		})
+	}
+	if v.ExceptionApplier != nil && len(reports) > 0 {
+		_, err = v.ExceptionApplier.Apply(ctx, obj, reports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Labels[starboard.LabelContainerName] < reports[j].Labels[starboard.LabelContainerName]
+	})
+	sort.Strings(unresolved)
+	var violations []string
+	for _, containerName := range unresolved {
+		violations = append(violations, fmt.Sprintf("container %s (%s): image tag cannot be resolved to a digest to verify vulnerabilities",
+			containerName, images[containerName]))
+	}
+	for _, report := range reports {
+		containerName := report.Labels[starboard.LabelContainerName]
+		for _, violation := range p.EvalVulnerabilities(report.Report.Summary) {
+			violations = append(violations, fmt.Sprintf("container %s (%s): %s", containerName, images[containerName], violation))
+		}
+	}
+	return violations, nil
+}
+
+// findReportData returns vulnerability report data of the given container
+// image or nil if the image has not been scanned. The image is looked up by
+// repo digests, which are resolved from the status of Pods running the image,
+// in ClusterVulnerabilityReports and then in VulnerabilityReports of the
+// given namespace. If the image is reported with several digests, e.g. while
+// a tag is being rolled out, the most vulnerable one is returned.
+//
+// If the image is referenced by a tag that cannot be resolved to a digest,
+// the most recent VulnerabilityReport of the same tag is returned if
+// tagFallback is true. Otherwise, the returned flag is false, because the
+// report of a mutable tag may describe another image.
+func (v *Validator) findReportData(ctx context.Context, namespace, image string, tagFallback bool) (*v1alpha1.VulnerabilityReportData, bool, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, true, nil
+	}
+
+	digests, err := v.repoDigests(ctx, namespace, ref)
+	if err != nil {
+		return nil, false, err
+	}
+	var worst *v1alpha1.VulnerabilityReportData
+	for _, digest := range digests {
+		report, err := v.Reader.FindClusterReportByImageDigest(ctx, v.Scanner, digest)
+		if err != nil {
+			return nil, false, fmt.Errorf("getting cluster vulnerability report: %w", err)
+		}
+		if report != nil && (worst == nil || moreVulnerable(report.Report.Summary, worst.Summary)) {
+			worst = report.Report.DeepCopy()
+		}
+	}
+	if worst != nil {
+		return worst, true, nil
+	}
+	if len(digests) == 0 && !tagFallback {
+		return nil, false, nil
+	}
+
+	var list v1alpha1.VulnerabilityReportList
+	err = v.Client.List(ctx, &list, client.InNamespace(namespace))
+	if err != nil {
+		return nil, false, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	var latest *v1alpha1.VulnerabilityReport
+	for i := range list.Items {
+		report := &list.Items[i]
+		if _, ok := report.Labels[starboard.LabelVulnerabilityReportAdditional]; ok {
+			continue
+		}
+		if len(digests) > 0 {
+			if matchesDigest(digests, report.Report) && (worst == nil || moreVulnerable(report.Report.Summary, worst.Summary)) {
+				worst = &report.Report
+			}
+			continue
+		}
+		if !matchesArtifact(ref, report.Report) {
+			continue
+		}
+		if latest == nil || latest.Report.UpdateTimestamp.Before(&report.Report.UpdateTimestamp) {
+			latest = report
+		}
+	}
+	if latest != nil {
+		worst = &latest.Report
+	}
+	if worst == nil {
+		return nil, true, nil
+	}
+	return worst.DeepCopy(), true, nil
+}
+
+// repoDigests returns repo digests of the given image reported by the
+// container runtime for containers, init containers, and ephemeral containers
+// of Pods in the given namespace.
+func (v *Validator) repoDigests(ctx context.Context, namespace string, ref name.Reference) ([]string, error) {
+	var digests []string
+	if _, ok := ref.(name.Digest); ok {
+		digests = append(digests, ref.String())
+	}
+
+	var pods corev1.PodList
+	err := v.Client.List(ctx, &pods, client.InNamespace(namespace))
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+	seen := make(map[string]bool)
+	for _, pod := range pods.Items {
+		var statuses []corev1.ContainerStatus
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		statuses = append(statuses, pod.Status.EphemeralContainerStatuses...)
+		for _, status := range statuses {
+			statusRef, err := name.ParseReference(status.Image)
+			if err != nil || statusRef.Name() != ref.Name() {
+				continue
+			}
+			digest, ok := kube.GetRepoDigestFromImageID(status.ImageID)
+			if !ok || seen[digest] {
+				continue
+			}
+			seen[digest] = true
+			digests = append(digests, digest)
+		}
+	}
+	return digests, nil
+}
+
+// moreVulnerable returns true if the given summary has more vulnerabilities
+// than the other summary, comparing counts from the critical to the unknown
+// severity.
+func moreVulnerable(summary, other v1alpha1.VulnerabilitySummary) bool {
+	counts := []int{summary.CriticalCount, summary.HighCount, summary.MediumCount, summary.LowCount, summary.UnknownCount}
+	otherCounts := []int{other.CriticalCount, other.HighCount, other.MediumCount, other.LowCount, other.UnknownCount}
+	for i := range counts {
+		if counts[i] != otherCounts[i] {
+			return counts[i] > otherCounts[i]
+		}
+	}
+	return false
+}
+
+// matchesDigest returns true if the given report data describes the image
+// with any of the specified repo digests.
+func matchesDigest(digests []string, data v1alpha1.VulnerabilityReportData) bool {
+	for _, digest := range digests {
+		ref, err := name.NewDigest(digest)
+		if err == nil && matchesArtifact(ref, data) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesArtifact returns true if the given report data describes the
+// specified image reference.
+func matchesArtifact(ref name.Reference, data v1alpha1.VulnerabilityReportData) bool {
+	repository, err := name.NewRepository(data.Registry.Server + "/" + data.Artifact.Repository)
+	if err != nil || repository.Name() != ref.Context().Name() {
+		return false
+	}
+	switch t := ref.(type) {
+	case name.Tag:
+		return data.Artifact.Tag == t.TagStr()
+	case name.Digest:
+		return data.Artifact.Digest == t.DigestStr()
+	}
+	return false
+}
+
+// evaluateChecks returns violations of the given policy by configuration
+// checks of the given workload, which are evaluated with policies stored in
+// the starboard.PoliciesConfigMapName ConfigMap.
+func (v *Validator) evaluateChecks(ctx context.Context, p Policy, obj client.Object) ([]string, error) {
+	policies, err := v.policies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applicable, _, err := policies.Applicable(obj)
+	if err != nil {
+		return nil, err
+	}
+	if !applicable {
+		return nil, nil
+	}
+	results, err := policies.Eval(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Metadata.ID < results[j].Metadata.ID
+	})
+	var violations []string
+	for _, result := range results {
+		if result.Success || !p.IsCheckDenied(result.Metadata.ID) {
+			continue
+		}
+		violations = append(violations, fmt.Sprintf("%s (%s) failed: %s",
+			result.Metadata.ID, result.Metadata.Title, strings.Join(result.Messages, ", ")))
+	}
+	return violations, nil
+}
+
+func (v *Validator) policies(ctx context.Context) (*policy.Policies, error) {
+	cm := &corev1.ConfigMap{}
+
+	err := v.Client.Get(ctx, client.ObjectKey{
+		Namespace: v.Config.Namespace,
+		Name:      starboard.PoliciesConfigMapName,
+	}, cm)
+	if err != nil {
+		if v.PolicyLoader == nil || !k8sapierror.IsNotFound(err) {
+			return nil, fmt.Errorf("failed getting policies from configmap: %s/%s: %w", v.Config.Namespace, starboard.PoliciesConfigMapName, err)
+		}
+	}
+	policies := policy.NewPolicies(cm.Data)
+	if v.PolicyLoader != nil {
+		policies, err = v.PolicyLoader.Policies(cm.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	policies.WithCache(v.PolicyCache)
+	if v.Inventory != nil {
+		policies.WithInventory(v.Inventory)
+	}
+	return policies, nil
+}
@@ -0,0 +1,375 @@
+package admission_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/admission"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrladmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const privilegedPolicy = `package appshield.kubernetes.KSV017
+
+__rego_metadata__ := {
+	"id": "KSV017",
+	"title": "Privileged container",
+	"description": "Privileged containers share namespaces with the host system and do not offer any security",
+	"severity": "HIGH",
+	"type": "Kubernetes Security Check"
+}
+
+deny[res] {
+	container := input.spec.template.spec.containers[_]
+	container.securityContext.privileged
+
+	msg := sprintf("Container '%s' should set 'securityContext.privileged' to false", [container.name])
+
+	res := {
+		"id": __rego_metadata__.id,
+		"title": __rego_metadata__.title,
+		"severity": __rego_metadata__.severity,
+		"type": __rego_metadata__.type,
+		"msg": msg
+	}
+}
+`
+
+func newDeployment(image string, privileged bool) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "checkout",
+			Namespace: "payments",
+			Labels:    map[string]string{"app": "checkout"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: image,
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: pointer.BoolPtr(privileged),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newRequest(t *testing.T, operation admissionv1.Operation, obj, old client.Object) ctrladmission.Request {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	require.NoError(t, err)
+	req := ctrladmission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			Kind: metav1.GroupVersionKind{
+				Group:   obj.GetObjectKind().GroupVersionKind().Group,
+				Version: obj.GetObjectKind().GroupVersionKind().Version,
+				Kind:    obj.GetObjectKind().GroupVersionKind().Kind,
+			},
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+	if old != nil {
+		raw, err = json.Marshal(old)
+		require.NoError(t, err)
+		req.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return req
+}
+
+func newValidator(t *testing.T, policy admission.Policy, objects ...client.Object) *admission.Validator {
+	t.Helper()
+	objects = append(objects, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      starboard.PoliciesConfigMapName,
+			Namespace: "starboard-system",
+		},
+		Data: map[string]string{
+			"policy.KSV017.kinds": "Workload",
+			"policy.KSV017.rego":  privilegedPolicy,
+		},
+	})
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+	validator := &admission.Validator{
+		Logger:  logr.Discard(),
+		Config:  etc.Config{Namespace: "starboard-system"},
+		Client:  client,
+		Reader:  vulnerabilityreport.NewReadWriter(client),
+		Scanner: "Trivy",
+		ExceptionApplier: &vulnerabilityreport.ExceptionApplier{
+			Client: client,
+			Clock:  ext.NewFixedClock(time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)),
+		},
+		Policy: policy,
+	}
+	decoder, err := ctrladmission.NewDecoder(starboard.NewScheme())
+	require.NoError(t, err)
+	require.NoError(t, validator.InjectDecoder(decoder))
+	return validator
+}
+
+func newVulnerabilityReport(name, tag string, criticalCount int) *v1alpha1.VulnerabilityReport {
+	return &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "payments",
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)),
+			Registry:        v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact:        v1alpha1.Artifact{Repository: "library/checkout", Tag: tag},
+			Summary:         v1alpha1.VulnerabilitySummary{CriticalCount: criticalCount},
+		},
+	}
+}
+
+func TestValidator_Handle(t *testing.T) {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+	}
+	clusterPolicy := admission.Policy{
+		Mode:                       admission.ModeEnforce,
+		MaxCriticalVulnerabilities: pointer.IntPtr(0),
+		DeniedChecks:               []string{"KSV017"},
+		TagFallback:                true,
+	}
+
+	t.Run("Should deny workload running image with critical vulnerabilities", func(t *testing.T) {
+		validator := newValidator(t, clusterPolicy, namespace,
+			newVulnerabilityReport("replicaset-checkout-1-app", "1.0", 2),
+			newVulnerabilityReport("replicaset-checkout-2-app", "2.0", 0))
+
+		response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newDeployment("checkout:1.0", false), nil))
+		assert.False(t, response.Allowed)
+		assert.Equal(t, "container app (checkout:1.0): 2 critical vulnerabilities exceed the limit of 0", string(response.Result.Reason))
+
+		response = validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newDeployment("checkout:2.0", false), nil))
+		assert.True(t, response.Allowed)
+	})
+
+	t.Run("Should allow workload running image that has not been scanned", func(t *testing.T) {
+		validator := newValidator(t, clusterPolicy, namespace)
+
+		response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newDeployment("checkout:3.0", false), nil))
+		assert.True(t, response.Allowed)
+	})
+
+	t.Run("Should deny workload failing denied check", func(t *testing.T) {
+		validator := newValidator(t, clusterPolicy, namespace)
+
+		response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newDeployment("checkout:3.0", true), nil))
+		assert.False(t, response.Allowed)
+		assert.Equal(t, "KSV017 (Privileged container) failed: Container 'app' should set 'securityContext.privileged' to false", string(response.Result.Reason))
+	})
+
+	t.Run("Should warn about violations in namespace with warn mode", func(t *testing.T) {
+		validator := newValidator(t, clusterPolicy, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "payments",
+				Annotations: map[string]string{starboard.AnnotationAdmissionMode: "warn"},
+			},
+		}, newVulnerabilityReport("replicaset-checkout-1-app", "1.0", 1))
+
+		response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newDeployment("checkout:1.0", true), nil))
+		assert.True(t, response.Allowed)
+		assert.Equal(t, []string{
+			"container app (checkout:1.0): 1 critical vulnerabilities exceed the limit of 0",
+			"KSV017 (Privileged container) failed: Container 'app' should set 'securityContext.privileged' to false",
+		}, response.Warnings)
+	})
+
+	t.Run("Should allow workload in namespace with disabled policy", func(t *testing.T) {
+		validator := newValidator(t, clusterPolicy, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "payments",
+				Annotations: map[string]string{starboard.AnnotationAdmissionMode: "disabled"},
+			},
+		})
+
+		response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newDeployment("checkout:1.0", true), nil))
+		assert.True(t, response.Allowed)
+		assert.Empty(t, response.Warnings)
+	})
+
+	t.Run("Should look up cached report by repo digest of running pod and apply exceptions", func(t *testing.T) {
+		digest := "index.docker.io/library/checkout@sha256:2e6a3a5c2d6b2f3d3b2c7d4f0d9a5c6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c"
+		data := v1alpha1.VulnerabilityReportData{
+			Registry: v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact: v1alpha1.Artifact{Repository: "library/checkout", Tag: "1.0"},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-44228", Resource: "log4j-core", Severity: v1alpha1.SeverityCritical},
+			},
+		}
+		data.Summary = v1alpha1.VulnerabilitySummaryFromVulnerabilities(data.Vulnerabilities)
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout-6799fc88d8-2ql6w", Namespace: "payments"},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", Image: "docker.io/library/checkout:1.0", ImageID: "docker-pullable://" + digest},
+				},
+			},
+		}
+		report, err := vulnerabilityreport.NewClusterReportBuilder().
+			Scanner("Trivy").
+			ImageDigest(digest).
+			Data(data).
+			Get()
+		require.NoError(t, err)
+		clusterReport := &report
+
+		validator := newValidator(t, clusterPolicy, namespace, pod, clusterReport)
+		response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newDeployment("checkout:1.0", false), nil))
+		assert.False(t, response.Allowed)
+		assert.Equal(t, "container app (checkout:1.0): 1 critical vulnerabilities exceed the limit of 0", string(response.Result.Reason))
+
+		validator = newValidator(t, clusterPolicy, namespace, pod, clusterReport, &v1alpha1.VulnerabilityException{
+			ObjectMeta: metav1.ObjectMeta{Name: "log4shell"},
+			Spec: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID: "CVE-2021-44228",
+				Selector:        &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+			},
+		})
+		response = validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newDeployment("checkout:1.0", false), nil))
+		assert.True(t, response.Allowed)
+	})
+
+	t.Run("Should deny image tag that cannot be resolved to a digest without tag fallback", func(t *testing.T) {
+		policy := clusterPolicy
+		policy.TagFallback = false
+		validator := newValidator(t, policy, namespace,
+			newVulnerabilityReport("replicaset-checkout-1-app", "1.0", 0))
+
+		response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newDeployment("checkout:1.0", false), nil))
+		assert.False(t, response.Allowed)
+		assert.Equal(t, "container app (checkout:1.0): image tag cannot be resolved to a digest to verify vulnerabilities", string(response.Result.Reason))
+	})
+
+	t.Run("Should evaluate the most vulnerable repo digest of image", func(t *testing.T) {
+		policy := clusterPolicy
+		policy.TagFallback = false
+		newPod := func(name string, status corev1.PodStatus) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments"},
+				Status:     status,
+			}
+		}
+		newClusterReport := func(digest string, criticalCount int) client.Object {
+			report, err := vulnerabilityreport.NewClusterReportBuilder().
+				Scanner("Trivy").
+				ImageDigest(digest).
+				Data(v1alpha1.VulnerabilityReportData{
+					Registry: v1alpha1.Registry{Server: "index.docker.io"},
+					Artifact: v1alpha1.Artifact{Repository: "library/checkout", Tag: "1.0"},
+					Summary:  v1alpha1.VulnerabilitySummary{CriticalCount: criticalCount},
+				}).
+				Get()
+			require.NoError(t, err)
+			return &report
+		}
+		patched := "index.docker.io/library/checkout@sha256:1e6a3a5c2d6b2f3d3b2c7d4f0d9a5c6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c"
+		vulnerable := "index.docker.io/library/checkout@sha256:3e6a3a5c2d6b2f3d3b2c7d4f0d9a5c6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c"
+
+		validator := newValidator(t, policy, namespace,
+			newPod("checkout-6799fc88d8-2ql6w", corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", Image: "docker.io/library/checkout:1.0", ImageID: "docker-pullable://" + patched},
+				},
+			}),
+			newPod("migrate-5c8f7d9b6c-x7k2p", corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{Name: "init", Image: "docker.io/library/checkout:1.0", ImageID: "docker-pullable://" + vulnerable},
+				},
+			}),
+			newClusterReport(patched, 0),
+			newClusterReport(vulnerable, 3))
+
+		response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newDeployment("checkout:1.0", false), nil))
+		assert.False(t, response.Allowed)
+		assert.Equal(t, "container app (checkout:1.0): 3 critical vulnerabilities exceed the limit of 0", string(response.Result.Reason))
+	})
+
+	t.Run("Should allow update that does not change pod spec", func(t *testing.T) {
+		validator := newValidator(t, clusterPolicy, namespace)
+
+		old := newDeployment("checkout:1.0", true)
+		updated := newDeployment("checkout:1.0", true)
+		updated.Labels["tier"] = "backend"
+
+		response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Update, updated, old))
+		assert.True(t, response.Allowed)
+	})
+
+	newControlledPod := func(ref metav1.OwnerReference) *corev1.Pod {
+		ref.Controller = pointer.BoolPtr(true)
+		return &corev1.Pod{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "checkout-6799fc88d8-2ql6w",
+				Namespace:       "payments",
+				OwnerReferences: []metav1.OwnerReference{ref},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "app", Image: "checkout:1.0", SecurityContext: &corev1.SecurityContext{Privileged: pointer.BoolPtr(true)}},
+				},
+			},
+		}
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout-6799fc88d8", Namespace: "payments", UID: "1"},
+	}
+
+	t.Run("Should allow pod controlled by another workload", func(t *testing.T) {
+		validator := newValidator(t, clusterPolicy, namespace, replicaSet,
+			newVulnerabilityReport("replicaset-checkout-1-app", "1.0", 2))
+
+		pod := newControlledPod(metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "checkout-6799fc88d8", UID: "1"})
+		response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, pod, nil))
+		assert.True(t, response.Allowed)
+	})
+
+	t.Run("Should evaluate pod with untrusted controller reference", func(t *testing.T) {
+		validator := newValidator(t, clusterPolicy, namespace, replicaSet,
+			newVulnerabilityReport("replicaset-checkout-1-app", "1.0", 2))
+
+		for _, ref := range []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "checkout-5c8f7d9b6c", UID: "2"},
+			{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "checkout-6799fc88d8", UID: "2"},
+			{APIVersion: "example.com/v1", Kind: "ReplicaSet", Name: "checkout-6799fc88d8", UID: "1"},
+			{APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout", Name: "checkout", UID: "1"},
+		} {
+			response := validator.Handle(context.TODO(), newRequest(t, admissionv1.Create, newControlledPod(ref), nil))
+			assert.False(t, response.Allowed, "%s %s/%s", ref.APIVersion, ref.Kind, ref.Name)
+			assert.Equal(t, "container app (checkout:1.0): 2 critical vulnerabilities exceed the limit of 0", string(response.Result.Reason))
+		}
+	})
+}
@@ -7,6 +7,10 @@ import (
 
 const (
 	TTLReportAnnotation = "starboard.aquasecurity.github.io/report-ttl"
+
+	// ClusterVulnerabilityReportNameAnnotation is set on a VulnerabilityReport
+	// copied from the ClusterVulnerabilityReport with the given name.
+	ClusterVulnerabilityReportNameAnnotation = "starboard.aquasecurity.github.io/cluster-vulnerability-report-name"
 )
 
 // Severity level of a vulnerability or a configuration audit check.
@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NamespaceSecurityReportsCRName = "namespacesecurityreports.aquasecurity.github.io"
+	NamespaceSecurityReportKind    = "NamespaceSecurityReport"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceSecurityReport summarizes VulnerabilityReports and
+// ConfigAuditReports of all workloads in a namespace.
+type NamespaceSecurityReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Report NamespaceSecurityReportData `json:"report"`
+}
+
+// NamespaceSecurityReportData is the aggregated security posture of a
+// namespace.
+type NamespaceSecurityReportData struct {
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// VulnerabilitySummary is the sum of summaries of all VulnerabilityReports
+	// in the namespace.
+	VulnerabilitySummary VulnerabilitySummary `json:"vulnerabilitySummary"`
+
+	// ConfigAuditSummary is the sum of summaries of all ConfigAuditReports in
+	// the namespace.
+	ConfigAuditSummary ConfigAuditSummary `json:"configAuditSummary"`
+
+	// TopVulnerableImages are container images with the highest number of
+	// vulnerabilities ordered by severity.
+	TopVulnerableImages []VulnerableImage `json:"topVulnerableImages"`
+
+	// TopFailedChecks are configuration checks that failed for the highest
+	// number of workloads.
+	TopFailedChecks []FailedCheck `json:"topFailedChecks"`
+}
+
+// VulnerableImage is a container image of a workload with the summary of its
+// VulnerabilityReport.
+type VulnerableImage struct {
+	Registry Registry             `json:"registry"`
+	Artifact Artifact             `json:"artifact"`
+	Summary  VulnerabilitySummary `json:"summary"`
+}
+
+// FailedCheck is a configuration check with the number of workloads for which
+// it failed.
+type FailedCheck struct {
+	ID                string   `json:"checkID"`
+	Title             string   `json:"title,omitempty"`
+	Severity          Severity `json:"severity"`
+	Category          string   `json:"category,omitempty"`
+	AffectedWorkloads int      `json:"affectedWorkloads"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceSecurityReportList is a list of NamespaceSecurityReport resources.
+type NamespaceSecurityReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NamespaceSecurityReport `json:"items"`
+}
@@ -34,6 +34,16 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VulnerabilityReportList{},
 		&ClusterVulnerabilityReport{},
 		&ClusterVulnerabilityReportList{},
+		&VulnerabilityException{},
+		&VulnerabilityExceptionList{},
+		&SummaryHistory{},
+		&SummaryHistoryList{},
+		&NamespaceSecurityReport{},
+		&NamespaceSecurityReportList{},
+		&SbomReport{},
+		&SbomReportList{},
+		&ClusterSbomReport{},
+		&ClusterSbomReportList{},
 		&CISKubeBenchReport{},
 		&CISKubeBenchReportList{},
 		&KubeHunterReport{},
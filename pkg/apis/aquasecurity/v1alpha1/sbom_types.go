@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SbomReportsCRName = "sbomreports.aquasecurity.github.io"
+	SbomReportKind    = "SbomReport"
+
+	ClusterSbomReportsCRName = "clustersbomreports.aquasecurity.github.io"
+	ClusterSbomReportKind    = "ClusterSbomReport"
+)
+
+// SbomSummary is a summary of Components found in an Artifact.
+type SbomSummary struct {
+	// ComponentsCount is the number of Components.
+	ComponentsCount int `json:"componentsCount"`
+}
+
+// Component is a software package, application, or library installed in an
+// Artifact.
+type Component struct {
+	// Name is the name of the Component.
+	Name string `json:"name"`
+
+	// Version is the installed version of the Component.
+	Version string `json:"version"`
+
+	// PackageURL is the package URL (purl) that identifies the Component
+	// regardless of the package manager it was installed with.
+	// @see https://github.com/package-url/purl-spec
+	PackageURL string `json:"purl,omitempty"`
+
+	// Licenses is a list of licenses declared by the Component.
+	Licenses []string `json:"licenses,omitempty"`
+
+	// Target is the operating system or the file, e.g. a lockfile, where the
+	// Component was found.
+	Target string `json:"target,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SbomReport is a specification for the SbomReport resource, which records
+// the software bill of materials (SBOM) of a container image.
+type SbomReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Report is the actual SBOM report data.
+	Report SbomReportData `json:"report"`
+}
+
+// SbomReportData is the spec for the inventory of Components of an Artifact.
+//
+// Unlike VulnerabilityReportData it lists all Components, including those
+// without any Vulnerability, and can be converted to standard SBOM formats
+// such as CycloneDX and SPDX.
+type SbomReportData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this report was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// Scanner is the scanner that generated this report.
+	Scanner Scanner `json:"scanner"`
+
+	// Registry is the registry the Artifact was pulled from.
+	Registry Registry `json:"registry"`
+
+	// Artifact is a container image inventoried in this report.
+	Artifact Artifact `json:"artifact"`
+
+	// Summary is a summary of Components found in the Artifact.
+	Summary SbomSummary `json:"summary"`
+
+	// Components is a list of operating system (OS) packages and application
+	// dependencies found in the Artifact.
+	Components []Component `json:"components"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SbomReportList is a list of SbomReport resources.
+type SbomReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SbomReport `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterSbomReport is a specification for the ClusterSbomReport resource.
+type ClusterSbomReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Report SbomReportData `json:"report"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterSbomReportList is a list of ClusterSbomReport resources.
+type ClusterSbomReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterSbomReport `json:"items"`
+}
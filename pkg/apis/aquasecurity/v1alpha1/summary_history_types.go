@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SummaryHistoriesCRName = "summaryhistories.aquasecurity.github.io"
+	SummaryHistoryKind     = "SummaryHistory"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SummaryHistory is a rolling series of severity counts recorded whenever
+// security reports of a workload, or of all workloads in a namespace, change.
+type SummaryHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Report SummaryHistoryData `json:"report"`
+}
+
+// SummaryHistoryData holds the recorded summaries.
+type SummaryHistoryData struct {
+	// ReportKind is the kind of security reports whose summaries are recorded,
+	// i.e. VulnerabilityReport or ConfigAuditReport.
+	ReportKind string `json:"reportKind"`
+
+	// Entries are sorted by timestamp, the oldest first.
+	Entries []SummaryHistoryEntry `json:"entries"`
+}
+
+// SummaryHistoryEntry is the sum of severity counts of security reports at
+// the given time.
+type SummaryHistoryEntry struct {
+	Timestamp     metav1.Time `json:"timestamp"`
+	CriticalCount int         `json:"criticalCount"`
+	HighCount     int         `json:"highCount"`
+	MediumCount   int         `json:"mediumCount"`
+	LowCount      int         `json:"lowCount"`
+	UnknownCount  int         `json:"unknownCount,omitempty"`
+}
+
+// SameCounts returns true if the given entry has the same severity counts as
+// this entry regardless of timestamps.
+func (e SummaryHistoryEntry) SameCounts(other SummaryHistoryEntry) bool {
+	return e.CriticalCount == other.CriticalCount &&
+		e.HighCount == other.HighCount &&
+		e.MediumCount == other.MediumCount &&
+		e.LowCount == other.LowCount &&
+		e.UnknownCount == other.UnknownCount
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SummaryHistoryList is a list of SummaryHistory resources.
+type SummaryHistoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SummaryHistory `json:"items"`
+}
@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	VulnerabilityExceptionsCRName = "vulnerabilityexceptions.aquasecurity.github.io"
+	VulnerabilityExceptionKind    = "VulnerabilityException"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VulnerabilityException accepts the risk of a vulnerability found in
+// container images of selected workloads. Matching vulnerabilities are marked
+// as suppressed in VulnerabilityReports until the exception expires.
+type VulnerabilityException struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VulnerabilityExceptionSpec `json:"spec"`
+}
+
+// VulnerabilityExceptionSpec selects vulnerabilities suppressed by a
+// VulnerabilityException. Empty selectors match everything.
+type VulnerabilityExceptionSpec struct {
+	// VulnerabilityID is the identifier of the accepted vulnerability, e.g.
+	// CVE-2021-44228.
+	VulnerabilityID string `json:"vulnerabilityID"`
+
+	// Resource is the name of the vulnerable package, application, or library.
+	Resource string `json:"resource,omitempty"`
+
+	// Image is a glob pattern matched against the image repository with the
+	// registry server, e.g. index.docker.io/library/nginx, optionally
+	// followed by a tag, e.g. index.docker.io/library/nginx:1.*.
+	Image string `json:"image,omitempty"`
+
+	// NamespaceSelector selects namespaces of workloads by labels.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Selector selects workloads by labels.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// ExpiresAt is the time after which the exception no longer applies.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Justification explains why the risk is accepted.
+	Justification string `json:"justification"`
+}
+
+// IsExpired returns true if this exception expired at the given time.
+func (e VulnerabilityException) IsExpired(now time.Time) bool {
+	return e.Spec.ExpiresAt != nil && !now.Before(e.Spec.ExpiresAt.Time)
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VulnerabilityExceptionList is a list of VulnerabilityException resources.
+type VulnerabilityExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VulnerabilityException `json:"items"`
+}
@@ -11,6 +11,7 @@ const (
 	VulnerabilityReportListKind   = "VulnerabilityReportList"
 
 	ClusterVulnerabilityReportsCRName = "clustervulnerabilityreports.aquasecurity.github.io"
+	ClusterVulnerabilityReportKind    = "ClusterVulnerabilityReport"
 )
 
 // VulnerabilitySummary is a summary of Vulnerability counts grouped by Severity.
@@ -32,6 +33,10 @@ type VulnerabilitySummary struct {
 
 	// NoneCount is the number of packages without any vulnerability.
 	NoneCount int `json:"noneCount"`
+
+	// SuppressedCount is the number of vulnerabilities suppressed by
+	// VulnerabilityExceptions, which are not included in other counts.
+	SuppressedCount int `json:"suppressedCount"`
 }
 
 // Registry is a collection of repositories used to store Artifacts.
@@ -76,6 +81,19 @@ type Vulnerability struct {
 	PrimaryLink string   `json:"primaryLink,omitempty"`
 	Links       []string `json:"links"`
 	Score       *float64 `json:"score,omitempty"`
+
+	// Suppressed indicates that the risk of this vulnerability is accepted
+	// by a VulnerabilityException.
+	Suppressed bool `json:"suppressed,omitempty"`
+
+	// SuppressedBy is the name of the VulnerabilityException that suppresses
+	// this vulnerability.
+	SuppressedBy string `json:"suppressedBy,omitempty"`
+
+	// Scanners are names of vulnerability scanners that reported this
+	// vulnerability. It's set only in reports that merge results of several
+	// scanners.
+	Scanners []string `json:"scanners,omitempty"`
 }
 
 // +genclient
@@ -112,6 +130,51 @@ type VulnerabilityReportData struct {
 
 	// Vulnerabilities is a list of operating system (OS) or application software Vulnerability items found in the Artifact.
 	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+
+	// Drift tells whether the tag of the Artifact points to a different
+	// digest in the registry than the digest run by the workload.
+	Drift *ImageDrift `json:"drift,omitempty"`
+}
+
+// ImageDrift is the result of resolving the tag of a scanned Artifact in the
+// registry.
+type ImageDrift struct {
+	// Digest is the digest that the tag of the Artifact resolved to.
+	Digest string `json:"digest"`
+
+	// Drifted is true if Digest differs from the digest of the Artifact, i.e.
+	// the workload does not run the image currently tagged in the registry.
+	Drifted bool `json:"drifted"`
+
+	// CheckTimestamp is the time when the tag was resolved.
+	CheckTimestamp metav1.Time `json:"checkTimestamp"`
+}
+
+// VulnerabilitySummaryFromVulnerabilities counts the given vulnerabilities by
+// severity. Suppressed vulnerabilities are counted separately.
+func VulnerabilitySummaryFromVulnerabilities(vulnerabilities []Vulnerability) VulnerabilitySummary {
+	summary := VulnerabilitySummary{}
+
+	for _, vulnerability := range vulnerabilities {
+		if vulnerability.Suppressed {
+			summary.SuppressedCount++
+			continue
+		}
+		switch vulnerability.Severity {
+		case SeverityCritical:
+			summary.CriticalCount++
+		case SeverityHigh:
+			summary.HighCount++
+		case SeverityMedium:
+			summary.MediumCount++
+		case SeverityLow:
+			summary.LowCount++
+		default:
+			summary.UnknownCount++
+		}
+	}
+
+	return summary
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -130,7 +193,7 @@ type VulnerabilityReportList struct {
 
 // ClusterVulnerabilityReport is a specification for the ClusterVulnerabilityReport resource.
 type ClusterVulnerabilityReport struct {
-	metav1.TypeMeta   `json:",incline"`
+	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Report VulnerabilityReportData `json:"report"`
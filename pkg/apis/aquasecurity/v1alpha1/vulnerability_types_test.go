@@ -0,0 +1,32 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVulnerabilitySummaryFromVulnerabilities(t *testing.T) {
+	vulnerabilities := []v1alpha1.Vulnerability{
+		{Severity: v1alpha1.SeverityCritical},
+		{Severity: v1alpha1.SeverityHigh},
+		{Severity: v1alpha1.SeverityHigh},
+		{Severity: v1alpha1.SeverityMedium},
+		{Severity: v1alpha1.SeverityLow},
+		{Severity: v1alpha1.SeverityLow},
+		{Severity: v1alpha1.SeverityLow},
+		{Severity: v1alpha1.SeverityUnknown},
+		{Severity: ""},
+		{Severity: v1alpha1.SeverityCritical, Suppressed: true},
+	}
+	summary := v1alpha1.VulnerabilitySummaryFromVulnerabilities(vulnerabilities)
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{
+		CriticalCount:   1,
+		HighCount:       2,
+		MediumCount:     1,
+		LowCount:        3,
+		UnknownCount:    2,
+		SuppressedCount: 1,
+	}, summary)
+}
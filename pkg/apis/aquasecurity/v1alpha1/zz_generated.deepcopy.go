@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -452,6 +453,66 @@ func (in *ClusterConfigAuditReportList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSbomReport) DeepCopyInto(out *ClusterSbomReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSbomReport.
+func (in *ClusterSbomReport) DeepCopy() *ClusterSbomReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSbomReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSbomReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSbomReportList) DeepCopyInto(out *ClusterSbomReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterSbomReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSbomReportList.
+func (in *ClusterSbomReportList) DeepCopy() *ClusterSbomReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSbomReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSbomReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterVulnerabilityReport) DeepCopyInto(out *ClusterVulnerabilityReport) {
 	*out = *in
@@ -528,6 +589,27 @@ func (in *Compliance) DeepCopy() *Compliance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Component) DeepCopyInto(out *Component) {
+	*out = *in
+	if in.Licenses != nil {
+		in, out := &in.Licenses, &out.Licenses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Component.
+func (in *Component) DeepCopy() *Component {
+	if in == nil {
+		return nil
+	}
+	out := new(Component)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigAuditReport) DeepCopyInto(out *ConfigAuditReport) {
 	*out = *in
@@ -715,6 +797,39 @@ func (in *ControlCheckDetails) DeepCopy() *ControlCheckDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedCheck) DeepCopyInto(out *FailedCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedCheck.
+func (in *FailedCheck) DeepCopy() *FailedCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageDrift) DeepCopyInto(out *ImageDrift) {
+	*out = *in
+	in.CheckTimestamp.DeepCopyInto(&out.CheckTimestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageDrift.
+func (in *ImageDrift) DeepCopy() *ImageDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeHunterReport) DeepCopyInto(out *KubeHunterReport) {
 	*out = *in
@@ -852,6 +967,95 @@ func (in *Mapping) DeepCopy() *Mapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSecurityReport) DeepCopyInto(out *NamespaceSecurityReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSecurityReport.
+func (in *NamespaceSecurityReport) DeepCopy() *NamespaceSecurityReport {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSecurityReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceSecurityReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSecurityReportData) DeepCopyInto(out *NamespaceSecurityReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.VulnerabilitySummary = in.VulnerabilitySummary
+	out.ConfigAuditSummary = in.ConfigAuditSummary
+	if in.TopVulnerableImages != nil {
+		in, out := &in.TopVulnerableImages, &out.TopVulnerableImages
+		*out = make([]VulnerableImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopFailedChecks != nil {
+		in, out := &in.TopFailedChecks, &out.TopFailedChecks
+		*out = make([]FailedCheck, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSecurityReportData.
+func (in *NamespaceSecurityReportData) DeepCopy() *NamespaceSecurityReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSecurityReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSecurityReportList) DeepCopyInto(out *NamespaceSecurityReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceSecurityReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSecurityReportList.
+func (in *NamespaceSecurityReportList) DeepCopy() *NamespaceSecurityReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSecurityReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceSecurityReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -930,6 +1134,110 @@ func (in *ResultDetails) DeepCopy() *ResultDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SbomReport) DeepCopyInto(out *SbomReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SbomReport.
+func (in *SbomReport) DeepCopy() *SbomReport {
+	if in == nil {
+		return nil
+	}
+	out := new(SbomReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SbomReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SbomReportData) DeepCopyInto(out *SbomReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Scanner = in.Scanner
+	out.Registry = in.Registry
+	out.Artifact = in.Artifact
+	out.Summary = in.Summary
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]Component, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SbomReportData.
+func (in *SbomReportData) DeepCopy() *SbomReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(SbomReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SbomReportList) DeepCopyInto(out *SbomReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SbomReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SbomReportList.
+func (in *SbomReportList) DeepCopy() *SbomReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(SbomReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SbomReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SbomSummary) DeepCopyInto(out *SbomSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SbomSummary.
+func (in *SbomSummary) DeepCopy() *SbomSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(SbomSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scanner) DeepCopyInto(out *Scanner) {
 	*out = *in
@@ -983,6 +1291,106 @@ func (in *SpecCheck) DeepCopy() *SpecCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SummaryHistory) DeepCopyInto(out *SummaryHistory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SummaryHistory.
+func (in *SummaryHistory) DeepCopy() *SummaryHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(SummaryHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SummaryHistory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SummaryHistoryData) DeepCopyInto(out *SummaryHistoryData) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]SummaryHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SummaryHistoryData.
+func (in *SummaryHistoryData) DeepCopy() *SummaryHistoryData {
+	if in == nil {
+		return nil
+	}
+	out := new(SummaryHistoryData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SummaryHistoryEntry) DeepCopyInto(out *SummaryHistoryEntry) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SummaryHistoryEntry.
+func (in *SummaryHistoryEntry) DeepCopy() *SummaryHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(SummaryHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SummaryHistoryList) DeepCopyInto(out *SummaryHistoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SummaryHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SummaryHistoryList.
+func (in *SummaryHistoryList) DeepCopy() *SummaryHistoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(SummaryHistoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SummaryHistoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
 	*out = *in
@@ -996,6 +1404,11 @@ func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
 		*out = new(float64)
 		**out = **in
 	}
+	if in.Scanners != nil {
+		in, out := &in.Scanners, &out.Scanners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1009,6 +1422,96 @@ func (in *Vulnerability) DeepCopy() *Vulnerability {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityException) DeepCopyInto(out *VulnerabilityException) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityException.
+func (in *VulnerabilityException) DeepCopy() *VulnerabilityException {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityException)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VulnerabilityException) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityExceptionList) DeepCopyInto(out *VulnerabilityExceptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VulnerabilityException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityExceptionList.
+func (in *VulnerabilityExceptionList) DeepCopy() *VulnerabilityExceptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityExceptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VulnerabilityExceptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityExceptionSpec) DeepCopyInto(out *VulnerabilityExceptionSpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityExceptionSpec.
+func (in *VulnerabilityExceptionSpec) DeepCopy() *VulnerabilityExceptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityExceptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VulnerabilityReport) DeepCopyInto(out *VulnerabilityReport) {
 	*out = *in
@@ -1051,6 +1554,11 @@ func (in *VulnerabilityReportData) DeepCopyInto(out *VulnerabilityReportData) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(ImageDrift)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1112,3 +1620,22 @@ func (in *VulnerabilitySummary) DeepCopy() *VulnerabilitySummary {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerableImage) DeepCopyInto(out *VulnerableImage) {
+	*out = *in
+	out.Registry = in.Registry
+	out.Artifact = in.Artifact
+	out.Summary = in.Summary
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerableImage.
+func (in *VulnerableImage) DeepCopy() *VulnerableImage {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerableImage)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -35,7 +35,7 @@ func NewCleanupCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFl
 				return err
 			}
 			configManager := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName)
-			installer := NewInstaller(buildInfo, kubeClientset, apiExtensionsClientset, kubeClient, configManager)
+			installer := NewInstaller(buildInfo, kubeClientset, apiExtensionsClientset, kubeClient, configManager, "")
 			return installer.Uninstall(context.Background())
 		},
 	}
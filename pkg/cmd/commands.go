@@ -69,6 +69,7 @@ func ComplianceNameFromArgs(args []string, suffix ...string) (types.NamespacedNa
 const (
 	scanJobTimeoutFlagName = "scan-job-timeout"
 	deleteScanJobFlagName  = "delete-scan-job"
+	pluginsDirFlagName     = "plugins-dir"
 )
 
 func registerScannerOpts(cmd *cobra.Command) {
@@ -78,6 +79,11 @@ func registerScannerOpts(cmd *cobra.Command) {
 	cmd.Flags().Bool(deleteScanJobFlagName, true, "If true, delete a scan job either complete or failed")
 }
 
+func registerPluginsDir(cmd *cobra.Command) {
+	cmd.Flags().String(pluginsDirFlagName, "",
+		"The directory of executables of out-of-tree plugins. Out-of-tree plugins are disabled if not set.")
+}
+
 func getScannerOpts(cmd *cobra.Command) (opts kube.ScannerOpts, err error) {
 	opts.ScanJobTimeout, err = cmd.Flags().GetDuration(scanJobTimeoutFlagName)
 	if err != nil {
@@ -15,7 +15,9 @@ func NewGetCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags,
 	}
 	getCmd.AddCommand(NewGetVulnerabilityReportsCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.AddCommand(NewGetConfigAuditReportsCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.AddCommand(NewGetSbomReportsCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.AddCommand(NewGetClusterComplianceReportsCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.AddCommand(NewGetTrendsCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.PersistentFlags().StringP("output", "o", "", "Output format. One of yaml|json")
 
 	return getCmd
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewGetSbomReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sbomreports (NAME | TYPE/NAME)",
+		Aliases: []string{"sboms", "sbom"},
+		Short:   "Get SBOM reports",
+		Long: `Get software bill of materials (SBOM) reports for the specified workload
+
+TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
+NAME is the name of a particular Kubernetes workload.
+
+In addition to yaml and json, a report can be converted to the cyclonedx or spdx
+output format. The container must be specified if the workload has more than
+one container.
+`,
+		Example: fmt.Sprintf(`  # Get SBOM reports for a Deployment with the specified name
+  %[1]s get sbomreports deploy/nginx
+
+  # Get SBOM reports for a Deployment with the specified name in the specified namespace
+  %[1]s get sboms deploy/nginx -n staging
+
+  # Get SBOM report for the specified container belonging to
+  # a Deployment with the specified name in CycloneDX output format
+  %[1]s get sbom deploy/nginx --container nginx -o cyclonedx
+
+  # Get SBOM report for a CronJob with the specified name in SPDX output format
+  %[1]s get sbom cj/my-job -o spdx`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			scheme := starboard.NewScheme()
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			workload, _, err := WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+
+			reader := sbomreport.NewReadWriter(kubeClient)
+			items, err := reader.FindByOwnerInHierarchy(ctx, workload)
+			if err != nil {
+				return fmt.Errorf("list sbom reports: %w", err)
+			}
+			if len(items) == 0 {
+				fmt.Fprintf(out, "No reports found in %s namespace.\n", workload.Namespace)
+				return nil
+			}
+
+			format := cmd.Flag("output").Value.String()
+			container := cmd.Flag("container").Value.String()
+
+			list := &v1alpha1.SbomReportList{
+				Items: []v1alpha1.SbomReport{},
+			}
+
+			for _, item := range items {
+				if container != "" && item.Labels[starboard.LabelContainerName] != container {
+					continue
+				}
+				list.Items = append(list.Items, item)
+			}
+			if len(items) > 0 && len(list.Items) == 0 {
+				return fmt.Errorf("container %s is not valid for %s %s", container, strings.ToLower(string(workload.Kind)), workload.Name)
+			}
+
+			var printer printers.ResourcePrinter
+
+			switch format {
+			case "yaml", "json":
+				printer, err = genericclioptions.NewPrintFlags("").
+					WithTypeSetter(starboard.NewScheme()).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return err
+				}
+			case "":
+				printer = printers.NewTablePrinter(printers.PrintOptions{})
+			case string(sbomreport.FormatCycloneDX), string(sbomreport.FormatSPDX):
+				if len(list.Items) > 1 {
+					var containers []string
+					for _, item := range list.Items {
+						containers = append(containers, item.Labels[starboard.LabelContainerName])
+					}
+					sort.Strings(containers)
+					return fmt.Errorf("%s output format requires a single report, specify one of the containers with --container: %s",
+						format, strings.Join(containers, ","))
+				}
+				return printSbom(out, sbomreport.Format(format), list.Items[0])
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json,cyclonedx,spdx", format)
+			}
+
+			return printer.PrintObj(list, out)
+		},
+	}
+
+	cmd.PersistentFlags().StringP("container", "c", "", "Get SBOM report of this container")
+
+	return cmd
+}
+
+// printSbom writes the given report as an SBOM document in the given format.
+func printSbom(out io.Writer, format sbomreport.Format, report v1alpha1.SbomReport) error {
+	var doc interface{}
+	switch format {
+	case sbomreport.FormatCycloneDX:
+		doc = sbomreport.NewCycloneDX(report.UID, report.Report)
+	case sbomreport.FormatSPDX:
+		doc = sbomreport.NewSPDX(report.UID, report.Report)
+	}
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(content))
+	return err
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/history"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewGetTrendsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "trends (NAME | TYPE/NAME)",
+		Aliases: []string{"trend"},
+		Short:   "Get severity counts of security reports over time",
+		Long: `Get severity counts of vulnerability and configuration audit reports over time for the specified workload or namespace
+
+TYPE is a Kubernetes workload or namespace. Shortcuts and API groups will be resolved, e.g. 'deploy' or 'ns'.
+NAME is the name of a particular Kubernetes workload or namespace.
+
+Severity counts are recorded only if the summaryHistory.enabled setting is "true".
+`,
+		Example: fmt.Sprintf(`  # Get trends for a namespace with the specified name
+  %[1]s get trends namespace/staging
+
+  # Get trends for a Deployment with the specified name in the specified namespace
+  %[1]s get trends deploy/nginx -n staging
+
+  # Get trends for a namespace with the specified name in YAML output format
+  %[1]s get trends ns/staging -o yaml`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			scheme := starboard.NewScheme()
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			owner, _, err := WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+
+			reader := history.NewReader(kubeClient)
+			list := &v1alpha1.SummaryHistoryList{
+				Items: []v1alpha1.SummaryHistory{},
+			}
+			for _, reportKind := range []string{v1alpha1.VulnerabilityReportKind, v1alpha1.ConfigAuditReportKind} {
+				item, err := reader.FindByOwner(ctx, reportKind, owner)
+				if err != nil {
+					return fmt.Errorf("getting summary history: %w", err)
+				}
+				if item != nil {
+					list.Items = append(list.Items, *item)
+				}
+			}
+			if len(list.Items) == 0 {
+				fmt.Fprintf(out, "No trends found for %s/%s.\n", owner.Kind, owner.Name)
+				return nil
+			}
+
+			format := cmd.Flag("output").Value.String()
+			switch format {
+			case "yaml", "json":
+				printer, err := genericclioptions.NewPrintFlags("").
+					WithTypeSetter(scheme).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return err
+				}
+				return printer.PrintObj(list, out)
+			case "":
+				return printTrends(list.Items, out)
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json", format)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func printTrends(histories []v1alpha1.SummaryHistory, out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	for i, h := range histories {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s\n", h.Report.ReportKind)
+		fmt.Fprintln(w, "TIMESTAMP\tCRITICAL\tHIGH\tMEDIUM\tLOW\tUNKNOWN")
+		for _, entry := range h.Report.Entries {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\n", entry.Timestamp.UTC().Format(time.RFC3339),
+				entry.CriticalCount, entry.HighCount, entry.MediumCount, entry.LowCount, entry.UnknownCount)
+		}
+	}
+	return w.Flush()
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/spf13/cobra"
@@ -24,6 +25,9 @@ func NewGetVulnerabilityReportsCmd(executable string, cf *genericclioptions.Conf
 
 TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
 NAME is the name of a particular Kubernetes workload.
+
+Reports are generated for init containers, containers, and ephemeral containers. Use the --container-type
+flag to get reports for one type of containers, i.e. Container, InitContainer, or EphemeralContainer.
 `,
 		Example: fmt.Sprintf(`  # Get vulnerability reports for a Deployment with the specified name
   %[1]s get vulnerabilityreports deploy/nginx
@@ -38,6 +42,10 @@ NAME is the name of a particular Kubernetes workload.
   # a ReplicaSet with the specified name
   %[1]s get vulns replicaset/nginx --container nginx
 
+  # Get vulnerability reports for init containers belonging to
+  # a Deployment with the specified name
+  %[1]s get vulns deploy/nginx --container-type InitContainer
+
   # Get vulnerability reports for a CronJob with the specified name in JSON output format
   %[1]s get vuln cj/my-job -o json`, executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -77,6 +85,13 @@ NAME is the name of a particular Kubernetes workload.
 
 			format := cmd.Flag("output").Value.String()
 			container := cmd.Flag("container").Value.String()
+			containerType := cmd.Flag("container-type").Value.String()
+			switch kube.ContainerType(containerType) {
+			case "", kube.ContainerTypeContainer, kube.ContainerTypeInitContainer, kube.ContainerTypeEphemeralContainer:
+			default:
+				return fmt.Errorf("invalid container type %q, allowed types are: %s,%s,%s", containerType,
+					kube.ContainerTypeContainer, kube.ContainerTypeInitContainer, kube.ContainerTypeEphemeralContainer)
+			}
 
 			var printer printers.ResourcePrinter
 
@@ -106,9 +121,16 @@ NAME is the name of a particular Kubernetes workload.
 				if container != "" && item.Labels[starboard.LabelContainerName] != container {
 					continue
 				}
+				if containerType != "" && item.Labels[starboard.LabelContainerType] != containerType {
+					continue
+				}
 				list.Items = append(list.Items, item)
 			}
 			if len(items) > 0 && len(list.Items) == 0 {
+				if containerType != "" {
+					fmt.Fprintf(out, "No reports found for %s containers of %s %s.\n", containerType, strings.ToLower(string(workload.Kind)), workload.Name)
+					return nil
+				}
 				return fmt.Errorf("container %s is not valid for %s %s", container, strings.ToLower(string(workload.Kind)), workload.Name)
 			}
 
@@ -117,6 +139,7 @@ NAME is the name of a particular Kubernetes workload.
 	}
 
 	cmd.PersistentFlags().StringP("container", "c", "", "Get vulnerability report of this container")
+	cmd.PersistentFlags().String("container-type", "", "Get vulnerability reports of containers of this type, i.e. Container, InitContainer, or EphemeralContainer")
 
 	return cmd
 }
@@ -24,6 +24,11 @@ Kubernetes cluster:
  - CustomResourceDefinition objects:
    - "vulnerabilityreports.aquasecurity.github.io"
    - "clustervulnerabilityreports.aquasecurity.github.io"
+   - "vulnerabilityexceptions.aquasecurity.github.io"
+   - "summaryhistories.aquasecurity.github.io"
+   - "namespacesecurityreports.aquasecurity.github.io"
+   - "sbomreports.aquasecurity.github.io"
+   - "clustersbomreports.aquasecurity.github.io"
    - "configauditreports.aquasecurity.github.io"
    - "clusterconfigauditreports.aquasecurity.github.io"
    - "ciskubebenchreports.aquasecurity.github.io"
@@ -62,8 +67,12 @@ the "uninstall" command.`,
 			if err != nil {
 				return err
 			}
+			pluginsDir, err := cmd.Flags().GetString(pluginsDirFlagName)
+			if err != nil {
+				return err
+			}
 			configManager := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName)
-			installer := NewInstaller(buildInfo, kubeClientset, apiExtensionsClientset, kubeClient, configManager)
+			installer := NewInstaller(buildInfo, kubeClientset, apiExtensionsClientset, kubeClient, configManager, pluginsDir)
 			err = installer.Install(context.Background())
 			if err != nil {
 				return err
@@ -73,5 +82,6 @@ the "uninstall" command.`,
 			return nil
 		},
 	}
+	registerPluginsDir(cmd)
 	return cmd
 }
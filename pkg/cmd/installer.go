@@ -128,6 +128,7 @@ type Installer struct {
 	clientset     kubernetes.Interface
 	clientsetext  extapi.ApiextensionsV1Interface
 	configManager starboard.ConfigManager
+	pluginsDir    string
 }
 
 // NewInstaller constructs an Installer with the given starboard.ConfigManager and kubernetes.Interface.
@@ -138,6 +139,7 @@ func NewInstaller(
 	clientsetext extapi.ApiextensionsV1Interface,
 	client client.Client,
 	configManager starboard.ConfigManager,
+	pluginsDir string,
 ) *Installer {
 	return &Installer{
 		buildInfo:     buildInfo,
@@ -145,6 +147,7 @@ func NewInstaller(
 		clientsetext:  clientsetext,
 		client:        client,
 		configManager: configManager,
+		pluginsDir:    pluginsDir,
 	}
 }
 
@@ -166,6 +169,46 @@ func (m *Installer) Install(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	vulnerabilityExceptionsCRD, err := embedded.GetVulnerabilityExceptionsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &vulnerabilityExceptionsCRD)
+	if err != nil {
+		return err
+	}
+	summaryHistoriesCRD, err := embedded.GetSummaryHistoriesCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &summaryHistoriesCRD)
+	if err != nil {
+		return err
+	}
+	namespaceSecurityReportsCRD, err := embedded.GetNamespaceSecurityReportsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &namespaceSecurityReportsCRD)
+	if err != nil {
+		return err
+	}
+	sbomReportsCRD, err := embedded.GetSbomReportsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &sbomReportsCRD)
+	if err != nil {
+		return err
+	}
+	clusterSbomReportsCRD, err := embedded.GetClusterSbomReportsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &clusterSbomReportsCRD)
+	if err != nil {
+		return err
+	}
 	kubeBenchReportsCRD, err := embedded.GetCISKubeBenchReportsCRD()
 	if err != nil {
 		return err
@@ -248,7 +291,8 @@ func (m *Installer) Install(ctx context.Context) error {
 		WithNamespace(starboard.NamespaceName).
 		WithServiceAccountName(starboard.ServiceAccountName).
 		WithConfig(config).
-		WithClient(m.client)
+		WithClient(m.client).
+		WithPluginsDir(m.pluginsDir)
 
 	vulnerabilityPlugin, pluginContext, err := pluginResolver.GetVulnerabilityPlugin()
 	if err != nil {
@@ -448,6 +492,26 @@ func (m *Installer) Uninstall(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = m.deleteCRD(ctx, v1alpha1.VulnerabilityExceptionsCRName)
+	if err != nil {
+		return err
+	}
+	err = m.deleteCRD(ctx, v1alpha1.SummaryHistoriesCRName)
+	if err != nil {
+		return err
+	}
+	err = m.deleteCRD(ctx, v1alpha1.NamespaceSecurityReportsCRName)
+	if err != nil {
+		return err
+	}
+	err = m.deleteCRD(ctx, v1alpha1.SbomReportsCRName)
+	if err != nil {
+		return err
+	}
+	err = m.deleteCRD(ctx, v1alpha1.ClusterSbomReportsCRName)
+	if err != nil {
+		return err
+	}
 	err = m.deleteCRD(ctx, v1alpha1.CISKubeBenchReportCRName)
 	if err != nil {
 		return err
@@ -2,34 +2,103 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
 
+	embedded "github.com/aquasecurity/starboard"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/history"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/manifest"
+	"github.com/aquasecurity/starboard/pkg/policy"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
 const (
 	configAuditCmdShort = "Run a variety of checks to ensure that a given workload is configured using best practices"
+	configAuditCmdLong  = `Run a variety of checks to ensure that a given workload is configured using best practices
+
+By default, the specified workload is read from the cluster and the results are
+stored as a ConfigAuditReport resource.
+
+With the --filename flag, Kubernetes objects are read from local manifests
+instead, and the results are printed rather than stored. This mode does not
+require access to a cluster, which makes it suitable for CI pipelines. The path
+may be a YAML or JSON file, or a directory, which is walked for such files.
+Directories with Chart.yaml are rendered with the helm template command, which
+requires Helm to be installed, and directories with a kustomization file are
+rendered with Kustomize.
+`
+)
+
+const (
+	filenameFlagName          = "filename"
+	policiesFlagName          = "policies"
+	outputFlagName            = "output"
+	severityThresholdFlagName = "severity-threshold"
+	helmValuesFlagName        = "helm-values"
 )
 
 func NewScanConfigAuditReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "configauditreports",
-		Short: configAuditCmdShort,
-		Args:  cobra.MaximumNArgs(1),
-		RunE:  ScanConfigAuditReports(buildInfo, cf),
+		Use:     "configauditreports",
+		Aliases: []string{"configaudit"},
+		Short:   configAuditCmdShort,
+		Long:    configAuditCmdLong,
+		Example: fmt.Sprintf(`  # Scan a Deployment with the specified name and store the ConfigAuditReport
+  %[1]s scan configauditreports deployment/nginx
+
+  # Scan manifests, Helm charts and Kustomize directories in the deploy directory
+  %[1]s scan configaudit -f ./deploy
+
+  # Scan local manifests with custom policies, and fail if any HIGH or CRITICAL check fails
+  %[1]s scan configaudit -f ./deploy --policies ./policies --severity-threshold HIGH
+
+  # Scan a Helm chart with the specified values and print results in the SARIF format
+  %[1]s scan configaudit -f ./charts/nginx --helm-values ./values-prod.yaml -o sarif`, buildInfo.Executable),
+		Args: cobra.MaximumNArgs(1),
+		RunE: ScanConfigAuditReports(buildInfo, cf),
 	}
 
 	registerScannerOpts(cmd)
 
+	cmd.Flags().StringP(filenameFlagName, "f", "",
+		"Path of a manifest file, or a directory of manifests, Helm charts and Kustomize directories, to scan without a cluster")
+	cmd.Flags().String(policiesFlagName, "",
+		"Path of a policies bundle directory or tarball, or of an exported starboard-policies-config ConfigMap, used with --filename. Defaults to built-in policies")
+	cmd.Flags().StringP(outputFlagName, "o", string(configauditreport.FormatTable),
+		"Output format used with --filename. One of table|json|sarif|junit")
+	cmd.Flags().String(severityThresholdFlagName, "",
+		"Exit with a non-zero code if any check of this severity or higher fails, used with --filename. One of LOW|MEDIUM|HIGH|CRITICAL")
+	cmd.Flags().StringSlice(helmValuesFlagName, nil,
+		"Values files passed to helm template when rendering Helm charts, used with --filename")
+
 	return cmd
 }
 
 func ScanConfigAuditReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
+		if filename := cmd.Flag(filenameFlagName).Value.String(); filename != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("workload must not be specified with --%s", filenameFlagName)
+			}
+			return scanLocalConfigAudit(ctx, cmd, buildInfo, cf, filename)
+		}
 		ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
 		if err != nil {
 			return err
@@ -48,12 +117,181 @@ func ScanConfigAuditReports(buildInfo starboard.BuildInfo, cf *genericclioptions
 		}
 		scheme := starboard.NewScheme()
 		kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
-		scanner := configauditreport.NewScanner(buildInfo, kubeClient)
+		if err != nil {
+			return err
+		}
+		kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+		if err != nil {
+			return err
+		}
+		config, err := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName).Read(ctx)
+		if err != nil {
+			return err
+		}
+		scanner := configauditreport.NewScanner(buildInfo, config, kubeClient)
 		reportBuilder, err := scanner.Scan(ctx, workload)
 		if err != nil {
 			return err
 		}
 		writer := configauditreport.NewReadWriter(kubeClient)
-		return reportBuilder.Write(ctx, writer)
+		err = reportBuilder.Write(ctx, writer)
+		if err != nil {
+			return err
+		}
+		if !config.SummaryHistoryEnabled() {
+			return nil
+		}
+		report, err := writer.FindReportByOwnerInHierarchy(ctx, workload)
+		if err != nil || report == nil {
+			return err
+		}
+		owner, err := kube.ObjectRefFromObjectMeta(report.ObjectMeta)
+		if err != nil {
+			return err
+		}
+		return history.NewRecorder(kubeClient, ext.NewSystemClock(), config).
+			Record(ctx, v1alpha1.ConfigAuditReportKind, owner)
+	}
+}
+
+// scanLocalConfigAudit audits Kubernetes objects read from local manifests
+// and prints the results without accessing a cluster.
+func scanLocalConfigAudit(ctx context.Context, cmd *cobra.Command, buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, filename string) error {
+	format := configauditreport.Format(cmd.Flag(outputFlagName).Value.String())
+	switch format {
+	case configauditreport.FormatTable, configauditreport.FormatJSON, configauditreport.FormatSARIF, configauditreport.FormatJUnit:
+	default:
+		return fmt.Errorf("invalid output format %q, allowed formats are: table,json,sarif,junit", format)
+	}
+	var threshold v1alpha1.Severity
+	if value := cmd.Flag(severityThresholdFlagName).Value.String(); value != "" {
+		threshold = v1alpha1.Severity(strings.ToUpper(value))
+		switch threshold {
+		case v1alpha1.SeverityLow, v1alpha1.SeverityMedium, v1alpha1.SeverityHigh, v1alpha1.SeverityCritical:
+		default:
+			return fmt.Errorf("invalid severity threshold %q, allowed severities are: LOW,MEDIUM,HIGH,CRITICAL", value)
+		}
+	}
+	valuesFiles, err := cmd.Flags().GetStringSlice(helmValuesFlagName)
+	if err != nil {
+		return err
+	}
+
+	policies, err := localPolicies(ctx, cmd.Flag(policiesFlagName).Value.String())
+	if err != nil {
+		return err
+	}
+	opts := manifest.Options{ValuesFiles: valuesFiles}
+	if cf.Namespace != nil {
+		opts.Namespace = *cf.Namespace
+	}
+	documents, err := manifest.Load(filename, opts)
+	if err != nil {
+		return err
+	}
+	reports, err := configauditreport.NewLocalScanner(buildInfo, ext.NewSystemClock(), policies).
+		Scan(ctx, documents)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	switch format {
+	case configauditreport.FormatTable:
+		err = printLocalConfigAuditTable(out, reports)
+	case configauditreport.FormatJSON:
+		if reports == nil {
+			reports = []configauditreport.LocalReport{}
+		}
+		err = printJSON(out, reports)
+	case configauditreport.FormatSARIF:
+		err = printJSON(out, configauditreport.NewSARIF(reports))
+	case configauditreport.FormatJUnit:
+		err = printJUnit(out, configauditreport.NewJUnit(reports))
+	}
+	if err != nil {
+		return err
+	}
+
+	if threshold == "" {
+		return nil
+	}
+	if failed := configauditreport.FailedChecks(reports, threshold); failed > 0 {
+		return fmt.Errorf("%d checks with %s severity or higher failed", failed, threshold)
+	}
+	return nil
+}
+
+// localPolicies returns policies loaded from the specified path, which is
+// either an exported ConfigMap with .yaml, .yml or .json extension, or a
+// policies bundle directory or tarball. If the path is empty, built-in
+// policies are returned.
+func localPolicies(ctx context.Context, path string) (*policy.Policies, error) {
+	if path == "" {
+		cm, err := embedded.PoliciesConfigMap()
+		if err != nil {
+			return nil, err
+		}
+		return policy.NewPolicies(cm.Data), nil
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var cm corev1.ConfigMap
+		if err := yaml.Unmarshal(content, &cm); err != nil {
+			return nil, fmt.Errorf("decoding policies ConfigMap: %s: %w", path, err)
+		}
+		if cm.Kind != "ConfigMap" {
+			return nil, fmt.Errorf("decoding policies ConfigMap: %s: unexpected kind %q", path, cm.Kind)
+		}
+		return policy.NewPolicies(cm.Data), nil
+	}
+	bundle, err := policy.NewFileSource(path, "").Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return policy.NewPolicies(bundle.Data), nil
+}
+
+func printLocalConfigAuditTable(out io.Writer, reports []configauditreport.LocalReport) error {
+	var failed int
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	for _, report := range reports {
+		for _, check := range report.Report.Checks {
+			if check.Success {
+				continue
+			}
+			if failed == 0 {
+				fmt.Fprintln(w, "PATH\tRESOURCE\tCHECK\tSEVERITY\tTITLE")
+			}
+			failed++
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", report.Path, report.ResourceName(), check.ID, check.Severity, check.Title)
+		}
+	}
+	if failed > 0 {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "Scanned %d resources, %d checks failed.\n", len(reports), failed)
+	return w.Flush()
+}
+
+func printJSON(out io.Writer, v interface{}) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(content))
+	return err
+}
+
+func printJUnit(out io.Writer, junit configauditreport.JUnit) error {
+	content, err := xml.MarshalIndent(junit, "", "  ")
+	if err != nil {
+		return err
 	}
+	_, err = fmt.Fprintf(out, "%s%s\n", xml.Header, content)
+	return err
 }
@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/history"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/plugin"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
@@ -58,6 +62,7 @@ func NewScanVulnerabilityReportsCmd(buildInfo starboard.BuildInfo, cf *genericcl
 	}
 
 	registerScannerOpts(cmd)
+	registerPluginsDir(cmd)
 
 	return cmd
 }
@@ -98,12 +103,17 @@ func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptio
 		if err != nil {
 			return err
 		}
+		pluginsDir, err := cmd.Flags().GetString(pluginsDirFlagName)
+		if err != nil {
+			return err
+		}
 		plugin, pluginContext, err := plugin.NewResolver().
 			WithBuildInfo(buildInfo).
 			WithNamespace(starboard.NamespaceName).
 			WithServiceAccountName(starboard.ServiceAccountName).
 			WithConfig(config).
 			WithClient(kubeClient).
+			WithPluginsDir(pluginsDir).
 			GetVulnerabilityPlugin()
 		if err != nil {
 			return err
@@ -114,6 +124,18 @@ func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptio
 			return err
 		}
 		writer := vulnerabilityreport.NewReadWriter(kubeClient)
-		return writer.Write(ctx, reports)
+		err = writer.Write(ctx, reports)
+		if err != nil {
+			return err
+		}
+		if config.SummaryHistoryEnabled() && len(reports) > 0 {
+			owner, err := kube.ObjectRefFromObjectMeta(reports[0].ObjectMeta)
+			if err != nil {
+				return err
+			}
+			return history.NewRecorder(kubeClient, ext.NewSystemClock(), config).
+				Record(ctx, v1alpha1.VulnerabilityReportKind, owner)
+		}
+		return nil
 	}
 }
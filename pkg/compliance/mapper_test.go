@@ -10,6 +10,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -153,6 +154,24 @@ func TestMapReportDataToMap(t *testing.T) {
 	}
 }
 
+func TestMapReportDataOfMultipleConfigAuditScanners(t *testing.T) {
+	reportList := &v1alpha1.ConfigAuditReportList{Items: []v1alpha1.ConfigAuditReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-rss-site", Namespace: "default", Labels: map[string]string{starboard.LabelConfigAuditReportScanner: "Polaris"}},
+			Report:     v1alpha1.ConfigAuditReportData{Checks: []v1alpha1.Check{{ID: "runAsRootAllowed", Success: false}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-rss-site-starboard", Namespace: "default", Labels: map[string]string{starboard.LabelConfigAuditReportScanner: "Starboard"}},
+			Report:     v1alpha1.ConfigAuditReportData{Checks: []v1alpha1.Check{{ID: "KSV012", Success: true}}},
+		},
+	}}
+	result := configAudit{}.mapReportData("Pod", reportList)
+	assert.Equal(t, map[string]*ScannerCheckResult{
+		"runAsRootAllowed": {ObjectType: "Pod", ID: "runAsRootAllowed", Details: []ResultDetails{{Name: "pod-rss-site", Namespace: "default", Status: v1alpha1.FailStatus}}},
+		"KSV012":           {ObjectType: "Pod", ID: "KSV012", Details: []ResultDetails{{Name: "pod-rss-site-starboard", Namespace: "default", Status: v1alpha1.PassStatus}}},
+	}, result)
+}
+
 func getWantResults(filePath string) map[string]*ScannerCheckResult {
 	var tct map[string]*ScannerCheckResult
 	data, err := ioutil.ReadFile(filePath)
@@ -159,6 +159,8 @@ type ReportBuilder struct {
 	controller       client.Object
 	resourceSpecHash string
 	pluginConfigHash string
+	scanner          string
+	additional       bool
 	data             v1alpha1.ConfigAuditReportData
 }
 
@@ -188,9 +190,29 @@ func (b *ReportBuilder) Data(data v1alpha1.ConfigAuditReportData) *ReportBuilder
 	return b
 }
 
+// Scanner sets the name of the configuration audit scanner that generated
+// the report data.
+func (b *ReportBuilder) Scanner(name string) *ReportBuilder {
+	b.scanner = name
+	return b
+}
+
+// Additional tells the builder that the report is generated by an additional
+// configuration audit scanner. Such a report is labeled with
+// starboard.LabelConfigAuditReportAdditional and its name is suffixed with the
+// name of the scanner so that it does not collide with the report of the
+// primary scanner.
+func (b *ReportBuilder) Additional(additional bool) *ReportBuilder {
+	b.additional = additional
+	return b
+}
+
 func (b *ReportBuilder) reportName() string {
 	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
 	name := b.controller.GetName()
+	if b.additional {
+		name = fmt.Sprintf("%s-%s", name, strings.ToLower(b.scanner))
+	}
 	reportName := fmt.Sprintf("%s-%s", strings.ToLower(kind), name)
 	if len(validation.IsValidLabelValue(reportName)) == 0 {
 		return reportName
@@ -198,7 +220,7 @@ func (b *ReportBuilder) reportName() string {
 	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name))
 }
 
-func (b *ReportBuilder) GetClusterReport() (v1alpha1.ClusterConfigAuditReport, error) {
+func (b *ReportBuilder) labels() labels.Set {
 	labelsSet := make(labels.Set)
 	if b.resourceSpecHash != "" {
 		labelsSet[starboard.LabelResourceSpecHash] = b.resourceSpecHash
@@ -206,11 +228,20 @@ func (b *ReportBuilder) GetClusterReport() (v1alpha1.ClusterConfigAuditReport, e
 	if b.pluginConfigHash != "" {
 		labelsSet[starboard.LabelPluginConfigHash] = b.pluginConfigHash
 	}
+	if b.scanner != "" {
+		labelsSet[starboard.LabelConfigAuditReportScanner] = b.scanner
+	}
+	if b.additional {
+		labelsSet[starboard.LabelConfigAuditReportAdditional] = "true"
+	}
+	return labelsSet
+}
 
+func (b *ReportBuilder) GetClusterReport() (v1alpha1.ClusterConfigAuditReport, error) {
 	report := v1alpha1.ClusterConfigAuditReport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   b.reportName(),
-			Labels: labelsSet,
+			Labels: b.labels(),
 		},
 		Report: b.data,
 	}
@@ -235,19 +266,11 @@ func (b *ReportBuilder) GetClusterReport() (v1alpha1.ClusterConfigAuditReport, e
 }
 
 func (b *ReportBuilder) GetReport() (v1alpha1.ConfigAuditReport, error) {
-	labelsSet := make(labels.Set)
-	if b.resourceSpecHash != "" {
-		labelsSet[starboard.LabelResourceSpecHash] = b.resourceSpecHash
-	}
-	if b.pluginConfigHash != "" {
-		labelsSet[starboard.LabelPluginConfigHash] = b.pluginConfigHash
-	}
-
 	report := v1alpha1.ConfigAuditReport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      b.reportName(),
 			Namespace: b.controller.GetNamespace(),
-			Labels:    labelsSet,
+			Labels:    b.labels(),
 		},
 		Report: b.data,
 	}
@@ -272,7 +295,7 @@ func (b *ReportBuilder) GetReport() (v1alpha1.ConfigAuditReport, error) {
 }
 
 func (b *ReportBuilder) Write(ctx context.Context, writer Writer) error {
-	if kube.IsClusterScopedKind(b.controller.GetObjectKind().GroupVersionKind().Kind) {
+	if kube.IsClusterScoped(b.controller) {
 		report, err := b.GetClusterReport()
 		if err != nil {
 			return err
@@ -115,6 +115,40 @@ func TestReportBuilder(t *testing.T) {
 			Report: v1alpha1.ConfigAuditReportData{},
 		}))
 	})
+
+	t.Run("Should build report of additional scanner", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		report, err := configauditreport.NewReportBuilder(scheme.Scheme).
+			Controller(&appsv1.ReplicaSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ReplicaSet",
+					APIVersion: "apps/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "some-owner",
+					Namespace: "qa",
+				},
+			}).
+			ResourceSpecHash("xyz").
+			PluginConfigHash("nop").
+			Scanner(configauditreport.BuiltInScanner).
+			Additional(true).
+			Data(v1alpha1.ConfigAuditReportData{}).
+			GetReport()
+
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(report.Name).To(Equal("replicaset-some-owner-starboard"))
+		g.Expect(report.Labels).To(Equal(map[string]string{
+			starboard.LabelResourceKind:                "ReplicaSet",
+			starboard.LabelResourceName:                "some-owner",
+			starboard.LabelResourceNamespace:           "qa",
+			starboard.LabelResourceSpecHash:            "xyz",
+			starboard.LabelPluginConfigHash:            "nop",
+			starboard.LabelConfigAuditReportScanner:    "Starboard",
+			starboard.LabelConfigAuditReportAdditional: "true",
+		}))
+	})
 }
 
 type testPlugin struct {
@@ -3,11 +3,14 @@ package configauditreport
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
 	"github.com/aquasecurity/starboard/pkg/operator/predicate"
 	"github.com/aquasecurity/starboard/pkg/policy"
 	"github.com/aquasecurity/starboard/pkg/starboard"
@@ -20,13 +23,17 @@ import (
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // ResourceController watches all Kubernetes kinds and generates
@@ -40,6 +47,27 @@ type ResourceController struct {
 	kube.ObjectResolver
 	ReadWriter
 	starboard.BuildInfo
+
+	// PolicyLoader, if set, loads the bundle of policies, which are evaluated
+	// in addition to policies of the starboard-policies-config ConfigMap.
+	// Reports of the built-in scanner are regenerated whenever the bundle
+	// changes.
+	PolicyLoader *policy.Loader
+
+	// PolicyCache, if set, holds queries prepared for policies, so that
+	// policies are compiled only when they change.
+	PolicyCache *policy.Cache
+
+	// Inventory, if set, exposes cached objects to policies as
+	// data.kubernetes.<kind>. Resources whose policies read objects of a kind
+	// are re-evaluated whenever any object of that kind changes, and their
+	// reports are updated if checks change.
+	Inventory *kube.Inventory
+
+	staleMu sync.Mutex
+	// stale holds resources that are re-evaluated because objects read by
+	// their policies changed, even if their reports are up-to-date.
+	stale map[kube.ObjectRef]bool
 }
 
 func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
@@ -48,6 +76,14 @@ func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// policyEvents are sent whenever a new bundle of policies is loaded, so
+	// that reports evaluated with previous policies are deleted in the same
+	// way as when the starboard-policies-config ConfigMap is updated.
+	policyEvents := &source.Channel{Source: r.policyEvents()}
+
+	// dataTargets enqueue resources whose policies read objects that changed.
+	var dataTargets []dataTarget
+
 	resources := []struct {
 		kind       kube.Kind
 		forObject  client.Object
@@ -81,15 +117,24 @@ func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
 		{kind: kube.KindPodSecurityPolicy, forObject: &policyv1beta1.PodSecurityPolicy{}, ownsObject: &v1alpha1.ClusterConfigAuditReport{}},
 	}
 
+	var staticKinds []kube.Kind
 	for _, resource := range resources {
+		staticKinds = append(staticKinds, resource.kind)
+		predicates := builder.WithPredicates(
+			predicate.Not(predicate.ManagedByStarboardOperator),
+			predicate.Not(predicate.IsLeaderElectionResource),
+			predicate.Not(predicate.IsBeingTerminated),
+			installModePredicate,
+		)
+		target, err := newDataTarget(mgr, resource.kind, resource.forObject)
+		if err != nil {
+			return err
+		}
+		dataTargets = append(dataTargets, target)
 		err = ctrl.NewControllerManagedBy(mgr).
-			For(resource.forObject, builder.WithPredicates(
-				predicate.Not(predicate.ManagedByStarboardOperator),
-				predicate.Not(predicate.IsLeaderElectionResource),
-				predicate.Not(predicate.IsBeingTerminated),
-				installModePredicate,
-			)).
+			For(resource.forObject, predicates).
 			Owns(resource.ownsObject).
+			Watches(&source.Channel{Source: target.events}, &handler.EnqueueRequestForObject{}, predicates).
 			Complete(r.reconcileResource(resource.kind))
 		if err != nil {
 			return fmt.Errorf("constructing controller for %s: %w", resource.kind, err)
@@ -101,6 +146,7 @@ func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
 				predicate.HasName(starboard.PoliciesConfigMapName),
 				predicate.InNamespace(r.Config.Namespace),
 			)).
+			Watches(policyEvents, &handler.EnqueueRequestForObject{}).
 			Complete(r.reconcileConfig(resource.kind))
 		if err != nil {
 			return err
@@ -109,13 +155,20 @@ func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
 	}
 
 	for _, resource := range clusterResources {
-
+		staticKinds = append(staticKinds, resource.kind)
+		predicates := builder.WithPredicates(
+			predicate.Not(predicate.ManagedByStarboardOperator),
+			predicate.Not(predicate.IsBeingTerminated),
+		)
+		target, err := newDataTarget(mgr, resource.kind, resource.forObject)
+		if err != nil {
+			return err
+		}
+		dataTargets = append(dataTargets, target)
 		err = ctrl.NewControllerManagedBy(mgr).
-			For(resource.forObject, builder.WithPredicates(
-				predicate.Not(predicate.ManagedByStarboardOperator),
-				predicate.Not(predicate.IsBeingTerminated),
-			)).
+			For(resource.forObject, predicates).
 			Owns(resource.ownsObject).
+			Watches(&source.Channel{Source: target.events}, &handler.EnqueueRequestForObject{}, predicates).
 			Complete(r.reconcileResource(resource.kind))
 		if err != nil {
 			return fmt.Errorf("constructing controller for %s: %w", resource.kind, err)
@@ -126,23 +179,71 @@ func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
 				predicate.Not(predicate.IsBeingTerminated),
 				predicate.HasName(starboard.PoliciesConfigMapName),
 				predicate.InNamespace(r.Config.Namespace))).
+			Watches(policyEvents, &handler.EnqueueRequestForObject{}).
 			Complete(r.reconcileClusterConfig(resource.kind))
 		if err != nil {
 			return err
 		}
 	}
 
-	return nil
+	// Other kinds named by policies, e.g. custom resources, are watched as
+	// unstructured objects, and watches are started and stopped whenever
+	// policies change.
+	watches, err := newKindWatches(r, mgr, staticKinds, dataTargets)
+	if err != nil {
+		return err
+	}
+	if err = mgr.Add(watches); err != nil {
+		return fmt.Errorf("adding watches of custom kinds: %w", err)
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			predicate.Not(predicate.IsBeingTerminated),
+			predicate.HasName(starboard.PoliciesConfigMapName),
+			predicate.InNamespace(r.Config.Namespace))).
+		Watches(policyEvents, &handler.EnqueueRequestForObject{}).
+		Complete(reconcile.Func(watches.reconcile))
+}
 
+// policyEvents returns the channel of events for the policies ConfigMap, which
+// are sent whenever the PolicyLoader loads a new bundle.
+func (r *ResourceController) policyEvents() <-chan event.GenericEvent {
+	events := make(chan event.GenericEvent, 1)
+	notify := func() {
+		select {
+		case events <- event.GenericEvent{Object: &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      starboard.PoliciesConfigMapName,
+				Namespace: r.Config.Namespace,
+			},
+		}}:
+		default:
+			// An event is already pending, which is enough because
+			// reconcilers always read the latest policies.
+		}
+	}
+	if r.PolicyLoader != nil {
+		r.PolicyLoader.Notify(notify)
+	}
+	return events
 }
 
 func (r *ResourceController) reconcileResource(resourceKind kube.Kind) reconcile.Func {
+	return r.reconcileObject(resourceKind, kube.IsClusterScopedKind(string(resourceKind)), r.ObjectFromObjectRef)
+}
+
+// objectGetter gets the client.Object referenced by kube.ObjectRef, and
+// returns an error that satisfies errors.IsNotFound if it does not exist.
+type objectGetter func(ctx context.Context, ref kube.ObjectRef) (client.Object, error)
+
+func (r *ResourceController) reconcileObject(resourceKind kube.Kind, clusterScoped bool, getObject objectGetter) reconcile.Func {
 	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 		log := r.Logger.WithValues("kind", resourceKind, "name", req.NamespacedName)
 
 		resourceRef := kube.ObjectRefFromKindAndObjectKey(resourceKind, req.NamespacedName)
+		stale := r.takeStale(resourceRef)
 
-		resource, err := r.ObjectFromObjectRef(ctx, resourceRef)
+		resource, err := getObject(ctx, resourceRef)
 		if err != nil {
 			if errors.IsNotFound(err) {
 				log.V(1).Info("Ignoring cached resource that must have been deleted")
@@ -211,15 +312,17 @@ func (r *ResourceController) reconcileResource(resourceKind kube.Kind) reconcile
 			return ctrl.Result{}, fmt.Errorf("computing policies hash: %w", err)
 		}
 
-		log.V(1).Info("Checking whether configuration audit report exists")
-		hasReport, err := r.hasReport(ctx, resourceRef, resourceHash, policiesHash)
-		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("checking whether configuration audit report exists: %w", err)
-		}
+		if !stale {
+			log.V(1).Info("Checking whether configuration audit report exists")
+			hasReport, err := r.hasReport(ctx, resourceRef, clusterScoped, resourceHash, policiesHash)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("checking whether configuration audit report exists: %w", err)
+			}
 
-		if hasReport {
-			log.V(1).Info("Configuration audit report exists")
-			return ctrl.Result{}, nil
+			if hasReport {
+				log.V(1).Info("Configuration audit report exists")
+				return ctrl.Result{}, nil
+			}
 		}
 
 		reportData, err := r.evaluate(ctx, policies, resource)
@@ -227,10 +330,23 @@ func (r *ResourceController) reconcileResource(resourceKind kube.Kind) reconcile
 			return ctrl.Result{}, fmt.Errorf("evaluating resource: %w", err)
 		}
 
+		if stale {
+			unchanged, err := r.hasChecks(ctx, resourceRef, clusterScoped, resourceHash, policiesHash, reportData.Checks)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("checking whether configuration audit report changed: %w", err)
+			}
+			if unchanged {
+				log.V(1).Info("Configuration audit report has not changed")
+				return ctrl.Result{}, nil
+			}
+		}
+
 		reportBuilder := NewReportBuilder(r.Client.Scheme()).
 			Controller(resource).
 			ResourceSpecHash(resourceHash).
 			PluginConfigHash(policiesHash).
+			Scanner(BuiltInScanner).
+			Additional(r.additional()).
 			Data(reportData)
 		err = reportBuilder.Write(ctx, r.ReadWriter)
 		if err != nil {
@@ -241,34 +357,89 @@ func (r *ResourceController) reconcileResource(resourceKind kube.Kind) reconcile
 	}
 }
 
-func (r *ResourceController) hasReport(ctx context.Context, owner kube.ObjectRef, podSpecHash string, pluginConfigHash string) (bool, error) {
-	if kube.IsClusterScopedKind(string(owner.Kind)) {
+func (r *ResourceController) hasReport(ctx context.Context, owner kube.ObjectRef, clusterScoped bool, podSpecHash string, pluginConfigHash string) (bool, error) {
+	if clusterScoped {
 		return r.hasClusterReport(ctx, owner, podSpecHash, pluginConfigHash)
 	}
-	// TODO FindByOwner should accept optional label selector to further narrow down search results
-	report, err := r.ReadWriter.FindReportByOwner(ctx, owner)
+	report, err := r.ReadWriter.FindReportByOwnerAndScanner(ctx, owner, BuiltInScanner)
 	if err != nil {
 		return false, err
 	}
 	if report != nil {
 		return report.Labels[starboard.LabelResourceSpecHash] == podSpecHash &&
-			report.Labels[starboard.LabelPluginConfigHash] == pluginConfigHash, nil
+			report.Labels[starboard.LabelPluginConfigHash] == pluginConfigHash &&
+			additional(report.Labels) == r.additional(), nil
 	}
 	return false, nil
 }
 
 func (r *ResourceController) hasClusterReport(ctx context.Context, owner kube.ObjectRef, podSpecHash string, pluginConfigHash string) (bool, error) {
-	report, err := r.ReadWriter.FindClusterReportByOwner(ctx, owner)
+	report, err := r.ReadWriter.FindClusterReportByOwnerAndScanner(ctx, owner, BuiltInScanner)
 	if err != nil {
 		return false, err
 	}
 	if report != nil {
 		return report.Labels[starboard.LabelResourceSpecHash] == podSpecHash &&
-			report.Labels[starboard.LabelPluginConfigHash] == pluginConfigHash, nil
+			report.Labels[starboard.LabelPluginConfigHash] == pluginConfigHash &&
+			additional(report.Labels) == r.additional(), nil
 	}
 	return false, nil
 }
 
+// hasChecks returns true if the up-to-date report of the specified owner has
+// the given checks.
+func (r *ResourceController) hasChecks(ctx context.Context, owner kube.ObjectRef, clusterScoped bool, podSpecHash string, pluginConfigHash string, checks []v1alpha1.Check) (bool, error) {
+	var reportLabels map[string]string
+	var reportChecks []v1alpha1.Check
+	if clusterScoped {
+		report, err := r.ReadWriter.FindClusterReportByOwnerAndScanner(ctx, owner, BuiltInScanner)
+		if err != nil || report == nil {
+			return false, err
+		}
+		reportLabels, reportChecks = report.Labels, report.Report.Checks
+	} else {
+		report, err := r.ReadWriter.FindReportByOwnerAndScanner(ctx, owner, BuiltInScanner)
+		if err != nil || report == nil {
+			return false, err
+		}
+		reportLabels, reportChecks = report.Labels, report.Report.Checks
+	}
+	return reportLabels[starboard.LabelResourceSpecHash] == podSpecHash &&
+		reportLabels[starboard.LabelPluginConfigHash] == pluginConfigHash &&
+		additional(reportLabels) == r.additional() &&
+		equality.Semantic.DeepEqual(reportChecks, checks), nil
+}
+
+// markStale marks the specified resource to be re-evaluated when it's
+// reconciled next time.
+func (r *ResourceController) markStale(ref kube.ObjectRef) {
+	r.staleMu.Lock()
+	defer r.staleMu.Unlock()
+	if r.stale == nil {
+		r.stale = make(map[kube.ObjectRef]bool)
+	}
+	r.stale[ref] = true
+}
+
+// takeStale returns true and unmarks the specified resource if it's marked
+// to be re-evaluated.
+func (r *ResourceController) takeStale(ref kube.ObjectRef) bool {
+	r.staleMu.Lock()
+	defer r.staleMu.Unlock()
+	if !r.stale[ref] {
+		return false
+	}
+	delete(r.stale, ref)
+	return true
+}
+
+// additional returns true if a plugin-based configuration audit scanner is
+// enabled as well, in which case reports of the built-in scanner are named
+// after the scanner so that they do not collide with reports of the plugin.
+func (r *ResourceController) additional() bool {
+	return r.Config.ConfigAuditScannerEnabled
+}
+
 func (r *ResourceController) policies(ctx context.Context) (*policy.Policies, error) {
 	cm := &corev1.ConfigMap{}
 
@@ -277,16 +448,32 @@ func (r *ResourceController) policies(ctx context.Context) (*policy.Policies, er
 		Name:      starboard.PoliciesConfigMapName,
 	}, cm)
 	if err != nil {
-		return nil, fmt.Errorf("failed getting policies from configmap: %s/%s: %w", r.Config.Namespace, starboard.PoliciesConfigMapName, err)
+		if r.PolicyLoader == nil || !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed getting policies from configmap: %s/%s: %w", r.Config.Namespace, starboard.PoliciesConfigMapName, err)
+		}
+	}
+	policies := policy.NewPolicies(cm.Data)
+	if r.PolicyLoader != nil {
+		policies, err = r.PolicyLoader.Policies(cm.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	policies.WithCache(r.PolicyCache)
+	if r.Inventory != nil {
+		policies.WithInventory(r.Inventory)
 	}
-	return policy.NewPolicies(cm.Data), nil
+	return policies, nil
 }
 
 func (r *ResourceController) evaluate(ctx context.Context, policies *policy.Policies, resource client.Object) (v1alpha1.ConfigAuditReportData, error) {
+	start := time.Now()
 	results, err := policies.Eval(ctx, resource)
 	if err != nil {
 		return v1alpha1.ConfigAuditReportData{}, err
 	}
+	metrics.PolicyEvaluationDuration.WithLabelValues(resource.GetObjectKind().GroupVersionKind().Kind).
+		Observe(time.Since(start).Seconds())
 
 	checks := make([]v1alpha1.Check, len(results))
 	for i, result := range results {
@@ -304,7 +491,7 @@ func (r *ResourceController) evaluate(ctx context.Context, policies *policy.Poli
 
 	return v1alpha1.ConfigAuditReportData{
 		Scanner: v1alpha1.Scanner{
-			Name:    "Starboard",
+			Name:    BuiltInScanner,
 			Vendor:  "Aqua Security",
 			Version: r.BuildInfo.Version,
 		},
@@ -322,12 +509,14 @@ func (r *ResourceController) reconcileConfig(kind kube.Kind) reconcile.Func {
 
 		cm := &corev1.ConfigMap{}
 
+		// Policies of the bundle are reconciled even if the ConfigMap does
+		// not exist.
 		err := r.Client.Get(ctx, req.NamespacedName, cm)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				log.V(1).Info("Ignoring cached ConfigMap that must have been deleted")
-				return ctrl.Result{}, nil
-			}
+		switch {
+		case errors.IsNotFound(err) && r.PolicyLoader == nil:
+			log.V(1).Info("Ignoring cached ConfigMap that must have been deleted")
+			return ctrl.Result{}, nil
+		case err != nil && !errors.IsNotFound(err):
 			return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
 		}
 
@@ -341,9 +530,10 @@ func (r *ResourceController) reconcileConfig(kind kube.Kind) reconcile.Func {
 			return ctrl.Result{}, fmt.Errorf("getting config hash: %w", err)
 		}
 
-		labelSelector, err := labels.Parse(fmt.Sprintf("%s!=%s,%s=%s",
+		labelSelector, err := labels.Parse(fmt.Sprintf("%s!=%s,%s=%s,%s=%s",
 			starboard.LabelPluginConfigHash, configHash,
-			starboard.LabelResourceKind, kind))
+			starboard.LabelResourceKind, kind,
+			starboard.LabelConfigAuditReportScanner, BuiltInScanner))
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("parsing label selector: %w", err)
 		}
@@ -387,12 +577,14 @@ func (r *ResourceController) reconcileClusterConfig(kind kube.Kind) reconcile.Fu
 
 		cm := &corev1.ConfigMap{}
 
+		// Policies of the bundle are reconciled even if the ConfigMap does
+		// not exist.
 		err := r.Client.Get(ctx, req.NamespacedName, cm)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				log.V(1).Info("Ignoring cached ConfigMap that must have been deleted")
-				return ctrl.Result{}, nil
-			}
+		switch {
+		case errors.IsNotFound(err) && r.PolicyLoader == nil:
+			log.V(1).Info("Ignoring cached ConfigMap that must have been deleted")
+			return ctrl.Result{}, nil
+		case err != nil && !errors.IsNotFound(err):
 			return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
 		}
 
@@ -406,9 +598,10 @@ func (r *ResourceController) reconcileClusterConfig(kind kube.Kind) reconcile.Fu
 			return ctrl.Result{}, fmt.Errorf("getting config hash: %w", err)
 		}
 
-		labelSelector, err := labels.Parse(fmt.Sprintf("%s!=%s,%s=%s",
+		labelSelector, err := labels.Parse(fmt.Sprintf("%s!=%s,%s=%s,%s=%s",
 			starboard.LabelPluginConfigHash, configHash,
-			starboard.LabelResourceKind, kind))
+			starboard.LabelResourceKind, kind,
+			starboard.LabelConfigAuditReportScanner, BuiltInScanner))
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("parsing label selector: %w", err)
 		}
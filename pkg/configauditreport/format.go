@@ -0,0 +1,297 @@
+package configauditreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// Format is an output format of LocalReport results.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatSARIF Format = "sarif"
+	FormatJUnit Format = "junit"
+)
+
+const (
+	sarifVersion        = "2.1.0"
+	sarifSchema         = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifInformationURI = "https://github.com/aquasecurity/starboard"
+)
+
+var severityOrder = map[v1alpha1.Severity]int{
+	v1alpha1.SeverityCritical: 4,
+	v1alpha1.SeverityHigh:     3,
+	v1alpha1.SeverityMedium:   2,
+	v1alpha1.SeverityLow:      1,
+}
+
+// securitySeverity maps severities to scores, which code scanning tools, such
+// as GitHub code scanning, use to rank SARIF results.
+var securitySeverity = map[v1alpha1.Severity]string{
+	v1alpha1.SeverityCritical: "9.5",
+	v1alpha1.SeverityHigh:     "8.0",
+	v1alpha1.SeverityMedium:   "5.5",
+	v1alpha1.SeverityLow:      "2.0",
+}
+
+// FailedChecks returns the number of failed checks of the given reports with
+// the specified severity or higher.
+func FailedChecks(reports []LocalReport, threshold v1alpha1.Severity) int {
+	var count int
+	for _, report := range reports {
+		for _, check := range report.Report.Checks {
+			if !check.Success && severityOrder[check.Severity] >= severityOrder[threshold] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// SARIF is a log in the Static Analysis Results Interchange Format.
+// @see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type SARIF struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+type SARIFRule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name,omitempty"`
+	ShortDescription     SARIFMessage           `json:"shortDescription"`
+	FullDescription      *SARIFMessage          `json:"fullDescription,omitempty"`
+	DefaultConfiguration SARIFRuleConfiguration `json:"defaultConfiguration"`
+	Properties           SARIFRuleProperties    `json:"properties"`
+}
+
+type SARIFRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SARIFRuleProperties struct {
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// NewSARIF converts failed checks of the given reports to SARIF results.
+// Checks are converted to rules, which are shared by results of all reports.
+func NewSARIF(reports []LocalReport) SARIF {
+	driver := SARIFDriver{
+		Name:           BuiltInScanner,
+		InformationURI: sarifInformationURI,
+		Rules:          []SARIFRule{},
+	}
+	checks := map[string]v1alpha1.Check{}
+	for _, report := range reports {
+		driver.Version = report.Report.Scanner.Version
+		for _, check := range report.Report.Checks {
+			checks[check.ID] = check
+		}
+	}
+	ids := make([]string, 0, len(checks))
+	for id := range checks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	ruleIndex := make(map[string]int, len(ids))
+	for i, id := range ids {
+		check := checks[id]
+		rule := SARIFRule{
+			ID:               id,
+			Name:             check.Title,
+			ShortDescription: SARIFMessage{Text: check.Title},
+			DefaultConfiguration: SARIFRuleConfiguration{
+				Level: sarifLevel(check.Severity),
+			},
+			Properties: SARIFRuleProperties{
+				SecuritySeverity: securitySeverity[check.Severity],
+				Tags:             []string{"security"},
+			},
+		}
+		if check.Description != "" {
+			rule.FullDescription = &SARIFMessage{Text: check.Description}
+		}
+		if check.Category != "" {
+			rule.Properties.Tags = append(rule.Properties.Tags, check.Category)
+		}
+		driver.Rules = append(driver.Rules, rule)
+		ruleIndex[id] = i
+	}
+
+	run := SARIFRun{
+		Tool:    SARIFTool{Driver: driver},
+		Results: []SARIFResult{},
+	}
+	for _, report := range reports {
+		for _, check := range report.Report.Checks {
+			if check.Success {
+				continue
+			}
+			run.Results = append(run.Results, SARIFResult{
+				RuleID:    check.ID,
+				RuleIndex: ruleIndex[check.ID],
+				Level:     sarifLevel(check.Severity),
+				Message:   SARIFMessage{Text: checkMessage(check)},
+				Locations: []SARIFLocation{
+					{
+						PhysicalLocation: SARIFPhysicalLocation{
+							ArtifactLocation: SARIFArtifactLocation{URI: filepath.ToSlash(report.Path)},
+						},
+						LogicalLocations: []SARIFLogicalLocation{
+							{
+								FullyQualifiedName: report.ResourceName(),
+								Kind:               "resource",
+							},
+						},
+					},
+				},
+			})
+		}
+	}
+	return SARIF{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []SARIFRun{run},
+	}
+}
+
+func sarifLevel(severity v1alpha1.Severity) string {
+	switch severity {
+	case v1alpha1.SeverityCritical, v1alpha1.SeverityHigh:
+		return "error"
+	case v1alpha1.SeverityMedium:
+		return "warning"
+	}
+	return "note"
+}
+
+// JUnit is a report in the JUnit XML format, which is understood by most CI
+// systems.
+type JUnit struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+type JUnitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// NewJUnit converts the given reports to JUnit test suites, one per report,
+// with a test case for each check.
+func NewJUnit(reports []LocalReport) JUnit {
+	junit := JUnit{
+		Name:   BuiltInScanner,
+		Suites: []JUnitTestSuite{},
+	}
+	for _, report := range reports {
+		suite := JUnitTestSuite{
+			Name:  fmt.Sprintf("%s: %s", filepath.ToSlash(report.Path), report.ResourceName()),
+			Tests: len(report.Report.Checks),
+			Cases: []JUnitTestCase{},
+		}
+		if !report.Report.UpdateTimestamp.IsZero() {
+			suite.Timestamp = report.Report.UpdateTimestamp.UTC().Format("2006-01-02T15:04:05")
+		}
+		for _, check := range report.Report.Checks {
+			testCase := JUnitTestCase{
+				ClassName: report.ResourceName(),
+				Name:      fmt.Sprintf("[%s] %s: %s", check.Severity, check.ID, check.Title),
+			}
+			if !check.Success {
+				testCase.Failure = &JUnitFailure{
+					Message:  check.Title,
+					Type:     string(check.Severity),
+					Contents: checkMessage(check),
+				}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		junit.Tests += suite.Tests
+		junit.Failures += suite.Failures
+		junit.Suites = append(junit.Suites, suite)
+	}
+	return junit
+}
+
+func checkMessage(check v1alpha1.Check) string {
+	if len(check.Messages) == 0 {
+		return check.Title
+	}
+	return strings.Join(check.Messages, "\n")
+}
@@ -0,0 +1,141 @@
+package configauditreport_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var sampleLocalReports = []configauditreport.LocalReport{
+	{
+		Path:      "deploy/nginx.yaml",
+		Kind:      "Deployment",
+		Namespace: "default",
+		Name:      "nginx",
+		Report: v1alpha1.ConfigAuditReportData{
+			UpdateTimestamp: metav1.NewTime(time.Date(2022, time.June, 1, 10, 30, 0, 0, time.UTC)),
+			Scanner: v1alpha1.Scanner{
+				Name:    "Starboard",
+				Vendor:  "Aqua Security",
+				Version: "v0.15.0",
+			},
+			Checks: []v1alpha1.Check{
+				{
+					ID:          "KSV012",
+					Title:       "Runs as root user",
+					Description: "Containers should run as a non-root user",
+					Severity:    v1alpha1.SeverityMedium,
+					Category:    "Kubernetes Security Check",
+					Success:     false,
+					Messages:    []string{"Deployment should set runAsNonRoot"},
+				},
+				{
+					ID:       "KSV017",
+					Title:    "Privileged container",
+					Severity: v1alpha1.SeverityHigh,
+					Success:  true,
+				},
+			},
+		},
+	},
+	{
+		Path: "rbac/role.yaml",
+		Kind: "ClusterRole",
+		Name: "admin",
+		Report: v1alpha1.ConfigAuditReportData{
+			Scanner: v1alpha1.Scanner{
+				Name:    "Starboard",
+				Vendor:  "Aqua Security",
+				Version: "v0.15.0",
+			},
+			Checks: []v1alpha1.Check{
+				{
+					ID:       "KSV046",
+					Title:    "Manage all resources",
+					Severity: v1alpha1.SeverityCritical,
+					Success:  false,
+				},
+			},
+		},
+	},
+}
+
+func TestNewSARIF(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sarif := configauditreport.NewSARIF(sampleLocalReports)
+
+	g.Expect(sarif.Version).To(Equal("2.1.0"))
+	g.Expect(sarif.Runs).To(HaveLen(1))
+
+	driver := sarif.Runs[0].Tool.Driver
+	g.Expect(driver.Name).To(Equal("Starboard"))
+	g.Expect(driver.Version).To(Equal("v0.15.0"))
+	g.Expect(driver.Rules).To(HaveLen(3))
+	g.Expect(driver.Rules[0].ID).To(Equal("KSV012"))
+	g.Expect(driver.Rules[0].FullDescription).To(Equal(&configauditreport.SARIFMessage{Text: "Containers should run as a non-root user"}))
+	g.Expect(driver.Rules[0].DefaultConfiguration.Level).To(Equal("warning"))
+	g.Expect(driver.Rules[0].Properties).To(Equal(configauditreport.SARIFRuleProperties{
+		SecuritySeverity: "5.5",
+		Tags:             []string{"security", "Kubernetes Security Check"},
+	}))
+	g.Expect(driver.Rules[1].ID).To(Equal("KSV017"))
+	g.Expect(driver.Rules[2].ID).To(Equal("KSV046"))
+
+	results := sarif.Runs[0].Results
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].RuleID).To(Equal("KSV012"))
+	g.Expect(results[0].RuleIndex).To(Equal(0))
+	g.Expect(results[0].Level).To(Equal("warning"))
+	g.Expect(results[0].Message.Text).To(Equal("Deployment should set runAsNonRoot"))
+	g.Expect(results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI).To(Equal("deploy/nginx.yaml"))
+	g.Expect(results[0].Locations[0].LogicalLocations[0].FullyQualifiedName).To(Equal("default/Deployment/nginx"))
+	g.Expect(results[1].RuleID).To(Equal("KSV046"))
+	g.Expect(results[1].RuleIndex).To(Equal(2))
+	g.Expect(results[1].Level).To(Equal("error"))
+	g.Expect(results[1].Message.Text).To(Equal("Manage all resources"))
+	g.Expect(results[1].Locations[0].LogicalLocations[0].FullyQualifiedName).To(Equal("ClusterRole/admin"))
+
+	_, err := json.Marshal(sarif)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestNewJUnit(t *testing.T) {
+	g := NewGomegaWithT(t)
+	junit := configauditreport.NewJUnit(sampleLocalReports)
+
+	g.Expect(junit.Tests).To(Equal(3))
+	g.Expect(junit.Failures).To(Equal(2))
+	g.Expect(junit.Suites).To(HaveLen(2))
+
+	suite := junit.Suites[0]
+	g.Expect(suite.Name).To(Equal("deploy/nginx.yaml: default/Deployment/nginx"))
+	g.Expect(suite.Tests).To(Equal(2))
+	g.Expect(suite.Failures).To(Equal(1))
+	g.Expect(suite.Timestamp).To(Equal("2022-06-01T10:30:00"))
+	g.Expect(suite.Cases).To(Equal([]configauditreport.JUnitTestCase{
+		{
+			ClassName: "default/Deployment/nginx",
+			Name:      "[MEDIUM] KSV012: Runs as root user",
+			Failure: &configauditreport.JUnitFailure{
+				Message:  "Runs as root user",
+				Type:     "MEDIUM",
+				Contents: "Deployment should set runAsNonRoot",
+			},
+		},
+		{
+			ClassName: "default/Deployment/nginx",
+			Name:      "[HIGH] KSV017: Privileged container",
+		},
+	}))
+	g.Expect(junit.Suites[1].Timestamp).To(BeEmpty())
+
+	content, err := xml.Marshal(junit)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(HavePrefix(`<testsuites name="Starboard" tests="3" failures="2">`))
+}
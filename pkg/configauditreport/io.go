@@ -6,6 +6,8 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/notification"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,8 +29,9 @@ type Writer interface {
 // TODO(danielpacak): Consider returning starboard.ResourceNotFound error instead of returning nil.
 type Reader interface {
 
-	// FindReportByOwner returns a v1alpha1.ConfigAuditReport owned by the given
-	// kube.ObjectRef or nil if the report is not found.
+	// FindReportByOwner returns a v1alpha1.ConfigAuditReport of the primary
+	// scanner owned by the given kube.ObjectRef or nil if the report is not
+	// found. Reports of an additional scanner are skipped.
 	FindReportByOwner(ctx context.Context, owner kube.ObjectRef) (*v1alpha1.ConfigAuditReport, error)
 
 	// FindReportByOwnerInHierarchy is similar to FindReportByOwner except that it tries to find
@@ -37,9 +40,18 @@ type Reader interface {
 	// active ReplicaSet (current revision) this method will return the report.
 	FindReportByOwnerInHierarchy(ctx context.Context, owner kube.ObjectRef) (*v1alpha1.ConfigAuditReport, error)
 
-	// FindClusterReportByOwner returns a v1alpha1.ClusterConfigAuditReport owned by the given
-	// kube.ObjectRef or nil if the report is not found.
+	// FindClusterReportByOwner returns a v1alpha1.ClusterConfigAuditReport of the primary
+	// scanner owned by the given kube.ObjectRef or nil if the report is not found.
+	// Reports of an additional scanner are skipped.
 	FindClusterReportByOwner(ctx context.Context, owner kube.ObjectRef) (*v1alpha1.ClusterConfigAuditReport, error)
+
+	// FindReportByOwnerAndScanner is similar to FindReportByOwner except that
+	// it only returns a v1alpha1.ConfigAuditReport generated by the given scanner.
+	FindReportByOwnerAndScanner(ctx context.Context, owner kube.ObjectRef, scanner string) (*v1alpha1.ConfigAuditReport, error)
+
+	// FindClusterReportByOwnerAndScanner is similar to FindClusterReportByOwner except that
+	// it only returns a v1alpha1.ClusterConfigAuditReport generated by the given scanner.
+	FindClusterReportByOwnerAndScanner(ctx context.Context, owner kube.ObjectRef, scanner string) (*v1alpha1.ClusterConfigAuditReport, error)
 }
 
 type ReadWriter interface {
@@ -49,6 +61,7 @@ type ReadWriter interface {
 
 type readWriter struct {
 	*kube.ObjectResolver
+	notifier notification.Notifier
 }
 
 // NewReadWriter constructs a new ReadWriter which is using the client package
@@ -60,6 +73,16 @@ func NewReadWriter(client client.Client) ReadWriter {
 	}
 }
 
+// NewNotifyingReadWriter constructs a new ReadWriter which, in addition to
+// what NewReadWriter does, sends notifications about findings that were not
+// present in the previous version of a written report.
+func NewNotifyingReadWriter(client client.Client, notifier notification.Notifier) ReadWriter {
+	return &readWriter{
+		ObjectResolver: &kube.ObjectResolver{Client: client},
+		notifier:       notifier,
+	}
+}
+
 func (r *readWriter) WriteReport(ctx context.Context, report v1alpha1.ConfigAuditReport) error {
 	var existing v1alpha1.ConfigAuditReport
 	err := r.Get(ctx, types.NamespacedName{
@@ -72,16 +95,37 @@ func (r *readWriter) WriteReport(ctx context.Context, report v1alpha1.ConfigAudi
 		copied.Labels = report.Labels
 		copied.Report = report.Report
 
-		return r.Update(ctx, copied)
+		err = r.Update(ctx, copied)
+		if err != nil {
+			return err
+		}
+		r.notify(ctx, report, &existing.Report)
+		return nil
 	}
 
 	if errors.IsNotFound(err) {
-		return r.Create(ctx, &report)
+		err = r.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		r.notify(ctx, report, nil)
+		return nil
 	}
 
 	return err
 }
 
+// notify sends a notification about findings in the given report that are
+// not present in the previous report data, if the notifier is configured.
+func (r *readWriter) notify(ctx context.Context, report v1alpha1.ConfigAuditReport, previous *v1alpha1.ConfigAuditReportData) {
+	if r.notifier == nil {
+		return
+	}
+	if n, ok := notification.NewConfigAuditNotification(report, previous); ok {
+		r.notifier.Notify(ctx, n)
+	}
+}
+
 func (r *readWriter) WriteClusterReport(ctx context.Context, report v1alpha1.ClusterConfigAuditReport) error {
 	var existing v1alpha1.ClusterConfigAuditReport
 	err := r.Get(ctx, types.NamespacedName{
@@ -104,18 +148,33 @@ func (r *readWriter) WriteClusterReport(ctx context.Context, report v1alpha1.Clu
 }
 
 func (r *readWriter) FindReportByOwner(ctx context.Context, owner kube.ObjectRef) (*v1alpha1.ConfigAuditReport, error) {
+	return r.findReport(ctx, kube.ObjectRefToLabels(owner), owner.Namespace)
+}
+
+func (r *readWriter) FindReportByOwnerAndScanner(ctx context.Context, owner kube.ObjectRef, scanner string) (*v1alpha1.ConfigAuditReport, error) {
+	labels := kube.ObjectRefToLabels(owner)
+	labels[starboard.LabelConfigAuditReportScanner] = scanner
+	return r.findReport(ctx, labels, owner.Namespace)
+}
+
+func (r *readWriter) findReport(ctx context.Context, ownerLabels map[string]string, namespace string) (*v1alpha1.ConfigAuditReport, error) {
 	var list v1alpha1.ConfigAuditReportList
 
-	labels := client.MatchingLabels(kube.ObjectRefToLabels(owner))
+	labels := client.MatchingLabels(ownerLabels)
 
-	err := r.List(ctx, &list, labels, client.InNamespace(owner.Namespace))
+	err := r.List(ctx, &list, labels, client.InNamespace(namespace))
 	if err != nil {
 		return nil, err
 	}
 
-	// Only one config audit per specific workload exists on the cluster
-	if len(list.Items) > 0 {
-		return &list.DeepCopy().Items[0], nil
+	// Besides the report of the primary scanner, a workload may have the
+	// report of an additional scanner, which is returned only if the scanner
+	// is specified.
+	for _, report := range list.Items {
+		if additional(report.Labels) && ownerLabels[starboard.LabelConfigAuditReportScanner] == "" {
+			continue
+		}
+		return report.DeepCopy(), nil
 	}
 	return nil, nil
 }
@@ -147,18 +206,40 @@ func (r *readWriter) FindReportByOwnerInHierarchy(ctx context.Context, owner kub
 }
 
 func (r *readWriter) FindClusterReportByOwner(ctx context.Context, owner kube.ObjectRef) (*v1alpha1.ClusterConfigAuditReport, error) {
+	return r.findClusterReport(ctx, kube.ObjectRefToLabels(owner))
+}
+
+func (r *readWriter) FindClusterReportByOwnerAndScanner(ctx context.Context, owner kube.ObjectRef, scanner string) (*v1alpha1.ClusterConfigAuditReport, error) {
+	labels := kube.ObjectRefToLabels(owner)
+	labels[starboard.LabelConfigAuditReportScanner] = scanner
+	return r.findClusterReport(ctx, labels)
+}
+
+func (r *readWriter) findClusterReport(ctx context.Context, ownerLabels map[string]string) (*v1alpha1.ClusterConfigAuditReport, error) {
 	var list v1alpha1.ClusterConfigAuditReportList
 
-	labels := client.MatchingLabels(kube.ObjectRefToLabels(owner))
+	labels := client.MatchingLabels(ownerLabels)
 
 	err := r.List(ctx, &list, labels)
 	if err != nil {
 		return nil, err
 	}
 
-	// Only one config audit per specific workload exists on the cluster
-	if len(list.Items) > 0 {
-		return &list.DeepCopy().Items[0], nil
+	// Besides the report of the primary scanner, a workload may have the
+	// report of an additional scanner, which is returned only if the scanner
+	// is specified.
+	for _, report := range list.Items {
+		if additional(report.Labels) && ownerLabels[starboard.LabelConfigAuditReportScanner] == "" {
+			continue
+		}
+		return report.DeepCopy(), nil
 	}
 	return nil, nil
 }
+
+// additional returns true if the given labels are labels of a report generated
+// by an additional configuration audit scanner.
+func additional(labels map[string]string) bool {
+	_, ok := labels[starboard.LabelConfigAuditReportAdditional]
+	return ok
+}
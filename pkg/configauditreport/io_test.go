@@ -247,6 +247,99 @@ func TestReadWriter(t *testing.T) {
 		}, found)
 	})
 
+	t.Run("Should find ConfigAuditReport by owner and scanner", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+			&v1alpha1.ConfigAuditReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "my-namespace",
+					Name:            "deployment-my-deploy",
+					ResourceVersion: "0",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:             string(kube.KindDeployment),
+						starboard.LabelResourceName:             "my-deploy",
+						starboard.LabelResourceNamespace:        "my-namespace",
+						starboard.LabelConfigAuditReportScanner: "Polaris",
+					},
+				},
+				Report: v1alpha1.ConfigAuditReportData{},
+			}, &v1alpha1.ConfigAuditReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "my-namespace",
+					Name:            "deployment-my-deploy-starboard",
+					ResourceVersion: "0",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:                string(kube.KindDeployment),
+						starboard.LabelResourceName:                "my-deploy",
+						starboard.LabelResourceNamespace:           "my-namespace",
+						starboard.LabelConfigAuditReportScanner:    "Starboard",
+						starboard.LabelConfigAuditReportAdditional: "true",
+					},
+				},
+				Report: v1alpha1.ConfigAuditReportData{},
+			}).Build()
+
+		readWriter := configauditreport.NewReadWriter(client)
+		owner := kube.ObjectRef{
+			Kind:      kube.KindDeployment,
+			Name:      "my-deploy",
+			Namespace: "my-namespace",
+		}
+
+		found, err := readWriter.FindReportByOwnerAndScanner(context.TODO(), owner, "Starboard")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "deployment-my-deploy-starboard", found.Name)
+
+		found, err = readWriter.FindReportByOwnerAndScanner(context.TODO(), owner, "Polaris")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "deployment-my-deploy", found.Name)
+
+		found, err = readWriter.FindReportByOwnerAndScanner(context.TODO(), owner, "Conftest")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Should find ConfigAuditReport of primary scanner by owner", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+			&v1alpha1.ConfigAuditReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-namespace",
+					Name:      "deployment-my-deploy-a-starboard",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:                string(kube.KindDeployment),
+						starboard.LabelResourceName:                "my-deploy",
+						starboard.LabelResourceNamespace:           "my-namespace",
+						starboard.LabelConfigAuditReportScanner:    "Starboard",
+						starboard.LabelConfigAuditReportAdditional: "true",
+					},
+				},
+				Report: v1alpha1.ConfigAuditReportData{},
+			}, &v1alpha1.ConfigAuditReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-namespace",
+					Name:      "deployment-my-deploy-b",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:             string(kube.KindDeployment),
+						starboard.LabelResourceName:             "my-deploy",
+						starboard.LabelResourceNamespace:        "my-namespace",
+						starboard.LabelConfigAuditReportScanner: "Polaris",
+					},
+				},
+				Report: v1alpha1.ConfigAuditReportData{},
+			}).Build()
+
+		readWriter := configauditreport.NewReadWriter(client)
+		found, err := readWriter.FindReportByOwner(context.TODO(), kube.ObjectRef{
+			Kind:      kube.KindDeployment,
+			Name:      "my-deploy",
+			Namespace: "my-namespace",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "deployment-my-deploy-b", found.Name)
+	})
+
 	t.Run("Should create ClusterConfigAuditReport", func(t *testing.T) {
 		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
 		readWriter := configauditreport.NewReadWriter(client)
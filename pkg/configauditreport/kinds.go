@@ -0,0 +1,643 @@
+package configauditreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/policy"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	predicatex "sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// kindWatches starts and stops watches of kinds named in .kinds entries of
+// policies, which are not watched by ResourceController with typed objects,
+// e.g. Istio VirtualServices, cert-manager Certificates or Argo Rollouts.
+//
+// Kinds are resolved to API resources with the discovery client and the
+// RESTMapper, which also determines whether a kind is namespaced or
+// cluster-scoped. Objects are represented as unstructured.Unstructured and
+// watched with dynamic informers. Each kind has its own informers and
+// controller, which are stopped when policies no longer name the kind.
+//
+// It also watches kinds of objects that policies read from data.kubernetes.
+// Whenever any of these objects changes, resources of kinds whose policies
+// read them are enqueued to be re-evaluated.
+type kindWatches struct {
+	r          *ResourceController
+	mgr        ctrl.Manager
+	discovery  discovery.DiscoveryInterface
+	dynamic    dynamic.Interface
+	namespaces []string
+
+	installModePredicate predicatex.Predicate
+
+	// static holds kinds watched by ResourceController with typed objects.
+	static map[kube.Kind]bool
+
+	mu      sync.Mutex
+	ctx     context.Context
+	watches map[schema.GroupVersionKind]*kindWatch
+	// stopped holds kinds that are no longer watched and whether they are
+	// cluster-scoped, until reports evaluated with previous policies are
+	// deleted.
+	stopped map[kube.Kind]bool
+	// resolved holds kinds named by policies the last time, and complete is
+	// true if all of them were resolved, so that the discovery client is not
+	// called again until policies change.
+	resolved string
+	complete bool
+
+	// dataTargets are kinds watched with typed objects, whose resources are
+	// enqueued if their policies read objects that changed.
+	dataTargets []dataTarget
+
+	// dataKinds holds kinds read by policies from data.kubernetes, whereas
+	// informers holds kinds of cached informers with registered event
+	// handlers, which cannot be removed. Kinds of objects that changed are
+	// held in changed until dependent resources are enqueued.
+	dataMu      sync.Mutex
+	dataKinds   map[string]bool
+	informers   map[string]bool
+	changed     map[string]bool
+	dataChanged chan struct{}
+}
+
+// kindWatch is a watch of a kind resolved to an API resource.
+type kindWatch struct {
+	mapping       *meta.RESTMapping
+	kind          kube.Kind
+	clusterScoped bool
+	// informers are keyed by namespace, which is blank if the informer
+	// watches all namespaces.
+	informers map[string]informers.GenericInformer
+	// events enqueue objects of the kind, whose policies read objects that
+	// changed.
+	events chan event.GenericEvent
+	cancel context.CancelFunc
+}
+
+func newKindWatches(r *ResourceController, mgr ctrl.Manager, static []kube.Kind, dataTargets []dataTarget) (*kindWatches, error) {
+	installModePredicate, err := predicate.InstallModePredicate(r.Config)
+	if err != nil {
+		return nil, err
+	}
+	mode, _, targetNamespaces, err := r.Config.ResolveInstallMode()
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []string{metav1.NamespaceAll}
+	if mode != etc.AllNamespaces {
+		namespaces = targetNamespaces
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("constructing discovery client: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("constructing dynamic client: %w", err)
+	}
+
+	staticKinds := make(map[kube.Kind]bool)
+	for _, kind := range static {
+		staticKinds[kind] = true
+	}
+
+	return &kindWatches{
+		r:          r,
+		mgr:        mgr,
+		discovery:  discoveryClient,
+		dynamic:    dynamicClient,
+		namespaces: namespaces,
+
+		installModePredicate: installModePredicate,
+
+		static:  staticKinds,
+		watches: make(map[schema.GroupVersionKind]*kindWatch),
+		stopped: make(map[kube.Kind]bool),
+
+		dataTargets: dataTargets,
+		dataKinds:   make(map[string]bool),
+		informers:   make(map[string]bool),
+		changed:     make(map[string]bool),
+		dataChanged: make(chan struct{}, 1),
+	}, nil
+}
+
+// Start holds the context in which watches are started until it's done, and
+// enqueues resources whose policies read objects that changed. It implements
+// manager.Runnable, so that watches are only started by the leader.
+func (w *kindWatches) Start(ctx context.Context) error {
+	w.mu.Lock()
+	w.ctx = ctx
+	w.mu.Unlock()
+
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			done = true
+		case <-w.dataChanged:
+			if err := w.enqueueDependents(ctx); err != nil {
+				w.r.Logger.Error(err, "Unable to enqueue resources whose policies read changed objects")
+			}
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for gvk, watch := range w.watches {
+		watch.cancel()
+		delete(w.watches, gvk)
+	}
+	return nil
+}
+
+// reconcile starts watches of kinds named by the latest policies that are
+// served by the API server, and stops watches of kinds that are no longer
+// named. Reports evaluated with previous policies are deleted for each kind
+// in the same way as for kinds watched with typed objects.
+func (w *kindWatches) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := w.r.Logger.WithValues("configMap", req.NamespacedName)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.ctx == nil {
+		return ctrl.Result{}, errors.New("watches of custom kinds not started yet")
+	}
+
+	// Policies of the bundle are reconciled even if the ConfigMap does not
+	// exist.
+	err := w.r.Client.Get(ctx, req.NamespacedName, &corev1.ConfigMap{})
+	switch {
+	case k8sapierror.IsNotFound(err) && w.r.PolicyLoader == nil:
+		log.V(1).Info("Ignoring cached ConfigMap that must have been deleted")
+		return ctrl.Result{}, nil
+	case err != nil && !k8sapierror.IsNotFound(err):
+		return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
+	}
+
+	policies, err := w.r.policies(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting policies: %w", err)
+	}
+
+	if err = w.watchDataKinds(ctx, policies); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var kinds []string
+	for _, kind := range policies.Kinds() {
+		if w.static[kube.Kind(kind)] || kube.IsWorkload(kind) {
+			continue
+		}
+		kinds = append(kinds, kind)
+	}
+
+	var result ctrl.Result
+	mappings := make(map[schema.GroupVersionKind]*meta.RESTMapping)
+	failedGroups := make(map[schema.GroupVersion]error)
+	resolved := strings.Join(kinds, ",")
+	complete := true
+	if w.complete && resolved == w.resolved {
+		// Policies name the same kinds, which are already watched.
+		for gvk, watch := range w.watches {
+			mappings[gvk] = watch.mapping
+		}
+	} else if len(kinds) > 0 {
+		resources, err := w.discovery.ServerPreferredResources()
+		if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+			return ctrl.Result{}, fmt.Errorf("discovering API resources: %w", err)
+		}
+		if err != nil {
+			// Kinds of API groups that are temporarily unavailable, e.g.
+			// served by an aggregated API server, are resolved when the
+			// key is requeued.
+			log.Error(err, "Failed discovering some API groups")
+			failedGroups = err.(*discovery.ErrGroupDiscoveryFailed).Groups
+			result.RequeueAfter = w.r.Config.ScanJobRetryAfter
+			complete = false
+		}
+		for _, kind := range kinds {
+			kindMappings, err := kube.RESTMappingsForKind(resources, w.mgr.GetRESTMapper(), kind)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("resolving kind %s: %w", kind, err)
+			}
+			if len(kindMappings) == 0 {
+				// The kind might be defined by a CustomResourceDefinition that
+				// is not installed yet.
+				log.V(1).Info("Ignoring kind not served by API server", "kind", kind,
+					"retryAfter", w.r.Config.ScanJobRetryAfter)
+				result.RequeueAfter = w.r.Config.ScanJobRetryAfter
+				complete = false
+				continue
+			}
+			for _, mapping := range kindMappings {
+				mappings[mapping.GroupVersionKind] = mapping
+			}
+		}
+	}
+
+	for gvk, watch := range w.watches {
+		if _, ok := mappings[gvk]; ok {
+			continue
+		}
+		if _, ok := failedGroups[gvk.GroupVersion()]; ok {
+			continue
+		}
+		log.Info("Stopping watch", "gvk", gvk)
+		watch.cancel()
+		delete(w.watches, gvk)
+		w.stopped[watch.kind] = watch.clusterScoped
+	}
+
+	for _, gvk := range sortedGVKs(mappings) {
+		if _, ok := w.watches[gvk]; ok {
+			continue
+		}
+		log.Info("Starting watch", "gvk", gvk, "resource", mappings[gvk].Resource)
+		watch, err := w.start(mappings[gvk])
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("starting watch of %s: %w", gvk, err)
+		}
+		w.watches[gvk] = watch
+		delete(w.stopped, watch.kind)
+	}
+	w.resolved, w.complete = resolved, complete
+
+	// Kinds served by more than one API group share reports, therefore their
+	// reports are deleted once.
+	kindScopes := make(map[kube.Kind]bool)
+	for _, watch := range w.watches {
+		kindScopes[watch.kind] = watch.clusterScoped
+	}
+	for kind, clusterScoped := range w.stopped {
+		kindScopes[kind] = clusterScoped
+	}
+	for kind, clusterScoped := range kindScopes {
+		reconcileConfig := w.r.reconcileConfig(kind)
+		if clusterScoped {
+			reconcileConfig = w.r.reconcileClusterConfig(kind)
+		}
+		kindResult, err := reconcileConfig(ctx, req)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if kindResult.RequeueAfter > 0 {
+			if result.RequeueAfter == 0 || kindResult.RequeueAfter < result.RequeueAfter {
+				result.RequeueAfter = kindResult.RequeueAfter
+			}
+			continue
+		}
+		delete(w.stopped, kind)
+	}
+
+	return result, nil
+}
+
+// start starts informers and a controller that reconciles objects of the
+// kind of the given mapping. It must be called with the lock held.
+func (w *kindWatches) start(mapping *meta.RESTMapping) (*kindWatch, error) {
+	gvk := mapping.GroupVersionKind
+	watch := &kindWatch{
+		mapping:       mapping,
+		kind:          kube.Kind(gvk.Kind),
+		clusterScoped: mapping.Scope.Name() == meta.RESTScopeNameRoot,
+		informers:     make(map[string]informers.GenericInformer),
+		events:        make(chan event.GenericEvent),
+	}
+
+	namespaces := w.namespaces
+	if watch.clusterScoped {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	for _, namespace := range namespaces {
+		watch.informers[namespace] = dynamicinformer.NewFilteredDynamicInformer(w.dynamic, mapping.Resource, namespace, 0,
+			toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc}, nil)
+	}
+
+	c, err := controller.NewUnmanaged(strings.ToLower(gvk.GroupKind().String()), w.mgr, controller.Options{
+		Reconciler: w.r.reconcileObject(watch.kind, watch.clusterScoped, watch.get),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	predicates := []predicatex.Predicate{
+		predicate.Not(predicate.ManagedByStarboardOperator),
+		predicate.Not(predicate.IsBeingTerminated),
+	}
+	if !watch.clusterScoped {
+		predicates = append(predicates, w.installModePredicate)
+	}
+	for _, informer := range watch.informers {
+		err = c.Watch(&source.Informer{Informer: informer.Informer()}, &handler.EnqueueRequestForObject{}, predicates...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = c.Watch(&source.Channel{Source: watch.events}, &handler.EnqueueRequestForObject{}, predicates...)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := &unstructured.Unstructured{}
+	owner.SetGroupVersionKind(gvk)
+	var report client.Object = &v1alpha1.ConfigAuditReport{}
+	if watch.clusterScoped {
+		report = &v1alpha1.ClusterConfigAuditReport{}
+	}
+	err = c.Watch(&source.Kind{Type: report}, &handler.EnqueueRequestForOwner{OwnerType: owner, IsController: true})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(w.ctx)
+	watch.cancel = cancel
+	log := w.r.Logger.WithValues("gvk", gvk)
+	go func() {
+		var synced []toolscache.InformerSynced
+		for _, informer := range watch.informers {
+			go informer.Informer().Run(ctx.Done())
+			synced = append(synced, informer.Informer().HasSynced)
+		}
+		if !toolscache.WaitForCacheSync(ctx.Done(), synced...) {
+			return
+		}
+		if err := c.Start(ctx); err != nil {
+			log.Error(err, "Failed running controller")
+		}
+	}()
+	return watch, nil
+}
+
+// get gets the object referenced by the given kube.ObjectRef from informers
+// of this kindWatch.
+func (w *kindWatch) get(_ context.Context, ref kube.ObjectRef) (client.Object, error) {
+	informer, ok := w.informers[ref.Namespace]
+	if !ok {
+		informer, ok = w.informers[metav1.NamespaceAll]
+	}
+	if !ok {
+		return nil, fmt.Errorf("namespace not watched: %s", ref.Namespace)
+	}
+	lister := informer.Lister()
+	var obj interface{}
+	var err error
+	if w.clusterScoped {
+		obj, err = lister.Get(ref.Name)
+	} else {
+		obj, err = lister.ByNamespace(ref.Namespace).Get(ref.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type: %T", obj)
+	}
+	return u.DeepCopy(), nil
+}
+
+// list returns objects of this kindWatch from its informers.
+func (w *kindWatch) list(_ context.Context) ([]client.Object, error) {
+	var objects []client.Object
+	for _, informer := range w.informers {
+		items, err := informer.Lister().List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return nil, fmt.Errorf("unexpected object type: %T", item)
+			}
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+// watchDataKinds registers event handlers with informers of kinds that the
+// given policies read from data.kubernetes. Informers are shared with the
+// Inventory, which lists objects from the same cache.
+func (w *kindWatches) watchDataKinds(ctx context.Context, policies *policy.Policies) error {
+	if w.r.Inventory == nil {
+		return nil
+	}
+	kinds, err := policies.AllDataKinds()
+	if err != nil {
+		return fmt.Errorf("listing kinds read from data.kubernetes: %w", err)
+	}
+
+	dataKinds := make(map[string]bool)
+	for _, kind := range kinds {
+		dataKinds[kind] = true
+	}
+	w.dataMu.Lock()
+	w.dataKinds = dataKinds
+	w.dataMu.Unlock()
+
+	for _, kind := range kinds {
+		if w.informers[kind] {
+			continue
+		}
+		obj, err := w.r.Inventory.NewObject(kind)
+		if err != nil {
+			return fmt.Errorf("resolving kind read from data.kubernetes: %s: %w", kind, err)
+		}
+		informer, err := w.mgr.GetCache().GetInformer(ctx, obj)
+		if err != nil {
+			return fmt.Errorf("getting informer: %s: %w", kind, err)
+		}
+		kind := kind
+		informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(_ interface{}) {
+				w.notifyDataKind(kind)
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldMeta, err := meta.Accessor(oldObj)
+				if err != nil {
+					return
+				}
+				newMeta, err := meta.Accessor(newObj)
+				if err != nil {
+					return
+				}
+				// Skip periodic resyncs.
+				if oldMeta.GetResourceVersion() != newMeta.GetResourceVersion() {
+					w.notifyDataKind(kind)
+				}
+			},
+			DeleteFunc: func(_ interface{}) {
+				w.notifyDataKind(kind)
+			},
+		})
+		w.informers[kind] = true
+	}
+	return nil
+}
+
+// notifyDataKind records that objects of the specified kind changed, if
+// policies still read them, and signals Start to enqueue dependent resources.
+func (w *kindWatches) notifyDataKind(kind string) {
+	w.dataMu.Lock()
+	defer w.dataMu.Unlock()
+	if !w.dataKinds[kind] {
+		return
+	}
+	w.changed[kind] = true
+	select {
+	case w.dataChanged <- struct{}{}:
+	default:
+		// A signal is already pending.
+	}
+}
+
+// dataTarget is a kind of resources, which are enqueued with events if
+// their policies read objects that changed.
+type dataTarget struct {
+	kind   kube.Kind
+	events chan event.GenericEvent
+	list   func(ctx context.Context) ([]client.Object, error)
+}
+
+// enqueueDependents enqueues resources whose policies read objects of kinds
+// that changed. Resources are marked as stale, so that they're re-evaluated
+// even if their reports are up-to-date.
+func (w *kindWatches) enqueueDependents(ctx context.Context) error {
+	w.dataMu.Lock()
+	changed := w.changed
+	w.changed = make(map[string]bool)
+	w.dataMu.Unlock()
+
+	targets := append([]dataTarget{}, w.dataTargets...)
+	w.mu.Lock()
+	for _, watch := range w.watches {
+		targets = append(targets, dataTarget{
+			kind:   watch.kind,
+			events: watch.events,
+			list:   watch.list,
+		})
+	}
+	w.mu.Unlock()
+
+	policies, err := w.r.policies(ctx)
+	if err != nil {
+		return fmt.Errorf("getting policies: %w", err)
+	}
+	for _, target := range targets {
+		dataKinds, err := policies.DataKinds(ctx, string(target.kind))
+		if err != nil {
+			return fmt.Errorf("listing kinds read from data.kubernetes: %s: %w", target.kind, err)
+		}
+		if !readsAny(dataKinds, changed) {
+			continue
+		}
+		objects, err := target.list(ctx)
+		if err != nil {
+			return fmt.Errorf("listing %s: %w", target.kind, err)
+		}
+		w.r.Logger.V(1).Info("Enqueuing resources whose policies read changed objects",
+			"kind", target.kind, "count", len(objects))
+		for _, obj := range objects {
+			w.r.markStale(kube.ObjectRef{
+				Kind:      target.kind,
+				Name:      obj.GetName(),
+				Namespace: obj.GetNamespace(),
+			})
+			select {
+			case target.events <- event.GenericEvent{Object: obj}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// newDataTarget returns the dataTarget of the specified kind, which is watched
+// with typed objects such as the given obj, and listed from the cache.
+func newDataTarget(mgr ctrl.Manager, kind kube.Kind, obj client.Object) (dataTarget, error) {
+	gvk, err := apiutil.GVKForObject(obj, mgr.GetScheme())
+	if err != nil {
+		return dataTarget{}, err
+	}
+	listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+	return dataTarget{
+		kind:   kind,
+		events: make(chan event.GenericEvent),
+		list: func(ctx context.Context) ([]client.Object, error) {
+			list, err := mgr.GetScheme().New(listGVK)
+			if err != nil {
+				return nil, err
+			}
+			objectList, ok := list.(client.ObjectList)
+			if !ok {
+				return nil, fmt.Errorf("unexpected list type: %T", list)
+			}
+			if err = mgr.GetCache().List(ctx, objectList); err != nil {
+				return nil, err
+			}
+			items, err := meta.ExtractList(objectList)
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]client.Object, 0, len(items))
+			for _, item := range items {
+				obj, ok := item.(client.Object)
+				if !ok {
+					return nil, fmt.Errorf("unexpected object type: %T", item)
+				}
+				objects = append(objects, obj)
+			}
+			return objects, nil
+		},
+	}, nil
+}
+
+func readsAny(dataKinds []string, changed map[string]bool) bool {
+	for _, kind := range dataKinds {
+		if changed[kind] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedGVKs(mappings map[schema.GroupVersionKind]*meta.RESTMapping) []schema.GroupVersionKind {
+	gvks := make([]schema.GroupVersionKind, 0, len(mappings))
+	for gvk := range mappings {
+		gvks = append(gvks, gvk)
+	}
+	sort.Slice(gvks, func(i, j int) bool {
+		return gvks[i].String() < gvks[j].String()
+	})
+	return gvks
+}
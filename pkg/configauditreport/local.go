@@ -0,0 +1,104 @@
+package configauditreport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/manifest"
+	"github.com/aquasecurity/starboard/pkg/policy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LocalReport holds the result of auditing a Kubernetes object read from a
+// local manifest rather than from a cluster.
+type LocalReport struct {
+	// Path is the path of the manifest, Helm chart or Kustomize directory
+	// that the object is read from.
+	Path      string `json:"path"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	Report v1alpha1.ConfigAuditReportData `json:"report"`
+}
+
+// ResourceName returns the name of the audited resource qualified by its
+// kind and namespace, e.g. staging/Deployment/nginx.
+func (r LocalReport) ResourceName() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s/%s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", r.Namespace, r.Kind, r.Name)
+}
+
+// LocalScanner evaluates policies with the built-in scanner against objects
+// read from local manifests, e.g. in a CI pipeline before they are applied,
+// without access to a cluster.
+type LocalScanner struct {
+	buildInfo starboard.BuildInfo
+	clock     ext.Clock
+	policies  *policy.Policies
+}
+
+// NewLocalScanner constructs a new LocalScanner with the specified policies.
+func NewLocalScanner(buildInfo starboard.BuildInfo, clock ext.Clock, policies *policy.Policies) *LocalScanner {
+	return &LocalScanner{
+		buildInfo: buildInfo,
+		clock:     clock,
+		policies:  policies,
+	}
+}
+
+// Scan returns reports of the given documents that policies are applicable
+// to. Policies reading data.kubernetes see the scanned documents rather than
+// objects in a cluster.
+func (s *LocalScanner) Scan(ctx context.Context, documents []manifest.Document) ([]LocalReport, error) {
+	policies := s.policies.WithCache(policy.NewCache()).WithInventory(newDocumentInventory(documents))
+
+	var reports []LocalReport
+	for _, doc := range documents {
+		applicable, _, err := policies.Applicable(doc.Object)
+		if err != nil {
+			return nil, err
+		}
+		if !applicable {
+			continue
+		}
+		results, err := policies.Eval(ctx, doc.Object)
+		if err != nil {
+			return nil, fmt.Errorf("failed evaluating policies: %s: %s/%s: %w", doc.Path, doc.Object.GetKind(), doc.Object.GetName(), err)
+		}
+		data := newReportData(s.buildInfo, results)
+		data.UpdateTimestamp = metav1.NewTime(s.clock.Now())
+		reports = append(reports, LocalReport{
+			Path:      doc.Path,
+			Kind:      doc.Object.GetKind(),
+			Namespace: doc.Object.GetNamespace(),
+			Name:      doc.Object.GetName(),
+			Report:    data,
+		})
+	}
+	return reports, nil
+}
+
+// documentInventory implements policy.Inventory with scanned documents.
+type documentInventory map[string][]interface{}
+
+func newDocumentInventory(documents []manifest.Document) documentInventory {
+	inventory := make(documentInventory)
+	for _, doc := range documents {
+		kind := doc.Object.GetKind()
+		inventory[kind] = append(inventory[kind], doc.Object.UnstructuredContent())
+	}
+	return inventory
+}
+
+func (i documentInventory) List(_ context.Context, kind string) ([]interface{}, error) {
+	if objects, ok := i[kind]; ok {
+		return objects, nil
+	}
+	return []interface{}{}, nil
+}
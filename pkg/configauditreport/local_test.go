@@ -0,0 +1,126 @@
+package configauditreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/manifest"
+	"github.com/aquasecurity/starboard/pkg/policy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const regoRunAsNonRoot = `package appshield.kubernetes.KSV012
+
+__rego_metadata__ := {
+	"id": "KSV012",
+	"title": "Runs as root user",
+	"description": "Containers should run as a non-root user",
+	"severity": "MEDIUM",
+	"type": "Kubernetes Security Check"
+}
+
+deny[res] {
+	not input.spec.template.spec.securityContext.runAsNonRoot
+	res := {"msg": "Deployment should set runAsNonRoot"}
+}
+`
+
+const regoWithoutService = `package appshield.kubernetes.KSV099
+
+__rego_metadata__ := {
+	"id": "KSV099",
+	"title": "Deployment without Service",
+	"description": "Deployments should be exposed by a Service",
+	"severity": "LOW",
+	"type": "Kubernetes Security Check"
+}
+
+deny[res] {
+	count([s | s := data.kubernetes.Service[_]; s.metadata.name == input.metadata.name]) == 0
+	res := {"msg": "Deployment is not exposed by any Service"}
+}
+`
+
+func newDocument(path string, obj map[string]interface{}) manifest.Document {
+	return manifest.Document{
+		Path:   path,
+		Object: &unstructured.Unstructured{Object: obj},
+	}
+}
+
+func newLocalDeployment(name string, runAsNonRoot bool) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"securityContext": map[string]interface{}{
+						"runAsNonRoot": runAsNonRoot,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestLocalScanner_Scan(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2022, time.June, 1, 10, 30, 0, 0, time.UTC)
+
+	policies := policy.NewPolicies(map[string]string{
+		"policy.run_as_non_root.rego":  regoRunAsNonRoot,
+		"policy.run_as_non_root.kinds": "Workload",
+		"policy.without_service.rego":  regoWithoutService,
+		"policy.without_service.kinds": "Deployment",
+	})
+	scanner := configauditreport.NewLocalScanner(starboard.BuildInfo{Version: "v0.15.0"},
+		ext.NewFixedClock(now), policies)
+
+	reports, err := scanner.Scan(context.TODO(), []manifest.Document{
+		newDocument("deploy/nginx.yaml", newLocalDeployment("nginx", true)),
+		newDocument("deploy/nginx.yaml", map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata": map[string]interface{}{
+				"name":      "nginx",
+				"namespace": "default",
+			},
+		}),
+		newDocument("deploy/redis.yaml", newLocalDeployment("redis", false)),
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reports).To(HaveLen(2))
+
+	g.Expect(reports[0].Path).To(Equal("deploy/nginx.yaml"))
+	g.Expect(reports[0].ResourceName()).To(Equal("default/Deployment/nginx"))
+	g.Expect(reports[0].Report.UpdateTimestamp.Time).To(Equal(now))
+	g.Expect(reports[0].Report.Scanner).To(Equal(v1alpha1.Scanner{
+		Name:    "Starboard",
+		Vendor:  "Aqua Security",
+		Version: "v0.15.0",
+	}))
+	g.Expect(reports[0].Report.Summary).To(Equal(v1alpha1.ConfigAuditSummary{}))
+	g.Expect(reports[0].Report.Checks).To(HaveLen(2))
+
+	g.Expect(reports[1].Path).To(Equal("deploy/redis.yaml"))
+	g.Expect(reports[1].ResourceName()).To(Equal("default/Deployment/redis"))
+	g.Expect(reports[1].Report.Summary).To(Equal(v1alpha1.ConfigAuditSummary{
+		MediumCount: 1,
+		LowCount:    1,
+	}))
+
+	g.Expect(configauditreport.FailedChecks(reports, v1alpha1.SeverityLow)).To(Equal(2))
+	g.Expect(configauditreport.FailedChecks(reports, v1alpha1.SeverityMedium)).To(Equal(1))
+	g.Expect(configauditreport.FailedChecks(reports, v1alpha1.SeverityHigh)).To(Equal(0))
+}
@@ -13,21 +13,29 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// BuiltInScanner is the name of the built-in configuration audit scanner,
+// which evaluates OPA Rego policies.
+const BuiltInScanner = "Starboard"
+
 type Scanner struct {
 	buildInfo      starboard.BuildInfo
+	config         starboard.ConfigData
 	scheme         *runtime.Scheme
 	client         client.Client
 	objectResolver *kube.ObjectResolver
+	inventory      *kube.Inventory
 }
 
-func NewScanner(buildInfo starboard.BuildInfo, client client.Client) *Scanner {
+func NewScanner(buildInfo starboard.BuildInfo, config starboard.ConfigData, client client.Client) *Scanner {
 	return &Scanner{
 		buildInfo: buildInfo,
+		config:    config,
 		scheme:    client.Scheme(),
 		client:    client,
 		objectResolver: &kube.ObjectResolver{
 			Client: client,
 		},
+		inventory: kube.NewInventory(client, client.RESTMapper(), client.Scheme()),
 	}
 }
 
@@ -62,6 +70,28 @@ func (s *Scanner) Scan(ctx context.Context, resourceRef kube.ObjectRef) (*Report
 		return nil, fmt.Errorf("failed evaluating policies: %w", err)
 	}
 
+	data := newReportData(s.buildInfo, results)
+
+	resourceHash, err := kube.ComputeSpecHash(resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed computing spec hash: %w", err)
+	}
+	scannerConfigHash, err := policies.Hash(resourceKind)
+	if err != nil {
+		return nil, fmt.Errorf("failed computing scanner config hash: %w", err)
+	}
+
+	return NewReportBuilder(s.scheme).
+		Controller(resource).
+		ResourceSpecHash(resourceHash).
+		PluginConfigHash(scannerConfigHash).
+		Scanner(BuiltInScanner).
+		Data(data), nil
+}
+
+// newReportData returns ConfigAuditReportData with checks of the given
+// results of evaluating policies with the built-in scanner.
+func newReportData(buildInfo starboard.BuildInfo, results policy.Results) v1alpha1.ConfigAuditReportData {
 	checks := make([]v1alpha1.Check, len(results))
 	for i, result := range results {
 		checks[i] = v1alpha1.Check{
@@ -75,12 +105,11 @@ func (s *Scanner) Scan(ctx context.Context, resourceRef kube.ObjectRef) (*Report
 			Messages: result.Messages,
 		}
 	}
-
-	data := v1alpha1.ConfigAuditReportData{
+	return v1alpha1.ConfigAuditReportData{
 		Scanner: v1alpha1.Scanner{
-			Name:    "Starboard",
+			Name:    BuiltInScanner,
 			Vendor:  "Aqua Security",
-			Version: s.buildInfo.Version,
+			Version: buildInfo.Version,
 		},
 		Summary: v1alpha1.ConfigAuditSummaryFromChecks(checks),
 		Checks:  checks,
@@ -88,21 +117,6 @@ func (s *Scanner) Scan(ctx context.Context, resourceRef kube.ObjectRef) (*Report
 		PodChecks:       checks,
 		ContainerChecks: map[string][]v1alpha1.Check{},
 	}
-
-	resourceHash, err := kube.ComputeSpecHash(resource)
-	if err != nil {
-		return nil, fmt.Errorf("failed computing spec hash: %w", err)
-	}
-	scannerConfigHash, err := policies.Hash(resourceKind)
-	if err != nil {
-		return nil, fmt.Errorf("failed computing scanner config hash: %w", err)
-	}
-
-	return NewReportBuilder(s.scheme).
-		Controller(resource).
-		ResourceSpecHash(resourceHash).
-		PluginConfigHash(scannerConfigHash).
-		Data(data), nil
 }
 
 func (s *Scanner) policies(ctx context.Context) (*policy.Policies, error) {
@@ -115,5 +129,17 @@ func (s *Scanner) policies(ctx context.Context) (*policy.Policies, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed getting policies from configmap: %s/%s: %w", starboard.NamespaceName, starboard.PoliciesConfigMapName, err)
 	}
-	return policy.NewPolicies(cm.Data), nil
+	url, digest, ok := s.config.GetPoliciesBundle()
+	if !ok {
+		return policy.NewPolicies(cm.Data).WithInventory(s.inventory), nil
+	}
+	source, err := policy.NewSource(url, digest)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := source.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return policy.NewPolicies(bundle.Merge(cm.Data)).WithInventory(s.inventory), nil
 }
@@ -0,0 +1,42 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// Resolver resolves tags of container images by querying registries.
+type Resolver struct {
+}
+
+// NewResolver constructs a new Resolver.
+func NewResolver() *Resolver {
+	return &Resolver{}
+}
+
+// ResolveTag returns the digest, e.g. sha256:..., of the manifest that the
+// specified image reference currently points to in its registry.
+func (r *Resolver) ResolveTag(ctx context.Context, imageRef string, credentials *Auth) (string, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return "", err
+	}
+
+	auth := authn.Anonymous
+	if credentials != nil {
+		auth = authn.FromConfig(authn.AuthConfig{
+			Username: credentials.Username,
+			Password: credentials.Password,
+		})
+	}
+
+	descriptor, err := remote.Head(ref, remote.WithContext(ctx), remote.WithAuth(auth))
+	if err != nil {
+		return "", fmt.Errorf("resolving tag: %s: %w", imageRef, err)
+	}
+	return descriptor.Digest.String(), nil
+}
@@ -0,0 +1,57 @@
+package docker_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver_ResolveTag(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	ref, err := name.ParseReference(host + "/library/app:1.0")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, empty.Image))
+	digest, err := empty.Image.Digest()
+	require.NoError(t, err)
+
+	resolver := docker.NewResolver()
+
+	resolved, err := resolver.ResolveTag(context.Background(), host+"/library/app:1.0", nil)
+	require.NoError(t, err)
+	assert.Equal(t, digest.String(), resolved)
+
+	t.Run("Should resolve digest that tag was moved to", func(t *testing.T) {
+		image, err := mutate.Config(empty.Image, v1.Config{Env: []string{"VERSION=1.0.1"}})
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(ref, image))
+		newDigest, err := image.Digest()
+		require.NoError(t, err)
+
+		resolved, err := resolver.ResolveTag(context.Background(), host+"/library/app:1.0", nil)
+		require.NoError(t, err)
+		assert.Equal(t, newDigest.String(), resolved)
+		assert.NotEqual(t, digest.String(), resolved)
+	})
+
+	t.Run("Should return error when tag does not exist", func(t *testing.T) {
+		_, err := resolver.ResolveTag(context.Background(), host+"/library/app:2.0", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resolving tag: "+host+"/library/app:2.0")
+	})
+}
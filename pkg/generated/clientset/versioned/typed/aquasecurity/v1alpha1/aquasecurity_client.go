@@ -16,9 +16,14 @@ type AquasecurityV1alpha1Interface interface {
 	ClusterComplianceDetailReportsGetter
 	ClusterComplianceReportsGetter
 	ClusterConfigAuditReportsGetter
+	ClusterSbomReportsGetter
 	ClusterVulnerabilityReportsGetter
 	ConfigAuditReportsGetter
 	KubeHunterReportsGetter
+	NamespaceSecurityReportsGetter
+	SbomReportsGetter
+	SummaryHistoriesGetter
+	VulnerabilityExceptionsGetter
 	VulnerabilityReportsGetter
 }
 
@@ -43,6 +48,10 @@ func (c *AquasecurityV1alpha1Client) ClusterConfigAuditReports() ClusterConfigAu
 	return newClusterConfigAuditReports(c)
 }
 
+func (c *AquasecurityV1alpha1Client) ClusterSbomReports() ClusterSbomReportInterface {
+	return newClusterSbomReports(c)
+}
+
 func (c *AquasecurityV1alpha1Client) ClusterVulnerabilityReports() ClusterVulnerabilityReportInterface {
 	return newClusterVulnerabilityReports(c)
 }
@@ -55,6 +64,22 @@ func (c *AquasecurityV1alpha1Client) KubeHunterReports() KubeHunterReportInterfa
 	return newKubeHunterReports(c)
 }
 
+func (c *AquasecurityV1alpha1Client) NamespaceSecurityReports(namespace string) NamespaceSecurityReportInterface {
+	return newNamespaceSecurityReports(c, namespace)
+}
+
+func (c *AquasecurityV1alpha1Client) SbomReports(namespace string) SbomReportInterface {
+	return newSbomReports(c, namespace)
+}
+
+func (c *AquasecurityV1alpha1Client) SummaryHistories(namespace string) SummaryHistoryInterface {
+	return newSummaryHistories(c, namespace)
+}
+
+func (c *AquasecurityV1alpha1Client) VulnerabilityExceptions() VulnerabilityExceptionInterface {
+	return newVulnerabilityExceptions(c)
+}
+
 func (c *AquasecurityV1alpha1Client) VulnerabilityReports(namespace string) VulnerabilityReportInterface {
 	return newVulnerabilityReports(c, namespace)
 }
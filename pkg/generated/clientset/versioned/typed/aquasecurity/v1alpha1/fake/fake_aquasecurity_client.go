@@ -28,6 +28,10 @@ func (c *FakeAquasecurityV1alpha1) ClusterConfigAuditReports() v1alpha1.ClusterC
 	return &FakeClusterConfigAuditReports{c}
 }
 
+func (c *FakeAquasecurityV1alpha1) ClusterSbomReports() v1alpha1.ClusterSbomReportInterface {
+	return &FakeClusterSbomReports{c}
+}
+
 func (c *FakeAquasecurityV1alpha1) ClusterVulnerabilityReports() v1alpha1.ClusterVulnerabilityReportInterface {
 	return &FakeClusterVulnerabilityReports{c}
 }
@@ -40,6 +44,22 @@ func (c *FakeAquasecurityV1alpha1) KubeHunterReports() v1alpha1.KubeHunterReport
 	return &FakeKubeHunterReports{c}
 }
 
+func (c *FakeAquasecurityV1alpha1) NamespaceSecurityReports(namespace string) v1alpha1.NamespaceSecurityReportInterface {
+	return &FakeNamespaceSecurityReports{c, namespace}
+}
+
+func (c *FakeAquasecurityV1alpha1) SbomReports(namespace string) v1alpha1.SbomReportInterface {
+	return &FakeSbomReports{c, namespace}
+}
+
+func (c *FakeAquasecurityV1alpha1) SummaryHistories(namespace string) v1alpha1.SummaryHistoryInterface {
+	return &FakeSummaryHistories{c, namespace}
+}
+
+func (c *FakeAquasecurityV1alpha1) VulnerabilityExceptions() v1alpha1.VulnerabilityExceptionInterface {
+	return &FakeVulnerabilityExceptions{c}
+}
+
 func (c *FakeAquasecurityV1alpha1) VulnerabilityReports(namespace string) v1alpha1.VulnerabilityReportInterface {
 	return &FakeVulnerabilityReports{c, namespace}
 }
@@ -10,10 +10,20 @@ type ClusterComplianceReportExpansion interface{}
 
 type ClusterConfigAuditReportExpansion interface{}
 
+type ClusterSbomReportExpansion interface{}
+
 type ClusterVulnerabilityReportExpansion interface{}
 
 type ConfigAuditReportExpansion interface{}
 
 type KubeHunterReportExpansion interface{}
 
+type NamespaceSecurityReportExpansion interface{}
+
+type SbomReportExpansion interface{}
+
+type SummaryHistoryExpansion interface{}
+
+type VulnerabilityExceptionExpansion interface{}
+
 type VulnerabilityReportExpansion interface{}
@@ -16,12 +16,22 @@ type Interface interface {
 	ClusterComplianceReports() ClusterComplianceReportInformer
 	// ClusterConfigAuditReports returns a ClusterConfigAuditReportInformer.
 	ClusterConfigAuditReports() ClusterConfigAuditReportInformer
+	// ClusterSbomReports returns a ClusterSbomReportInformer.
+	ClusterSbomReports() ClusterSbomReportInformer
 	// ClusterVulnerabilityReports returns a ClusterVulnerabilityReportInformer.
 	ClusterVulnerabilityReports() ClusterVulnerabilityReportInformer
 	// ConfigAuditReports returns a ConfigAuditReportInformer.
 	ConfigAuditReports() ConfigAuditReportInformer
 	// KubeHunterReports returns a KubeHunterReportInformer.
 	KubeHunterReports() KubeHunterReportInformer
+	// NamespaceSecurityReports returns a NamespaceSecurityReportInformer.
+	NamespaceSecurityReports() NamespaceSecurityReportInformer
+	// SbomReports returns a SbomReportInformer.
+	SbomReports() SbomReportInformer
+	// SummaryHistories returns a SummaryHistoryInformer.
+	SummaryHistories() SummaryHistoryInformer
+	// VulnerabilityExceptions returns a VulnerabilityExceptionInformer.
+	VulnerabilityExceptions() VulnerabilityExceptionInformer
 	// VulnerabilityReports returns a VulnerabilityReportInformer.
 	VulnerabilityReports() VulnerabilityReportInformer
 }
@@ -57,6 +67,11 @@ func (v *version) ClusterConfigAuditReports() ClusterConfigAuditReportInformer {
 	return &clusterConfigAuditReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterSbomReports returns a ClusterSbomReportInformer.
+func (v *version) ClusterSbomReports() ClusterSbomReportInformer {
+	return &clusterSbomReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterVulnerabilityReports returns a ClusterVulnerabilityReportInformer.
 func (v *version) ClusterVulnerabilityReports() ClusterVulnerabilityReportInformer {
 	return &clusterVulnerabilityReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -72,6 +87,26 @@ func (v *version) KubeHunterReports() KubeHunterReportInformer {
 	return &kubeHunterReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// NamespaceSecurityReports returns a NamespaceSecurityReportInformer.
+func (v *version) NamespaceSecurityReports() NamespaceSecurityReportInformer {
+	return &namespaceSecurityReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// SbomReports returns a SbomReportInformer.
+func (v *version) SbomReports() SbomReportInformer {
+	return &sbomReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// SummaryHistories returns a SummaryHistoryInformer.
+func (v *version) SummaryHistories() SummaryHistoryInformer {
+	return &summaryHistoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VulnerabilityExceptions returns a VulnerabilityExceptionInformer.
+func (v *version) VulnerabilityExceptions() VulnerabilityExceptionInformer {
+	return &vulnerabilityExceptionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // VulnerabilityReports returns a VulnerabilityReportInformer.
 func (v *version) VulnerabilityReports() VulnerabilityReportInformer {
 	return &vulnerabilityReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
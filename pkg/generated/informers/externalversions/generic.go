@@ -45,12 +45,22 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ClusterComplianceReports().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clusterconfigauditreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ClusterConfigAuditReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("clustersbomreports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ClusterSbomReports().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clustervulnerabilityreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ClusterVulnerabilityReports().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("configauditreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ConfigAuditReports().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("kubehunterreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().KubeHunterReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("namespacesecurityreports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().NamespaceSecurityReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("sbomreports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().SbomReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("summaryhistories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().SummaryHistories().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("vulnerabilityexceptions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().VulnerabilityExceptions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("vulnerabilityreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().VulnerabilityReports().Informer()}, nil
 
@@ -26,6 +26,10 @@ type ClusterComplianceReportNamespaceListerExpansion interface{}
 // ClusterConfigAuditReportLister.
 type ClusterConfigAuditReportListerExpansion interface{}
 
+// ClusterSbomReportListerExpansion allows custom methods to be added to
+// ClusterSbomReportLister.
+type ClusterSbomReportListerExpansion interface{}
+
 // ClusterVulnerabilityReportListerExpansion allows custom methods to be added to
 // ClusterVulnerabilityReportLister.
 type ClusterVulnerabilityReportListerExpansion interface{}
@@ -42,6 +46,34 @@ type ConfigAuditReportNamespaceListerExpansion interface{}
 // KubeHunterReportLister.
 type KubeHunterReportListerExpansion interface{}
 
+// NamespaceSecurityReportListerExpansion allows custom methods to be added to
+// NamespaceSecurityReportLister.
+type NamespaceSecurityReportListerExpansion interface{}
+
+// NamespaceSecurityReportNamespaceListerExpansion allows custom methods to be added to
+// NamespaceSecurityReportNamespaceLister.
+type NamespaceSecurityReportNamespaceListerExpansion interface{}
+
+// SbomReportListerExpansion allows custom methods to be added to
+// SbomReportLister.
+type SbomReportListerExpansion interface{}
+
+// SbomReportNamespaceListerExpansion allows custom methods to be added to
+// SbomReportNamespaceLister.
+type SbomReportNamespaceListerExpansion interface{}
+
+// SummaryHistoryListerExpansion allows custom methods to be added to
+// SummaryHistoryLister.
+type SummaryHistoryListerExpansion interface{}
+
+// SummaryHistoryNamespaceListerExpansion allows custom methods to be added to
+// SummaryHistoryNamespaceLister.
+type SummaryHistoryNamespaceListerExpansion interface{}
+
+// VulnerabilityExceptionListerExpansion allows custom methods to be added to
+// VulnerabilityExceptionLister.
+type VulnerabilityExceptionListerExpansion interface{}
+
 // VulnerabilityReportListerExpansion allows custom methods to be added to
 // VulnerabilityReportLister.
 type VulnerabilityReportListerExpansion interface{}
@@ -0,0 +1,657 @@
+package harbor
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	apiPrefix = "/api/v1"
+
+	// scanContainerName is the name of the container of the Pod, which is
+	// passed to the vulnerabilityreport.Plugin to describe the scanned
+	// artifact.
+	scanContainerName = "artifact"
+
+	// refreshAfter is the number of seconds after which Harbor should retry
+	// to get the report of a scan that is still in progress.
+	refreshAfter = "15"
+
+	// Names of files in the SecretDir of the Adapter, which is typically
+	// mounted from a Secret.
+	certFileName  = "tls.crt"
+	keyFileName   = "tls.key"
+	tokenFileName = "token"
+)
+
+var errScanJobsLimitExceeded = errors.New("concurrent scan jobs limit exceeded")
+
+// Adapter implements the Harbor Scanner Adapter API v1.0. Scan requests are
+// processed by scan jobs created with the configured
+// vulnerabilityreport.Plugin, and results are saved as
+// v1alpha1.ClusterVulnerabilityReport objects, which are shared with the
+// cache of vulnerability reports used by workload scans.
+//
+// Adapter implements the http.Handler interface. It also implements the
+// manager.Runnable interface, so that the HTTPS server listening on the
+// BindAddress is started and stopped by the controllers manager.
+//
+// Requests must carry the bearer token read from the token file in the
+// SecretDir, which also contains the tls.crt and tls.key files of the server.
+// Files are reloaded when they change, so that the token and the certificate
+// can be rotated by updating the mounted Secret.
+type Adapter struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	client.Client
+	kube.LogsReader
+	controller.LimitChecker
+	vulnerabilityreport.Plugin
+	starboard.PluginContext
+	vulnerabilityreport.ReadWriter
+	ext.Clock
+	starboard.BuildInfo
+	BindAddress string
+	SecretDir   string
+}
+
+// Start runs the HTTPS server until the given context is cancelled.
+func (a *Adapter) Start(ctx context.Context) error {
+	if _, err := a.token(); err != nil {
+		return err
+	}
+	watcher, err := certwatcher.New(filepath.Join(a.SecretDir, certFileName), filepath.Join(a.SecretDir, keyFileName))
+	if err != nil {
+		return fmt.Errorf("loading Harbor scanner adapter certificate: %w", err)
+	}
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			a.Logger.Error(err, "Unable to watch certificate")
+		}
+	}()
+
+	server := &http.Server{
+		Addr:    a.BindAddress,
+		Handler: a,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: watcher.GetCertificate,
+		},
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		a.Logger.Info("Starting Harbor scanner adapter", "address", a.BindAddress)
+		errCh <- server.ListenAndServeTLS("", "")
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("running Harbor scanner adapter: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection returns false, so that the HTTP server is run by each
+// replica of the operator.
+func (a *Adapter) NeedLeaderElection() bool {
+	return false
+}
+
+// ServeHTTP routes requests to handlers of the Harbor Scanner Adapter API.
+func (a *Adapter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !a.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="starboard"`)
+		a.writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, apiPrefix)
+	switch {
+	case path == "/metadata" && r.Method == http.MethodGet:
+		a.getMetadata(w, r)
+	case path == "/scan" && r.Method == http.MethodPost:
+		a.acceptScanRequest(w, r)
+	case strings.HasPrefix(path, "/scan/") && strings.HasSuffix(path, "/report") && r.Method == http.MethodGet:
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/scan/"), "/report")
+		a.getScanReport(w, r, id)
+	default:
+		a.writeError(w, http.StatusNotFound, fmt.Errorf("%s %s not found", r.Method, r.URL.Path))
+	}
+}
+
+// authorized returns true if the given request carries the bearer token of
+// the Adapter. The token file is read on each request, so that a rotated
+// token is accepted as soon as the mounted Secret is updated.
+func (a *Adapter) authorized(r *http.Request) bool {
+	scheme, value, ok := cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	token, err := a.token()
+	if err != nil {
+		a.Logger.Error(err, "Unable to read token")
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(value), []byte(token)) == 1
+}
+
+func (a *Adapter) token() (string, error) {
+	path := filepath.Join(a.SecretDir, tokenFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading Harbor scanner adapter token: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("Harbor scanner adapter token must not be empty: %s", path)
+	}
+	return token, nil
+}
+
+func (a *Adapter) getMetadata(w http.ResponseWriter, _ *http.Request) {
+	a.writeJSON(w, http.StatusOK, MimeTypeMetadata, ScannerAdapterMetadata{
+		Scanner: Scanner{
+			Name:    "Starboard",
+			Vendor:  "Aqua Security",
+			Version: a.BuildInfo.Version,
+		},
+		Capabilities: []Capability{
+			{
+				ConsumesMimeTypes: []string{
+					MimeTypeOCIImageManifest,
+					MimeTypeDockerImageManifest,
+				},
+				ProducesMimeTypes: []string{
+					MimeTypeHarborReport,
+				},
+			},
+		},
+		Properties: map[string]string{
+			"harbor.scanner-adapter/scanner-type":             "os-package-vulnerability",
+			"org.aquasecurity.starboard/vulnerability-plugin": a.PluginContext.GetName(),
+		},
+	})
+}
+
+func (a *Adapter) acceptScanRequest(w http.ResponseWriter, r *http.Request) {
+	var req ScanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		a.writeError(w, http.StatusBadRequest, fmt.Errorf("decoding scan request: %w", err))
+		return
+	}
+	imageRef, err := GetImageRef(req)
+	if err != nil {
+		a.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Artifact.MimeType != "" && req.Artifact.MimeType != MimeTypeOCIImageManifest &&
+		req.Artifact.MimeType != MimeTypeDockerImageManifest {
+		a.writeError(w, http.StatusUnprocessableEntity, fmt.Errorf("unsupported artifact MIME type: %s", req.Artifact.MimeType))
+		return
+	}
+	auth, err := ParseAuthorization(req.Registry.Authorization)
+	if err != nil {
+		a.writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	id := vulnerabilityreport.GetClusterReportName(a.PluginContext.GetName(), imageRef)
+	log := a.Logger.WithValues("id", id, "image", imageRef)
+
+	report, err := a.FindClusterReportByImageDigest(r.Context(), a.PluginContext.GetName(), imageRef)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if report != nil && a.Clock.Now().Sub(report.Report.UpdateTimestamp.Time) < a.Config.VulnerabilityScannerCacheReportTTL {
+		log.V(1).Info("Reusing cached vulnerability report")
+		a.writeJSON(w, http.StatusAccepted, MimeTypeScanResponse, ScanResponse{ID: id})
+		return
+	}
+
+	log.V(1).Info("Submitting scan job")
+	if err := a.submitScanJob(r.Context(), id, imageRef, auth); err != nil {
+		if errors.Is(err, errScanJobsLimitExceeded) {
+			log.V(1).Info("Pushing back scan request", "retryAfter", a.Config.ScanJobRetryAfter)
+			w.Header().Set("Retry-After", strconv.Itoa(int(a.Config.ScanJobRetryAfter.Seconds())))
+			a.writeError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+		a.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	a.writeJSON(w, http.StatusAccepted, MimeTypeScanResponse, ScanResponse{ID: id})
+}
+
+func (a *Adapter) getScanReport(w http.ResponseWriter, r *http.Request, id string) {
+	if accept := r.Header.Get("Accept"); accept != "" && accept != "*/*" &&
+		!strings.HasPrefix(accept, strings.Split(MimeTypeHarborReport, ";")[0]) {
+		a.writeError(w, http.StatusBadRequest, fmt.Errorf("unsupported report MIME type: %s", accept))
+		return
+	}
+
+	ctx := r.Context()
+	job := &batchv1.Job{}
+	err := a.Client.Get(ctx, client.ObjectKey{
+		Namespace: a.PluginContext.GetNamespace(),
+		Name:      vulnerabilityreport.GetScanJobName(a.artifactPod(id, "")),
+	}, job)
+	if err != nil && !k8sapierror.IsNotFound(err) {
+		a.writeError(w, http.StatusInternalServerError, fmt.Errorf("getting scan job: %w", err))
+		return
+	}
+	if err == nil {
+		if len(job.Status.Conditions) == 0 {
+			w.Header().Set("Refresh-After", refreshAfter)
+			w.Header().Set("Location", r.URL.Path)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		switch job.Status.Conditions[0].Type {
+		case batchv1.JobComplete:
+			// The report is returned as is, because it might not be in the
+			// cache of the client yet.
+			report, err := a.processCompleteScanJob(ctx, job)
+			if err != nil {
+				a.writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			a.writeJSON(w, http.StatusOK, MimeTypeHarborReport, ToVulnerabilityReport(report.Report))
+			return
+		case batchv1.JobFailed:
+			a.writeError(w, http.StatusInternalServerError, a.processFailedScanJob(ctx, job))
+			return
+		}
+	}
+
+	report := &v1alpha1.ClusterVulnerabilityReport{}
+	err = a.Client.Get(ctx, client.ObjectKey{Name: id}, report)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			a.writeError(w, http.StatusNotFound, fmt.Errorf("scan request %s not found", id))
+			return
+		}
+		a.writeError(w, http.StatusInternalServerError, fmt.Errorf("getting vulnerability report: %w", err))
+		return
+	}
+	a.writeJSON(w, http.StatusOK, MimeTypeHarborReport, ToVulnerabilityReport(report.Report))
+}
+
+// artifactPod returns the Pod, which describes the scanned artifact. The Pod
+// is never created. It's the workload passed to the
+// vulnerabilityreport.ScanJobBuilder, and it determines the name of the scan
+// job created for the scan request with the given id.
+func (a *Adapter) artifactPod(id, imageRef string) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       string(kube.KindPod),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "harbor-" + id,
+			Namespace: a.PluginContext.GetNamespace(),
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: a.PluginContext.GetServiceAccountName(),
+			Containers: []corev1.Container{
+				{
+					Name:  scanContainerName,
+					Image: imageRef,
+				},
+			},
+		},
+	}
+}
+
+func (a *Adapter) submitScanJob(ctx context.Context, id, imageRef string, auth *docker.Auth) error {
+	limitExceeded, _, err := a.LimitChecker.Check(ctx)
+	if err != nil {
+		return fmt.Errorf("checking scan jobs limit: %w", err)
+	}
+	if limitExceeded {
+		metrics.ScanJobsThrottled.WithLabelValues(metrics.VulnerabilityReport).Inc()
+		return errScanJobsLimitExceeded
+	}
+
+	credentials := make(map[string]docker.Auth)
+	if auth != nil {
+		credentials[scanContainerName] = *auth
+	}
+
+	scanJobTolerations, err := a.GetScanJobTolerations()
+	if err != nil {
+		return fmt.Errorf("getting scan job tolerations: %w", err)
+	}
+
+	scanJobAnnotations, err := a.GetScanJobAnnotations()
+	if err != nil {
+		return fmt.Errorf("getting scan job annotations: %w", err)
+	}
+
+	scanJobPodTemplateLabels, err := a.GetScanJobPodTemplateLabels()
+	if err != nil {
+		return fmt.Errorf("getting scan job template labels: %w", err)
+	}
+
+	scanJob, secrets, err := vulnerabilityreport.NewScanJobBuilder().
+		WithPlugin(a.Plugin).
+		WithPluginContext(a.PluginContext).
+		WithTimeout(a.Config.ScanJobTimeout).
+		WithObject(a.artifactPod(id, imageRef)).
+		WithTolerations(scanJobTolerations).
+		WithAnnotations(scanJobAnnotations).
+		WithPodTemplateLabels(scanJobPodTemplateLabels).
+		WithCredentials(credentials).
+		Get()
+	if err != nil {
+		return fmt.Errorf("constructing scan job: %w", err)
+	}
+
+	// Scan jobs of Harbor artifacts are processed by the adapter rather than
+	// by the controllers of workloads.
+	for _, labels := range []map[string]string{scanJob.Labels, scanJob.Spec.Template.Labels} {
+		delete(labels, starboard.LabelVulnerabilityReportScanner)
+		labels[starboard.LabelHarborScanRequest] = id
+	}
+
+	// Secrets are labeled, so that the Collector can delete them if the scan
+	// job is never created.
+	for _, secret := range secrets {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[starboard.LabelK8SAppManagedBy] = starboard.AppStarboard
+		secret.Labels[starboard.LabelHarborScanRequest] = id
+		err = a.Client.Create(ctx, secret)
+		if k8sapierror.IsAlreadyExists(err) {
+			// Left behind by a previous scan request, whose scan job could
+			// not be created. Its credentials might be stale.
+			err = a.updateSecret(ctx, secret)
+		}
+		if err != nil {
+			return fmt.Errorf("creating secret used by scan job failed: %s: %w", secret.Namespace+"/"+secret.Name, err)
+		}
+	}
+
+	err = a.Client.Create(ctx, scanJob)
+	if err != nil {
+		if k8sapierror.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("creating scan job failed: %s: %w", scanJob.Namespace+"/"+scanJob.Name, err)
+	}
+
+	for _, secret := range secrets {
+		err = controllerutil.SetOwnerReference(scanJob, secret, a.Client.Scheme())
+		if err != nil {
+			return fmt.Errorf("setting owner reference: %w", err)
+		}
+		err := a.Client.Update(ctx, secret)
+		if err != nil {
+			return fmt.Errorf("setting owner reference of secret used by scan job failed: %s: %w", secret.Namespace+"/"+secret.Name, err)
+		}
+	}
+	return nil
+}
+
+func (a *Adapter) updateSecret(ctx context.Context, secret *corev1.Secret) error {
+	existing := &corev1.Secret{}
+	err := a.Client.Get(ctx, client.ObjectKeyFromObject(secret), existing)
+	if err != nil {
+		return err
+	}
+	existing.Labels = secret.Labels
+	existing.Data = secret.Data
+	existing.StringData = secret.StringData
+	err = a.Client.Update(ctx, existing)
+	if err != nil {
+		return err
+	}
+	existing.DeepCopyInto(secret)
+	return nil
+}
+
+func (a *Adapter) processCompleteScanJob(ctx context.Context, job *batchv1.Job) (v1alpha1.ClusterVulnerabilityReport, error) {
+	containerImages, err := kube.GetContainerImagesFromJob(job)
+	if err != nil {
+		return v1alpha1.ClusterVulnerabilityReport{}, fmt.Errorf("getting container images: %w", err)
+	}
+	imageRef, ok := containerImages[scanContainerName]
+	if !ok {
+		return v1alpha1.ClusterVulnerabilityReport{}, fmt.Errorf("expected image of container %s not set", scanContainerName)
+	}
+
+	logsStream, err := a.LogsReader.GetLogsByJobAndContainerName(ctx, job, scanContainerName)
+	if err != nil {
+		return v1alpha1.ClusterVulnerabilityReport{}, fmt.Errorf("getting logs: %w", err)
+	}
+	defer func() {
+		_ = logsStream.Close()
+	}()
+
+	data, err := a.Plugin.ParseVulnerabilityReportData(a.PluginContext, imageRef, logsStream)
+	if err != nil {
+		return v1alpha1.ClusterVulnerabilityReport{}, err
+	}
+
+	report, err := vulnerabilityreport.NewClusterReportBuilder().
+		Scanner(a.PluginContext.GetName()).
+		ImageDigest(imageRef).
+		Data(data).
+		ReportTTL(a.Config.VulnerabilityScannerCacheReportTTL).
+		Get()
+	if err != nil {
+		return v1alpha1.ClusterVulnerabilityReport{}, err
+	}
+	err = a.WriteClusterReport(ctx, report)
+	if err != nil {
+		return v1alpha1.ClusterVulnerabilityReport{}, err
+	}
+	return report, a.deleteJob(ctx, job)
+}
+
+func (a *Adapter) processFailedScanJob(ctx context.Context, job *batchv1.Job) error {
+	statuses, err := a.LogsReader.GetTerminatedContainersStatusesByJob(ctx, job)
+	if err != nil {
+		return fmt.Errorf("getting terminated containers statuses: %w", err)
+	}
+	if err := a.deleteJob(ctx, job); err != nil {
+		return err
+	}
+	if status, ok := statuses[scanContainerName]; ok && status.Message != "" {
+		return fmt.Errorf("scan job failed: %s", status.Message)
+	}
+	return errors.New("scan job failed")
+}
+
+func (a *Adapter) deleteJob(ctx context.Context, job *batchv1.Job) error {
+	err := a.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("deleting job: %w", err)
+	}
+	return nil
+}
+
+func (a *Adapter) writeJSON(w http.ResponseWriter, statusCode int, mimeType string, v interface{}) {
+	w.Header().Set("Content-Type", mimeType)
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		a.Logger.Error(err, "Unable to write response")
+	}
+}
+
+func (a *Adapter) writeError(w http.ResponseWriter, statusCode int, err error) {
+	if statusCode >= http.StatusInternalServerError {
+		a.Logger.Error(err, "Unable to process request")
+	}
+	a.writeJSON(w, statusCode, MimeTypeError, ErrorResponse{Error: Error{Message: err.Error()}})
+}
+
+// GetImageRef returns the reference of the image identified by the given scan
+// request, e.g. core.harbor.domain/library/nginx@sha256:..., which is the
+// key of the v1alpha1.ClusterVulnerabilityReport of the image.
+func GetImageRef(req ScanRequest) (string, error) {
+	if req.Registry.URL == "" {
+		return "", errors.New("registry url must be set")
+	}
+	if req.Artifact.Repository == "" {
+		return "", errors.New("artifact repository must be set")
+	}
+	if req.Artifact.Digest == "" {
+		return "", errors.New("artifact digest must be set")
+	}
+	registryURL, err := url.Parse(req.Registry.URL)
+	if err != nil {
+		return "", fmt.Errorf("parsing registry url: %w", err)
+	}
+	if registryURL.Host == "" {
+		return "", fmt.Errorf("registry url must be absolute: %s", req.Registry.URL)
+	}
+	return fmt.Sprintf("%s/%s@%s", registryURL.Host, req.Artifact.Repository, req.Artifact.Digest), nil
+}
+
+// ParseAuthorization converts the value of the HTTP Authorization header
+// passed by Harbor to registry credentials. Only the Basic authentication
+// scheme is supported. It returns nil if the authorization is empty.
+func ParseAuthorization(authorization string) (*docker.Auth, error) {
+	if authorization == "" {
+		return nil, nil
+	}
+	scheme, value, ok := cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Basic") {
+		return nil, errors.New("unsupported registry authorization type, expected Basic")
+	}
+	basic := docker.BasicAuth(value)
+	username, password, err := basic.Decode()
+	if err != nil {
+		return nil, fmt.Errorf("decoding registry authorization: %w", err)
+	}
+	return &docker.Auth{
+		Auth:     basic,
+		Username: username,
+		Password: password,
+	}, nil
+}
+
+// cut slices s around the first instance of sep. It's the same as
+// strings.Cut, which is not available in Go 1.17.
+func cut(s, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// ToVulnerabilityReport converts the given report data to the report format
+// defined by Harbor.
+func ToVulnerabilityReport(data v1alpha1.VulnerabilityReportData) VulnerabilityReport {
+	report := VulnerabilityReport{
+		GeneratedAt: data.UpdateTimestamp.Time.UTC(),
+		Artifact: Artifact{
+			Repository: data.Artifact.Repository,
+			Digest:     data.Artifact.Digest,
+			Tag:        data.Artifact.Tag,
+			MimeType:   data.Artifact.MimeType,
+		},
+		Scanner: Scanner{
+			Name:    data.Scanner.Name,
+			Vendor:  data.Scanner.Vendor,
+			Version: data.Scanner.Version,
+		},
+		Severity:        SeverityUnknown,
+		Vulnerabilities: make([]VulnerabilityItem, 0, len(data.Vulnerabilities)),
+	}
+	for _, vulnerability := range data.Vulnerabilities {
+		if vulnerability.Suppressed {
+			continue
+		}
+		severity := toSeverity(vulnerability.Severity)
+		if severityRank[severity] > severityRank[report.Severity] {
+			report.Severity = severity
+		}
+		links := make([]string, 0, len(vulnerability.Links)+1)
+		if vulnerability.PrimaryLink != "" {
+			links = append(links, vulnerability.PrimaryLink)
+		}
+		for _, link := range vulnerability.Links {
+			if !ext.SliceContainsString(links, link) {
+				links = append(links, link)
+			}
+		}
+		description := vulnerability.Description
+		if description == "" {
+			description = vulnerability.Title
+		}
+		report.Vulnerabilities = append(report.Vulnerabilities, VulnerabilityItem{
+			ID:          vulnerability.VulnerabilityID,
+			Package:     vulnerability.Resource,
+			Version:     vulnerability.InstalledVersion,
+			FixVersion:  vulnerability.FixedVersion,
+			Severity:    severity,
+			Description: description,
+			Links:       links,
+		})
+	}
+	return report
+}
+
+var severityRank = map[Severity]int{
+	SeverityUnknown:    0,
+	SeverityNegligible: 1,
+	SeverityLow:        2,
+	SeverityMedium:     3,
+	SeverityHigh:       4,
+	SeverityCritical:   5,
+}
+
+func toSeverity(severity v1alpha1.Severity) Severity {
+	switch severity {
+	case v1alpha1.SeverityCritical:
+		return SeverityCritical
+	case v1alpha1.SeverityHigh:
+		return SeverityHigh
+	case v1alpha1.SeverityMedium:
+		return SeverityMedium
+	case v1alpha1.SeverityLow:
+		return SeverityLow
+	case v1alpha1.SeverityNone:
+		return SeverityNegligible
+	default:
+		return SeverityUnknown
+	}
+}
@@ -0,0 +1,367 @@
+package harbor_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/harbor"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const token = "s3cr3t"
+
+const imageRef = "core.harbor.domain/library/nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+
+type fakePlugin struct{}
+
+func (p *fakePlugin) Init(_ starboard.PluginContext) error {
+	return nil
+}
+
+func (p *fakePlugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	pod := workload.(*corev1.Pod)
+	env := []corev1.EnvVar{}
+	if auth, ok := credentials["artifact"]; ok {
+		env = append(env, corev1.EnvVar{Name: "USERNAME", Value: auth.Username})
+	}
+	return corev1.PodSpec{
+		ServiceAccountName: ctx.GetServiceAccountName(),
+		RestartPolicy:      corev1.RestartPolicyNever,
+		Containers: []corev1.Container{
+			{
+				Name:  "artifact",
+				Image: "scanner:0.1.0",
+				Args:  []string{pod.Spec.Containers[0].Image},
+				Env:   env,
+			},
+		},
+	}, nil, nil
+}
+
+func (p *fakePlugin) ParseVulnerabilityReportData(_ starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	var vulnerabilities []v1alpha1.Vulnerability
+	if err := json.NewDecoder(logsReader).Decode(&vulnerabilities); err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)),
+		Scanner:         v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.25.2"},
+		Registry:        v1alpha1.Registry{Server: "core.harbor.domain"},
+		Artifact:        v1alpha1.Artifact{Repository: "library/nginx"},
+		Summary:         v1alpha1.VulnerabilitySummaryFromVulnerabilities(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}, nil
+}
+
+type fakeLogsReader string
+
+func (r fakeLogsReader) GetLogsByJobAndContainerName(_ context.Context, _ *batchv1.Job, _ string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(string(r))), nil
+}
+
+func (r fakeLogsReader) GetTerminatedContainersStatusesByJob(_ context.Context, _ *batchv1.Job) (map[string]*corev1.ContainerStateTerminated, error) {
+	return map[string]*corev1.ContainerStateTerminated{
+		"artifact": {Message: "unauthorized"},
+	}, nil
+}
+
+// fakeLimitChecker reports whether the limit of scan jobs is exceeded.
+type fakeLimitChecker bool
+
+func (c fakeLimitChecker) Check(_ context.Context) (bool, int, error) {
+	return bool(c), 0, nil
+}
+
+func newAdapter(t *testing.T, c client.Client, logs string) *harbor.Adapter {
+	t.Helper()
+	secretDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(secretDir, "token"), []byte(token+"\n"), 0600))
+	return &harbor.Adapter{
+		Logger: logr.Discard(),
+		Config: etc.Config{
+			ScanJobTimeout:                     5 * time.Minute,
+			ScanJobRetryAfter:                  30 * time.Second,
+			VulnerabilityScannerCacheReportTTL: 24 * time.Hour,
+		},
+		ConfigData:   starboard.ConfigData{},
+		Client:       c,
+		LogsReader:   fakeLogsReader(logs),
+		LimitChecker: fakeLimitChecker(false),
+		Plugin:       &fakePlugin{},
+		PluginContext: starboard.NewPluginContext().
+			WithName("Trivy").
+			WithNamespace("starboard-system").
+			WithServiceAccountName("starboard-operator").
+			WithClient(c).
+			Get(),
+		ReadWriter: vulnerabilityreport.NewReadWriter(c),
+		Clock:      ext.NewFixedClock(time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)),
+		BuildInfo:  starboard.BuildInfo{Version: "0.15.4"},
+		SecretDir:  secretDir,
+	}
+}
+
+func serve(adapter http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	return serveWithToken(adapter, method, path, body, token)
+}
+
+func serveWithToken(adapter http.Handler, method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	adapter.ServeHTTP(rec, req)
+	return rec
+}
+
+const scanRequest = `{
+  "registry": {
+    "url": "https://core.harbor.domain",
+    "authorization": "Basic cm9ib3Q6czNjcjN0"
+  },
+  "artifact": {
+    "repository": "library/nginx",
+    "digest": "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+    "mime_type": "application/vnd.docker.distribution.manifest.v2+json"
+  }
+}`
+
+func TestAdapter_Metadata(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	rec := serve(newAdapter(t, c, ""), http.MethodGet, "/api/v1/metadata", "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, harbor.MimeTypeMetadata, rec.Header().Get("Content-Type"))
+	var metadata harbor.ScannerAdapterMetadata
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&metadata))
+	assert.Equal(t, harbor.Scanner{Name: "Starboard", Vendor: "Aqua Security", Version: "0.15.4"}, metadata.Scanner)
+	assert.Equal(t, []string{harbor.MimeTypeHarborReport}, metadata.Capabilities[0].ProducesMimeTypes)
+	assert.Equal(t, "Trivy", metadata.Properties["org.aquasecurity.starboard/vulnerability-plugin"])
+}
+
+func TestAdapter_Scan(t *testing.T) {
+	id := vulnerabilityreport.GetClusterReportName("Trivy", imageRef)
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	adapter := newAdapter(t, c, `[{"vulnerabilityID":"CVE-2019-1549","resource":"libcrypto1.1","installedVersion":"1.1.1c-r0","fixedVersion":"1.1.1d-r0","severity":"HIGH","title":"openssl: information disclosure in fork()","primaryLink":"https://avd.aquasec.com/nvd/cve-2019-1549","links":[]}]`)
+
+	rec := serve(adapter, http.MethodPost, "/api/v1/scan", scanRequest)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, harbor.MimeTypeScanResponse, rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"id":"`+id+`"}`, rec.Body.String())
+
+	var jobs batchv1.JobList
+	require.NoError(t, c.List(context.TODO(), &jobs, client.InNamespace("starboard-system")))
+	require.Len(t, jobs.Items, 1)
+	job := jobs.Items[0]
+	assert.Equal(t, id, job.Labels[starboard.LabelHarborScanRequest])
+	assert.NotContains(t, job.Labels, starboard.LabelVulnerabilityReportScanner)
+	assert.Equal(t, []string{imageRef}, job.Spec.Template.Spec.Containers[0].Args)
+	assert.Equal(t, []corev1.EnvVar{{Name: "USERNAME", Value: "robot"}}, job.Spec.Template.Spec.Containers[0].Env)
+
+	t.Run("Should redirect while scan job is running", func(t *testing.T) {
+		rec := serve(adapter, http.MethodGet, "/api/v1/scan/"+id+"/report", "")
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "15", rec.Header().Get("Refresh-After"))
+	})
+
+	t.Run("Should return report when scan job is complete", func(t *testing.T) {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		require.NoError(t, c.Status().Update(context.TODO(), &job))
+
+		rec := serve(adapter, http.MethodGet, "/api/v1/scan/"+id+"/report", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, harbor.MimeTypeHarborReport, rec.Header().Get("Content-Type"))
+		var report harbor.VulnerabilityReport
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+		assert.Equal(t, harbor.VulnerabilityReport{
+			GeneratedAt: time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC),
+			Artifact: harbor.Artifact{
+				Repository: "library/nginx",
+				Digest:     "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+			},
+			Scanner:  harbor.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.25.2"},
+			Severity: harbor.SeverityHigh,
+			Vulnerabilities: []harbor.VulnerabilityItem{
+				{
+					ID:          "CVE-2019-1549",
+					Package:     "libcrypto1.1",
+					Version:     "1.1.1c-r0",
+					FixVersion:  "1.1.1d-r0",
+					Severity:    harbor.SeverityHigh,
+					Description: "openssl: information disclosure in fork()",
+					Links:       []string{"https://avd.aquasec.com/nvd/cve-2019-1549"},
+				},
+			},
+		}, report)
+
+		var clusterReport v1alpha1.ClusterVulnerabilityReport
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Name: id}, &clusterReport))
+		assert.Equal(t, "24h0m0s", clusterReport.Annotations[v1alpha1.TTLReportAnnotation])
+
+		require.NoError(t, c.List(context.TODO(), &jobs))
+		assert.Empty(t, jobs.Items)
+	})
+
+	t.Run("Should return cached report", func(t *testing.T) {
+		rec := serve(adapter, http.MethodPost, "/api/v1/scan", scanRequest)
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		require.NoError(t, c.List(context.TODO(), &jobs))
+		assert.Empty(t, jobs.Items)
+
+		rec = serve(adapter, http.MethodGet, "/api/v1/scan/"+id+"/report", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("Should rescan when cached report is stale", func(t *testing.T) {
+		adapter.Clock = ext.NewFixedClock(time.Date(2022, time.June, 3, 12, 0, 0, 0, time.UTC))
+		rec := serve(adapter, http.MethodPost, "/api/v1/scan", scanRequest)
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		require.NoError(t, c.List(context.TODO(), &jobs))
+		assert.Len(t, jobs.Items, 1)
+	})
+
+	t.Run("Should return error when scan job failed", func(t *testing.T) {
+		job := jobs.Items[0]
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+		require.NoError(t, c.Status().Update(context.TODO(), &job))
+
+		rec := serve(adapter, http.MethodGet, "/api/v1/scan/"+id+"/report", "")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, harbor.MimeTypeError, rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"error":{"message":"scan job failed: unauthorized"}}`, rec.Body.String())
+	})
+}
+
+func TestAdapter_Errors(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	adapter := newAdapter(t, c, "")
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   int
+	}{
+		{
+			name:   "Should reject invalid scan request",
+			method: http.MethodPost,
+			path:   "/api/v1/scan",
+			body:   `{"registry":{"url":"https://core.harbor.domain"},"artifact":{"repository":"library/nginx"}}`,
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "Should reject unsupported authorization",
+			method: http.MethodPost,
+			path:   "/api/v1/scan",
+			body:   strings.Replace(scanRequest, "Basic cm9ib3Q6czNjcjN0", "Bearer token", 1),
+			code:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "Should return not found for unknown scan request",
+			method: http.MethodGet,
+			path:   "/api/v1/scan/unknown/report",
+			code:   http.StatusNotFound,
+		},
+		{
+			name:   "Should return not found for unknown path",
+			method: http.MethodGet,
+			path:   "/api/v1/unknown",
+			code:   http.StatusNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(adapter, tc.method, tc.path, tc.body)
+			assert.Equal(t, tc.code, rec.Code)
+			assert.Equal(t, harbor.MimeTypeError, rec.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestAdapter_Unauthorized(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	adapter := newAdapter(t, c, "")
+
+	for _, token := range []string{"", "invalid"} {
+		rec := serveWithToken(adapter, http.MethodPost, "/api/v1/scan", scanRequest, token)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `Bearer realm="starboard"`, rec.Header().Get("WWW-Authenticate"))
+	}
+
+	var jobs batchv1.JobList
+	require.NoError(t, c.List(context.TODO(), &jobs))
+	assert.Empty(t, jobs.Items)
+
+	t.Run("Should reject requests when token is not set", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(adapter.SecretDir, "token"), []byte("\n"), 0600))
+		rec := serveWithToken(adapter, http.MethodGet, "/api/v1/metadata", "", "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestAdapter_ScanJobsLimitExceeded(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	adapter := newAdapter(t, c, "")
+	adapter.LimitChecker = fakeLimitChecker(true)
+
+	rec := serve(adapter, http.MethodPost, "/api/v1/scan", scanRequest)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":{"message":"concurrent scan jobs limit exceeded"}}`, rec.Body.String())
+
+	var jobs batchv1.JobList
+	require.NoError(t, c.List(context.TODO(), &jobs))
+	assert.Empty(t, jobs.Items)
+}
+
+func TestGetImageRef(t *testing.T) {
+	ref, err := harbor.GetImageRef(harbor.ScanRequest{
+		Registry: harbor.Registry{URL: "http://core.harbor.domain:8080"},
+		Artifact: harbor.Artifact{Repository: "library/nginx", Digest: "sha256:0d17"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "core.harbor.domain:8080/library/nginx@sha256:0d17", ref)
+
+	_, err = harbor.GetImageRef(harbor.ScanRequest{
+		Registry: harbor.Registry{URL: "core.harbor.domain"},
+		Artifact: harbor.Artifact{Repository: "library/nginx", Digest: "sha256:0d17"},
+	})
+	assert.EqualError(t, err, "registry url must be absolute: core.harbor.domain")
+}
+
+func TestToVulnerabilityReport(t *testing.T) {
+	report := harbor.ToVulnerabilityReport(v1alpha1.VulnerabilityReportData{
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-1", Severity: v1alpha1.SeverityNone},
+			{VulnerabilityID: "CVE-2", Severity: v1alpha1.SeverityCritical, Suppressed: true},
+			{VulnerabilityID: "CVE-3", Severity: v1alpha1.SeverityUnknown},
+		},
+	})
+	assert.Equal(t, harbor.SeverityNegligible, report.Severity)
+	require.Len(t, report.Vulnerabilities, 2)
+	assert.Equal(t, harbor.SeverityNegligible, report.Vulnerabilities[0].Severity)
+	assert.Equal(t, harbor.SeverityUnknown, report.Vulnerabilities[1].Severity)
+}
@@ -0,0 +1,142 @@
+package harbor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	userAgent      = "Starboard"
+)
+
+// ErrReportNotReady is returned by Client's GetReport if the scan is still
+// in progress.
+var ErrReportNotReady = errors.New("report not ready")
+
+// Client calls a remote scanner adapter, which implements the Harbor Scanner
+// Adapter API v1.0, e.g. the Trivy adapter or the Clair adapter.
+type Client struct {
+	baseURL       string
+	authorization string
+	httpClient    *http.Client
+}
+
+// NewClient constructs a new API client with the specified base URL, e.g.
+// http://harbor-scanner-trivy:8080, and the value of the HTTP Authorization
+// header sent with each request, which may be empty if the adapter does not
+// require authentication.
+func NewClient(baseURL, authorization string) *Client {
+	return &Client{
+		baseURL:       strings.TrimSuffix(baseURL, "/"),
+		authorization: authorization,
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+			// The adapter responds with 302 Found while the report is not
+			// ready, which must not be followed.
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// GetMetadata returns the metadata of the scanner adapter.
+func (c *Client) GetMetadata(ctx context.Context) (ScannerAdapterMetadata, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/metadata", nil)
+	if err != nil {
+		return ScannerAdapterMetadata{}, err
+	}
+	req.Header.Set("Accept", MimeTypeMetadata)
+
+	var metadata ScannerAdapterMetadata
+	_, err = c.do(req, http.StatusOK, &metadata)
+	return metadata, err
+}
+
+// Scan submits the given scan request and returns its identifier.
+func (c *Client) Scan(ctx context.Context, scanRequest ScanRequest) (ScanResponse, error) {
+	body, err := json.Marshal(scanRequest)
+	if err != nil {
+		return ScanResponse{}, err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "/scan", bytes.NewReader(body))
+	if err != nil {
+		return ScanResponse{}, err
+	}
+	req.Header.Set("Content-Type", MimeTypeScanRequest)
+	req.Header.Set("Accept", MimeTypeScanResponse)
+
+	var scanResponse ScanResponse
+	_, err = c.do(req, http.StatusAccepted, &scanResponse)
+	return scanResponse, err
+}
+
+// GetReport returns the report of the scan request with the given
+// identifier. If the scan is still in progress it returns ErrReportNotReady
+// and the duration after which the report should be requested again, which
+// is zero if the adapter did not suggest it.
+func (c *Client) GetReport(ctx context.Context, id string) (VulnerabilityReport, time.Duration, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/scan/"+id+"/report", nil)
+	if err != nil {
+		return VulnerabilityReport{}, 0, err
+	}
+	req.Header.Set("Accept", MimeTypeHarborReport)
+
+	var report VulnerabilityReport
+	resp, err := c.do(req, http.StatusOK, &report)
+	if resp != nil && resp.StatusCode == http.StatusFound {
+		var refreshAfter time.Duration
+		if seconds, err := strconv.Atoi(resp.Header.Get("Refresh-After")); err == nil {
+			refreshAfter = time.Duration(seconds) * time.Second
+		}
+		return VulnerabilityReport{}, refreshAfter, ErrReportNotReady
+	}
+	return report, 0, err
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+apiPrefix+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	return req, nil
+}
+
+// do sends the given request and decodes the response body to v if the
+// response status is the expected one. Otherwise, it returns an error with
+// the message of the ErrorResponse, if any.
+func (c *Client) do(req *http.Request, expectedStatus int, v interface{}) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == expectedStatus {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return resp, fmt.Errorf("decoding response: %w", err)
+		}
+		return resp, nil
+	}
+
+	var errorResponse ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err == nil && errorResponse.Error.Message != "" {
+		return resp, fmt.Errorf("unexpected response status: %s: %s", resp.Status, errorResponse.Error.Message)
+	}
+	return resp, fmt.Errorf("unexpected response status: %s", resp.Status)
+}
@@ -0,0 +1,110 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultCollectInterval is the default interval of garbage collection of
+// scan jobs and Secrets created for Harbor scan requests.
+const DefaultCollectInterval = 10 * time.Minute
+
+// Collector garbage-collects scan jobs created by the Adapter, which are
+// otherwise deleted only when Harbor gets the report of a scan request. Jobs
+// that have finished longer than the Interval ago are deleted, and reports of
+// complete jobs are saved before, so that they're still served if Harbor asks
+// for them later. Secrets of scan jobs that were never created are deleted
+// as well.
+//
+// Collector implements the manager.Runnable interface, and it's run by the
+// leader only.
+type Collector struct {
+	Adapter  *Adapter
+	Interval time.Duration
+}
+
+// Start collects garbage until the given context is cancelled. Errors are
+// logged and the collection is retried after the Interval.
+func (c *Collector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Collect(ctx); err != nil {
+			c.Adapter.Logger.Error(err, "Unable to collect scan jobs")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect deletes finished scan jobs and abandoned Secrets of Harbor scan
+// requests.
+func (c *Collector) Collect(ctx context.Context) error {
+	a := c.Adapter
+	now := a.Clock.Now()
+
+	var jobs batchv1.JobList
+	err := a.Client.List(ctx, &jobs, client.InNamespace(a.PluginContext.GetNamespace()),
+		client.HasLabels{starboard.LabelHarborScanRequest})
+	if err != nil {
+		return fmt.Errorf("listing scan jobs: %w", err)
+	}
+	requests := make(map[string]bool)
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		requests[job.Labels[starboard.LabelHarborScanRequest]] = true
+		if len(job.Status.Conditions) == 0 || now.Sub(job.Status.Conditions[0].LastTransitionTime.Time) < c.Interval {
+			continue
+		}
+		log := a.Logger.WithValues("job", job.Namespace+"/"+job.Name)
+		switch job.Status.Conditions[0].Type {
+		case batchv1.JobComplete:
+			log.V(1).Info("Collecting complete scan job")
+			if _, err := a.processCompleteScanJob(ctx, job); err != nil {
+				log.Error(err, "Unable to process complete scan job")
+				// The report cannot be saved, e.g. because Pod logs are
+				// gone, therefore the scan will be repeated.
+				if err := a.deleteJob(ctx, job); err != nil {
+					return err
+				}
+			}
+		case batchv1.JobFailed:
+			log.V(1).Info("Collecting failed scan job")
+			if err := a.deleteJob(ctx, job); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Secrets of existing scan jobs are deleted by the garbage collector of
+	// Kubernetes along with their owner.
+	var secrets corev1.SecretList
+	err = a.Client.List(ctx, &secrets, client.InNamespace(a.PluginContext.GetNamespace()),
+		client.HasLabels{starboard.LabelHarborScanRequest})
+	if err != nil {
+		return fmt.Errorf("listing secrets: %w", err)
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if requests[secret.Labels[starboard.LabelHarborScanRequest]] ||
+			now.Sub(secret.CreationTimestamp.Time) < c.Interval {
+			continue
+		}
+		a.Logger.V(1).Info("Deleting abandoned secret", "secret", secret.Namespace+"/"+secret.Name)
+		err = a.Client.Delete(ctx, secret)
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			return fmt.Errorf("deleting secret: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package harbor_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/harbor"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCollector_Collect(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	adapter := newAdapter(t, c, `[]`)
+	collector := &harbor.Collector{Adapter: adapter, Interval: 10 * time.Minute}
+	now := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	id := vulnerabilityreport.GetClusterReportName("Trivy", imageRef)
+	rec := serve(adapter, http.MethodPost, "/api/v1/scan", scanRequest)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	var jobs batchv1.JobList
+	require.NoError(t, c.List(context.TODO(), &jobs))
+	require.Len(t, jobs.Items, 1)
+	job := jobs.Items[0]
+
+	require.NoError(t, c.Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "scan-vulnerabilityreport-abandoned-regcred",
+			Namespace:         "starboard-system",
+			Labels:            map[string]string{starboard.LabelHarborScanRequest: "abandoned"},
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+	}))
+	require.NoError(t, c.Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "scan-vulnerabilityreport-new-regcred",
+			Namespace:         "starboard-system",
+			Labels:            map[string]string{starboard.LabelHarborScanRequest: "new"},
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+		},
+	}))
+
+	t.Run("Should keep running scan job", func(t *testing.T) {
+		require.NoError(t, collector.Collect(context.TODO()))
+		require.NoError(t, c.List(context.TODO(), &jobs))
+		assert.Len(t, jobs.Items, 1)
+
+		var secrets corev1.SecretList
+		require.NoError(t, c.List(context.TODO(), &secrets))
+		require.Len(t, secrets.Items, 1)
+		assert.Equal(t, "scan-vulnerabilityreport-new-regcred", secrets.Items[0].Name)
+	})
+
+	t.Run("Should keep recently completed scan job", func(t *testing.T) {
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))},
+		}
+		require.NoError(t, c.Status().Update(context.TODO(), &job))
+
+		require.NoError(t, collector.Collect(context.TODO()))
+		require.NoError(t, c.List(context.TODO(), &jobs))
+		assert.Len(t, jobs.Items, 1)
+	})
+
+	t.Run("Should save report and delete abandoned scan job", func(t *testing.T) {
+		job.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now.Add(-time.Hour))
+		require.NoError(t, c.Status().Update(context.TODO(), &job))
+
+		require.NoError(t, collector.Collect(context.TODO()))
+		require.NoError(t, c.List(context.TODO(), &jobs))
+		assert.Empty(t, jobs.Items)
+
+		var report v1alpha1.ClusterVulnerabilityReport
+		require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Name: id}, &report))
+
+		rec := serve(adapter, http.MethodGet, "/api/v1/scan/"+id+"/report", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,6 @@
+// Package harbor implements the Harbor Scanner Adapter API v1.0, which allows
+// Harbor registries to scan artifacts with vulnerability scanners configured
+// for Starboard.
+//
+// See https://github.com/goharbor/pluggable-scanner-spec
+package harbor
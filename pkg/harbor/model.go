@@ -0,0 +1,108 @@
+package harbor
+
+import (
+	"time"
+)
+
+// MIME types defined by the Harbor Scanner Adapter API v1.0.
+const (
+	MimeTypeMetadata     = "application/vnd.scanner.adapter.metadata+json; version=1.0"
+	MimeTypeScanRequest  = "application/vnd.scanner.adapter.scan.request+json; version=1.0"
+	MimeTypeScanResponse = "application/vnd.scanner.adapter.scan.response+json; version=1.0"
+	MimeTypeError        = "application/vnd.scanner.adapter.error+json; version=1.0"
+	MimeTypeHarborReport = "application/vnd.scanner.adapter.vuln.report.harbor+json; version=1.0"
+
+	MimeTypeOCIImageManifest    = "application/vnd.oci.image.manifest.v1+json"
+	MimeTypeDockerImageManifest = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// Severity of a vulnerability as defined by Harbor.
+type Severity string
+
+const (
+	SeverityUnknown    Severity = "Unknown"
+	SeverityNegligible Severity = "Negligible"
+	SeverityLow        Severity = "Low"
+	SeverityMedium     Severity = "Medium"
+	SeverityHigh       Severity = "High"
+	SeverityCritical   Severity = "Critical"
+)
+
+// Registry is the registry that hosts the scanned artifact. Authorization is
+// the value of the HTTP Authorization header used to pull the artifact, e.g.
+// Basic <base64 encoded username:password>.
+type Registry struct {
+	URL           string `json:"url"`
+	Authorization string `json:"authorization"`
+}
+
+// Artifact is the scanned artifact.
+type Artifact struct {
+	Repository string `json:"repository"`
+	Digest     string `json:"digest"`
+	Tag        string `json:"tag,omitempty"`
+	MimeType   string `json:"mime_type,omitempty"`
+}
+
+// ScanRequest is the body of the POST /scan request.
+type ScanRequest struct {
+	Registry Registry `json:"registry"`
+	Artifact Artifact `json:"artifact"`
+}
+
+// ScanResponse is returned when a ScanRequest is accepted.
+type ScanResponse struct {
+	ID string `json:"id"`
+}
+
+// Scanner describes the scanner, which generated a report.
+type Scanner struct {
+	Name    string `json:"name"`
+	Vendor  string `json:"vendor"`
+	Version string `json:"version"`
+}
+
+// Capability describes MIME types of artifacts that can be scanned and MIME
+// types of reports that can be generated.
+type Capability struct {
+	ConsumesMimeTypes []string `json:"consumes_mime_types"`
+	ProducesMimeTypes []string `json:"produces_mime_types"`
+}
+
+// ScannerAdapterMetadata is returned by the GET /metadata request.
+type ScannerAdapterMetadata struct {
+	Scanner      Scanner           `json:"scanner"`
+	Capabilities []Capability      `json:"capabilities"`
+	Properties   map[string]string `json:"properties"`
+}
+
+// VulnerabilityItem is a vulnerability of a package installed in the
+// scanned artifact.
+type VulnerabilityItem struct {
+	ID          string   `json:"id"`
+	Package     string   `json:"package"`
+	Version     string   `json:"version"`
+	FixVersion  string   `json:"fix_version,omitempty"`
+	Severity    Severity `json:"severity"`
+	Description string   `json:"description"`
+	Links       []string `json:"links"`
+}
+
+// VulnerabilityReport is returned by the GET /scan/{id}/report request.
+type VulnerabilityReport struct {
+	GeneratedAt     time.Time           `json:"generated_at"`
+	Artifact        Artifact            `json:"artifact"`
+	Scanner         Scanner             `json:"scanner"`
+	Severity        Severity            `json:"severity"`
+	Vulnerabilities []VulnerabilityItem `json:"vulnerabilities"`
+}
+
+// Error is returned when a request cannot be processed.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// ErrorResponse wraps the Error returned when a request cannot be processed.
+type ErrorResponse struct {
+	Error Error `json:"error"`
+}
@@ -0,0 +1,73 @@
+package history
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Controller watches v1alpha1.VulnerabilityReport and v1alpha1.ConfigAuditReport
+// instances and records their severity counts whenever they are created,
+// updated, or deleted.
+type Controller struct {
+	logr.Logger
+	*Recorder
+}
+
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	reports := []struct {
+		kind   string
+		object client.Object
+	}{
+		{kind: v1alpha1.VulnerabilityReportKind, object: &v1alpha1.VulnerabilityReport{}},
+		{kind: v1alpha1.ConfigAuditReportKind, object: &v1alpha1.ConfigAuditReport{}},
+	}
+	for _, report := range reports {
+		err := ctrl.NewControllerManagedBy(mgr).
+			Named(strings.ToLower(report.kind)+"-history").
+			For(report.object, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			Complete(r.reconcileReport(report.kind, report.object))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Controller) reconcileReport(reportKind string, report client.Object) reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("kind", reportKind, "report", req.NamespacedName)
+
+		obj := report.DeepCopyObject().(client.Object)
+		err := r.Client.Get(ctx, req.NamespacedName, obj)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Recording namespace summary after report was deleted")
+				return ctrl.Result{}, r.RecordNamespace(ctx, reportKind, req.Namespace)
+			}
+			return ctrl.Result{}, err
+		}
+
+		owner, err := kube.ObjectRefFromObjectMeta(metav1.ObjectMeta{
+			Labels:      obj.GetLabels(),
+			Annotations: obj.GetAnnotations(),
+		})
+		if err != nil {
+			log.V(1).Info("Recording namespace summary of report without owner")
+			return ctrl.Result{}, r.RecordNamespace(ctx, reportKind, req.Namespace)
+		}
+
+		log.V(1).Info("Recording summary", "owner", owner)
+		return ctrl.Result{}, r.Record(ctx, reportKind, owner)
+	}
+}
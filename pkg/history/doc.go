@@ -0,0 +1,3 @@
+// Package history provides primitives for recording severity counts of
+// security reports over time as v1alpha1.SummaryHistory instances.
+package history
@@ -0,0 +1,96 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reader is the interface that wraps the FindByOwner method.
+//
+// FindByOwner returns the v1alpha1.SummaryHistory of reports of the given kind
+// recorded for the given workload or namespace, or nil if the history is not
+// found.
+type Reader interface {
+	FindByOwner(ctx context.Context, reportKind string, owner kube.ObjectRef) (*v1alpha1.SummaryHistory, error)
+}
+
+type reader struct {
+	client client.Client
+}
+
+// NewReader constructs a new Reader which is using the client package
+// provided by the controller-runtime libraries for interacting with the
+// Kubernetes API server.
+func NewReader(client client.Client) Reader {
+	return &reader{
+		client: client,
+	}
+}
+
+func (r *reader) FindByOwner(ctx context.Context, reportKind string, owner kube.ObjectRef) (*v1alpha1.SummaryHistory, error) {
+	var history v1alpha1.SummaryHistory
+	err := r.client.Get(ctx, types.NamespacedName{
+		Name:      GetHistoryName(reportKind, owner),
+		Namespace: historyNamespace(owner),
+	}, &history)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &history, nil
+}
+
+// GetHistoryName returns the name of the v1alpha1.SummaryHistory of reports
+// of the given kind recorded for the given workload or namespace.
+func GetHistoryName(reportKind string, owner kube.ObjectRef) string {
+	prefix := fmt.Sprintf("%s-%s", strings.ToLower(reportKind), strings.ToLower(string(owner.Kind)))
+	name := fmt.Sprintf("%s-%s", prefix, owner.Name)
+	if len(validation.IsValidLabelValue(name)) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", prefix, kube.ComputeHash(owner.Name))
+}
+
+// historyNamespace returns the namespace of the v1alpha1.SummaryHistory
+// recorded for the given workload or namespace.
+func historyNamespace(owner kube.ObjectRef) string {
+	if owner.Kind == kube.KindNamespace {
+		return owner.Name
+	}
+	return owner.Namespace
+}
+
+// Append adds the given entry to the given entries, which are sorted by
+// timestamp. If the last entry was recorded within the same time span of the
+// given resolution, it is replaced rather than followed by the given entry.
+// The oldest entries are removed so that at most maxEntries are returned. The
+// second return value is false if the entries have not changed.
+func Append(entries []v1alpha1.SummaryHistoryEntry, entry v1alpha1.SummaryHistoryEntry,
+	resolution time.Duration, maxEntries int) ([]v1alpha1.SummaryHistoryEntry, bool) {
+	updated := append(entries[:0:0], entries...)
+	if n := len(updated); n > 0 {
+		last := updated[n-1]
+		if last.Timestamp.Truncate(resolution).Equal(entry.Timestamp.Truncate(resolution)) {
+			if last.SameCounts(entry) {
+				return entries, false
+			}
+			updated = updated[:n-1]
+		}
+	}
+	updated = append(updated, entry)
+	if len(updated) > maxEntries {
+		updated = updated[len(updated)-maxEntries:]
+	}
+	return updated, true
+}
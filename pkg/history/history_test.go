@@ -0,0 +1,103 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/history"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func entry(timestamp string, criticalCount int) v1alpha1.SummaryHistoryEntry {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		panic(err)
+	}
+	return v1alpha1.SummaryHistoryEntry{
+		Timestamp:     metav1.NewTime(t),
+		CriticalCount: criticalCount,
+	}
+}
+
+func TestAppend(t *testing.T) {
+	testCases := []struct {
+		name            string
+		entries         []v1alpha1.SummaryHistoryEntry
+		entry           v1alpha1.SummaryHistoryEntry
+		expectedEntries []v1alpha1.SummaryHistoryEntry
+		expectedChanged bool
+	}{
+		{
+			name:            "Should append first entry",
+			entry:           entry("2022-05-01T10:00:00Z", 3),
+			expectedEntries: []v1alpha1.SummaryHistoryEntry{entry("2022-05-01T10:00:00Z", 3)},
+			expectedChanged: true,
+		},
+		{
+			name:            "Should append entry in new time span",
+			entries:         []v1alpha1.SummaryHistoryEntry{entry("2022-05-01T10:00:00Z", 3)},
+			entry:           entry("2022-05-02T09:00:00Z", 3),
+			expectedEntries: []v1alpha1.SummaryHistoryEntry{entry("2022-05-01T10:00:00Z", 3), entry("2022-05-02T09:00:00Z", 3)},
+			expectedChanged: true,
+		},
+		{
+			name:            "Should replace entry in same time span",
+			entries:         []v1alpha1.SummaryHistoryEntry{entry("2022-05-01T10:00:00Z", 3)},
+			entry:           entry("2022-05-01T18:00:00Z", 2),
+			expectedEntries: []v1alpha1.SummaryHistoryEntry{entry("2022-05-01T18:00:00Z", 2)},
+			expectedChanged: true,
+		},
+		{
+			name:            "Should not change entries with same counts in same time span",
+			entries:         []v1alpha1.SummaryHistoryEntry{entry("2022-05-01T10:00:00Z", 3)},
+			entry:           entry("2022-05-01T18:00:00Z", 3),
+			expectedEntries: []v1alpha1.SummaryHistoryEntry{entry("2022-05-01T10:00:00Z", 3)},
+			expectedChanged: false,
+		},
+		{
+			name: "Should remove oldest entries",
+			entries: []v1alpha1.SummaryHistoryEntry{
+				entry("2022-05-01T10:00:00Z", 5),
+				entry("2022-05-02T10:00:00Z", 4),
+				entry("2022-05-03T10:00:00Z", 3),
+			},
+			entry: entry("2022-05-04T10:00:00Z", 2),
+			expectedEntries: []v1alpha1.SummaryHistoryEntry{
+				entry("2022-05-02T10:00:00Z", 4),
+				entry("2022-05-03T10:00:00Z", 3),
+				entry("2022-05-04T10:00:00Z", 2),
+			},
+			expectedChanged: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, changed := history.Append(tc.entries, tc.entry, 24*time.Hour, 3)
+			assert.Equal(t, tc.expectedChanged, changed)
+			assert.Equal(t, tc.expectedEntries, entries)
+		})
+	}
+}
+
+func TestGetHistoryName(t *testing.T) {
+	assert.Equal(t, "vulnerabilityreport-deployment-nginx",
+		history.GetHistoryName(v1alpha1.VulnerabilityReportKind, kube.ObjectRef{
+			Kind:      kube.KindDeployment,
+			Name:      "nginx",
+			Namespace: "default",
+		}))
+	assert.Equal(t, "configauditreport-namespace-default",
+		history.GetHistoryName(v1alpha1.ConfigAuditReportKind, kube.ObjectRef{
+			Kind: kube.KindNamespace,
+			Name: "default",
+		}))
+	assert.Equal(t, "vulnerabilityreport-deployment-5bd5b6b8c9",
+		history.GetHistoryName(v1alpha1.VulnerabilityReportKind, kube.ObjectRef{
+			Kind:      kube.KindDeployment,
+			Name:      "a-deployment-with-a-very-long-name-which-cannot-be-used-as-label-value",
+			Namespace: "default",
+		}))
+}
@@ -0,0 +1,213 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Recorder appends the sum of severity counts of security reports to
+// v1alpha1.SummaryHistory instances of workloads and namespaces.
+type Recorder struct {
+	client.Client
+	ext.Clock
+
+	// MaxEntries is the maximum number of entries kept in a history.
+	MaxEntries int
+
+	// Resolution is the time span covered by a single entry of a history.
+	Resolution time.Duration
+}
+
+// NewRecorder constructs a new Recorder with settings read from the given
+// Starboard config.
+func NewRecorder(client client.Client, clock ext.Clock, config starboard.ConfigData) *Recorder {
+	return &Recorder{
+		Client:     client,
+		Clock:      clock,
+		MaxEntries: config.SummaryHistoryMaxEntries(),
+		Resolution: config.SummaryHistoryResolution(),
+	}
+}
+
+// Record records severity counts of reports of the given kind owned by the
+// given workload, and of all reports of the given kind in the namespace of
+// the workload.
+//
+// Reports owned by the current revision of a Deployment are recorded in the
+// history of the Deployment, so that the history is not lost when the
+// Deployment is rolled out.
+func (r *Recorder) Record(ctx context.Context, reportKind string, owner kube.ObjectRef) error {
+	subject, ownerRef, err := r.resolveSubject(ctx, owner)
+	if err != nil {
+		return err
+	}
+	if ownerRef != nil {
+		entry, err := r.summarize(ctx, reportKind, client.InNamespace(owner.Namespace),
+			client.MatchingLabels(kube.ObjectRefToLabels(owner)))
+		if err != nil {
+			return err
+		}
+		err = r.append(ctx, reportKind, subject, ownerRef, entry)
+		if err != nil {
+			return err
+		}
+	}
+	return r.RecordNamespace(ctx, reportKind, owner.Namespace)
+}
+
+// RecordNamespace records severity counts of all reports of the given kind in
+// the given namespace.
+func (r *Recorder) RecordNamespace(ctx context.Context, reportKind string, namespace string) error {
+	entry, err := r.summarize(ctx, reportKind, client.InNamespace(namespace))
+	if err != nil {
+		return err
+	}
+	return r.append(ctx, reportKind, kube.ObjectRef{Kind: kube.KindNamespace, Name: namespace}, nil, entry)
+}
+
+// resolveSubject returns the workload whose history is updated with reports
+// owned by the given workload along with the reference used to garbage
+// collect the history. The returned reference is nil if the history must not
+// be updated, e.g. because the workload was deleted or reports are owned by
+// an old revision of a Deployment.
+func (r *Recorder) resolveSubject(ctx context.Context, owner kube.ObjectRef) (kube.ObjectRef, *metav1.OwnerReference, error) {
+	resolver := kube.ObjectResolver{Client: r.Client}
+	obj, err := resolver.ObjectFromObjectRef(ctx, owner)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return owner, nil, nil
+		}
+		return owner, nil, fmt.Errorf("getting %s/%s: %w", owner.Kind, owner.Name, err)
+	}
+	if _, ok := obj.(*appsv1.ReplicaSet); ok {
+		controller := metav1.GetControllerOf(obj)
+		if controller != nil && controller.Kind == string(kube.KindDeployment) {
+			active, err := resolver.IsActiveReplicaSet(ctx, obj, controller)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return owner, nil, nil
+				}
+				return owner, nil, fmt.Errorf("checking active replicaset: %w", err)
+			}
+			if !active {
+				return owner, nil, nil
+			}
+			deployment := kube.ObjectRef{
+				Kind:      kube.KindDeployment,
+				Name:      controller.Name,
+				Namespace: owner.Namespace,
+			}
+			return deployment, &metav1.OwnerReference{
+				APIVersion: controller.APIVersion,
+				Kind:       controller.Kind,
+				Name:       controller.Name,
+				UID:        controller.UID,
+			}, nil
+		}
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return owner, &metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       obj.GetName(),
+		UID:        obj.GetUID(),
+	}, nil
+}
+
+// summarize returns the sum of severity counts of reports of the given kind
+// matching the given list options.
+func (r *Recorder) summarize(ctx context.Context, reportKind string, opts ...client.ListOption) (v1alpha1.SummaryHistoryEntry, error) {
+	entry := v1alpha1.SummaryHistoryEntry{
+		Timestamp: metav1.NewTime(r.Clock.Now()),
+	}
+	switch reportKind {
+	case v1alpha1.VulnerabilityReportKind:
+		var list v1alpha1.VulnerabilityReportList
+		err := r.List(ctx, &list, opts...)
+		if err != nil {
+			return entry, fmt.Errorf("listing vulnerability reports: %w", err)
+		}
+		for _, report := range list.Items {
+			// Skip reports of additional scanners so that findings
+			// reported by several scanners are not counted twice.
+			if _, ok := report.Labels[starboard.LabelVulnerabilityReportAdditional]; ok {
+				continue
+			}
+			entry.CriticalCount += report.Report.Summary.CriticalCount
+			entry.HighCount += report.Report.Summary.HighCount
+			entry.MediumCount += report.Report.Summary.MediumCount
+			entry.LowCount += report.Report.Summary.LowCount
+			entry.UnknownCount += report.Report.Summary.UnknownCount
+		}
+	case v1alpha1.ConfigAuditReportKind:
+		var list v1alpha1.ConfigAuditReportList
+		err := r.List(ctx, &list, opts...)
+		if err != nil {
+			return entry, fmt.Errorf("listing config audit reports: %w", err)
+		}
+		for _, report := range list.Items {
+			// Skip reports of an additional scanner so that failed checks
+			// are not counted twice.
+			if _, ok := report.Labels[starboard.LabelConfigAuditReportAdditional]; ok {
+				continue
+			}
+			entry.CriticalCount += report.Report.Summary.CriticalCount
+			entry.HighCount += report.Report.Summary.HighCount
+			entry.MediumCount += report.Report.Summary.MediumCount
+			entry.LowCount += report.Report.Summary.LowCount
+		}
+	default:
+		return entry, fmt.Errorf("unsupported report kind: %s", reportKind)
+	}
+	return entry, nil
+}
+
+func (r *Recorder) append(ctx context.Context, reportKind string, subject kube.ObjectRef,
+	ownerRef *metav1.OwnerReference, entry v1alpha1.SummaryHistoryEntry) error {
+	var existing v1alpha1.SummaryHistory
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      GetHistoryName(reportKind, subject),
+		Namespace: historyNamespace(subject),
+	}, &existing)
+
+	if err == nil {
+		entries, changed := Append(existing.Report.Entries, entry, r.Resolution, r.MaxEntries)
+		if !changed {
+			return nil
+		}
+		copied := existing.DeepCopy()
+		copied.Report.Entries = entries
+		return r.Update(ctx, copied)
+	}
+
+	if errors.IsNotFound(err) {
+		history := &v1alpha1.SummaryHistory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GetHistoryName(reportKind, subject),
+				Namespace: historyNamespace(subject),
+				Labels:    kube.ObjectRefToLabels(subject),
+			},
+			Report: v1alpha1.SummaryHistoryData{
+				ReportKind: reportKind,
+				Entries:    []v1alpha1.SummaryHistoryEntry{entry},
+			},
+		}
+		if ownerRef != nil {
+			history.OwnerReferences = []metav1.OwnerReference{*ownerRef}
+		}
+		return r.Create(ctx, history)
+	}
+
+	return err
+}
@@ -0,0 +1,162 @@
+package history_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/history"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func vulnerabilityReport(name string, owner kube.ObjectRef, criticalCount, highCount int) *v1alpha1.VulnerabilityReport {
+	return &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.Namespace,
+			Labels:    kube.ObjectRefToLabels(owner),
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Summary: v1alpha1.VulnerabilitySummary{
+				CriticalCount: criticalCount,
+				HighCount:     highCount,
+			},
+		},
+	}
+}
+
+func TestRecorder_Record(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+			UID:       "734c1370-2281-4946-9b5f-940b33f3e4b8",
+			Annotations: map[string]string{
+				"deployment.kubernetes.io/revision": "2",
+			},
+		},
+	}
+	activeReplicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"deployment.kubernetes.io/revision": "2",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "nginx",
+					UID:        "734c1370-2281-4946-9b5f-940b33f3e4b8",
+					Controller: pointer.BoolPtr(true),
+				},
+			},
+		},
+	}
+	activeReplicaSetRef := kube.ObjectRef{Kind: kube.KindReplicaSet, Name: "nginx-6d4cf56db6", Namespace: "default"}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "redis",
+			Namespace: "default",
+			UID:       "4ab0b1a1-8bf4-4a6a-9a4d-0a2b7fa0b6a3",
+		},
+	}
+	statefulSetRef := kube.ObjectRef{Kind: kube.KindStatefulSet, Name: "redis", Namespace: "default"}
+
+	now := time.Date(2022, time.May, 1, 10, 0, 0, 0, time.Local)
+
+	kubeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		deployment,
+		activeReplicaSet,
+		statefulSet,
+		vulnerabilityReport("replicaset-nginx-6d4cf56db6-nginx", activeReplicaSetRef, 2, 5),
+		vulnerabilityReport("replicaset-nginx-6d4cf56db6-sidecar", activeReplicaSetRef, 1, 0),
+		vulnerabilityReport("statefulset-redis-redis", statefulSetRef, 0, 3),
+		vulnerabilityReport("pod-other-nginx", kube.ObjectRef{Kind: kube.KindPod, Name: "other", Namespace: "staging"}, 9, 9),
+	).Build()
+
+	recorder := history.NewRecorder(kubeClient, ext.NewFixedClock(now), starboard.ConfigData{})
+	err := recorder.Record(context.TODO(), v1alpha1.VulnerabilityReportKind, activeReplicaSetRef)
+	require.NoError(t, err)
+
+	reader := history.NewReader(kubeClient)
+
+	t.Run("Should record history of Deployment", func(t *testing.T) {
+		found, err := reader.FindByOwner(context.TODO(), v1alpha1.VulnerabilityReportKind,
+			kube.ObjectRef{Kind: kube.KindDeployment, Name: "nginx", Namespace: "default"})
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "vulnerabilityreport-deployment-nginx", found.Name)
+		assert.Equal(t, []metav1.OwnerReference{
+			{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "nginx",
+				UID:        "734c1370-2281-4946-9b5f-940b33f3e4b8",
+			},
+		}, found.OwnerReferences)
+		assert.Equal(t, v1alpha1.SummaryHistoryData{
+			ReportKind: v1alpha1.VulnerabilityReportKind,
+			Entries: []v1alpha1.SummaryHistoryEntry{
+				{Timestamp: metav1.NewTime(now), CriticalCount: 3, HighCount: 5},
+			},
+		}, found.Report)
+	})
+
+	t.Run("Should record history of namespace", func(t *testing.T) {
+		found, err := reader.FindByOwner(context.TODO(), v1alpha1.VulnerabilityReportKind,
+			kube.ObjectRef{Kind: kube.KindNamespace, Name: "default"})
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "default", found.Namespace)
+		assert.Empty(t, found.OwnerReferences)
+		assert.Equal(t, []v1alpha1.SummaryHistoryEntry{
+			{Timestamp: metav1.NewTime(now), CriticalCount: 3, HighCount: 8},
+		}, found.Report.Entries)
+	})
+
+	t.Run("Should append entry to existing history", func(t *testing.T) {
+		err := kubeClient.Delete(context.TODO(), vulnerabilityReport("statefulset-redis-redis", statefulSetRef, 0, 3))
+		require.NoError(t, err)
+
+		recorder := history.NewRecorder(kubeClient, ext.NewFixedClock(now.Add(24*time.Hour)), starboard.ConfigData{})
+		err = recorder.RecordNamespace(context.TODO(), v1alpha1.VulnerabilityReportKind, "default")
+		require.NoError(t, err)
+
+		found, err := reader.FindByOwner(context.TODO(), v1alpha1.VulnerabilityReportKind,
+			kube.ObjectRef{Kind: kube.KindNamespace, Name: "default"})
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, []v1alpha1.SummaryHistoryEntry{
+			{Timestamp: metav1.NewTime(now), CriticalCount: 3, HighCount: 8},
+			{Timestamp: metav1.NewTime(now.Add(24 * time.Hour)), CriticalCount: 3, HighCount: 5},
+		}, found.Report.Entries)
+	})
+
+	t.Run("Should not record history of deleted workload", func(t *testing.T) {
+		podRef := kube.ObjectRef{Kind: kube.KindPod, Name: "other", Namespace: "staging"}
+		err := recorder.Record(context.TODO(), v1alpha1.VulnerabilityReportKind, podRef)
+		require.NoError(t, err)
+
+		found, err := reader.FindByOwner(context.TODO(), v1alpha1.VulnerabilityReportKind, podRef)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		var list v1alpha1.SummaryHistoryList
+		err = kubeClient.List(context.TODO(), &list, client.InNamespace("staging"))
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "vulnerabilityreport-namespace-staging", list.Items[0].Name)
+	})
+}
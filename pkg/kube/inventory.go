@@ -0,0 +1,120 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Inventory lists objects of any kind with client.Reader, which is typically
+// the controller-runtime cache, so that listing objects does not hit the
+// Kubernetes API server. It implements policy.Inventory.
+//
+// Kinds are resolved to their preferred versions with meta.RESTMapper. Objects
+// of kinds registered with the runtime.Scheme are listed as typed objects,
+// whereas objects of other kinds, e.g. custom resources, are listed as
+// unstructured.Unstructured.
+type Inventory struct {
+	reader client.Reader
+	mapper meta.RESTMapper
+	scheme *runtime.Scheme
+}
+
+// NewInventory constructs a new Inventory with the specified client.Reader,
+// meta.RESTMapper, and runtime.Scheme.
+func NewInventory(reader client.Reader, mapper meta.RESTMapper, scheme *runtime.Scheme) *Inventory {
+	return &Inventory{
+		reader: reader,
+		mapper: mapper,
+		scheme: scheme,
+	}
+}
+
+// GroupVersionKind resolves the specified kind, e.g. NetworkPolicy, to its
+// preferred group and version.
+func (i *Inventory) GroupVersionKind(kind string) (schema.GroupVersionKind, error) {
+	// The RESTMapper maps singular resources, which are lowercase kinds,
+	// to kinds.
+	return i.mapper.KindFor(schema.GroupVersionResource{Resource: strings.ToLower(kind)})
+}
+
+// NewObject returns an empty client.Object of the specified kind, which can be
+// used to get an informer of the kind from the controller-runtime cache.
+func (i *Inventory) NewObject(kind string) (client.Object, error) {
+	gvk, err := i.GroupVersionKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if i.scheme.Recognizes(gvk) {
+		obj, err := i.scheme.New(gvk)
+		if err != nil {
+			return nil, err
+		}
+		if obj, ok := obj.(client.Object); ok {
+			return obj, nil
+		}
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	return obj, nil
+}
+
+// Objects returns objects of the specified kind.
+func (i *Inventory) Objects(ctx context.Context, kind string) ([]runtime.Object, schema.GroupVersionKind, error) {
+	gvk, err := i.GroupVersionKind(kind)
+	if err != nil {
+		return nil, gvk, fmt.Errorf("resolving kind: %s: %w", kind, err)
+	}
+	listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+
+	var list client.ObjectList
+	if i.scheme.Recognizes(listGVK) {
+		obj, err := i.scheme.New(listGVK)
+		if err != nil {
+			return nil, gvk, err
+		}
+		var ok bool
+		if list, ok = obj.(client.ObjectList); !ok {
+			return nil, gvk, fmt.Errorf("unsupported list: %T", obj)
+		}
+	} else {
+		unstructuredList := &unstructured.UnstructuredList{}
+		unstructuredList.SetGroupVersionKind(listGVK)
+		list = unstructuredList
+	}
+
+	if err = i.reader.List(ctx, list); err != nil {
+		return nil, gvk, fmt.Errorf("listing %s: %w", gvk, err)
+	}
+	objects, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, gvk, err
+	}
+	return objects, gvk, nil
+}
+
+// List returns objects of the specified kind as JSON values.
+func (i *Inventory) List(ctx context.Context, kind string) ([]interface{}, error) {
+	objects, gvk, err := i.Objects(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(objects))
+	for j, obj := range objects {
+		value, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("converting %s: %w", gvk, err)
+		}
+		// Typed objects returned by the cache do not have the TypeMeta set.
+		value["apiVersion"] = gvk.GroupVersion().String()
+		value["kind"] = gvk.Kind
+		values[j] = value
+	}
+	return values, nil
+}
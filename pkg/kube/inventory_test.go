@@ -0,0 +1,79 @@
+package kube_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestInventory(t *testing.T) {
+	networkPolicyGVK := networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy")
+	virtualServiceGVK := schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "VirtualService"}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(networkPolicyGVK, meta.RESTScopeNamespace)
+	mapper.Add(virtualServiceGVK, meta.RESTScopeNamespace)
+
+	virtualService := &unstructured.Unstructured{}
+	virtualService.SetGroupVersionKind(virtualServiceGVK)
+	virtualService.SetNamespace("default")
+	virtualService.SetName("reviews")
+	require.NoError(t, unstructured.SetNestedStringSlice(virtualService.Object, []string{"reviews"}, "spec", "hosts"))
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "deny-all",
+		},
+	}
+
+	scheme := starboard.NewScheme()
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(networkPolicy, virtualService).Build()
+	inventory := kube.NewInventory(client, mapper, scheme)
+
+	t.Run("Should list typed objects", func(t *testing.T) {
+		values, err := inventory.List(context.TODO(), "NetworkPolicy")
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		value := values[0].(map[string]interface{})
+		assert.Equal(t, "networking.k8s.io/v1", value["apiVersion"])
+		assert.Equal(t, "NetworkPolicy", value["kind"])
+		assert.Equal(t, "deny-all", value["metadata"].(map[string]interface{})["name"])
+	})
+
+	t.Run("Should list unstructured objects", func(t *testing.T) {
+		values, err := inventory.List(context.TODO(), "VirtualService")
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		value := values[0].(map[string]interface{})
+		assert.Equal(t, "networking.istio.io/v1beta1", value["apiVersion"])
+		assert.Equal(t, "VirtualService", value["kind"])
+		assert.Equal(t, []interface{}{"reviews"}, value["spec"].(map[string]interface{})["hosts"])
+	})
+
+	t.Run("Should return new object of kind", func(t *testing.T) {
+		obj, err := inventory.NewObject("NetworkPolicy")
+		require.NoError(t, err)
+		assert.IsType(t, &networkingv1.NetworkPolicy{}, obj)
+
+		obj, err = inventory.NewObject("VirtualService")
+		require.NoError(t, err)
+		assert.Equal(t, virtualServiceGVK, obj.GetObjectKind().GroupVersionKind())
+	})
+
+	t.Run("Should return error when kind is unknown", func(t *testing.T) {
+		_, err := inventory.List(context.TODO(), "Rollout")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resolving kind: Rollout")
+	})
+}
@@ -18,9 +18,11 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -96,9 +98,10 @@ func IsWorkload(kind string) bool {
 }
 
 // IsClusterScopedKind returns true if the specified kind is ClusterRole,
-// ClusterRoleBinding, and CustomResourceDefinition.
+// ClusterRoleBinding, CustomResourceDefinition, and PodSecurityPolicy.
 //
-// TODO Use discovery client to have a generic implementation.
+// Only built-in kinds known to Starboard are recognized. Use RESTMappingsForKind
+// to determine the scope of other kinds with the discovery client.
 func IsClusterScopedKind(kind string) bool {
 	switch kind {
 	case string(KindClusterRole), string(KindClusterRoleBindings), string(KindCustomResourceDefinition), string(KindPodSecurityPolicy):
@@ -108,6 +111,47 @@ func IsClusterScopedKind(kind string) bool {
 	}
 }
 
+// IsClusterScoped returns true if the specified client.Object is cluster-scoped.
+// Unlike IsClusterScopedKind, it supports objects of any kind represented as
+// unstructured.Unstructured, e.g. custom resources, which are cluster-scoped if
+// they do not have a namespace.
+func IsClusterScoped(obj client.Object) bool {
+	if _, ok := obj.(*unstructured.Unstructured); ok {
+		return obj.GetNamespace() == ""
+	}
+	return IsClusterScopedKind(obj.GetObjectKind().GroupVersionKind().Kind)
+}
+
+// RESTMappingsForKind returns RESTMappings of the specified kind in all API
+// groups listed by the discovery client, which typically returns preferred
+// versions of API resources. The kind is resolved for each group through the
+// given meta.RESTMapper. Subresources and API resources that cannot be listed
+// and watched are skipped.
+func RESTMappingsForKind(resources []*metav1.APIResourceList, mapper meta.RESTMapper, kind string) ([]*meta.RESTMapping, error) {
+	var mappings []*meta.RESTMapping
+	for _, list := range resources {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			return nil, fmt.Errorf("parsing group version: %s: %w", list.GroupVersion, err)
+		}
+		for _, resource := range list.APIResources {
+			if resource.Kind != kind || strings.Contains(resource.Name, "/") {
+				continue
+			}
+			verbs := sets.NewString(resource.Verbs...)
+			if !verbs.HasAll("list", "watch") {
+				continue
+			}
+			mapping, err := mapper.RESTMapping(schema.GroupKind{Group: gv.Group, Kind: kind}, gv.Version)
+			if err != nil {
+				return nil, fmt.Errorf("getting REST mapping: %s: %w", gv.WithKind(kind), err)
+			}
+			mappings = append(mappings, mapping)
+		}
+	}
+	return mappings, nil
+}
+
 // ObjectRefToLabels encodes the specified ObjectRef as a set of labels.
 //
 // If Object's name cannot be used as the value of the
@@ -258,6 +302,15 @@ func ComputeSpecHash(obj client.Object) (string, error) {
 		return ComputeHash(obj), nil
 	case *policyv1beta1.PodSecurityPolicy:
 		return ComputeHash(obj), nil
+	case *unstructured.Unstructured:
+		// Skip the status and metadata updated by the API server, which do
+		// not change the specification of an object.
+		content := t.DeepCopy().Object
+		unstructured.RemoveNestedField(content, "status")
+		for _, field := range []string{"resourceVersion", "generation", "managedFields"} {
+			unstructured.RemoveNestedField(content, "metadata", field)
+		}
+		return ComputeHash(content), nil
 	default:
 		return "", fmt.Errorf("computing spec hash of unsupported object: %T", t)
 	}
@@ -288,6 +341,54 @@ func GetPodSpec(obj client.Object) (corev1.PodSpec, error) {
 	}
 }
 
+// WithContainerImages returns a copy of the specified workload with images of
+// containers in its pod template replaced by the given images, e.g. repo
+// digests of images run by active pods. Containers without an image in the
+// given map are left unchanged.
+func WithContainerImages(obj client.Object, images ContainerImages) (client.Object, error) {
+	copied, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unsupported workload: %T", obj)
+	}
+	var spec *corev1.PodSpec
+	switch t := copied.(type) {
+	case *corev1.Pod:
+		spec = &t.Spec
+	case *appsv1.Deployment:
+		spec = &t.Spec.Template.Spec
+	case *appsv1.ReplicaSet:
+		spec = &t.Spec.Template.Spec
+	case *corev1.ReplicationController:
+		spec = &t.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		spec = &t.Spec.Template.Spec
+	case *appsv1.DaemonSet:
+		spec = &t.Spec.Template.Spec
+	case *batchv1beta1.CronJob:
+		spec = &t.Spec.JobTemplate.Spec.Template.Spec
+	case *batchv1.Job:
+		spec = &t.Spec.Template.Spec
+	default:
+		return nil, fmt.Errorf("unsupported workload: %T", t)
+	}
+	for i, c := range spec.InitContainers {
+		if image, ok := images[c.Name]; ok {
+			spec.InitContainers[i].Image = image
+		}
+	}
+	for i, c := range spec.Containers {
+		if image, ok := images[c.Name]; ok {
+			spec.Containers[i].Image = image
+		}
+	}
+	for i, c := range spec.EphemeralContainers {
+		if image, ok := images[c.Name]; ok {
+			spec.EphemeralContainers[i].Image = image
+		}
+	}
+	return copied, nil
+}
+
 var ErrReplicaSetNotFound = errors.New("replicaset not found")
 var ErrNoRunningPods = errors.New("no active pods for controller")
 var ErrUnSupportedKind = errors.New("unsupported workload kind")
@@ -547,53 +648,42 @@ func (o *ObjectResolver) RelatedReplicaSetName(ctx context.Context, object Objec
 // ErrReplicaSetNotFound error is returned. If the specified workload is a
 // CronJob the ErrUnSupportedKind error is returned.
 func (o *ObjectResolver) GetNodeName(ctx context.Context, obj client.Object) (string, error) {
+	pods, err := o.GetActivePodsByWorkload(ctx, obj)
+	if err != nil {
+		return "", err
+	}
+	return pods[0].Spec.NodeName, nil
+}
+
+// GetActivePodsByWorkload returns pods controlled by the given workload.
+// If there are no running pods then the ErrNoRunningPods error is
+// returned. If there are no active ReplicaSets for the Deployment the
+// ErrReplicaSetNotFound error is returned. If the specified workload is a
+// CronJob the ErrUnSupportedKind error is returned.
+func (o *ObjectResolver) GetActivePodsByWorkload(ctx context.Context, obj client.Object) ([]corev1.Pod, error) {
 	switch obj.(type) {
 	case *corev1.Pod:
-		return (obj.(*corev1.Pod)).Spec.NodeName, nil
+		return []corev1.Pod{*(obj.(*corev1.Pod))}, nil
 	case *appsv1.Deployment:
 		replicaSet, err := o.ReplicaSetByDeployment(ctx, obj.(*appsv1.Deployment))
 		if err != nil {
-			return "", err
-		}
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), replicaSet.Spec.Selector.MatchLabels)
-		if err != nil {
-			return "", err
+			return nil, err
 		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), replicaSet.Spec.Selector.MatchLabels)
 	case *appsv1.ReplicaSet:
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.ReplicaSet).Spec.Selector.MatchLabels)
-		if err != nil {
-			return "", err
-		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.ReplicaSet).Spec.Selector.MatchLabels)
 	case *corev1.ReplicationController:
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*corev1.ReplicationController).Spec.Selector)
-		if err != nil {
-			return "", err
-		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*corev1.ReplicationController).Spec.Selector)
 	case *appsv1.StatefulSet:
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.StatefulSet).Spec.Selector.MatchLabels)
-		if err != nil {
-			return "", err
-		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.StatefulSet).Spec.Selector.MatchLabels)
 	case *appsv1.DaemonSet:
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.DaemonSet).Spec.Selector.MatchLabels)
-		if err != nil {
-			return "", err
-		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.DaemonSet).Spec.Selector.MatchLabels)
 	case *batchv1beta1.CronJob:
-		return "", ErrUnSupportedKind
+		return nil, ErrUnSupportedKind
 	case *batchv1.Job:
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*batchv1.Job).Spec.Selector.MatchLabels)
-		if err != nil {
-			return "", err
-		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*batchv1.Job).Spec.Selector.MatchLabels)
 	default:
-		return "", ErrUnSupportedKind
+		return nil, ErrUnSupportedKind
 	}
 }
 
@@ -13,7 +13,11 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -151,6 +155,189 @@ func TestIsClusterScopedKind(t *testing.T) {
 	}
 }
 
+func TestIsClusterScoped(t *testing.T) {
+	newUnstructured := func(kind, namespace string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("example.com/v1")
+		obj.SetKind(kind)
+		obj.SetName("test")
+		obj.SetNamespace(namespace)
+		return obj
+	}
+	testCases := []struct {
+		name string
+		obj  client.Object
+		want bool
+	}{
+		{
+			name: "Should return false when object is Pod",
+			obj: &corev1.Pod{
+				TypeMeta:   metav1.TypeMeta{Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			},
+			want: false,
+		},
+		{
+			name: "Should return true when object is ClusterRole",
+			obj: &rbacv1.ClusterRole{
+				TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			},
+			want: true,
+		},
+		{
+			name: "Should return false when custom resource has namespace",
+			obj:  newUnstructured("VirtualService", "default"),
+			want: false,
+		},
+		{
+			name: "Should return true when custom resource does not have namespace",
+			obj:  newUnstructured("ClusterIssuer", ""),
+			want: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, kube.IsClusterScoped(tc.obj))
+		})
+	}
+}
+
+func TestRESTMappingsForKind(t *testing.T) {
+	virtualServiceV1Beta1 := schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "VirtualService"}
+	virtualServiceV1Alpha3 := schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1alpha3", Kind: "VirtualService"}
+	clusterIssuer := schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "ClusterIssuer"}
+	gateway := schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "Gateway"}
+	gatewayAPI := schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1beta1", Kind: "Gateway"}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(virtualServiceV1Beta1, meta.RESTScopeNamespace)
+	mapper.Add(virtualServiceV1Alpha3, meta.RESTScopeNamespace)
+	mapper.Add(clusterIssuer, meta.RESTScopeRoot)
+	mapper.Add(gateway, meta.RESTScopeNamespace)
+	mapper.Add(gatewayAPI, meta.RESTScopeNamespace)
+
+	verbs := metav1.Verbs{"get", "list", "watch"}
+	resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: "networking.istio.io/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "virtualservices", Namespaced: true, Kind: "VirtualService", Verbs: verbs},
+				{Name: "virtualservices/status", Namespaced: true, Kind: "VirtualService", Verbs: metav1.Verbs{"get", "patch"}},
+				{Name: "gateways", Namespaced: true, Kind: "Gateway", Verbs: verbs},
+			},
+		},
+		{
+			GroupVersion: "cert-manager.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "clusterissuers", Namespaced: false, Kind: "ClusterIssuer", Verbs: verbs},
+			},
+		},
+		{
+			GroupVersion: "gateway.networking.k8s.io/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "gateways", Namespaced: true, Kind: "Gateway", Verbs: verbs},
+			},
+		},
+		{
+			GroupVersion: "metrics.k8s.io/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "PodMetrics", Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		kind          string
+		expectedGVKs  []schema.GroupVersionKind
+		expectedScope []meta.RESTScopeName
+	}{
+		{
+			kind:          "VirtualService",
+			expectedGVKs:  []schema.GroupVersionKind{virtualServiceV1Beta1},
+			expectedScope: []meta.RESTScopeName{meta.RESTScopeNameNamespace},
+		},
+		{
+			kind:          "ClusterIssuer",
+			expectedGVKs:  []schema.GroupVersionKind{clusterIssuer},
+			expectedScope: []meta.RESTScopeName{meta.RESTScopeNameRoot},
+		},
+		{
+			kind:          "Gateway",
+			expectedGVKs:  []schema.GroupVersionKind{gateway, gatewayAPI},
+			expectedScope: []meta.RESTScopeName{meta.RESTScopeNameNamespace, meta.RESTScopeNameNamespace},
+		},
+		{
+			kind: "PodMetrics",
+		},
+		{
+			kind: "Rollout",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run("Should return mappings of kind "+tc.kind, func(t *testing.T) {
+			mappings, err := kube.RESTMappingsForKind(resources, mapper, tc.kind)
+			require.NoError(t, err)
+			var gvks []schema.GroupVersionKind
+			var scopes []meta.RESTScopeName
+			for _, mapping := range mappings {
+				gvks = append(gvks, mapping.GroupVersionKind)
+				scopes = append(scopes, mapping.Scope.Name())
+			}
+			assert.Equal(t, tc.expectedGVKs, gvks)
+			assert.Equal(t, tc.expectedScope, scopes)
+		})
+	}
+
+	t.Run("Should return error when kind is not known to mapper", func(t *testing.T) {
+		_, err := kube.RESTMappingsForKind([]*metav1.APIResourceList{
+			{
+				GroupVersion: "argoproj.io/v1alpha1",
+				APIResources: []metav1.APIResource{
+					{Name: "rollouts", Namespaced: true, Kind: "Rollout", Verbs: verbs},
+				},
+			},
+		}, mapper, "Rollout")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "getting REST mapping: argoproj.io/v1alpha1, Kind=Rollout")
+	})
+}
+
+func TestComputeSpecHash(t *testing.T) {
+	newVirtualService := func(resourceVersion, host string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "networking.istio.io/v1beta1",
+			"kind":       "VirtualService",
+			"metadata": map[string]interface{}{
+				"name":            "reviews",
+				"namespace":       "default",
+				"resourceVersion": resourceVersion,
+			},
+			"spec": map[string]interface{}{
+				"hosts": []interface{}{host},
+			},
+			"status": map[string]interface{}{
+				"observedGeneration": resourceVersion,
+			},
+		}}
+	}
+
+	hash, err := kube.ComputeSpecHash(newVirtualService("1", "reviews"))
+	require.NoError(t, err)
+
+	t.Run("Should ignore status and resource version of custom resource", func(t *testing.T) {
+		other, err := kube.ComputeSpecHash(newVirtualService("2", "reviews"))
+		require.NoError(t, err)
+		assert.Equal(t, hash, other)
+	})
+
+	t.Run("Should change when spec of custom resource changes", func(t *testing.T) {
+		other, err := kube.ComputeSpecHash(newVirtualService("2", "ratings"))
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+}
+
 func TestObjectRefToLabels(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -539,6 +726,42 @@ func TestGetPodSpec(t *testing.T) {
 	}
 }
 
+func TestWithContainerImages(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{Name: "init", Image: "busybox:1.34"},
+					},
+					Containers: []corev1.Container{
+						{Name: "nginx", Image: "nginx:1.16"},
+						{Name: "sidecar", Image: "sidecar:latest"},
+					},
+				},
+			},
+		},
+	}
+
+	obj, err := kube.WithContainerImages(deployment, kube.ContainerImages{
+		"init":  "busybox@sha256:ea3b3a4fcd0f7fe5a12b2d0a1e4c5a7a1b0c6a4e3e8a0a2b1f9a1d7f1a5e2c3b",
+		"nginx": "nginx@sha256:2963fc49cc50883ba9af25f977a9997ff9af06b45c12d968b7985dc1e9254e4b",
+	})
+	require.NoError(t, err)
+
+	spec, err := kube.GetPodSpec(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "busybox@sha256:ea3b3a4fcd0f7fe5a12b2d0a1e4c5a7a1b0c6a4e3e8a0a2b1f9a1d7f1a5e2c3b", spec.InitContainers[0].Image)
+	assert.Equal(t, "nginx@sha256:2963fc49cc50883ba9af25f977a9997ff9af06b45c12d968b7985dc1e9254e4b", spec.Containers[0].Image)
+	assert.Equal(t, "sidecar:latest", spec.Containers[1].Image)
+
+	assert.Equal(t, "nginx:1.16", deployment.Spec.Template.Spec.Containers[0].Image, "original workload must not be modified")
+}
+
 func TestObjectResolver_RelatedReplicaSetName(t *testing.T) {
 
 	instance := &kube.ObjectResolver{Client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
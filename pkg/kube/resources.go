@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"hash"
 	"hash/fnv"
+	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/davecgh/go-spew/spew"
@@ -12,11 +13,57 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 )
 
+// ContainerType is the type of a container defined in a v1.PodSpec.
+type ContainerType string
+
+const (
+	ContainerTypeContainer          ContainerType = "Container"
+	ContainerTypeInitContainer      ContainerType = "InitContainer"
+	ContainerTypeEphemeralContainer ContainerType = "EphemeralContainer"
+)
+
+// GetContainers returns init containers, containers, and ephemeral containers
+// from the specified v1.PodSpec. Ephemeral containers are converted to
+// v1.Container, which has the same fields as v1.EphemeralContainerCommon.
+func GetContainers(spec corev1.PodSpec) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers)+len(spec.EphemeralContainers))
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, container := range spec.EphemeralContainers {
+		containers = append(containers, corev1.Container(container.EphemeralContainerCommon))
+	}
+	return containers
+}
+
+// GetContainerType returns the type of the container with the specified name
+// defined in the specified v1.PodSpec. The second return value is false if
+// there is no such container.
+func GetContainerType(spec corev1.PodSpec, name string) (ContainerType, bool) {
+	for _, container := range spec.InitContainers {
+		if container.Name == name {
+			return ContainerTypeInitContainer, true
+		}
+	}
+	for _, container := range spec.Containers {
+		if container.Name == name {
+			return ContainerTypeContainer, true
+		}
+	}
+	for _, container := range spec.EphemeralContainers {
+		if container.Name == name {
+			return ContainerTypeEphemeralContainer, true
+		}
+	}
+	return "", false
+}
+
 // GetContainerImagesFromPodSpec returns a map of container names
-// to container images from the specified v1.PodSpec.
+// to container images from the specified v1.PodSpec. Container names are
+// unique across init containers, containers, and ephemeral containers,
+// therefore images of all of them are returned.
 func GetContainerImagesFromPodSpec(spec corev1.PodSpec) ContainerImages {
 	images := ContainerImages{}
-	for _, container := range spec.Containers {
+	for _, container := range GetContainers(spec) {
 		images[container.Name] = container.Image
 	}
 	return images
@@ -41,6 +88,67 @@ func GetContainerImagesFromJob(job *batchv1.Job) (ContainerImages, error) {
 	return containerImages, nil
 }
 
+// GetContainerImageDigestsFromJob returns a map of container names
+// to repo digests from the specified v1.Job.
+// The mapping is encoded as JSON value of the AnnotationContainerImageDigests
+// annotation. If the annotation is not set an empty map is returned.
+func GetContainerImageDigestsFromJob(job *batchv1.Job) (ContainerImages, error) {
+	digests := ContainerImages{}
+	digestsAsJSON, ok := job.Annotations[starboard.AnnotationContainerImageDigests]
+	if !ok {
+		return digests, nil
+	}
+	err := digests.FromJSON(digestsAsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("parsing annotation: %s: %w", starboard.AnnotationContainerImageDigests, err)
+	}
+	return digests, nil
+}
+
+// GetContainerImageDigestsFromPods returns a map of container names to repo
+// digests, e.g. nginx@sha256:..., of images run by the specified pods.
+// The repo digest is resolved from the v1.ContainerStatus reported by the
+// container runtime. Containers without a repo digest, or with different
+// digests across pods, are omitted.
+func GetContainerImageDigestsFromPods(pods []corev1.Pod) ContainerImages {
+	digests := ContainerImages{}
+	conflicts := map[string]bool{}
+	for _, pod := range pods {
+		var statuses []corev1.ContainerStatus
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		statuses = append(statuses, pod.Status.EphemeralContainerStatuses...)
+		for _, status := range statuses {
+			digest, ok := GetRepoDigestFromImageID(status.ImageID)
+			if !ok {
+				continue
+			}
+			if existing, ok := digests[status.Name]; ok && existing != digest {
+				conflicts[status.Name] = true
+				continue
+			}
+			digests[status.Name] = digest
+		}
+	}
+	for containerName := range conflicts {
+		delete(digests, containerName)
+	}
+	return digests
+}
+
+// GetRepoDigestFromImageID returns the repo digest, e.g. nginx@sha256:...,
+// from the specified image ID reported by the container runtime. The second
+// return value is false if the image ID does not refer to a repo digest.
+func GetRepoDigestFromImageID(imageID string) (string, bool) {
+	if i := strings.Index(imageID, "://"); i >= 0 {
+		imageID = imageID[i+3:]
+	}
+	if i := strings.Index(imageID, "@"); i <= 0 || i == len(imageID)-1 {
+		return "", false
+	}
+	return imageID, true
+}
+
 // ComputeHash returns a hash value calculated from a given object.
 // The hash will be safe encoded to avoid bad words.
 func ComputeHash(obj interface{}) string {
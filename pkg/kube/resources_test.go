@@ -29,6 +29,67 @@ func TestGetContainerImagesFromPodSpec(t *testing.T) {
 		"nginx":   "nginx:1.16",
 		"sidecar": "sidecar:1.32.7",
 	}, images)
+
+	t.Run("Should return images of init and ephemeral containers", func(t *testing.T) {
+		images := kube.GetContainerImagesFromPodSpec(podSpecWithAllContainerTypes)
+		assert.Equal(t, kube.ContainerImages{
+			"migrate":  "migrate:0.3.1",
+			"nginx":    "nginx:1.16",
+			"debugger": "busybox:1.28",
+		}, images)
+	})
+}
+
+var podSpecWithAllContainerTypes = corev1.PodSpec{
+	InitContainers: []corev1.Container{
+		{
+			Name:  "migrate",
+			Image: "migrate:0.3.1",
+		},
+	},
+	Containers: []corev1.Container{
+		{
+			Name:  "nginx",
+			Image: "nginx:1.16",
+		},
+	},
+	EphemeralContainers: []corev1.EphemeralContainer{
+		{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+				Name:  "debugger",
+				Image: "busybox:1.28",
+			},
+		},
+	},
+}
+
+func TestGetContainers(t *testing.T) {
+	containers := kube.GetContainers(podSpecWithAllContainerTypes)
+	assert.Equal(t, []corev1.Container{
+		{Name: "migrate", Image: "migrate:0.3.1"},
+		{Name: "nginx", Image: "nginx:1.16"},
+		{Name: "debugger", Image: "busybox:1.28"},
+	}, containers)
+}
+
+func TestGetContainerType(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expectedType  kube.ContainerType
+		expectedFound bool
+	}{
+		{name: "migrate", expectedType: kube.ContainerTypeInitContainer, expectedFound: true},
+		{name: "nginx", expectedType: kube.ContainerTypeContainer, expectedFound: true},
+		{name: "debugger", expectedType: kube.ContainerTypeEphemeralContainer, expectedFound: true},
+		{name: "unknown", expectedType: "", expectedFound: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			containerType, found := kube.GetContainerType(podSpecWithAllContainerTypes, tc.name)
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Equal(t, tc.expectedType, containerType)
+		})
+	}
 }
 
 func TestGetContainerImagesFromJob(t *testing.T) {
@@ -65,6 +126,73 @@ func TestGetContainerImagesFromJob(t *testing.T) {
 	})
 }
 
+func TestGetRepoDigestFromImageID(t *testing.T) {
+	testCases := []struct {
+		imageID        string
+		expectedDigest string
+		expectedOK     bool
+	}{
+		{
+			imageID:        "docker-pullable://nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514",
+			expectedDigest: "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514",
+			expectedOK:     true,
+		},
+		{
+			imageID:        "docker.io/library/nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514",
+			expectedDigest: "docker.io/library/nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514",
+			expectedOK:     true,
+		},
+		{
+			imageID:    "sha256:f6d0b4767a6c466c178bf718f99bea0d3742b26679081e52dbf8e0c7c4c42d74",
+			expectedOK: false,
+		},
+		{
+			imageID:    "",
+			expectedOK: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.imageID, func(t *testing.T) {
+			digest, ok := kube.GetRepoDigestFromImageID(tc.imageID)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedDigest, digest)
+		})
+	}
+}
+
+func TestGetContainerImageDigestsFromPods(t *testing.T) {
+	digests := kube.GetContainerImageDigestsFromPods([]corev1.Pod{
+		{
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{Name: "migrate", ImageID: "docker-pullable://migrate@sha256:cccc"},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "nginx", ImageID: "docker-pullable://nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514"},
+					{Name: "sidecar", ImageID: "docker-pullable://sidecar@sha256:aaaa"},
+					{Name: "pending"},
+				},
+				EphemeralContainerStatuses: []corev1.ContainerStatus{
+					{Name: "debugger", ImageID: "docker-pullable://busybox@sha256:dddd"},
+				},
+			},
+		},
+		{
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "nginx", ImageID: "docker-pullable://nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514"},
+					{Name: "sidecar", ImageID: "docker-pullable://sidecar@sha256:bbbb"},
+				},
+			},
+		},
+	})
+	assert.Equal(t, kube.ContainerImages{
+		"migrate":  "migrate@sha256:cccc",
+		"nginx":    "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514",
+		"debugger": "busybox@sha256:dddd",
+	}, digests)
+}
+
 func TestComputeHash(t *testing.T) {
 
 	booleanValue1 := true
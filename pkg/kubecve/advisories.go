@@ -0,0 +1,202 @@
+package kubecve
+
+import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// Kubernetes components whose versions can be checked against Advisories.
+const (
+	KubeAPIServer         = "kube-apiserver"
+	KubeControllerManager = "kube-controller-manager"
+	Kubelet               = "kubelet"
+	KubeProxy             = "kube-proxy"
+)
+
+// Range is a range of affected versions of a Kubernetes component. The range
+// includes the Introduced version and excludes the Fixed version. An empty
+// Introduced version means that all versions prior to Fixed are affected,
+// whereas an empty Fixed version means that the vulnerability is not fixed
+// in versions after Introduced.
+type Range struct {
+	Introduced string
+	Fixed      string
+}
+
+// Advisory describes a known vulnerability of Kubernetes components.
+type Advisory struct {
+	ID         string
+	Title      string
+	Severity   v1alpha1.Severity
+	Score      float64
+	Components []string
+	Ranges     []Range
+}
+
+// AdvisoriesUpdated is the date when Advisories were last updated. Kubernetes
+// vulnerabilities published after that date are not reported.
+const AdvisoriesUpdated = "2021-09-20"
+
+// Advisories is a static list of known Kubernetes vulnerabilities published
+// by the Kubernetes Security Response Committee, which affect components of
+// the control plane or nodes, rather than the configuration of a cluster.
+//
+// The list is maintained by hand and is not complete. It's not synchronized
+// with a vulnerability database, therefore AdvisoriesUpdated must be bumped
+// whenever advisories are added.
+var Advisories = []Advisory{
+	{
+		ID:         "CVE-2018-1002105",
+		Title:      "Proxy request handling in kube-apiserver can leave vulnerable TCP connections",
+		Severity:   v1alpha1.SeverityCritical,
+		Score:      9.8,
+		Components: []string{KubeAPIServer},
+		Ranges: []Range{
+			{Fixed: "1.10.11"},
+			{Introduced: "1.11.0", Fixed: "1.11.5"},
+			{Introduced: "1.12.0", Fixed: "1.12.3"},
+		},
+	},
+	{
+		ID:         "CVE-2019-1002100",
+		Title:      "kube-apiserver can be forced to consume excessive resources by json-patch requests",
+		Severity:   v1alpha1.SeverityMedium,
+		Score:      6.5,
+		Components: []string{KubeAPIServer},
+		Ranges: []Range{
+			{Fixed: "1.11.8"},
+			{Introduced: "1.12.0", Fixed: "1.12.6"},
+			{Introduced: "1.13.0", Fixed: "1.13.4"},
+		},
+	},
+	{
+		ID:         "CVE-2019-11247",
+		Title:      "Access to custom resources of any namespace by namespace-scoped permissions",
+		Severity:   v1alpha1.SeverityHigh,
+		Score:      8.1,
+		Components: []string{KubeAPIServer},
+		Ranges: []Range{
+			{Fixed: "1.13.9"},
+			{Introduced: "1.14.0", Fixed: "1.14.5"},
+			{Introduced: "1.15.0", Fixed: "1.15.2"},
+		},
+	},
+	{
+		ID:         "CVE-2019-11253",
+		Title:      "kube-apiserver is vulnerable to YAML parsing \"billion laughs\" attack",
+		Severity:   v1alpha1.SeverityHigh,
+		Score:      7.5,
+		Components: []string{KubeAPIServer},
+		Ranges: []Range{
+			{Fixed: "1.13.12"},
+			{Introduced: "1.14.0", Fixed: "1.14.8"},
+			{Introduced: "1.15.0", Fixed: "1.15.5"},
+			{Introduced: "1.16.0", Fixed: "1.16.2"},
+		},
+	},
+	{
+		ID:         "CVE-2020-8555",
+		Title:      "Half-blind SSRF in kube-controller-manager",
+		Severity:   v1alpha1.SeverityMedium,
+		Score:      6.3,
+		Components: []string{KubeControllerManager},
+		Ranges: []Range{
+			{Fixed: "1.15.12"},
+			{Introduced: "1.16.0", Fixed: "1.16.9"},
+			{Introduced: "1.17.0", Fixed: "1.17.5"},
+		},
+	},
+	{
+		ID:         "CVE-2020-8554",
+		Title:      "Man in the middle using LoadBalancer or ExternalIPs",
+		Severity:   v1alpha1.SeverityMedium,
+		Score:      5.0,
+		Components: []string{KubeAPIServer},
+		Ranges: []Range{
+			{},
+		},
+	},
+	{
+		ID:         "CVE-2020-8557",
+		Title:      "Node disk DOS by writing to container /etc/hosts",
+		Severity:   v1alpha1.SeverityMedium,
+		Score:      5.5,
+		Components: []string{Kubelet},
+		Ranges: []Range{
+			{Fixed: "1.16.13"},
+			{Introduced: "1.17.0", Fixed: "1.17.9"},
+			{Introduced: "1.18.0", Fixed: "1.18.6"},
+		},
+	},
+	{
+		ID:         "CVE-2020-8558",
+		Title:      "Node setting allows for neighboring hosts to bypass localhost boundary",
+		Severity:   v1alpha1.SeverityMedium,
+		Score:      5.4,
+		Components: []string{Kubelet, KubeProxy},
+		Ranges: []Range{
+			{Fixed: "1.16.11"},
+			{Introduced: "1.17.0", Fixed: "1.17.7"},
+			{Introduced: "1.18.0", Fixed: "1.18.4"},
+		},
+	},
+	{
+		ID:         "CVE-2020-8559",
+		Title:      "Privilege escalation from compromised node to cluster",
+		Severity:   v1alpha1.SeverityMedium,
+		Score:      6.4,
+		Components: []string{KubeAPIServer},
+		Ranges: []Range{
+			{Fixed: "1.16.13"},
+			{Introduced: "1.17.0", Fixed: "1.17.9"},
+			{Introduced: "1.18.0", Fixed: "1.18.6"},
+		},
+	},
+	{
+		ID:         "CVE-2021-25735",
+		Title:      "Validating admission webhook does not observe some previous fields",
+		Severity:   v1alpha1.SeverityMedium,
+		Score:      6.5,
+		Components: []string{KubeAPIServer},
+		Ranges: []Range{
+			{Fixed: "1.18.18"},
+			{Introduced: "1.19.0", Fixed: "1.19.10"},
+			{Introduced: "1.20.0", Fixed: "1.20.6"},
+		},
+	},
+	{
+		ID:         "CVE-2021-25737",
+		Title:      "Holes in EndpointSlice validation enable host network hijack",
+		Severity:   v1alpha1.SeverityLow,
+		Score:      2.7,
+		Components: []string{KubeAPIServer},
+		Ranges: []Range{
+			{Introduced: "1.16.0", Fixed: "1.18.19"},
+			{Introduced: "1.19.0", Fixed: "1.19.11"},
+			{Introduced: "1.20.0", Fixed: "1.20.7"},
+			{Introduced: "1.21.0", Fixed: "1.21.1"},
+		},
+	},
+	{
+		ID:         "CVE-2021-25740",
+		Title:      "Endpoint & EndpointSlice permissions allow cross-Namespace forwarding",
+		Severity:   v1alpha1.SeverityLow,
+		Score:      3.1,
+		Components: []string{KubeAPIServer},
+		Ranges: []Range{
+			{},
+		},
+	},
+	{
+		ID:         "CVE-2021-25741",
+		Title:      "Symlink exchange can allow host filesystem access",
+		Severity:   v1alpha1.SeverityHigh,
+		Score:      8.8,
+		Components: []string{Kubelet},
+		Ranges: []Range{
+			{Fixed: "1.19.15"},
+			{Introduced: "1.20.0", Fixed: "1.20.11"},
+			{Introduced: "1.21.0", Fixed: "1.21.5"},
+			{Introduced: "1.22.0", Fixed: "1.22.2"},
+		},
+	},
+}
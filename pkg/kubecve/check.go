@@ -0,0 +1,72 @@
+package kubecve
+
+import (
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/hashicorp/go-version"
+)
+
+// Check returns vulnerabilities of the given Kubernetes component, e.g.
+// kubelet, in the specified version, e.g. v1.21.2. Pre-release and build
+// metadata of the version, such as -eks-0389ca3 or +k3s1, are ignored.
+func Check(component, componentVersion string) ([]v1alpha1.Vulnerability, error) {
+	installed, err := version.NewVersion(componentVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing version of %s: %w", component, err)
+	}
+	installed = installed.Core()
+
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
+	for _, advisory := range Advisories {
+		if !ext.SliceContainsString(advisory.Components, component) {
+			continue
+		}
+		for _, r := range advisory.Ranges {
+			affected, err := r.contains(installed)
+			if err != nil {
+				return nil, fmt.Errorf("checking %s: %w", advisory.ID, err)
+			}
+			if !affected {
+				continue
+			}
+			score := advisory.Score
+			vulnerabilities = append(vulnerabilities, v1alpha1.Vulnerability{
+				VulnerabilityID:  advisory.ID,
+				Resource:         component,
+				InstalledVersion: componentVersion,
+				FixedVersion:     r.Fixed,
+				Severity:         advisory.Severity,
+				Title:            advisory.Title,
+				PrimaryLink:      "https://nvd.nist.gov/vuln/detail/" + advisory.ID,
+				Links:            []string{},
+				Score:            &score,
+			})
+			break
+		}
+	}
+	return vulnerabilities, nil
+}
+
+func (r Range) contains(v *version.Version) (bool, error) {
+	if r.Introduced != "" {
+		introduced, err := version.NewVersion(r.Introduced)
+		if err != nil {
+			return false, err
+		}
+		if v.LessThan(introduced) {
+			return false, nil
+		}
+	}
+	if r.Fixed != "" {
+		fixed, err := version.NewVersion(r.Fixed)
+		if err != nil {
+			return false, err
+		}
+		if !v.LessThan(fixed) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
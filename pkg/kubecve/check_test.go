@@ -0,0 +1,86 @@
+package kubecve_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/kubecve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name      string
+		component string
+		version   string
+		expected  map[string]string
+	}{
+		{
+			name:      "Should report vulnerabilities fixed in later patch release",
+			component: kubecve.Kubelet,
+			version:   "v1.20.10",
+			expected: map[string]string{
+				"CVE-2021-25741": "1.20.11",
+			},
+		},
+		{
+			name:      "Should ignore vulnerabilities fixed in installed version",
+			component: kubecve.Kubelet,
+			version:   "v1.20.11",
+			expected:  map[string]string{},
+		},
+		{
+			name:      "Should ignore build metadata and pre-release of managed distributions",
+			component: kubecve.KubeAPIServer,
+			version:   "v1.20.4-eks-6b7464",
+			expected: map[string]string{
+				"CVE-2020-8554":  "",
+				"CVE-2021-25735": "1.20.6",
+				"CVE-2021-25737": "1.20.7",
+				"CVE-2021-25740": "",
+			},
+		},
+		{
+			name:      "Should report vulnerabilities of releases without fix",
+			component: kubecve.KubeAPIServer,
+			version:   "v1.11.2+k3s1",
+			expected: map[string]string{
+				"CVE-2018-1002105": "1.11.5",
+				"CVE-2019-1002100": "1.11.8",
+				"CVE-2019-11247":   "1.13.9",
+				"CVE-2019-11253":   "1.13.12",
+				"CVE-2020-8554":    "",
+				"CVE-2020-8559":    "1.16.13",
+				"CVE-2021-25735":   "1.18.18",
+				"CVE-2021-25740":   "",
+			},
+		},
+		{
+			name:      "Should report vulnerabilities of given component only",
+			component: kubecve.KubeProxy,
+			version:   "1.18.3",
+			expected: map[string]string{
+				"CVE-2020-8558": "1.18.4",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vulnerabilities, err := kubecve.Check(tc.component, tc.version)
+			require.NoError(t, err)
+			actual := make(map[string]string)
+			for _, vulnerability := range vulnerabilities {
+				assert.Equal(t, tc.component, vulnerability.Resource)
+				assert.Equal(t, tc.version, vulnerability.InstalledVersion)
+				actual[vulnerability.VulnerabilityID] = vulnerability.FixedVersion
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	t.Run("Should return error for invalid version", func(t *testing.T) {
+		_, err := kubecve.Check(kubecve.Kubelet, "latest")
+		assert.Error(t, err)
+	})
+}
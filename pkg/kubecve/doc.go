@@ -0,0 +1,4 @@
+// Package kubecve provides primitives for checking versions of Kubernetes
+// components against a static, incomplete list of known Kubernetes
+// vulnerabilities, which was last updated on AdvisoriesUpdated.
+package kubecve
@@ -0,0 +1,3 @@
+// Package manifest provides primitives for reading Kubernetes objects from
+// local YAML and JSON files, Helm charts, and Kustomize directories.
+package manifest
@@ -0,0 +1,242 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const (
+	// chartFile is the name of the file that marks the root directory of
+	// a Helm chart.
+	chartFile = "Chart.yaml"
+
+	// defaultHelmCommand is the name of the Helm executable used to render
+	// charts unless Options.HelmCommand is specified.
+	defaultHelmCommand = "helm"
+
+	// defaultReleaseName is the name of the release charts are rendered
+	// with unless Options.ReleaseName is specified.
+	defaultReleaseName = "release-name"
+)
+
+// Document is a Kubernetes object read from a local path.
+type Document struct {
+	// Path is the path of the file that the object is read from, or the path
+	// of the Helm chart or Kustomize directory that the object is rendered
+	// from.
+	Path string
+
+	// Object is the Kubernetes object.
+	Object *unstructured.Unstructured
+}
+
+// Options configure how Helm charts are rendered.
+type Options struct {
+	// HelmCommand is the path of the Helm executable, which is looked up in
+	// PATH if not absolute. Defaults to helm.
+	HelmCommand string
+
+	// ReleaseName is the name of the release passed to helm template.
+	ReleaseName string
+
+	// Namespace is the namespace of the release passed to helm template.
+	Namespace string
+
+	// ValuesFiles are values files passed to helm template.
+	ValuesFiles []string
+}
+
+// Load reads Kubernetes objects from the specified path.
+//
+// If the path is a file, objects are decoded from YAML or JSON documents of
+// the file. If the path is a directory, it is walked and objects are decoded
+// from files with .yaml, .yml, or .json extension. Directories that contain
+// Chart.yaml are rendered with helm template, and directories that contain
+// a kustomization file are rendered with Kustomize, instead of being walked.
+// Hidden directories, such as .git, are skipped.
+//
+// Documents without apiVersion or kind, e.g. Helm values files, are ignored.
+// Lists of objects are expanded to their items.
+func Load(path string, opts Options) ([]Document, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return loadFile(path)
+	}
+
+	root := path
+	var documents []Document
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			if !isManifestFile(info.Name()) {
+				return nil
+			}
+			docs, err := loadFile(path)
+			if err != nil {
+				return err
+			}
+			documents = append(documents, docs...)
+			return nil
+		}
+		if path != root && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		var docs []Document
+		switch {
+		case isChart(path):
+			docs, err = renderChart(path, opts)
+		case isKustomization(path):
+			docs, err = renderKustomization(path)
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		documents = append(documents, docs...)
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func isManifestFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func isChart(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, chartFile))
+	return err == nil && !info.IsDir()
+}
+
+func isKustomization(dir string) bool {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+func loadFile(path string) ([]Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	documents, err := decode(path, f)
+	if err != nil {
+		return nil, fmt.Errorf("decoding manifest: %s: %w", path, err)
+	}
+	return documents, nil
+}
+
+func renderChart(dir string, opts Options) ([]Document, error) {
+	command := opts.HelmCommand
+	if command == "" {
+		command = defaultHelmCommand
+	}
+	releaseName := opts.ReleaseName
+	if releaseName == "" {
+		releaseName = defaultReleaseName
+	}
+	args := []string{"template", releaseName, dir}
+	if opts.Namespace != "" {
+		args = append(args, "--namespace", opts.Namespace)
+	}
+	for _, file := range opts.ValuesFiles {
+		args = append(args, "--values", file)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(command, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("rendering Helm chart: %s: %w: Helm must be installed to scan charts", dir, err)
+		}
+		return nil, fmt.Errorf("rendering Helm chart: %s: %w: %s", dir, err, strings.TrimSpace(stderr.String()))
+	}
+	documents, err := decode(dir, &stdout)
+	if err != nil {
+		return nil, fmt.Errorf("decoding rendered Helm chart: %s: %w", dir, err)
+	}
+	return documents, nil
+}
+
+func renderKustomization(dir string) ([]Document, error) {
+	resources, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("rendering Kustomize directory: %s: %w", dir, err)
+	}
+	content, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("rendering Kustomize directory: %s: %w", dir, err)
+	}
+	documents, err := decode(dir, bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("decoding rendered Kustomize directory: %s: %w", dir, err)
+	}
+	return documents, nil
+}
+
+// decode returns objects of YAML or JSON documents read from the given reader.
+func decode(path string, r io.Reader) ([]Document, error) {
+	var documents []Document
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var value map[string]interface{}
+		err := decoder.Decode(&value)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		obj := &unstructured.Unstructured{Object: value}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			continue
+		}
+		if !obj.IsList() {
+			documents = append(documents, Document{Path: path, Object: obj})
+			continue
+		}
+		err = obj.EachListItem(func(item runtime.Object) error {
+			if item, ok := item.(*unstructured.Unstructured); ok {
+				documents = append(documents, Document{Path: path, Object: item})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return documents, nil
+}
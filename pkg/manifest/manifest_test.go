@@ -0,0 +1,177 @@
+package manifest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+spec:
+  template:
+    spec:
+      containers:
+        - name: nginx
+          image: nginx:1.16
+`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+type object struct {
+	path      string
+	kind      string
+	namespace string
+	name      string
+}
+
+func objects(documents []manifest.Document) []object {
+	var objects []object
+	for _, doc := range documents {
+		objects = append(objects, object{
+			path:      doc.Path,
+			kind:      doc.Object.GetKind(),
+			namespace: doc.Object.GetNamespace(),
+			name:      doc.Object.GetName(),
+		})
+	}
+	return objects
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Should load documents of YAML and JSON files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"deploy/nginx.yaml": deployment + `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: nginx
+---
+`,
+			"deploy/list.yml": `apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: ConfigMap
+    metadata:
+      name: settings
+  - apiVersion: v1
+    kind: Secret
+    metadata:
+      name: credentials
+`,
+			"deploy/role.json":   `{"apiVersion": "rbac.authorization.k8s.io/v1", "kind": "Role", "metadata": {"name": "reader"}}`,
+			"values.yaml":        "replicaCount: 1\n",
+			"README.md":          "# Manifests\n",
+			".github/build.yaml": "apiVersion: v1\nkind: Pod\nmetadata:\n  name: ignored\n",
+		})
+
+		documents, err := manifest.Load(dir, manifest.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, []object{
+			{path: filepath.Join(dir, "deploy/list.yml"), kind: "ConfigMap", name: "settings"},
+			{path: filepath.Join(dir, "deploy/list.yml"), kind: "Secret", name: "credentials"},
+			{path: filepath.Join(dir, "deploy/nginx.yaml"), kind: "Deployment", name: "nginx"},
+			{path: filepath.Join(dir, "deploy/nginx.yaml"), kind: "Service", name: "nginx"},
+			{path: filepath.Join(dir, "deploy/role.json"), kind: "Role", name: "reader"},
+		}, objects(documents))
+	})
+
+	t.Run("Should load documents of a single file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"nginx.yaml": deployment})
+
+		documents, err := manifest.Load(filepath.Join(dir, "nginx.yaml"), manifest.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, []object{
+			{path: filepath.Join(dir, "nginx.yaml"), kind: "Deployment", name: "nginx"},
+		}, objects(documents))
+	})
+
+	t.Run("Should render Kustomize directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"base/kustomization.yaml": "resources:\n  - deployment.yaml\n",
+			"base/deployment.yaml":    deployment,
+			"overlays/prod/kustomization.yaml": `resources:
+  - ../../base
+namespace: prod
+namePrefix: prod-
+`,
+		})
+
+		documents, err := manifest.Load(filepath.Join(dir, "overlays"), manifest.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, []object{
+			{path: filepath.Join(dir, "overlays/prod"), kind: "Deployment", namespace: "prod", name: "prod-nginx"},
+		}, objects(documents))
+	})
+
+	t.Run("Should render Helm chart", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("fake Helm executable is a shell script")
+		}
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"chart/Chart.yaml":            "apiVersion: v2\nname: nginx\nversion: 0.1.0\n",
+			"chart/values.yaml":           "image: nginx:1.16\n",
+			"chart/templates/deploy.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: {{ .Release.Name }}\n",
+			"helm": `#!/bin/sh
+echo "# $@"
+echo "---"
+echo "apiVersion: apps/v1"
+echo "kind: Deployment"
+echo "metadata:"
+echo "  name: $2"
+echo "  namespace: $5"
+`,
+		})
+		require.NoError(t, os.Chmod(filepath.Join(dir, "helm"), 0755))
+
+		documents, err := manifest.Load(dir, manifest.Options{
+			HelmCommand: filepath.Join(dir, "helm"),
+			ReleaseName: "nginx",
+			Namespace:   "staging",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []object{
+			{path: filepath.Join(dir, "chart"), kind: "Deployment", namespace: "staging", name: "nginx"},
+		}, objects(documents))
+	})
+
+	t.Run("Should return error when Helm is not installed", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"Chart.yaml": "apiVersion: v2\nname: nginx\nversion: 0.1.0\n",
+		})
+
+		_, err := manifest.Load(dir, manifest.Options{HelmCommand: "starboard-helm-not-installed"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Helm must be installed to scan charts")
+	})
+
+	t.Run("Should return error when file is not valid YAML", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"invalid.yaml": "kind: [Deployment\n"})
+
+		_, err := manifest.Load(dir, manifest.Options{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "decoding manifest: "+filepath.Join(dir, "invalid.yaml"))
+	})
+}
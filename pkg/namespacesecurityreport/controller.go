@@ -0,0 +1,126 @@
+package namespacesecurityreport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// TopN is the number of vulnerable images and failed checks listed in a
+// v1alpha1.NamespaceSecurityReport.
+const TopN = 5
+
+// Controller watches v1alpha1.VulnerabilityReport and v1alpha1.ConfigAuditReport
+// instances and keeps the v1alpha1.NamespaceSecurityReport of their namespace
+// up to date.
+type Controller struct {
+	logr.Logger
+	client.Client
+	ext.Clock
+}
+
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	// Events of all reports in a namespace are mapped to the same request, so
+	// that the workqueue collapses bursts of report changes into a single
+	// aggregation.
+	mapToNamespace := handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Namespace: obj.GetNamespace(),
+					Name:      GetReportName(obj.GetNamespace()),
+				},
+			},
+		}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.NamespaceSecurityReport{}).
+		Watches(&source.Kind{Type: &v1alpha1.VulnerabilityReport{}}, mapToNamespace).
+		Watches(&source.Kind{Type: &v1alpha1.ConfigAuditReport{}}, mapToNamespace).
+		Complete(r.reconcileNamespace())
+}
+
+func (r *Controller) reconcileNamespace() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("namespace", req.Namespace)
+		log.V(1).Info("Aggregating security reports")
+		return ctrl.Result{}, r.UpdateReport(ctx, req.Namespace)
+	}
+}
+
+// UpdateReport aggregates security reports in the given namespace and
+// creates or updates the corresponding v1alpha1.NamespaceSecurityReport.
+// The v1alpha1.NamespaceSecurityReport is deleted if there are no reports
+// left in the namespace.
+func (r *Controller) UpdateReport(ctx context.Context, namespace string) error {
+	var vulnerabilityReports v1alpha1.VulnerabilityReportList
+	err := r.List(ctx, &vulnerabilityReports, client.InNamespace(namespace))
+	if err != nil {
+		return fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	// Skip reports of additional scanners so that findings reported by
+	// several scanners are not counted twice.
+	var primaryReports []v1alpha1.VulnerabilityReport
+	for _, report := range vulnerabilityReports.Items {
+		if _, ok := report.Labels[starboard.LabelVulnerabilityReportAdditional]; !ok {
+			primaryReports = append(primaryReports, report)
+		}
+	}
+	vulnerabilityReports.Items = primaryReports
+	var configAuditReports v1alpha1.ConfigAuditReportList
+	err = r.List(ctx, &configAuditReports, client.InNamespace(namespace))
+	if err != nil {
+		return fmt.Errorf("listing config audit reports: %w", err)
+	}
+
+	var existing v1alpha1.NamespaceSecurityReport
+	err = r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: GetReportName(namespace)}, &existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("getting namespace security report: %w", err)
+	}
+	found := err == nil
+
+	if len(vulnerabilityReports.Items) == 0 && len(configAuditReports.Items) == 0 {
+		if !found {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, &existing))
+	}
+
+	data := NewReportData(vulnerabilityReports.Items, configAuditReports.Items, TopN)
+	data.UpdateTimestamp = metav1.NewTime(r.Clock.Now())
+
+	if found {
+		if sameData(existing.Report, data) {
+			return nil
+		}
+		copied := existing.DeepCopy()
+		copied.Report = data
+		return r.Update(ctx, copied)
+	}
+
+	return r.Create(ctx, &v1alpha1.NamespaceSecurityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetReportName(namespace),
+			Namespace: namespace,
+			Labels: kube.ObjectRefToLabels(kube.ObjectRef{
+				Kind: kube.KindNamespace,
+				Name: namespace,
+			}),
+		},
+		Report: data,
+	})
+}
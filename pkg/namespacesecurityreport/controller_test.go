@@ -0,0 +1,97 @@
+package namespacesecurityreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/namespacesecurityreport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestController_UpdateReport(t *testing.T) {
+	now := time.Date(2022, time.May, 1, 10, 0, 0, 0, time.Local)
+	nginxReport := vulnerabilityReport("replicaset-nginx-6d4cf56db6-nginx", "library/nginx",
+		v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 4})
+
+	kubeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&nginxReport).Build()
+	key := types.NamespacedName{Namespace: "default", Name: "namespace-default"}
+
+	controller := &namespacesecurityreport.Controller{
+		Logger: logr.Discard(),
+		Client: kubeClient,
+		Clock:  ext.NewFixedClock(now),
+	}
+
+	t.Run("Should create report", func(t *testing.T) {
+		err := controller.UpdateReport(context.TODO(), "default")
+		require.NoError(t, err)
+
+		var report v1alpha1.NamespaceSecurityReport
+		err = kubeClient.Get(context.TODO(), key, &report)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"starboard.resource.kind":      "Namespace",
+			"starboard.resource.name":      "default",
+			"starboard.resource.namespace": "",
+		}, report.Labels)
+		assert.Equal(t, metav1.NewTime(now), report.Report.UpdateTimestamp)
+		assert.Equal(t, v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 4}, report.Report.VulnerabilitySummary)
+		assert.Len(t, report.Report.TopVulnerableImages, 1)
+	})
+
+	t.Run("Should not update report with same data", func(t *testing.T) {
+		var before v1alpha1.NamespaceSecurityReport
+		err := kubeClient.Get(context.TODO(), key, &before)
+		require.NoError(t, err)
+
+		controller.Clock = ext.NewFixedClock(now.Add(time.Hour))
+		err = controller.UpdateReport(context.TODO(), "default")
+		require.NoError(t, err)
+
+		var after v1alpha1.NamespaceSecurityReport
+		err = kubeClient.Get(context.TODO(), key, &after)
+		require.NoError(t, err)
+		assert.Equal(t, before.ResourceVersion, after.ResourceVersion)
+		assert.Equal(t, metav1.NewTime(now), after.Report.UpdateTimestamp)
+	})
+
+	t.Run("Should update report", func(t *testing.T) {
+		redisReport := vulnerabilityReport("statefulset-redis-redis", "library/redis",
+			v1alpha1.VulnerabilitySummary{CriticalCount: 3})
+		err := kubeClient.Create(context.TODO(), &redisReport)
+		require.NoError(t, err)
+
+		controller.Clock = ext.NewFixedClock(now.Add(2 * time.Hour))
+		err = controller.UpdateReport(context.TODO(), "default")
+		require.NoError(t, err)
+
+		var report v1alpha1.NamespaceSecurityReport
+		err = kubeClient.Get(context.TODO(), key, &report)
+		require.NoError(t, err)
+		assert.Equal(t, metav1.NewTime(now.Add(2*time.Hour)), report.Report.UpdateTimestamp)
+		assert.Equal(t, v1alpha1.VulnerabilitySummary{CriticalCount: 4, HighCount: 4}, report.Report.VulnerabilitySummary)
+		assert.Len(t, report.Report.TopVulnerableImages, 2)
+	})
+
+	t.Run("Should delete report when there are no security reports", func(t *testing.T) {
+		err := kubeClient.DeleteAllOf(context.TODO(), &v1alpha1.VulnerabilityReport{})
+		require.NoError(t, err)
+
+		err = controller.UpdateReport(context.TODO(), "default")
+		require.NoError(t, err)
+
+		var report v1alpha1.NamespaceSecurityReport
+		err = kubeClient.Get(context.TODO(), key, &report)
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
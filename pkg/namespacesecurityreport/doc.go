@@ -0,0 +1,4 @@
+// Package namespacesecurityreport provides primitives for aggregating
+// security reports of workloads in a namespace as v1alpha1.NamespaceSecurityReport
+// instances.
+package namespacesecurityreport
@@ -0,0 +1,70 @@
+package namespacesecurityreport
+
+import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/report"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetReportName returns the name of the v1alpha1.NamespaceSecurityReport
+// of the given namespace.
+func GetReportName(namespace string) string {
+	return "namespace-" + namespace
+}
+
+// NewReportData aggregates the given VulnerabilityReports and
+// ConfigAuditReports, which belong to the same namespace. At most N vulnerable
+// images and failed checks are returned in TopVulnerableImages and
+// TopFailedChecks respectively. The UpdateTimestamp is not set.
+func NewReportData(vulnerabilityReports []v1alpha1.VulnerabilityReport, configAuditReports []v1alpha1.ConfigAuditReport, N int) v1alpha1.NamespaceSecurityReportData {
+	data := v1alpha1.NamespaceSecurityReportData{
+		TopVulnerableImages: []v1alpha1.VulnerableImage{},
+		TopFailedChecks:     []v1alpha1.FailedCheck{},
+	}
+
+	for _, r := range vulnerabilityReports {
+		summary := r.Report.Summary
+		data.VulnerabilitySummary.CriticalCount += summary.CriticalCount
+		data.VulnerabilitySummary.HighCount += summary.HighCount
+		data.VulnerabilitySummary.MediumCount += summary.MediumCount
+		data.VulnerabilitySummary.LowCount += summary.LowCount
+		data.VulnerabilitySummary.UnknownCount += summary.UnknownCount
+		data.VulnerabilitySummary.NoneCount += summary.NoneCount
+		data.VulnerabilitySummary.SuppressedCount += summary.SuppressedCount
+	}
+	for _, r := range configAuditReports {
+		summary := r.Report.Summary
+		data.ConfigAuditSummary.CriticalCount += summary.CriticalCount
+		data.ConfigAuditSummary.HighCount += summary.HighCount
+		data.ConfigAuditSummary.MediumCount += summary.MediumCount
+		data.ConfigAuditSummary.LowCount += summary.LowCount
+	}
+
+	for _, r := range report.TopNImagesBySeverityCount(vulnerabilityReports, N) {
+		data.TopVulnerableImages = append(data.TopVulnerableImages, v1alpha1.VulnerableImage{
+			Registry: r.Report.Registry,
+			Artifact: r.Report.Artifact,
+			Summary:  r.Report.Summary,
+		})
+	}
+	for _, check := range report.TopNFailedChecksByAffectedWorkloadsCount(configAuditReports, N) {
+		data.TopFailedChecks = append(data.TopFailedChecks, v1alpha1.FailedCheck{
+			ID:                check.ID,
+			Title:             check.Title,
+			Severity:          check.Severity,
+			Category:          check.Category,
+			AffectedWorkloads: check.AffectedWorkloads,
+		})
+	}
+
+	return data
+}
+
+// sameData returns true if the given report data are equal regardless of
+// their UpdateTimestamp.
+func sameData(a, b v1alpha1.NamespaceSecurityReportData) bool {
+	a.UpdateTimestamp = metav1.Time{}
+	b.UpdateTimestamp = metav1.Time{}
+	return equality.Semantic.DeepEqual(a, b)
+}
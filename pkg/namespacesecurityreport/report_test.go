@@ -0,0 +1,95 @@
+package namespacesecurityreport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/namespacesecurityreport"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func vulnerabilityReport(name, repository string, summary v1alpha1.VulnerabilitySummary) v1alpha1.VulnerabilityReport {
+	return v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Registry: v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact: v1alpha1.Artifact{Repository: repository, Tag: "latest"},
+			Summary:  summary,
+		},
+	}
+}
+
+func configAuditReport(name string, summary v1alpha1.ConfigAuditSummary, checks ...v1alpha1.Check) v1alpha1.ConfigAuditReport {
+	return v1alpha1.ConfigAuditReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Report: v1alpha1.ConfigAuditReportData{
+			Summary: summary,
+			Checks:  checks,
+		},
+	}
+}
+
+func TestGetReportName(t *testing.T) {
+	assert.Equal(t, "namespace-default", namespacesecurityreport.GetReportName("default"))
+}
+
+func TestNewReportData(t *testing.T) {
+	runAsRoot := v1alpha1.Check{ID: "KSV012", Title: "Runs as root user", Severity: v1alpha1.SeverityMedium, Category: "Kubernetes Security Check"}
+	privileged := v1alpha1.Check{ID: "KSV017", Title: "Privileged container", Severity: v1alpha1.SeverityHigh, Category: "Kubernetes Security Check"}
+	readOnlyFS := v1alpha1.Check{ID: "KSV014", Title: "Root file system is not read-only", Severity: v1alpha1.SeverityLow, Success: true}
+
+	data := namespacesecurityreport.NewReportData(
+		[]v1alpha1.VulnerabilityReport{
+			vulnerabilityReport("replicaset-nginx-6d4cf56db6-nginx", "library/nginx",
+				v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 4, LowCount: 10}),
+			vulnerabilityReport("statefulset-redis-redis", "library/redis",
+				v1alpha1.VulnerabilitySummary{CriticalCount: 3, MediumCount: 2, SuppressedCount: 1}),
+			vulnerabilityReport("daemonset-fluentd-fluentd", "library/fluentd",
+				v1alpha1.VulnerabilitySummary{HighCount: 7}),
+		},
+		[]v1alpha1.ConfigAuditReport{
+			configAuditReport("replicaset-nginx-6d4cf56db6",
+				v1alpha1.ConfigAuditSummary{HighCount: 1, MediumCount: 1}, runAsRoot, privileged, readOnlyFS),
+			configAuditReport("statefulset-redis",
+				v1alpha1.ConfigAuditSummary{MediumCount: 1}, runAsRoot, readOnlyFS),
+		},
+		2,
+	)
+
+	assert.Equal(t, v1alpha1.NamespaceSecurityReportData{
+		VulnerabilitySummary: v1alpha1.VulnerabilitySummary{
+			CriticalCount:   4,
+			HighCount:       11,
+			MediumCount:     2,
+			LowCount:        10,
+			SuppressedCount: 1,
+		},
+		ConfigAuditSummary: v1alpha1.ConfigAuditSummary{
+			HighCount:   1,
+			MediumCount: 2,
+		},
+		TopVulnerableImages: []v1alpha1.VulnerableImage{
+			{
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/redis", Tag: "latest"},
+				Summary:  v1alpha1.VulnerabilitySummary{CriticalCount: 3, MediumCount: 2, SuppressedCount: 1},
+			},
+			{
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "latest"},
+				Summary:  v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 4, LowCount: 10},
+			},
+		},
+		TopFailedChecks: []v1alpha1.FailedCheck{
+			{ID: "KSV012", Title: "Runs as root user", Severity: v1alpha1.SeverityMedium, Category: "Kubernetes Security Check", AffectedWorkloads: 2},
+			{ID: "KSV017", Title: "Privileged container", Severity: v1alpha1.SeverityHigh, Category: "Kubernetes Security Check", AffectedWorkloads: 1},
+		},
+	}, data)
+}
@@ -0,0 +1,149 @@
+package notification
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+const (
+	keyPrefixNotifications = "notifications."
+	keySuffixType          = ".type"
+	keySuffixURL           = ".url"
+	keySuffixSeverity      = ".severity"
+	keySuffixNamespaces    = ".namespaces"
+)
+
+// SinkType is the type of destination of notifications.
+type SinkType string
+
+const (
+	// SinkTypeWebhook posts notifications as JSON documents to an HTTP
+	// endpoint.
+	SinkTypeWebhook SinkType = "webhook"
+	// SinkTypeSlack posts notifications as messages to a Slack-compatible
+	// incoming webhook.
+	SinkTypeSlack SinkType = "slack"
+	// SinkTypeEvent records notifications as Kubernetes Events of the
+	// workload that a report was generated for.
+	SinkTypeEvent SinkType = "event"
+)
+
+// SinkConfig holds settings of a sink, which are stored in the starboard
+// ConfigMap with keys prefixed with notifications.<name>.
+type SinkConfig struct {
+	// Name is the name of the sink.
+	Name string
+
+	// Type is the type of the sink.
+	Type SinkType
+
+	// URL is the URL of the HTTP endpoint of webhook and Slack sinks.
+	URL string
+
+	// Severity is the minimum severity of findings sent to the sink.
+	Severity v1alpha1.Severity
+
+	// Namespaces are glob patterns of namespaces whose findings are sent to
+	// the sink. Findings from all namespaces are sent if blank.
+	Namespaces []string
+}
+
+// NewSinkConfigs parses configs of sinks from the given Starboard config. A
+// sink is configured with the notifications.<name>.type key, whereas
+// notifications.<name>.url, notifications.<name>.severity, and
+// notifications.<name>.namespaces keys are optional.
+func NewSinkConfigs(config starboard.ConfigData) ([]SinkConfig, error) {
+	var configs []SinkConfig
+	for key, value := range config {
+		if !strings.HasPrefix(key, keyPrefixNotifications) || !strings.HasSuffix(key, keySuffixType) {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, keyPrefixNotifications), keySuffixType)
+		prefix := keyPrefixNotifications + name
+
+		sinkConfig := SinkConfig{
+			Name:     name,
+			Type:     SinkType(strings.ToLower(value)),
+			URL:      config[prefix+keySuffixURL],
+			Severity: v1alpha1.SeverityHigh,
+		}
+		switch sinkConfig.Type {
+		case SinkTypeWebhook, SinkTypeSlack:
+			if _, err := url.ParseRequestURI(sinkConfig.URL); err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", prefix+keySuffixURL, err)
+			}
+		case SinkTypeEvent:
+		default:
+			return nil, fmt.Errorf("parsing %s: invalid sink type %q, allowed types are: %s,%s,%s",
+				key, value, SinkTypeWebhook, SinkTypeSlack, SinkTypeEvent)
+		}
+		if value, ok := config[prefix+keySuffixSeverity]; ok {
+			severity, err := v1alpha1.StringToSeverity(value)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", prefix+keySuffixSeverity, err)
+			}
+			sinkConfig.Severity = severity
+		}
+		for _, namespace := range strings.Split(config[prefix+keySuffixNamespaces], ",") {
+			namespace = strings.TrimSpace(namespace)
+			if namespace == "" {
+				continue
+			}
+			if _, err := filepath.Match(namespace, ""); err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", prefix+keySuffixNamespaces, err)
+			}
+			sinkConfig.Namespaces = append(sinkConfig.Namespaces, namespace)
+		}
+		configs = append(configs, sinkConfig)
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].Name < configs[j].Name
+	})
+	return configs, nil
+}
+
+// MatchesNamespace returns true if findings from the given namespace are sent
+// to the sink.
+func (c SinkConfig) MatchesNamespace(namespace string) bool {
+	if len(c.Namespaces) == 0 {
+		return true
+	}
+	for _, pattern := range c.Namespaces {
+		if matches, _ := filepath.Match(pattern, namespace); matches {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterFindings returns the given findings with at least the minimum
+// severity of the sink.
+func (c SinkConfig) FilterFindings(findings []Finding) []Finding {
+	var filtered []Finding
+	for _, finding := range findings {
+		if severityRank(finding.Severity) < severityRank(c.Severity) {
+			continue
+		}
+		filtered = append(filtered, finding)
+	}
+	return filtered
+}
+
+var severityRanks = map[v1alpha1.Severity]int{
+	v1alpha1.SeverityCritical: 4,
+	v1alpha1.SeverityHigh:     3,
+	v1alpha1.SeverityMedium:   2,
+	v1alpha1.SeverityLow:      1,
+}
+
+// severityRank returns the rank of the given severity. Unknown severities
+// have the lowest rank.
+func severityRank(severity v1alpha1.Severity) int {
+	return severityRanks[severity]
+}
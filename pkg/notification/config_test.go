@@ -0,0 +1,114 @@
+package notification_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/notification"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSinkConfigs(t *testing.T) {
+	testCases := []struct {
+		name            string
+		config          starboard.ConfigData
+		expectedConfigs []notification.SinkConfig
+		expectedError   string
+	}{
+		{
+			name: "Should return nil when no sinks are configured",
+			config: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Trivy",
+			},
+		},
+		{
+			name: "Should return sinks sorted by name",
+			config: starboard.ConfigData{
+				"notifications.team-a.type":       "slack",
+				"notifications.team-a.url":        "https://hooks.slack.com/services/T0/B0/X",
+				"notifications.team-a.namespaces": "team-a, team-a-*",
+				"notifications.audit.type":        "Webhook",
+				"notifications.audit.url":         "http://audit.example.com/starboard",
+				"notifications.audit.severity":    "CRITICAL",
+				"notifications.events.type":       "event",
+			},
+			expectedConfigs: []notification.SinkConfig{
+				{
+					Name:     "audit",
+					Type:     notification.SinkTypeWebhook,
+					URL:      "http://audit.example.com/starboard",
+					Severity: v1alpha1.SeverityCritical,
+				},
+				{
+					Name:     "events",
+					Type:     notification.SinkTypeEvent,
+					Severity: v1alpha1.SeverityHigh,
+				},
+				{
+					Name:       "team-a",
+					Type:       notification.SinkTypeSlack,
+					URL:        "https://hooks.slack.com/services/T0/B0/X",
+					Severity:   v1alpha1.SeverityHigh,
+					Namespaces: []string{"team-a", "team-a-*"},
+				},
+			},
+		},
+		{
+			name: "Should return error when sink type is invalid",
+			config: starboard.ConfigData{
+				"notifications.audit.type": "email",
+			},
+			expectedError: "parsing notifications.audit.type: invalid sink type \"email\", allowed types are: webhook,slack,event",
+		},
+		{
+			name: "Should return error when URL is missing",
+			config: starboard.ConfigData{
+				"notifications.audit.type": "webhook",
+			},
+			expectedError: "parsing notifications.audit.url: parse \"\": empty url",
+		},
+		{
+			name: "Should return error when severity is invalid",
+			config: starboard.ConfigData{
+				"notifications.events.type":     "event",
+				"notifications.events.severity": "URGENT",
+			},
+			expectedError: "parsing notifications.events.severity: unrecognized name literal: URGENT",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configs, err := notification.NewSinkConfigs(tc.config)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfigs, configs)
+		})
+	}
+}
+
+func TestSinkConfig_MatchesNamespace(t *testing.T) {
+	config := notification.SinkConfig{Namespaces: []string{"prod", "team-*"}}
+	assert.True(t, config.MatchesNamespace("prod"))
+	assert.True(t, config.MatchesNamespace("team-a"))
+	assert.False(t, config.MatchesNamespace("staging"))
+	assert.True(t, notification.SinkConfig{}.MatchesNamespace("staging"))
+}
+
+func TestSinkConfig_FilterFindings(t *testing.T) {
+	config := notification.SinkConfig{Severity: v1alpha1.SeverityHigh}
+	findings := config.FilterFindings([]notification.Finding{
+		{ID: "CVE-2022-0001", Severity: v1alpha1.SeverityCritical},
+		{ID: "CVE-2022-0002", Severity: v1alpha1.SeverityMedium},
+		{ID: "CVE-2022-0003", Severity: v1alpha1.SeverityHigh},
+		{ID: "CVE-2022-0004", Severity: v1alpha1.SeverityUnknown},
+	})
+	assert.Equal(t, []notification.Finding{
+		{ID: "CVE-2022-0001", Severity: v1alpha1.SeverityCritical},
+		{ID: "CVE-2022-0003", Severity: v1alpha1.SeverityHigh},
+	}, findings)
+}
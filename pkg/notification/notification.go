@@ -0,0 +1,168 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// Finding is a vulnerability or a failing configuration check, which was not
+// present in the previous version of a security report.
+type Finding struct {
+	// ID is the vulnerability ID, e.g. CVE-2021-44228, or the check ID, e.g.
+	// KSV017.
+	ID       string            `json:"id"`
+	Title    string            `json:"title,omitempty"`
+	Severity v1alpha1.Severity `json:"severity"`
+
+	// Resource is the vulnerable package. It is blank for configuration
+	// checks.
+	Resource         string `json:"resource,omitempty"`
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	FixedVersion     string `json:"fixedVersion,omitempty"`
+	PrimaryLink      string `json:"primaryLink,omitempty"`
+
+	// Messages describe why a configuration check failed.
+	Messages []string `json:"messages,omitempty"`
+}
+
+// Notification describes new findings in a security report written by the
+// operator.
+type Notification struct {
+	// ReportKind is the kind of the report, e.g. VulnerabilityReport.
+	ReportKind string
+	ReportName string
+
+	// Owner is the workload or resource that the report was generated for.
+	Owner kube.ObjectRef
+
+	// Container is the name of the container whose image was scanned. It is
+	// blank for configuration audit reports.
+	Container string
+
+	Findings []Finding
+}
+
+// Notifier is the interface that wraps the Notify method.
+//
+// Notify sends the given Notification to configured sinks. Errors are logged
+// rather than returned, because failing to deliver a notification must not
+// prevent the operator from writing security reports.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification)
+}
+
+// NewVulnerabilityNotification returns a Notification about vulnerabilities
+// in the given report that are not present in the previous report data. The
+// previous report data is nil if the report is created. The second return
+// value is false if there are no new vulnerabilities.
+func NewVulnerabilityNotification(report v1alpha1.VulnerabilityReport, previous *v1alpha1.VulnerabilityReportData) (Notification, bool) {
+	findings := DiffVulnerabilities(previous, report.Report)
+	if len(findings) == 0 {
+		return Notification{}, false
+	}
+	owner, _ := kube.ObjectRefFromObjectMeta(report.ObjectMeta)
+	return Notification{
+		ReportKind: v1alpha1.VulnerabilityReportKind,
+		ReportName: report.Name,
+		Owner:      owner,
+		Container:  report.Labels[starboard.LabelContainerName],
+		Findings:   findings,
+	}, true
+}
+
+// NewConfigAuditNotification returns a Notification about checks failing in
+// the given report that did not fail in the previous report data. The
+// previous report data is nil if the report is created. The second return
+// value is false if there are no newly failing checks.
+func NewConfigAuditNotification(report v1alpha1.ConfigAuditReport, previous *v1alpha1.ConfigAuditReportData) (Notification, bool) {
+	findings := DiffChecks(previous, report.Report)
+	if len(findings) == 0 {
+		return Notification{}, false
+	}
+	owner, _ := kube.ObjectRefFromObjectMeta(report.ObjectMeta)
+	return Notification{
+		ReportKind: v1alpha1.ConfigAuditReportKind,
+		ReportName: report.Name,
+		Owner:      owner,
+		Findings:   findings,
+	}, true
+}
+
+// DiffVulnerabilities returns vulnerabilities in the current report data that
+// are not present in the previous report data. Vulnerabilities are identified
+// by their ID and the vulnerable package. Suppressed vulnerabilities are
+// skipped.
+func DiffVulnerabilities(previous *v1alpha1.VulnerabilityReportData, current v1alpha1.VulnerabilityReportData) []Finding {
+	known := make(map[string]bool)
+	if previous != nil {
+		for _, vulnerability := range previous.Vulnerabilities {
+			if vulnerability.Suppressed {
+				continue
+			}
+			known[vulnerabilityKey(vulnerability)] = true
+		}
+	}
+	var findings []Finding
+	for _, vulnerability := range current.Vulnerabilities {
+		key := vulnerabilityKey(vulnerability)
+		if vulnerability.Suppressed || known[key] {
+			continue
+		}
+		known[key] = true
+		findings = append(findings, Finding{
+			ID:               vulnerability.VulnerabilityID,
+			Title:            vulnerability.Title,
+			Severity:         vulnerability.Severity,
+			Resource:         vulnerability.Resource,
+			InstalledVersion: vulnerability.InstalledVersion,
+			FixedVersion:     vulnerability.FixedVersion,
+			PrimaryLink:      vulnerability.PrimaryLink,
+		})
+	}
+	return findings
+}
+
+// DiffChecks returns checks failing in the current report data that did not
+// fail in the previous report data. Checks are identified by their ID and
+// scope.
+func DiffChecks(previous *v1alpha1.ConfigAuditReportData, current v1alpha1.ConfigAuditReportData) []Finding {
+	known := make(map[string]bool)
+	if previous != nil {
+		for _, check := range previous.Checks {
+			if check.Success {
+				continue
+			}
+			known[checkKey(check)] = true
+		}
+	}
+	var findings []Finding
+	for _, check := range current.Checks {
+		key := checkKey(check)
+		if check.Success || known[key] {
+			continue
+		}
+		known[key] = true
+		findings = append(findings, Finding{
+			ID:       check.ID,
+			Title:    check.Title,
+			Severity: check.Severity,
+			Messages: check.Messages,
+		})
+	}
+	return findings
+}
+
+func vulnerabilityKey(vulnerability v1alpha1.Vulnerability) string {
+	return fmt.Sprintf("%s/%s", vulnerability.VulnerabilityID, vulnerability.Resource)
+}
+
+func checkKey(check v1alpha1.Check) string {
+	if check.Scope == nil {
+		return check.ID
+	}
+	return fmt.Sprintf("%s/%s/%s", check.ID, check.Scope.Type, check.Scope.Value)
+}
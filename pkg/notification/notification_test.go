@@ -0,0 +1,116 @@
+package notification_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/notification"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiffVulnerabilities(t *testing.T) {
+	previous := &v1alpha1.VulnerabilityReportData{
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2022-0001", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+			{VulnerabilityID: "CVE-2022-0002", Resource: "curl", Severity: v1alpha1.SeverityHigh, Suppressed: true},
+		},
+	}
+	current := v1alpha1.VulnerabilityReportData{
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2022-0001", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+			{VulnerabilityID: "CVE-2022-0001", Resource: "libssl", Severity: v1alpha1.SeverityCritical},
+			{VulnerabilityID: "CVE-2022-0002", Resource: "curl", Severity: v1alpha1.SeverityHigh},
+			{VulnerabilityID: "CVE-2022-0003", Resource: "zlib", Severity: v1alpha1.SeverityLow, Suppressed: true},
+		},
+	}
+
+	t.Run("Should return new and no longer suppressed vulnerabilities", func(t *testing.T) {
+		findings := notification.DiffVulnerabilities(previous, current)
+		assert.Equal(t, []notification.Finding{
+			{ID: "CVE-2022-0001", Resource: "libssl", Severity: v1alpha1.SeverityCritical},
+			{ID: "CVE-2022-0002", Resource: "curl", Severity: v1alpha1.SeverityHigh},
+		}, findings)
+	})
+
+	t.Run("Should return all unsuppressed vulnerabilities when previous is nil", func(t *testing.T) {
+		findings := notification.DiffVulnerabilities(nil, current)
+		assert.Len(t, findings, 3)
+	})
+
+	t.Run("Should return nothing when there are no changes", func(t *testing.T) {
+		findings := notification.DiffVulnerabilities(&current, current)
+		assert.Empty(t, findings)
+	})
+}
+
+func TestDiffChecks(t *testing.T) {
+	previous := &v1alpha1.ConfigAuditReportData{
+		Checks: []v1alpha1.Check{
+			{ID: "KSV001", Severity: v1alpha1.SeverityHigh, Success: false},
+			{ID: "KSV003", Severity: v1alpha1.SeverityHigh, Success: true},
+			{ID: "KSV014", Severity: v1alpha1.SeverityLow, Success: false,
+				Scope: &v1alpha1.CheckScope{Type: "Container", Value: "nginx"}},
+		},
+	}
+	current := v1alpha1.ConfigAuditReportData{
+		Checks: []v1alpha1.Check{
+			{ID: "KSV001", Severity: v1alpha1.SeverityHigh, Success: false},
+			{ID: "KSV003", Severity: v1alpha1.SeverityHigh, Success: false, Messages: []string{"privileged"}},
+			{ID: "KSV014", Severity: v1alpha1.SeverityLow, Success: false,
+				Scope: &v1alpha1.CheckScope{Type: "Container", Value: "sidecar"}},
+			{ID: "KSV020", Severity: v1alpha1.SeverityMedium, Success: true},
+		},
+	}
+
+	findings := notification.DiffChecks(previous, current)
+	assert.Equal(t, []notification.Finding{
+		{ID: "KSV003", Severity: v1alpha1.SeverityHigh, Messages: []string{"privileged"}},
+		{ID: "KSV014", Severity: v1alpha1.SeverityLow},
+	}, findings)
+}
+
+func TestNewVulnerabilityNotification(t *testing.T) {
+	report := v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replicaset-nginx-6d4cf56db6-nginx",
+			Namespace: "default",
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      "nginx-6d4cf56db6",
+				starboard.LabelResourceNamespace: "default",
+				starboard.LabelContainerName:     "nginx",
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2022-0001", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+			},
+		},
+	}
+
+	t.Run("Should return notification about new vulnerabilities", func(t *testing.T) {
+		n, ok := notification.NewVulnerabilityNotification(report, nil)
+		assert.True(t, ok)
+		assert.Equal(t, notification.Notification{
+			ReportKind: v1alpha1.VulnerabilityReportKind,
+			ReportName: "replicaset-nginx-6d4cf56db6-nginx",
+			Owner: kube.ObjectRef{
+				Kind:      kube.KindReplicaSet,
+				Name:      "nginx-6d4cf56db6",
+				Namespace: "default",
+			},
+			Container: "nginx",
+			Findings: []notification.Finding{
+				{ID: "CVE-2022-0001", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+			},
+		}, n)
+	})
+
+	t.Run("Should return false when there are no new vulnerabilities", func(t *testing.T) {
+		_, ok := notification.NewVulnerabilityNotification(report, &report.Report)
+		assert.False(t, ok)
+	})
+}
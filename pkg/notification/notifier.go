@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/record"
+)
+
+type configuredSink struct {
+	config SinkConfig
+	sink   Sink
+}
+
+type notifier struct {
+	logger logr.Logger
+	sinks  []configuredSink
+}
+
+// NewNotifier constructs a new Notifier, which sends notifications to sinks
+// with the given configs. Findings are filtered by namespace and severity
+// for each sink separately.
+func NewNotifier(logger logr.Logger, configs []SinkConfig, resolver kube.ObjectResolver, recorder record.EventRecorder) (Notifier, error) {
+	n := &notifier{
+		logger: logger,
+	}
+	httpClient := newHTTPClient()
+	for _, config := range configs {
+		sink, err := newSink(config, httpClient, resolver, recorder)
+		if err != nil {
+			return nil, err
+		}
+		n.sinks = append(n.sinks, configuredSink{config: config, sink: sink})
+	}
+	return n, nil
+}
+
+func (n *notifier) Notify(ctx context.Context, notification Notification) {
+	for _, s := range n.sinks {
+		if !s.config.MatchesNamespace(notification.Owner.Namespace) {
+			continue
+		}
+		findings := s.config.FilterFindings(notification.Findings)
+		if len(findings) == 0 {
+			continue
+		}
+		filtered := notification
+		filtered.Findings = findings
+
+		err := s.sink.Send(ctx, filtered)
+		if err != nil {
+			n.logger.Error(err, "Sending notification", "sink", s.config.Name,
+				"report", notification.ReportKind+"/"+notification.ReportName)
+			continue
+		}
+		n.logger.V(1).Info("Sent notification", "sink", s.config.Name,
+			"report", notification.ReportKind+"/"+notification.ReportName, "findings", len(findings))
+	}
+}
@@ -0,0 +1,201 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/notification"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var newVulnerabilities = notification.Notification{
+	ReportKind: v1alpha1.VulnerabilityReportKind,
+	ReportName: "replicaset-nginx-6d4cf56db6-nginx",
+	Owner: kube.ObjectRef{
+		Kind:      kube.KindReplicaSet,
+		Name:      "nginx-6d4cf56db6",
+		Namespace: "default",
+	},
+	Container: "nginx",
+	Findings: []notification.Finding{
+		{ID: "CVE-2022-0001", Resource: "openssl", InstalledVersion: "1.1.1k", Severity: v1alpha1.SeverityCritical},
+		{ID: "CVE-2022-0002", Resource: "curl", InstalledVersion: "7.74.0", Severity: v1alpha1.SeverityMedium},
+	},
+}
+
+func TestNotifier(t *testing.T) {
+
+	t.Run("Should post findings to webhook sink", func(t *testing.T) {
+		var payloads []notification.WebhookPayload
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload notification.WebhookPayload
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			payloads = append(payloads, payload)
+		}))
+		defer server.Close()
+
+		notifier := newNotifier(t, starboard.ConfigData{
+			"notifications.audit.type": "webhook",
+			"notifications.audit.url":  server.URL,
+		}, kube.ObjectResolver{}, nil)
+		notifier.Notify(context.TODO(), newVulnerabilities)
+
+		assert.Equal(t, []notification.WebhookPayload{
+			{
+				ReportKind: v1alpha1.VulnerabilityReportKind,
+				ReportName: "replicaset-nginx-6d4cf56db6-nginx",
+				Owner: notification.WebhookOwner{
+					Kind:      "ReplicaSet",
+					Name:      "nginx-6d4cf56db6",
+					Namespace: "default",
+				},
+				Container: "nginx",
+				Findings: []notification.Finding{
+					{ID: "CVE-2022-0001", Resource: "openssl", InstalledVersion: "1.1.1k", Severity: v1alpha1.SeverityCritical},
+				},
+			},
+		}, payloads)
+	})
+
+	t.Run("Should post message to Slack sink", func(t *testing.T) {
+		var payloads []notification.SlackPayload
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload notification.SlackPayload
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			payloads = append(payloads, payload)
+		}))
+		defer server.Close()
+
+		notifier := newNotifier(t, starboard.ConfigData{
+			"notifications.team.type":     "slack",
+			"notifications.team.url":      server.URL,
+			"notifications.team.severity": "MEDIUM",
+		}, kube.ObjectResolver{}, nil)
+		notifier.Notify(context.TODO(), newVulnerabilities)
+
+		assert.Equal(t, []notification.SlackPayload{
+			{
+				Text: "*2 new vulnerabilities* in ReplicaSet default/nginx-6d4cf56db6 (container nginx)" +
+					"\n• CRITICAL CVE-2022-0001 in openssl 1.1.1k" +
+					"\n• MEDIUM CVE-2022-0002 in curl 7.74.0",
+			},
+		}, payloads)
+	})
+
+	t.Run("Should skip sinks with unmatched namespace", func(t *testing.T) {
+		var count int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count++
+		}))
+		defer server.Close()
+
+		notifier := newNotifier(t, starboard.ConfigData{
+			"notifications.audit.type":       "webhook",
+			"notifications.audit.url":        server.URL,
+			"notifications.audit.namespaces": "prod-*",
+		}, kube.ObjectResolver{}, nil)
+		notifier.Notify(context.TODO(), newVulnerabilities)
+
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("Should record event on owner", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nginx-6d4cf56db6",
+				Namespace: "default",
+			},
+		}).Build()
+		recorder := record.NewFakeRecorder(10)
+
+		notifier := newNotifier(t, starboard.ConfigData{
+			"notifications.events.type": "event",
+		}, kube.ObjectResolver{Client: client}, recorder)
+		notifier.Notify(context.TODO(), newVulnerabilities)
+
+		require.Len(t, recorder.Events, 1)
+		assert.Equal(t, "Warning NewVulnerabilities 1 new vulnerabilities in container nginx: CVE-2022-0001 (CRITICAL)",
+			<-recorder.Events)
+	})
+}
+
+// blockingNotifier records notifications, which are only sent once the
+// release channel is closed.
+type blockingNotifier struct {
+	release chan struct{}
+	sent    chan notification.Notification
+}
+
+func (n *blockingNotifier) Notify(_ context.Context, notification notification.Notification) {
+	<-n.release
+	n.sent <- notification
+}
+
+func TestQueue(t *testing.T) {
+
+	t.Run("Should send notifications in the background", func(t *testing.T) {
+		notifier := &blockingNotifier{release: make(chan struct{}), sent: make(chan notification.Notification, 1)}
+		queue := notification.NewQueue(logr.Discard(), notifier, 1)
+
+		// Notify returns although the notifier is blocked.
+		queue.Notify(context.TODO(), newVulnerabilities)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			_ = queue.Start(ctx)
+		}()
+		close(notifier.release)
+
+		select {
+		case sent := <-notifier.sent:
+			assert.Equal(t, newVulnerabilities, sent)
+		case <-time.After(5 * time.Second):
+			t.Fatal("notification was not sent")
+		}
+	})
+
+	t.Run("Should drop notifications when queue is full", func(t *testing.T) {
+		notifier := &blockingNotifier{release: make(chan struct{}), sent: make(chan notification.Notification, 2)}
+		queue := notification.NewQueue(logr.Discard(), notifier, 1)
+
+		queue.Notify(context.TODO(), newVulnerabilities)
+		queue.Notify(context.TODO(), newVulnerabilities)
+
+		close(notifier.release)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			_ = queue.Start(ctx)
+		}()
+
+		<-notifier.sent
+		select {
+		case <-notifier.sent:
+			t.Fatal("notification was not dropped")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
+
+func newNotifier(t *testing.T, config starboard.ConfigData, resolver kube.ObjectResolver, recorder record.EventRecorder) notification.Notifier {
+	t.Helper()
+	configs, err := notification.NewSinkConfigs(config)
+	require.NoError(t, err)
+	notifier, err := notification.NewNotifier(logr.Discard(), configs, resolver, recorder)
+	require.NoError(t, err)
+	return notifier
+}
@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+)
+
+// DefaultQueueSize is the default number of notifications that are held by
+// a Queue until they're sent.
+const DefaultQueueSize = 1000
+
+// Queue is a Notifier that sends notifications with another Notifier in the
+// background, so that writing reports does not wait for slow or unavailable
+// sinks. Notifications are dropped if the queue is full.
+//
+// It implements the manager.Runnable interface. Notifications are held by
+// the queue until the manager starts it.
+type Queue struct {
+	logger   logr.Logger
+	notifier Notifier
+	queue    chan Notification
+}
+
+// NewQueue constructs a new Queue, which holds up to size notifications
+// until they're sent with the given Notifier.
+func NewQueue(logger logr.Logger, notifier Notifier, size int) *Queue {
+	return &Queue{
+		logger:   logger,
+		notifier: notifier,
+		queue:    make(chan Notification, size),
+	}
+}
+
+// Notify adds the given notification to the queue without blocking. The
+// context is ignored, because the notification is sent after the caller
+// returns.
+func (q *Queue) Notify(_ context.Context, notification Notification) {
+	select {
+	case q.queue <- notification:
+	default:
+		q.logger.Error(nil, "Dropping notification because the queue is full",
+			"report", notification.ReportKind+"/"+notification.ReportName)
+	}
+}
+
+// Start sends queued notifications until the given context is cancelled.
+func (q *Queue) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-q.queue:
+			q.notifier.Notify(ctx, notification)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Notifications
+// are sent by each replica of the operator that writes reports.
+func (q *Queue) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,189 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// ReasonNewVulnerabilities is the reason of Kubernetes Events recorded
+	// for new vulnerabilities.
+	ReasonNewVulnerabilities = "NewVulnerabilities"
+	// ReasonNewConfigAuditFailures is the reason of Kubernetes Events recorded
+	// for newly failing configuration checks.
+	ReasonNewConfigAuditFailures = "NewConfigAuditFailures"
+)
+
+// maxFindingsInMessage is the maximum number of findings listed in messages
+// sent to Slack and recorded as Kubernetes Events.
+const maxFindingsInMessage = 10
+
+// Sink is the interface that wraps the Send method.
+//
+// Send delivers the given Notification to its destination.
+type Sink interface {
+	Send(ctx context.Context, notification Notification) error
+}
+
+// newSink constructs a new Sink of the type specified in the given config.
+func newSink(config SinkConfig, httpClient *http.Client, resolver kube.ObjectResolver, recorder record.EventRecorder) (Sink, error) {
+	switch config.Type {
+	case SinkTypeWebhook:
+		return &webhookSink{url: config.URL, client: httpClient}, nil
+	case SinkTypeSlack:
+		return &slackSink{url: config.URL, client: httpClient}, nil
+	case SinkTypeEvent:
+		return &eventSink{resolver: resolver, recorder: recorder}, nil
+	default:
+		return nil, fmt.Errorf("unsupported sink type: %s", config.Type)
+	}
+}
+
+// newHTTPClient returns the http.Client used by webhook and Slack sinks.
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+// WebhookPayload is the JSON document posted by the webhook sink.
+type WebhookPayload struct {
+	ReportKind string       `json:"reportKind"`
+	ReportName string       `json:"reportName"`
+	Owner      WebhookOwner `json:"owner"`
+	Container  string       `json:"container,omitempty"`
+	Findings   []Finding    `json:"findings"`
+}
+
+// WebhookOwner describes the workload or resource that a report was
+// generated for.
+type WebhookOwner struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *webhookSink) Send(ctx context.Context, notification Notification) error {
+	return postJSON(ctx, s.client, s.url, WebhookPayload{
+		ReportKind: notification.ReportKind,
+		ReportName: notification.ReportName,
+		Owner: WebhookOwner{
+			Kind:      string(notification.Owner.Kind),
+			Name:      notification.Owner.Name,
+			Namespace: notification.Owner.Namespace,
+		},
+		Container: notification.Container,
+		Findings:  notification.Findings,
+	})
+}
+
+// SlackPayload is the message posted by the Slack sink.
+type SlackPayload struct {
+	Text string `json:"text"`
+}
+
+type slackSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *slackSink) Send(ctx context.Context, notification Notification) error {
+	var text strings.Builder
+	fmt.Fprintf(&text, "*%s* in %s %s/%s", summary(notification),
+		notification.Owner.Kind, notification.Owner.Namespace, notification.Owner.Name)
+	if notification.Container != "" {
+		fmt.Fprintf(&text, " (container %s)", notification.Container)
+	}
+	for i, finding := range notification.Findings {
+		if i == maxFindingsInMessage {
+			fmt.Fprintf(&text, "\n• and %d more", len(notification.Findings)-maxFindingsInMessage)
+			break
+		}
+		fmt.Fprintf(&text, "\n• %s %s", finding.Severity, finding.ID)
+		if finding.Resource != "" {
+			fmt.Fprintf(&text, " in %s %s", finding.Resource, finding.InstalledVersion)
+		}
+		if finding.Title != "" {
+			fmt.Fprintf(&text, ": %s", finding.Title)
+		}
+	}
+	return postJSON(ctx, s.client, s.url, SlackPayload{Text: text.String()})
+}
+
+type eventSink struct {
+	resolver kube.ObjectResolver
+	recorder record.EventRecorder
+}
+
+func (s *eventSink) Send(ctx context.Context, notification Notification) error {
+	obj, err := s.resolver.ObjectFromObjectRef(ctx, notification.Owner)
+	if err != nil {
+		return fmt.Errorf("getting %s/%s: %w", notification.Owner.Kind, notification.Owner.Name, err)
+	}
+	reason := ReasonNewConfigAuditFailures
+	if notification.ReportKind == v1alpha1.VulnerabilityReportKind {
+		reason = ReasonNewVulnerabilities
+	}
+
+	var ids []string
+	for i, finding := range notification.Findings {
+		if i == maxFindingsInMessage {
+			ids = append(ids, fmt.Sprintf("and %d more", len(notification.Findings)-maxFindingsInMessage))
+			break
+		}
+		ids = append(ids, fmt.Sprintf("%s (%s)", finding.ID, finding.Severity))
+	}
+	message := summary(notification)
+	if notification.Container != "" {
+		message += " in container " + notification.Container
+	}
+	s.recorder.Event(obj, corev1.EventTypeWarning, reason, message+": "+strings.Join(ids, ", "))
+	return nil
+}
+
+// summary returns a short description of the given notification, e.g.
+// 2 new vulnerabilities.
+func summary(notification Notification) string {
+	if notification.ReportKind == v1alpha1.VulnerabilityReportKind {
+		return fmt.Sprintf("%d new vulnerabilities", len(notification.Findings))
+	}
+	return fmt.Sprintf("%d new failing checks", len(notification.Findings))
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/kubebench"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
@@ -33,6 +34,10 @@ import (
 // own benchmark reports, so that it will automatically call the reconcile
 // callback on the underlying corev1.Node when a v1alpha1.CISKubeBenchReport
 // changes, is deleted, etc.
+// kubeBenchScanner is the name of the scanner used to label metrics of
+// CIS Kubernetes Benchmark scan jobs.
+const kubeBenchScanner = "kube-bench"
+
 type CISKubeBenchReportReconciler struct {
 	logr.Logger
 	etc.Config
@@ -107,6 +112,7 @@ func (r *CISKubeBenchReportReconciler) reconcileNodes() reconcile.Func {
 		log.V(1).Info("Checking scan jobs limit", "count", jobsCount, "limit", r.ConcurrentScanJobsLimit)
 
 		if limitExceeded {
+			metrics.ScanJobsThrottled.WithLabelValues(metrics.CISKubeBenchReport).Inc()
 			log.V(1).Info("Pushing back scan job", "count", jobsCount, "retryAfter", r.ScanJobRetryAfter)
 			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
 		}
@@ -124,6 +130,7 @@ func (r *CISKubeBenchReportReconciler) reconcileNodes() reconcile.Func {
 			}
 			return ctrl.Result{}, fmt.Errorf("creating job: %w", err)
 		}
+		metrics.ScanJobsSubmitted.WithLabelValues(metrics.CISKubeBenchReport, kubeBenchScanner).Inc()
 
 		return ctrl.Result{}, nil
 	}
@@ -234,14 +241,20 @@ func (r *CISKubeBenchReportReconciler) reconcileJobs() reconcile.Func {
 			return ctrl.Result{}, nil
 		}
 
+		var status string
 		switch jobCondition := job.Status.Conditions[0].Type; jobCondition {
 		case batchv1.JobComplete:
+			status = metrics.StatusComplete
 			err = r.processCompleteScanJob(ctx, job)
 		case batchv1.JobFailed:
+			status = metrics.StatusFailed
 			err = r.processFailedScanJob(ctx, job)
 		default:
 			err = fmt.Errorf("unrecognized job condition: %v", jobCondition)
 		}
+		if err == nil {
+			metrics.RecordScanJobFinished(metrics.CISKubeBenchReport, kubeBenchScanner, job, status)
+		}
 
 		return ctrl.Result{}, err
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -232,6 +233,7 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 		log.V(1).Info("Checking scan jobs limit", "count", scanJobsCount, "limit", r.ConcurrentScanJobsLimit)
 
 		if limitExceeded {
+			metrics.ScanJobsThrottled.WithLabelValues(metrics.ConfigAuditReport).Inc()
 			log.V(1).Info("Pushing back reconcile key",
 				"reason", "scan jobs limit exceeded",
 				"scanJobsCount", scanJobsCount,
@@ -288,6 +290,7 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			}
 			return ctrl.Result{}, fmt.Errorf("creating job: %w", err)
 		}
+		metrics.ScanJobsSubmitted.WithLabelValues(metrics.ConfigAuditReport, r.PluginContext.GetName()).Inc()
 
 		for _, secret := range secrets {
 			err := controllerutil.SetOwnerReference(job, secret, r.Client.Scheme())
@@ -308,8 +311,7 @@ func (r *ConfigAuditReportReconciler) hasReport(ctx context.Context, owner kube.
 	if kube.IsClusterScopedKind(string(owner.Kind)) {
 		return r.hasClusterReport(ctx, owner, podSpecHash, pluginConfigHash)
 	}
-	// TODO FindByOwner should accept optional label selector to further narrow down search results
-	report, err := r.ReadWriter.FindReportByOwner(ctx, owner)
+	report, err := r.ReadWriter.FindReportByOwnerAndScanner(ctx, owner, r.PluginContext.GetName())
 	if err != nil {
 		return false, err
 	}
@@ -321,7 +323,7 @@ func (r *ConfigAuditReportReconciler) hasReport(ctx context.Context, owner kube.
 }
 
 func (r *ConfigAuditReportReconciler) hasClusterReport(ctx context.Context, owner kube.ObjectRef, podSpecHash string, pluginConfigHash string) (bool, error) {
-	report, err := r.ReadWriter.FindClusterReportByOwner(ctx, owner)
+	report, err := r.ReadWriter.FindClusterReportByOwnerAndScanner(ctx, owner, r.PluginContext.GetName())
 	if err != nil {
 		return false, err
 	}
@@ -368,14 +370,20 @@ func (r *ConfigAuditReportReconciler) reconcileJobs() reconcile.Func {
 			return ctrl.Result{}, nil
 		}
 
+		var status string
 		switch jobCondition := job.Status.Conditions[0].Type; jobCondition {
 		case batchv1.JobComplete:
+			status = metrics.StatusComplete
 			err = r.processCompleteScanJob(ctx, job)
 		case batchv1.JobFailed:
+			status = metrics.StatusFailed
 			err = r.processFailedScanJob(ctx, job)
 		default:
 			err = fmt.Errorf("unrecognized job condition: %v", jobCondition)
 		}
+		if err == nil {
+			metrics.RecordScanJobFinished(metrics.ConfigAuditReport, job.Labels[starboard.LabelConfigAuditReportScanner], job, status)
+		}
 
 		return ctrl.Result{}, err
 	}
@@ -444,6 +452,7 @@ func (r *ConfigAuditReportReconciler) processCompleteScanJob(ctx context.Context
 		Controller(owner).
 		ResourceSpecHash(resourceSpecHash).
 		PluginConfigHash(pluginConfigHash).
+		Scanner(r.PluginContext.GetName()).
 		Data(reportData)
 	err = reportBuilder.Write(ctx, r.ReadWriter)
 	if err != nil {
@@ -75,9 +75,10 @@ func (r *PluginsConfigReconciler) reconcileConfig(kind kube.Kind) reconcile.Func
 			return ctrl.Result{}, fmt.Errorf("getting config hash: %w", err)
 		}
 
-		labelSelector, err := labels.Parse(fmt.Sprintf("%s!=%s,%s=%s",
+		labelSelector, err := labels.Parse(fmt.Sprintf("%s!=%s,%s=%s,%s=%s",
 			starboard.LabelPluginConfigHash, configHash,
-			starboard.LabelResourceKind, kind))
+			starboard.LabelResourceKind, kind,
+			starboard.LabelConfigAuditReportScanner, r.PluginContext.GetName()))
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("parsing label selector: %w", err)
 		}
@@ -135,9 +136,10 @@ func (r *PluginsConfigReconciler) reconcileClusterConfig(kind kube.Kind) reconci
 			return ctrl.Result{}, fmt.Errorf("getting config hash: %w", err)
 		}
 
-		labelSelector, err := labels.Parse(fmt.Sprintf("%s!=%s,%s=%s",
+		labelSelector, err := labels.Parse(fmt.Sprintf("%s!=%s,%s=%s,%s=%s",
 			starboard.LabelPluginConfigHash, configHash,
-			starboard.LabelResourceKind, kind))
+			starboard.LabelResourceKind, kind,
+			starboard.LabelConfigAuditReportScanner, r.PluginContext.GetName()))
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("parsing label selector: %w", err)
 		}
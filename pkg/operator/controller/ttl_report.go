@@ -11,6 +11,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -24,6 +25,7 @@ type TTLReportReconciler struct {
 	etc.Config
 	client.Client
 	ext.Clock
+	starboard.ConfigData
 }
 
 func (r *TTLReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -32,13 +34,35 @@ func (r *TTLReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	err = ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.VulnerabilityReport{}, builder.WithPredicates(
-			predicate.Not(predicate.IsBeingTerminated),
-			installModePredicate)).
-		Complete(r.reconcileReport())
-	if err != nil {
-		return err
+	if r.Config.VulnerabilityScannerReportTTL != nil {
+		err = ctrl.NewControllerManagedBy(mgr).
+			For(&v1alpha1.VulnerabilityReport{}, builder.WithPredicates(
+				predicate.Not(predicate.IsBeingTerminated),
+				installModePredicate)).
+			Complete(r.reconcileReport())
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.Config.VulnerabilityScannerCacheEnabled {
+		err = ctrl.NewControllerManagedBy(mgr).
+			For(&v1alpha1.ClusterVulnerabilityReport{}, builder.WithPredicates(
+				predicate.Not(predicate.IsBeingTerminated))).
+			Complete(r.reconcileClusterReport())
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.Config.VulnerabilityScannerCacheEnabled && r.ConfigData.SbomReportsEnabled() {
+		err = ctrl.NewControllerManagedBy(mgr).
+			For(&v1alpha1.ClusterSbomReport{}, builder.WithPredicates(
+				predicate.Not(predicate.IsBeingTerminated))).
+			Complete(r.reconcileClusterSbomReport())
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -57,27 +81,67 @@ func (r *TTLReportReconciler) reconcileReport() reconcile.Func {
 			return ctrl.Result{}, fmt.Errorf("getting report from cache: %w", err)
 		}
 
-		ttlReportAnnotationStr, ok := report.Annotations[v1alpha1.TTLReportAnnotation]
-		if !ok {
-			log.V(1).Info("Ignoring report without TTL set")
-			return ctrl.Result{}, nil
-		}
+		return r.deleteReportIfTTLExpired(ctx, log, report, report.Report.UpdateTimestamp.Time)
+	}
+}
+
+func (r *TTLReportReconciler) reconcileClusterReport() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("report", req.Name)
 
-		reportTTLTime, err := time.ParseDuration(ttlReportAnnotationStr)
+		report := &v1alpha1.ClusterVulnerabilityReport{}
+		err := r.Client.Get(ctx, req.NamespacedName, report)
 		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed parsing %v with value %v %w", v1alpha1.TTLReportAnnotation, ttlReportAnnotationStr, err)
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached report that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting report from cache: %w", err)
 		}
-		ttlExpired, durationToTTLExpiration := utils.IsTTLExpired(reportTTLTime, report.Report.UpdateTimestamp.Time, r.Clock)
-		if ttlExpired {
-			log.V(1).Info("Removing vulnerabilityReport with expired TTL")
-			err := r.Client.Delete(ctx, report, &client.DeleteOptions{})
-			if err != nil && !errors.IsNotFound(err) {
-				return ctrl.Result{}, err
+
+		return r.deleteReportIfTTLExpired(ctx, log, report, report.Report.UpdateTimestamp.Time)
+	}
+}
+
+func (r *TTLReportReconciler) reconcileClusterSbomReport() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("report", req.Name)
+
+		report := &v1alpha1.ClusterSbomReport{}
+		err := r.Client.Get(ctx, req.NamespacedName, report)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached report that must have been deleted")
+				return ctrl.Result{}, nil
 			}
-			// Since the report is deleted there is no reason to requeue
-			return ctrl.Result{}, nil
+			return ctrl.Result{}, fmt.Errorf("getting report from cache: %w", err)
+		}
+
+		return r.deleteReportIfTTLExpired(ctx, log, report, report.Report.UpdateTimestamp.Time)
+	}
+}
+
+func (r *TTLReportReconciler) deleteReportIfTTLExpired(ctx context.Context, log logr.Logger, report client.Object, updateTimestamp time.Time) (ctrl.Result, error) {
+	ttlReportAnnotationStr, ok := report.GetAnnotations()[v1alpha1.TTLReportAnnotation]
+	if !ok {
+		log.V(1).Info("Ignoring report without TTL set")
+		return ctrl.Result{}, nil
+	}
+
+	reportTTLTime, err := time.ParseDuration(ttlReportAnnotationStr)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed parsing %v with value %v %w", v1alpha1.TTLReportAnnotation, ttlReportAnnotationStr, err)
+	}
+	ttlExpired, durationToTTLExpiration := utils.IsTTLExpired(reportTTLTime, updateTimestamp, r.Clock)
+	if ttlExpired {
+		log.V(1).Info("Removing report with expired TTL")
+		err := r.Client.Delete(ctx, report, &client.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
 		}
-		log.V(1).Info("RequeueAfter", "durationToTTLExpiration", durationToTTLExpiration)
-		return ctrl.Result{RequeueAfter: durationToTTLExpiration}, nil
+		// Since the report is deleted there is no reason to requeue
+		return ctrl.Result{}, nil
 	}
+	log.V(1).Info("RequeueAfter", "durationToTTLExpiration", durationToTTLExpiration)
+	return ctrl.Result{RequeueAfter: durationToTTLExpiration}, nil
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/caarlos0/env/v6"
+	"github.com/gorhill/cronexpr"
 )
 
 // Config defines parameters for running the operator.
@@ -21,25 +22,91 @@ type Config struct {
 	BatchDeleteLimit                             int            `env:"OPERATOR_BATCH_DELETE_LIMIT" envDefault:"10"`
 	BatchDeleteDelay                             time.Duration  `env:"OPERATOR_BATCH_DELETE_DELAY" envDefault:"10s"`
 	MetricsBindAddress                           string         `env:"OPERATOR_METRICS_BIND_ADDRESS" envDefault:":8080"`
+	MetricsFindingsEnabled                       bool           `env:"OPERATOR_METRICS_FINDINGS_ENABLED" envDefault:"true"`
 	HealthProbeBindAddress                       string         `env:"OPERATOR_HEALTH_PROBE_BIND_ADDRESS" envDefault:":9090"`
 	CISKubernetesBenchmarkEnabled                bool           `env:"OPERATOR_CIS_KUBERNETES_BENCHMARK_ENABLED" envDefault:"true"`
 	VulnerabilityScannerEnabled                  bool           `env:"OPERATOR_VULNERABILITY_SCANNER_ENABLED" envDefault:"true"`
 	VulnerabilityScannerScanOnlyCurrentRevisions bool           `env:"OPERATOR_VULNERABILITY_SCANNER_SCAN_ONLY_CURRENT_REVISIONS" envDefault:"false"`
 	VulnerabilityScannerReportTTL                *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL"`
+	VulnerabilityScannerCacheEnabled             bool           `env:"OPERATOR_VULNERABILITY_SCANNER_CACHE_ENABLED" envDefault:"false"`
+	VulnerabilityScannerCacheReportTTL           time.Duration  `env:"OPERATOR_VULNERABILITY_SCANNER_CACHE_REPORT_TTL" envDefault:"72h"`
+	VulnerabilityScannerRescanInterval           *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_RESCAN_INTERVAL"`
+	VulnerabilityScannerRescanSchedule           string         `env:"OPERATOR_VULNERABILITY_SCANNER_RESCAN_SCHEDULE"`
+	VulnerabilityScannerRescanJitter             time.Duration  `env:"OPERATOR_VULNERABILITY_SCANNER_RESCAN_JITTER" envDefault:"1h"`
+	VulnerabilityScannerScanImageDigests         bool           `env:"OPERATOR_VULNERABILITY_SCANNER_SCAN_IMAGE_DIGESTS" envDefault:"true"`
+	VulnerabilityScannerDriftCheckInterval       *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_DRIFT_CHECK_INTERVAL"`
 	ClusterComplianceEnabled                     bool           `env:"OPERATOR_CLUSTER_COMPLIANCE_ENABLED" envDefault:"true"`
+	NamespaceSecurityReportEnabled               bool           `env:"OPERATOR_NAMESPACE_SECURITY_REPORT_ENABLED" envDefault:"true"`
 	ConfigAuditScannerEnabled                    bool           `env:"OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED" envDefault:"false"`
 	ConfigAuditScannerScanOnlyCurrentRevisions   bool           `env:"OPERATOR_CONFIG_AUDIT_SCANNER_SCAN_ONLY_CURRENT_REVISIONS" envDefault:"false"`
 
 	// ConfigAuditScannerBuiltIn tells Starboard to use the built-in
-	// configuration audit scanner instead of Polaris or Conftest
-	// plugins.
+	// configuration audit scanner.
 	//
-	// You cannot use Polaris or Conftest and the built-in scanner at the same
-	// time. The built-in scanners is much faster and does not create Kubernetes
-	// Job objects to perform scans asynchronously. Instead, it evaluates OPA
-	// Rego policies synchronously within the reconciliation loop.
+	// The built-in scanner is much faster than Polaris or Conftest plugins and
+	// does not create Kubernetes Job objects to perform scans asynchronously.
+	// Instead, it evaluates OPA Rego policies synchronously within the
+	// reconciliation loop. It can be used together with a plugin enabled by
+	// ConfigAuditScannerEnabled, in which case each scanner generates its own
+	// reports.
 	ConfigAuditScannerBuiltIn bool `env:"OPERATOR_CONFIG_AUDIT_SCANNER_BUILTIN" envDefault:"true"`
 
+	// ConfigAuditScannerPoliciesReloadInterval is the interval of reloading
+	// the bundle of policies configured with the
+	// configAuditReports.policies.bundle key of the starboard ConfigMap.
+	ConfigAuditScannerPoliciesReloadInterval time.Duration `env:"OPERATOR_CONFIG_AUDIT_SCANNER_POLICIES_RELOAD_INTERVAL" envDefault:"5m"`
+
+	// VulnerabilityScannerBuiltIn tells Starboard to use the built-in
+	// vulnerability scanner instead of the plugin configured in the
+	// starboard ConfigMap.
+	//
+	// The built-in scanner does not create Kubernetes Job objects. Instead, it
+	// pulls container images and matches installed packages against the OSV
+	// database mirrored to VulnerabilityScannerBuiltInDBDir within the
+	// reconciliation loop.
+	VulnerabilityScannerBuiltIn      bool   `env:"OPERATOR_VULNERABILITY_SCANNER_BUILTIN" envDefault:"false"`
+	VulnerabilityScannerBuiltInDBDir string `env:"OPERATOR_VULNERABILITY_SCANNER_BUILTIN_DB_DIR" envDefault:"/var/lib/starboard/vulndb"`
+
+	// NodeVulnerabilityScannerEnabled tells Starboard to scan root file
+	// systems of cluster nodes with the Trivy plugin and to check versions of
+	// the Kubernetes API server and kubelets against known Kubernetes
+	// vulnerabilities.
+	//
+	// Nodes are rescanned when their system information changes, e.g. after
+	// an upgrade, or when their reports are older than
+	// NodeVulnerabilityScannerRescanInterval, which is also the interval of
+	// checking the version of the API server.
+	NodeVulnerabilityScannerEnabled        bool          `env:"OPERATOR_NODE_VULNERABILITY_SCANNER_ENABLED" envDefault:"false"`
+	NodeVulnerabilityScannerRescanInterval time.Duration `env:"OPERATOR_NODE_VULNERABILITY_SCANNER_RESCAN_INTERVAL" envDefault:"24h"`
+
+	// HarborAdapterEnabled tells Starboard to serve the Harbor Scanner Adapter
+	// API v1.0 on HarborAdapterBindAddress, so that Harbor registries can
+	// scan artifacts with the vulnerability scanner plugin configured for
+	// Starboard. Results are shared with the cache of
+	// ClusterVulnerabilityReports.
+	//
+	// The API is served over TLS with the tls.crt and tls.key files in
+	// HarborAdapterSecretDir, and requests must carry the bearer token read
+	// from the token file in the same directory.
+	HarborAdapterEnabled     bool   `env:"OPERATOR_HARBOR_ADAPTER_ENABLED" envDefault:"false"`
+	HarborAdapterBindAddress string `env:"OPERATOR_HARBOR_ADAPTER_BIND_ADDRESS" envDefault:":8090"`
+	HarborAdapterSecretDir   string `env:"OPERATOR_HARBOR_ADAPTER_SECRET_DIR" envDefault:"/tmp/harbor-adapter/secret"`
+
+	// WebhookEnabled tells Starboard to serve a validating admission webhook,
+	// which denies workloads that violate the admission policy based on
+	// existing VulnerabilityReports and configuration checks.
+	//
+	// The webhook server listens on WebhookBindPort and reads the TLS
+	// certificate and key from WebhookCertDir.
+	WebhookEnabled  bool   `env:"OPERATOR_WEBHOOK_ENABLED" envDefault:"false"`
+	WebhookBindPort int    `env:"OPERATOR_WEBHOOK_BIND_PORT" envDefault:"9443"`
+	WebhookCertDir  string `env:"OPERATOR_WEBHOOK_CERT_DIR" envDefault:"/tmp/k8s-webhook-server/serving-certs"`
+
+	// PluginsDir is the directory of executables of out-of-tree plugins,
+	// which are selected with the plugins.<name>.command key of the starboard
+	// ConfigMap. Out-of-tree plugins are disabled unless it's set.
+	PluginsDir string `env:"OPERATOR_PLUGINS_DIR"`
+
 	LeaderElectionEnabled bool   `env:"OPERATOR_LEADER_ELECTION_ENABLED" envDefault:"false"`
 	LeaderElectionID      string `env:"OPERATOR_LEADER_ELECTION_ID" envDefault:"starboard-lock"`
 }
@@ -49,8 +116,14 @@ func GetOperatorConfig() (Config, error) {
 	var config Config
 	err := env.Parse(&config)
 
-	if config.ConfigAuditScannerEnabled && config.ConfigAuditScannerBuiltIn {
-		return Config{}, fmt.Errorf("plugin-based and built-in configuration audit scanners cannot be enabled at the same time")
+	if config.VulnerabilityScannerRescanInterval != nil && config.VulnerabilityScannerRescanSchedule != "" {
+		return Config{}, fmt.Errorf("vulnerability rescan interval and schedule cannot be set at the same time")
+	}
+
+	if config.VulnerabilityScannerRescanSchedule != "" {
+		if _, err := cronexpr.Parse(config.VulnerabilityScannerRescanSchedule); err != nil {
+			return Config{}, fmt.Errorf("parsing vulnerability rescan schedule: %w", err)
+		}
 	}
 
 	return config, err
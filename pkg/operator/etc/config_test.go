@@ -1,7 +1,6 @@
 package etc_test
 
 import (
-	"os"
 	"testing"
 
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
@@ -11,11 +10,28 @@ import (
 
 func TestGetOperatorConfig(t *testing.T) {
 
-	t.Run("Should return error when plugin-based and built-in scanners are enabled", func(t *testing.T) {
-		os.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "true")
-		os.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_BUILTIN", "true")
+	t.Run("Should enable plugin-based and built-in scanners at the same time", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "true")
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_BUILTIN", "true")
+		config, err := etc.GetOperatorConfig()
+		require.NoError(t, err)
+		assert.True(t, config.ConfigAuditScannerEnabled)
+		assert.True(t, config.ConfigAuditScannerBuiltIn)
+	})
+
+	t.Run("Should return error when rescan interval and schedule are set", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		t.Setenv("OPERATOR_VULNERABILITY_SCANNER_RESCAN_INTERVAL", "24h")
+		t.Setenv("OPERATOR_VULNERABILITY_SCANNER_RESCAN_SCHEDULE", "0 2 * * *")
+		_, err := etc.GetOperatorConfig()
+		assert.EqualError(t, err, "vulnerability rescan interval and schedule cannot be set at the same time")
+	})
+
+	t.Run("Should return error when rescan schedule is invalid", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		t.Setenv("OPERATOR_VULNERABILITY_SCANNER_RESCAN_SCHEDULE", "* *")
 		_, err := etc.GetOperatorConfig()
-		assert.EqualError(t, err, "plugin-based and built-in configuration audit scanners cannot be enabled at the same time")
+		assert.EqualError(t, err, "parsing vulnerability rescan schedule: missing field(s)")
 	})
 
 }
@@ -0,0 +1,210 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	vulnerabilitiesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "vulnerabilities"),
+		"Number of vulnerabilities in a container image of a workload, partitioned by severity.",
+		[]string{"namespace", "resource_kind", "resource_name", "container_name",
+			"image_registry", "image_repository", "image_tag", "image_digest", "severity"},
+		nil,
+	)
+	configAuditCheckFailuresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "config_audit_check_failures"),
+		"Number of resources that failed a configuration audit check. Cluster-scoped resources have an empty namespace.",
+		[]string{"namespace", "check_id", "severity"},
+		nil,
+	)
+	cisKubeBenchChecksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cis_kube_bench_checks"),
+		"Number of CIS Kubernetes Benchmark checks run on a node, partitioned by status.",
+		[]string{"node_name", "status"},
+		nil,
+	)
+	complianceControlChecksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "compliance_control_checks"),
+		"Number of resources that passed or failed a control of a cluster compliance report.",
+		[]string{"report", "control_id", "control_name", "severity", "status"},
+		nil,
+	)
+)
+
+// ReportsCollector is a prometheus.Collector that exposes summaries of
+// security reports as gauges. Reports are listed from the informers cache
+// maintained by the controllers manager on each scrape, therefore
+// collecting metrics does not send requests to the Kubernetes API server.
+type ReportsCollector struct {
+	logr.Logger
+	etc.Config
+	client.Client
+}
+
+// NewReportsCollector constructs a new ReportsCollector, which reads reports
+// generated by scanners enabled in the given config.
+func NewReportsCollector(logger logr.Logger, config etc.Config, client client.Client) *ReportsCollector {
+	return &ReportsCollector{
+		Logger: logger,
+		Config: config,
+		Client: client,
+	}
+}
+
+func (c *ReportsCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- vulnerabilitiesDesc
+	descs <- configAuditCheckFailuresDesc
+	descs <- cisKubeBenchChecksDesc
+	descs <- complianceControlChecksDesc
+}
+
+func (c *ReportsCollector) Collect(metrics chan<- prometheus.Metric) {
+	ctx := context.Background()
+	if c.Config.VulnerabilityScannerEnabled {
+		c.collectVulnerabilityReports(ctx, metrics)
+	}
+	if c.Config.ConfigAuditScannerEnabled || c.Config.ConfigAuditScannerBuiltIn {
+		c.collectConfigAuditReports(ctx, metrics)
+	}
+	if c.Config.CISKubernetesBenchmarkEnabled {
+		c.collectCISKubeBenchReports(ctx, metrics)
+	}
+	if c.Config.ClusterComplianceEnabled {
+		c.collectClusterComplianceReports(ctx, metrics)
+	}
+}
+
+func (c *ReportsCollector) collectVulnerabilityReports(ctx context.Context, metrics chan<- prometheus.Metric) {
+	var list v1alpha1.VulnerabilityReportList
+	if err := c.List(ctx, &list); err != nil {
+		c.Logger.Error(err, "Unable to list vulnerabilityreports")
+		return
+	}
+	for _, report := range list.Items {
+		// Reports of additional scanners would yield metrics with the same
+		// label values as reports of the primary scanner or merged reports.
+		if _, ok := report.Labels[starboard.LabelVulnerabilityReportAdditional]; ok {
+			continue
+		}
+		labelValues := []string{
+			report.Namespace,
+			report.Labels[starboard.LabelResourceKind],
+			report.Labels[starboard.LabelResourceName],
+			report.Labels[starboard.LabelContainerName],
+			report.Report.Registry.Server,
+			report.Report.Artifact.Repository,
+			report.Report.Artifact.Tag,
+			report.Report.Artifact.Digest,
+		}
+		summary := report.Report.Summary
+		for severity, count := range map[v1alpha1.Severity]int{
+			v1alpha1.SeverityCritical: summary.CriticalCount,
+			v1alpha1.SeverityHigh:     summary.HighCount,
+			v1alpha1.SeverityMedium:   summary.MediumCount,
+			v1alpha1.SeverityLow:      summary.LowCount,
+			v1alpha1.SeverityUnknown:  summary.UnknownCount,
+		} {
+			metrics <- prometheus.MustNewConstMetric(vulnerabilitiesDesc, prometheus.GaugeValue,
+				float64(count), append(labelValues, string(severity))...)
+		}
+	}
+}
+
+func (c *ReportsCollector) collectConfigAuditReports(ctx context.Context, metrics chan<- prometheus.Metric) {
+	type failure struct {
+		namespace string
+		checkID   string
+		severity  v1alpha1.Severity
+	}
+	failures := map[failure]int{}
+
+	var list v1alpha1.ConfigAuditReportList
+	if err := c.List(ctx, &list); err != nil {
+		c.Logger.Error(err, "Unable to list configauditreports")
+		return
+	}
+	for _, report := range list.Items {
+		// Reports of an additional scanner would count failed checks of
+		// resources twice.
+		if _, ok := report.Labels[starboard.LabelConfigAuditReportAdditional]; ok {
+			continue
+		}
+		for _, check := range report.Report.Checks {
+			if !check.Success {
+				failures[failure{namespace: report.Namespace, checkID: check.ID, severity: check.Severity}]++
+			}
+		}
+	}
+
+	var clusterList v1alpha1.ClusterConfigAuditReportList
+	if err := c.List(ctx, &clusterList); err != nil {
+		c.Logger.Error(err, "Unable to list clusterconfigauditreports")
+		return
+	}
+	for _, report := range clusterList.Items {
+		if _, ok := report.Labels[starboard.LabelConfigAuditReportAdditional]; ok {
+			continue
+		}
+		for _, check := range report.Report.Checks {
+			if !check.Success {
+				failures[failure{checkID: check.ID, severity: check.Severity}]++
+			}
+		}
+	}
+
+	for f, count := range failures {
+		metrics <- prometheus.MustNewConstMetric(configAuditCheckFailuresDesc, prometheus.GaugeValue,
+			float64(count), f.namespace, f.checkID, string(f.severity))
+	}
+}
+
+func (c *ReportsCollector) collectCISKubeBenchReports(ctx context.Context, metrics chan<- prometheus.Metric) {
+	var list v1alpha1.CISKubeBenchReportList
+	if err := c.List(ctx, &list); err != nil {
+		c.Logger.Error(err, "Unable to list ciskubebenchreports")
+		return
+	}
+	for _, report := range list.Items {
+		nodeName := report.Labels[starboard.LabelResourceName]
+		if nodeName == "" {
+			nodeName = report.Name
+		}
+		summary := report.Report.Summary
+		for _, status := range []struct {
+			name  string
+			count int
+		}{
+			{name: "PASS", count: summary.PassCount},
+			{name: "FAIL", count: summary.FailCount},
+			{name: "WARN", count: summary.WarnCount},
+			{name: "INFO", count: summary.InfoCount},
+		} {
+			metrics <- prometheus.MustNewConstMetric(cisKubeBenchChecksDesc, prometheus.GaugeValue,
+				float64(status.count), nodeName, status.name)
+		}
+	}
+}
+
+func (c *ReportsCollector) collectClusterComplianceReports(ctx context.Context, metrics chan<- prometheus.Metric) {
+	var list v1alpha1.ClusterComplianceReportList
+	if err := c.List(ctx, &list); err != nil {
+		c.Logger.Error(err, "Unable to list clustercompliancereports")
+		return
+	}
+	for _, report := range list.Items {
+		for _, control := range report.Status.ControlChecks {
+			metrics <- prometheus.MustNewConstMetric(complianceControlChecksDesc, prometheus.GaugeValue,
+				float64(control.PassTotal), report.Name, control.ID, control.Name, string(control.Severity), string(v1alpha1.PassStatus))
+			metrics <- prometheus.MustNewConstMetric(complianceControlChecksDesc, prometheus.GaugeValue,
+				float64(control.FailTotal), report.Name, control.ID, control.Name, string(control.Severity), string(v1alpha1.FailStatus))
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReportsCollector(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6-nginx",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:  "ReplicaSet",
+					starboard.LabelResourceName:  "nginx-6d4cf56db6",
+					starboard.LabelContainerName: "nginx",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+				Summary: v1alpha1.VulnerabilitySummary{
+					CriticalCount: 2,
+					HighCount:     5,
+					MediumCount:   7,
+					LowCount:      11,
+					UnknownCount:  1,
+				},
+			},
+		},
+		&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6-nginx-grype",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:                  "ReplicaSet",
+					starboard.LabelResourceName:                  "nginx-6d4cf56db6",
+					starboard.LabelContainerName:                 "nginx",
+					starboard.LabelVulnerabilityReportScanner:    "Grype",
+					starboard.LabelVulnerabilityReportAdditional: "true",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+				Summary: v1alpha1.VulnerabilitySummary{
+					CriticalCount: 3,
+				},
+			},
+		},
+		&v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6",
+				Namespace: "default",
+			},
+			Report: v1alpha1.ConfigAuditReportData{
+				Checks: []v1alpha1.Check{
+					{ID: "KSV001", Severity: v1alpha1.SeverityMedium, Success: false},
+					{ID: "KSV002", Severity: v1alpha1.SeverityMedium, Success: true},
+				},
+			},
+		},
+		&v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6-starboard",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelConfigAuditReportScanner:    "Starboard",
+					starboard.LabelConfigAuditReportAdditional: "true",
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{
+				Checks: []v1alpha1.Check{
+					{ID: "KSV001", Severity: v1alpha1.SeverityMedium, Success: false},
+				},
+			},
+		},
+		&v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-redis-7c5ddbdf54",
+				Namespace: "default",
+			},
+			Report: v1alpha1.ConfigAuditReportData{
+				Checks: []v1alpha1.Check{
+					{ID: "KSV001", Severity: v1alpha1.SeverityMedium, Success: false},
+				},
+			},
+		},
+		&v1alpha1.ClusterConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "clusterrole-admin",
+			},
+			Report: v1alpha1.ConfigAuditReportData{
+				Checks: []v1alpha1.Check{
+					{ID: "KSV046", Severity: v1alpha1.SeverityCritical, Success: false},
+				},
+			},
+		},
+		&v1alpha1.CISKubeBenchReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kind-control-plane",
+				Labels: map[string]string{
+					starboard.LabelResourceKind: "Node",
+					starboard.LabelResourceName: "kind-control-plane",
+				},
+			},
+			Report: v1alpha1.CISKubeBenchReportData{
+				Summary: v1alpha1.CISKubeBenchSummary{PassCount: 40, FailCount: 12, WarnCount: 30, InfoCount: 0},
+			},
+		},
+		&v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "nsa",
+			},
+			Status: v1alpha1.ReportStatus{
+				ControlChecks: []v1alpha1.ControlCheck{
+					{ID: "1.0", Name: "Non-root containers", Severity: v1alpha1.SeverityMedium, PassTotal: 3, FailTotal: 1},
+				},
+			},
+		},
+	).Build()
+
+	collector := metrics.NewReportsCollector(logr.Discard(), etc.Config{
+		VulnerabilityScannerEnabled:   true,
+		ConfigAuditScannerBuiltIn:     true,
+		CISKubernetesBenchmarkEnabled: true,
+		ClusterComplianceEnabled:      true,
+	}, client)
+
+	expected := `
+# HELP starboard_cis_kube_bench_checks Number of CIS Kubernetes Benchmark checks run on a node, partitioned by status.
+# TYPE starboard_cis_kube_bench_checks gauge
+starboard_cis_kube_bench_checks{node_name="kind-control-plane",status="FAIL"} 12
+starboard_cis_kube_bench_checks{node_name="kind-control-plane",status="INFO"} 0
+starboard_cis_kube_bench_checks{node_name="kind-control-plane",status="PASS"} 40
+starboard_cis_kube_bench_checks{node_name="kind-control-plane",status="WARN"} 30
+# HELP starboard_compliance_control_checks Number of resources that passed or failed a control of a cluster compliance report.
+# TYPE starboard_compliance_control_checks gauge
+starboard_compliance_control_checks{control_id="1.0",control_name="Non-root containers",report="nsa",severity="MEDIUM",status="FAIL"} 1
+starboard_compliance_control_checks{control_id="1.0",control_name="Non-root containers",report="nsa",severity="MEDIUM",status="PASS"} 3
+# HELP starboard_config_audit_check_failures Number of resources that failed a configuration audit check. Cluster-scoped resources have an empty namespace.
+# TYPE starboard_config_audit_check_failures gauge
+starboard_config_audit_check_failures{check_id="KSV001",namespace="default",severity="MEDIUM"} 2
+starboard_config_audit_check_failures{check_id="KSV046",namespace="",severity="CRITICAL"} 1
+# HELP starboard_vulnerabilities Number of vulnerabilities in a container image of a workload, partitioned by severity.
+# TYPE starboard_vulnerabilities gauge
+starboard_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",severity="CRITICAL"} 2
+starboard_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",severity="HIGH"} 5
+starboard_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",severity="LOW"} 11
+starboard_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",severity="MEDIUM"} 7
+starboard_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",severity="UNKNOWN"} 1
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+	assert.NoError(t, err)
+}
+
+func TestReportsCollector_DisabledScanners(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6-nginx",
+				Namespace: "default",
+			},
+		},
+	).Build()
+
+	collector := metrics.NewReportsCollector(logr.Discard(), etc.Config{}, client)
+	assert.Equal(t, 0, testutil.CollectAndCount(collector))
+}
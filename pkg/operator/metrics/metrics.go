@@ -0,0 +1,120 @@
+// Package metrics provides Prometheus metrics exposed by Starboard Operator.
+//
+// Metrics are registered with the controller-runtime registry and served
+// at the address configured with OPERATOR_METRICS_BIND_ADDRESS.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "starboard"
+)
+
+// Types of reports generated by scan jobs.
+const (
+	VulnerabilityReport     = "vulnerabilityreport"
+	NodeVulnerabilityReport = "nodevulnerabilityreport"
+	ConfigAuditReport       = "configauditreport"
+	CISKubeBenchReport      = "ciskubebenchreport"
+)
+
+// Statuses of finished scan jobs.
+const (
+	StatusComplete = "complete"
+	StatusFailed   = "failed"
+)
+
+var (
+	// ScanJobsSubmitted counts scan jobs created by the operator.
+	ScanJobsSubmitted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scan_jobs_submitted_total",
+		Help:      "Total number of scan jobs submitted by the operator.",
+	}, []string{"type", "scanner"})
+
+	// ScanJobsFinished counts scan jobs that completed or failed.
+	ScanJobsFinished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scan_jobs_finished_total",
+		Help:      "Total number of scan jobs that finished, partitioned by status.",
+	}, []string{"type", "scanner", "status"})
+
+	// ScanJobDuration observes the time elapsed between the start of a scan
+	// job and its completion or failure.
+	ScanJobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scan_job_duration_seconds",
+		Help:      "Duration of scan jobs in seconds, partitioned by status.",
+		Buckets:   prometheus.ExponentialBuckets(5, 2, 10),
+	}, []string{"type", "scanner", "status"})
+
+	// ScanJobsThrottled counts scan jobs pushed back because the limit of
+	// concurrent scan jobs was exceeded.
+	ScanJobsThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scan_jobs_throttled_total",
+		Help:      "Total number of scan jobs pushed back because the concurrent scan jobs limit was exceeded.",
+	}, []string{"type"})
+
+	// PolicyEvaluationDuration observes the time of evaluating config audit
+	// policies by the built-in scanner.
+	PolicyEvaluationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "policy_evaluation_duration_seconds",
+		Help:      "Duration of evaluating config audit policies for a resource in seconds, partitioned by resource kind.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 12),
+	}, []string{"kind"})
+
+	// PolicyCompilationDuration observes the time of compiling and preparing
+	// queries for config audit policies, which happens only when policies
+	// change.
+	PolicyCompilationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "policy_compilation_duration_seconds",
+		Help:      "Duration of compiling config audit policies applicable to a resource kind in seconds.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"kind"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		ScanJobsSubmitted,
+		ScanJobsFinished,
+		ScanJobDuration,
+		ScanJobsThrottled,
+		PolicyEvaluationDuration,
+		PolicyCompilationDuration,
+	)
+}
+
+// RecordScanJobFinished records the status and duration of the given scan job.
+// The duration is not observed if the start or finish time of the job is
+// unknown.
+func RecordScanJobFinished(reportType, scanner string, job *batchv1.Job, status string) {
+	ScanJobsFinished.WithLabelValues(reportType, scanner, status).Inc()
+
+	if job.Status.StartTime == nil || len(job.Status.Conditions) == 0 {
+		return
+	}
+	finishTime := job.Status.Conditions[0].LastTransitionTime.Time
+	if job.Status.CompletionTime != nil {
+		finishTime = job.Status.CompletionTime.Time
+	}
+	if finishTime.Before(job.Status.StartTime.Time) {
+		return
+	}
+	ScanJobDuration.WithLabelValues(reportType, scanner, status).
+		Observe(finishTime.Sub(job.Status.StartTime.Time).Seconds())
+}
+
+// RecordPolicyCompilation records the duration of compiling policies
+// applicable to the given kind of resources.
+func RecordPolicyCompilation(kind string, duration time.Duration) {
+	PolicyCompilationDuration.WithLabelValues(kind).Observe(duration.Seconds())
+}
@@ -0,0 +1,49 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecordScanJobFinished(t *testing.T) {
+	startTime := time.Date(2022, time.June, 1, 10, 30, 0, 0, time.UTC)
+
+	metrics.RecordScanJobFinished(metrics.VulnerabilityReport, "Trivy", &batchv1.Job{
+		Status: batchv1.JobStatus{
+			StartTime:      &metav1.Time{Time: startTime},
+			CompletionTime: &metav1.Time{Time: startTime.Add(40 * time.Second)},
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete},
+			},
+		},
+	}, metrics.StatusComplete)
+
+	metrics.RecordScanJobFinished(metrics.VulnerabilityReport, "Trivy", &batchv1.Job{
+		Status: batchv1.JobStatus{
+			StartTime: &metav1.Time{Time: startTime},
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, LastTransitionTime: metav1.Time{Time: startTime.Add(10 * time.Second)}},
+			},
+		},
+	}, metrics.StatusFailed)
+
+	metrics.RecordScanJobFinished(metrics.VulnerabilityReport, "Trivy", &batchv1.Job{}, metrics.StatusFailed)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.ScanJobsFinished.WithLabelValues(metrics.VulnerabilityReport, "Trivy", metrics.StatusComplete)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.ScanJobsFinished.WithLabelValues(metrics.VulnerabilityReport, "Trivy", metrics.StatusFailed)))
+	// Only jobs with known start and finish time are observed.
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.ScanJobDuration))
+}
+
+func TestRecordPolicyCompilation(t *testing.T) {
+	metrics.RecordPolicyCompilation("Deployment", 250*time.Millisecond)
+	metrics.RecordPolicyCompilation("Pod", 100*time.Millisecond)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.PolicyCompilationDuration))
+}
@@ -4,22 +4,33 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aquasecurity/starboard/pkg/admission"
 	"github.com/aquasecurity/starboard/pkg/compliance"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/docker"
 	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/harbor"
+	"github.com/aquasecurity/starboard/pkg/history"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/aquasecurity/starboard/pkg/namespacesecurityreport"
+	"github.com/aquasecurity/starboard/pkg/notification"
 	"github.com/aquasecurity/starboard/pkg/operator/controller"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
 	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/policy"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport/builtin"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 var (
@@ -45,6 +56,11 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		HealthProbeBindAddress: operatorConfig.HealthProbeBindAddress,
 	}
 
+	if operatorConfig.WebhookEnabled {
+		options.Port = operatorConfig.WebhookBindPort
+		options.CertDir = operatorConfig.WebhookCertDir
+	}
+
 	if operatorConfig.LeaderElectionEnabled {
 		options.LeaderElection = operatorConfig.LeaderElectionEnabled
 		options.LeaderElectionID = operatorConfig.LeaderElectionID
@@ -104,6 +120,11 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		return fmt.Errorf("constructing controllers manager: %w", err)
 	}
 
+	if operatorConfig.MetricsFindingsEnabled {
+		crmetrics.Registry.MustRegister(metrics.NewReportsCollector(
+			ctrl.Log.WithName("metrics"), operatorConfig, mgr.GetClient()))
+	}
+
 	err = mgr.AddReadyzCheck("ping", healthz.Ping)
 	if err != nil {
 		return err
@@ -127,48 +148,138 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 
 	objectResolver := kube.ObjectResolver{Client: mgr.GetClient()}
 	limitChecker := controller.NewLimitChecker(operatorConfig, mgr.GetClient(), starboardConfig)
+
+	sinkConfigs, err := notification.NewSinkConfigs(starboardConfig)
+	if err != nil {
+		return fmt.Errorf("parsing notification sinks: %w", err)
+	}
+	var notifier notification.Notifier
+	if len(sinkConfigs) > 0 {
+		setupLog.Info("Enabling notifications", "sinks", len(sinkConfigs))
+		sinks, err := notification.NewNotifier(ctrl.Log.WithName("notification"), sinkConfigs,
+			objectResolver, mgr.GetEventRecorderFor("starboard-operator"))
+		if err != nil {
+			return fmt.Errorf("initializing notification sinks: %w", err)
+		}
+		// Notifications are sent in the background, so that writing reports
+		// does not wait for sinks.
+		queue := notification.NewQueue(ctrl.Log.WithName("notification"), sinks, notification.DefaultQueueSize)
+		if err = mgr.Add(queue); err != nil {
+			return fmt.Errorf("unable to add notification queue: %w", err)
+		}
+		notifier = queue
+	}
 	logsReader := kube.NewLogsReader(kubeClientset)
 	secretsReader := kube.NewSecretsReader(mgr.GetClient())
 
 	if operatorConfig.VulnerabilityScannerEnabled {
-		plugin, pluginContext, err := plugin.NewResolver().
-			WithBuildInfo(buildInfo).
-			WithNamespace(operatorNamespace).
-			WithServiceAccountName(operatorConfig.ServiceAccount).
-			WithConfig(starboardConfig).
-			WithClient(mgr.GetClient()).
-			GetVulnerabilityPlugin()
-		if err != nil {
-			return err
+		newWorkloadController := func() *vulnerabilityreport.WorkloadController {
+			return &vulnerabilityreport.WorkloadController{
+				Logger:         ctrl.Log.WithName("reconciler").WithName("vulnerabilityreport"),
+				Config:         operatorConfig,
+				ConfigData:     starboardConfig,
+				Client:         mgr.GetClient(),
+				ObjectResolver: objectResolver,
+				LimitChecker:   limitChecker,
+				LogsReader:     logsReader,
+				SecretsReader:  secretsReader,
+				ReadWriter:     vulnerabilityreport.NewNotifyingReadWriter(mgr.GetClient(), notifier),
+				Clock:          ext.NewSystemClock(),
+				SbomReadWriter: sbomreport.NewReadWriter(mgr.GetClient()),
+				ExceptionApplier: &vulnerabilityreport.ExceptionApplier{
+					Client: mgr.GetClient(),
+					Clock:  ext.NewSystemClock(),
+				},
+			}
 		}
+		workloadController := newWorkloadController()
+		workloadControllers := []*vulnerabilityreport.WorkloadController{workloadController}
 
-		err = plugin.Init(pluginContext)
-		if err != nil {
-			return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
+		if operatorConfig.VulnerabilityScannerDriftCheckInterval != nil {
+			setupLog.Info("Enabling image drift detection", "interval", *operatorConfig.VulnerabilityScannerDriftCheckInterval)
+			workloadController.DriftChecker = &vulnerabilityreport.DriftChecker{
+				TagResolver: docker.NewResolver(),
+				Clock:       ext.NewSystemClock(),
+				Interval:    *operatorConfig.VulnerabilityScannerDriftCheckInterval,
+			}
 		}
 
-		if err = (&vulnerabilityreport.WorkloadController{
-			Logger:         ctrl.Log.WithName("reconciler").WithName("vulnerabilityreport"),
-			Config:         operatorConfig,
-			ConfigData:     starboardConfig,
+		if operatorConfig.VulnerabilityScannerBuiltIn {
+			setupLog.Info("Enabling built-in vulnerability scanner")
+			workloadController.ImageScanner = builtin.NewScanner(ext.NewSystemClock(), buildInfo,
+				operatorConfig.VulnerabilityScannerBuiltInDBDir)
+		} else {
+			scanners, err := starboardConfig.GetVulnerabilityReportsScanners()
+			if err != nil {
+				return err
+			}
+			var mergedScanners []string
+			if starboardConfig.VulnerabilityReportsMergeEnabled() && len(scanners) > 1 {
+				for _, scanner := range scanners {
+					mergedScanners = append(mergedScanners, string(scanner))
+				}
+				setupLog.Info("Enabling merged vulnerability reports", "scanners", mergedScanners)
+			}
+			resolver := plugin.NewResolver().
+				WithBuildInfo(buildInfo).
+				WithNamespace(operatorNamespace).
+				WithServiceAccountName(operatorConfig.ServiceAccount).
+				WithConfig(starboardConfig).
+				WithClient(mgr.GetClient()).
+				WithPluginsDir(operatorConfig.PluginsDir)
+			for i, scanner := range scanners {
+				scannerController := workloadController
+				if i > 0 {
+					// Image drift is recorded in reports of the primary
+					// scanner only.
+					scannerController = newWorkloadController()
+					scannerController.Logger = scannerController.Logger.WithValues("scanner", scanner)
+					scannerController.AdditionalScanner = true
+					workloadControllers = append(workloadControllers, scannerController)
+				}
+				plugin, pluginContext, err := resolver.GetVulnerabilityPluginForScanner(scanner)
+				if err != nil {
+					return err
+				}
+
+				err = plugin.Init(pluginContext)
+				if err != nil {
+					return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
+				}
+				scannerController.Plugin = plugin
+				scannerController.PluginContext = pluginContext
+				if imageScannerPlugin, ok := plugin.(vulnerabilityreport.ImageScannerPlugin); ok {
+					scannerController.ImageScanner, err = imageScannerPlugin.GetImageScanner(pluginContext)
+					if err != nil {
+						return fmt.Errorf("getting %s image scanner: %w", pluginContext.GetName(), err)
+					}
+				}
+				scannerController.MergedScanners = mergedScanners
+			}
+		}
+
+		for _, workloadController := range workloadControllers {
+			if err = workloadController.SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup vulnerabilityreport reconciler: %w", err)
+			}
+		}
+
+		if err = (&vulnerabilityreport.ExceptionController{
+			Logger:         ctrl.Log.WithName("reconciler").WithName("vulnerabilityexception"),
 			Client:         mgr.GetClient(),
 			ObjectResolver: objectResolver,
-			LimitChecker:   limitChecker,
-			LogsReader:     logsReader,
-			SecretsReader:  secretsReader,
-			Plugin:         plugin,
-			PluginContext:  pluginContext,
-			ReadWriter:     vulnerabilityreport.NewReadWriter(mgr.GetClient()),
+			Clock:          ext.NewSystemClock(),
 		}).SetupWithManager(mgr); err != nil {
-			return fmt.Errorf("unable to setup vulnerabilityreport reconciler: %w", err)
+			return fmt.Errorf("unable to setup vulnerabilityexception reconciler: %w", err)
 		}
 
-		if operatorConfig.VulnerabilityScannerReportTTL != nil {
+		if operatorConfig.VulnerabilityScannerReportTTL != nil || operatorConfig.VulnerabilityScannerCacheEnabled {
 			if err = (&controller.TTLReportReconciler{
-				Logger: ctrl.Log.WithName("reconciler").WithName("ttlreport"),
-				Config: operatorConfig,
-				Client: mgr.GetClient(),
-				Clock:  ext.NewSystemClock(),
+				Logger:     ctrl.Log.WithName("reconciler").WithName("ttlreport"),
+				Config:     operatorConfig,
+				Client:     mgr.GetClient(),
+				Clock:      ext.NewSystemClock(),
+				ConfigData: starboardConfig,
 			}).SetupWithManager(mgr); err != nil {
 				return fmt.Errorf("unable to setup TTLreport reconciler: %w", err)
 			}
@@ -182,6 +293,7 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			WithServiceAccountName(operatorConfig.ServiceAccount).
 			WithConfig(starboardConfig).
 			WithClient(mgr.GetClient()).
+			WithPluginsDir(operatorConfig.PluginsDir).
 			GetConfigAuditPlugin()
 		if err != nil {
 			return err
@@ -202,7 +314,7 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			LogsReader:     logsReader,
 			Plugin:         plugin,
 			PluginContext:  pluginContext,
-			ReadWriter:     configauditreport.NewReadWriter(mgr.GetClient()),
+			ReadWriter:     configauditreport.NewNotifyingReadWriter(mgr.GetClient(), notifier),
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup configauditreport reconciler: %w", err)
 		}
@@ -233,6 +345,120 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		}
 	}
 
+	if operatorConfig.NodeVulnerabilityScannerEnabled {
+		setupLog.Info("Enabling node vulnerability scanner", "rescanInterval", operatorConfig.NodeVulnerabilityScannerRescanInterval)
+		trivyPlugin, pluginContext, err := plugin.NewResolver().
+			WithBuildInfo(buildInfo).
+			WithNamespace(operatorNamespace).
+			WithServiceAccountName(operatorConfig.ServiceAccount).
+			WithConfig(starboardConfig).
+			WithClient(mgr.GetClient()).
+			GetVulnerabilityPluginForScanner(plugin.Trivy)
+		if err != nil {
+			return err
+		}
+		nodePlugin, ok := trivyPlugin.(vulnerabilityreport.NodePlugin)
+		if !ok {
+			return fmt.Errorf("%s plugin does not support scanning nodes", pluginContext.GetName())
+		}
+
+		err = trivyPlugin.Init(pluginContext)
+		if err != nil {
+			return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
+		}
+
+		if err = (&vulnerabilityreport.NodeController{
+			Logger:        ctrl.Log.WithName("reconciler").WithName("nodevulnerabilityreport"),
+			Config:        operatorConfig,
+			ConfigData:    starboardConfig,
+			Client:        mgr.GetClient(),
+			LimitChecker:  limitChecker,
+			LogsReader:    logsReader,
+			NodePlugin:    nodePlugin,
+			PluginContext: pluginContext,
+			ReadWriter:    vulnerabilityreport.NewReadWriter(mgr.GetClient()),
+			Clock:         ext.NewSystemClock(),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup nodevulnerabilityreport reconciler: %w", err)
+		}
+
+		if err = mgr.Add(&vulnerabilityreport.KubernetesVersionChecker{
+			Logger:                 ctrl.Log.WithName("kubernetesversionchecker"),
+			ServerVersionInterface: kubeClientset.Discovery(),
+			Writer:                 vulnerabilityreport.NewReadWriter(mgr.GetClient()),
+			Clock:                  ext.NewSystemClock(),
+			BuildInfo:              buildInfo,
+			Interval:               operatorConfig.NodeVulnerabilityScannerRescanInterval,
+		}); err != nil {
+			return fmt.Errorf("unable to setup Kubernetes version checker: %w", err)
+		}
+	}
+
+	if operatorConfig.HarborAdapterEnabled {
+		setupLog.Info("Enabling Harbor scanner adapter", "address", operatorConfig.HarborAdapterBindAddress)
+		vulnerabilityPlugin, pluginContext, err := plugin.NewResolver().
+			WithBuildInfo(buildInfo).
+			WithNamespace(operatorNamespace).
+			WithServiceAccountName(operatorConfig.ServiceAccount).
+			WithConfig(starboardConfig).
+			WithClient(mgr.GetClient()).
+			WithPluginsDir(operatorConfig.PluginsDir).
+			GetVulnerabilityPlugin()
+		if err != nil {
+			return err
+		}
+
+		err = vulnerabilityPlugin.Init(pluginContext)
+		if err != nil {
+			return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
+		}
+
+		adapter := &harbor.Adapter{
+			Logger:        ctrl.Log.WithName("harbor"),
+			Config:        operatorConfig,
+			ConfigData:    starboardConfig,
+			Client:        mgr.GetClient(),
+			LogsReader:    logsReader,
+			LimitChecker:  limitChecker,
+			Plugin:        vulnerabilityPlugin,
+			PluginContext: pluginContext,
+			ReadWriter:    vulnerabilityreport.NewReadWriter(mgr.GetClient()),
+			Clock:         ext.NewSystemClock(),
+			BuildInfo:     buildInfo,
+			BindAddress:   operatorConfig.HarborAdapterBindAddress,
+			SecretDir:     operatorConfig.HarborAdapterSecretDir,
+		}
+		if err = mgr.Add(adapter); err != nil {
+			return fmt.Errorf("unable to setup Harbor scanner adapter: %w", err)
+		}
+		if err = mgr.Add(&harbor.Collector{
+			Adapter:  adapter,
+			Interval: harbor.DefaultCollectInterval,
+		}); err != nil {
+			return fmt.Errorf("unable to setup Harbor scan jobs collector: %w", err)
+		}
+	}
+
+	policyCache := policy.NewCache()
+	policyCache.OnCompile(metrics.RecordPolicyCompilation)
+	// Policies read objects from data.kubernetes through the cache, which
+	// starts informers of kinds that are not cached yet.
+	inventory := kube.NewInventory(mgr.GetCache(), mgr.GetRESTMapper(), mgr.GetScheme())
+
+	var policyLoader *policy.Loader
+	if url, digest, ok := starboardConfig.GetPoliciesBundle(); ok {
+		setupLog.Info("Enabling policies bundle", "url", url)
+		policySource, err := policy.NewSource(url, digest)
+		if err != nil {
+			return fmt.Errorf("configuring policies bundle: %w", err)
+		}
+		policyLoader = policy.NewLoader(policySource, operatorConfig.ConfigAuditScannerPoliciesReloadInterval,
+			ctrl.Log.WithName("policies"))
+		if err = mgr.Add(policyLoader); err != nil {
+			return fmt.Errorf("unable to setup policies bundle loader: %w", err)
+		}
+	}
+
 	if operatorConfig.ConfigAuditScannerBuiltIn {
 		setupLog.Info("Enabling built-in configuration audit scanner")
 		if err = (&configauditreport.ResourceController{
@@ -241,13 +467,69 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			ConfigData:     starboardConfig,
 			Client:         mgr.GetClient(),
 			ObjectResolver: objectResolver,
-			ReadWriter:     configauditreport.NewReadWriter(mgr.GetClient()),
+			ReadWriter:     configauditreport.NewNotifyingReadWriter(mgr.GetClient(), notifier),
 			BuildInfo:      buildInfo,
+			PolicyLoader:   policyLoader,
+			PolicyCache:    policyCache,
+			Inventory:      inventory,
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup resource controller: %w", err)
 		}
 	}
 
+	if starboardConfig.SummaryHistoryEnabled() {
+		setupLog.Info("Enabling summary history")
+		if err = (&history.Controller{
+			Logger:   ctrl.Log.WithName("reconciler").WithName("summaryhistory"),
+			Recorder: history.NewRecorder(mgr.GetClient(), ext.NewSystemClock(), starboardConfig),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup summaryhistory reconciler: %w", err)
+		}
+	}
+
+	if operatorConfig.NamespaceSecurityReportEnabled {
+		if err = (&namespacesecurityreport.Controller{
+			Logger: ctrl.Log.WithName("reconciler").WithName("namespacesecurityreport"),
+			Client: mgr.GetClient(),
+			Clock:  ext.NewSystemClock(),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup namespacesecurityreport reconciler: %w", err)
+		}
+	}
+
+	if operatorConfig.WebhookEnabled {
+		setupLog.Info("Enabling validating admission webhook")
+		admissionPolicy, err := admission.NewPolicy(starboardConfig)
+		if err != nil {
+			return fmt.Errorf("parsing admission policy: %w", err)
+		}
+		vulnerabilityScanner := vulnerabilityreport.BuiltInScanner
+		if !operatorConfig.VulnerabilityScannerBuiltIn {
+			scanner, err := starboardConfig.GetVulnerabilityReportsScanner()
+			if err != nil {
+				return err
+			}
+			vulnerabilityScanner = string(scanner)
+		}
+		if err = (&admission.Validator{
+			Logger:  ctrl.Log.WithName("webhook").WithName("admission"),
+			Config:  operatorConfig,
+			Client:  mgr.GetClient(),
+			Reader:  vulnerabilityreport.NewReadWriter(mgr.GetClient()),
+			Scanner: vulnerabilityScanner,
+			ExceptionApplier: &vulnerabilityreport.ExceptionApplier{
+				Client: mgr.GetClient(),
+				Clock:  ext.NewSystemClock(),
+			},
+			Policy:       admissionPolicy,
+			PolicyLoader: policyLoader,
+			PolicyCache:  policyCache,
+			Inventory:    inventory,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup admission webhook: %w", err)
+		}
+	}
+
 	if operatorConfig.ClusterComplianceEnabled {
 		logger := ctrl.Log.WithName("reconciler").WithName("clustercompliancereport")
 		cc := &compliance.ClusterComplianceReportReconciler{
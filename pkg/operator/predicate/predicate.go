@@ -111,6 +111,15 @@ var IsVulnerabilityReportScan = predicate.NewPredicateFuncs(func(obj client.Obje
 	return false
 })
 
+// IsVulnerabilityReportScanBy is a predicate.Predicate that returns true if
+// the specified client.Object is a vulnerability scan job run by the desired
+// scanner.
+var IsVulnerabilityReportScanBy = func(scanner string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return scanner == obj.GetLabels()[starboard.LabelVulnerabilityReportScanner]
+	})
+}
+
 var IsConfigAuditReportScan = predicate.NewPredicateFuncs(func(obj client.Object) bool {
 	if _, ok := obj.GetLabels()[starboard.LabelConfigAuditReportScanner]; ok {
 		return true
@@ -125,6 +134,13 @@ var IsKubeBenchReportScan = predicate.NewPredicateFuncs(func(obj client.Object)
 	return false
 })
 
+var IsNodeVulnerabilityReportScan = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	if _, ok := obj.GetLabels()[starboard.LabelNodeVulnerabilityReportScanner]; ok {
+		return true
+	}
+	return false
+})
+
 var IsLinuxNode = predicate.NewPredicateFuncs(func(obj client.Object) bool {
 	if os, exists := obj.GetLabels()[corev1.LabelOSStable]; exists && os == "linux" {
 		return true
@@ -8,6 +8,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -209,6 +210,44 @@ var _ = Describe("Predicate", func() {
 		})
 	})
 
+	Describe("When checking a IsVulnerabilityReportScanBy predicate", func() {
+		Context("When object is labeled with desired scanner", func() {
+			It("Should return true", func() {
+				instance := predicate.IsVulnerabilityReportScanBy("Grype")
+				obj := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							starboard.LabelVulnerabilityReportScanner: "Grype",
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeTrue())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+			})
+		})
+
+		Context("When object is labeled with different scanner", func() {
+			It("Should return false", func() {
+				instance := predicate.IsVulnerabilityReportScanBy("Grype")
+				obj := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							starboard.LabelVulnerabilityReportScanner: "Trivy",
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeFalse())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("When checking a InNamespace predicate", func() {
 		Context("When object is in desired namespace", func() {
 			It("Should return true", func() {
@@ -175,8 +175,9 @@ func (s *plugin) getPodSpecForImageCommand(ctx starboard.PluginContext, config C
 		return corev1.PodSpec{}, nil, err
 	}
 
-	scanJobContainers := make([]corev1.Container, len(spec.Containers))
-	for i, container := range spec.Containers {
+	containers := kube.GetContainers(spec)
+	scanJobContainers := make([]corev1.Container, len(containers))
+	for i, container := range containers {
 		var err error
 		scanJobContainers[i], err = s.newScanJobContainer(ctx, config, container)
 		if err != nil {
@@ -306,8 +307,9 @@ func (s *plugin) getPodSpecForFileSystemCommand(ctx starboard.PluginContext, con
 		return corev1.PodSpec{}, nil, err
 	}
 	env = append(env, envVars...)
-	scanJobContainers := make([]corev1.Container, len(spec.Containers))
-	for i, container := range spec.Containers {
+	containers := kube.GetContainers(spec)
+	scanJobContainers := make([]corev1.Container, len(containers))
+	for i, container := range containers {
 		var err error
 		scanJobContainers[i], err = s.newScanJobContainerFSCommand(config, container, env)
 		if err != nil {
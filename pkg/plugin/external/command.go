@@ -0,0 +1,104 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 60 * time.Second
+
+// Command executes the plugin's executable once for each Request.
+type Command struct {
+	path    string
+	args    []string
+	timeout time.Duration
+}
+
+// NewCommand constructs a new Command, which runs the given executable with
+// optional arguments.
+func NewCommand(path string, args ...string) *Command {
+	return &Command{
+		path:    path,
+		args:    args,
+		timeout: defaultTimeout,
+	}
+}
+
+// LookupCommand constructs a new Command for the given command line of an
+// out-of-tree plugin. The executable must be the name of a file in the given
+// plugins directory, which is set by the cluster administrator, so that the
+// starboard ConfigMap cannot run arbitrary executables. Out-of-tree plugins
+// are disabled if the directory is not set.
+func LookupCommand(dir string, command []string) (*Command, error) {
+	if dir == "" {
+		return nil, errors.New("plugins directory is not set")
+	}
+	if len(command) == 0 {
+		return nil, errors.New("command is not set")
+	}
+	name := command[0]
+	if name != filepath.Base(name) || name == "." || name == ".." {
+		return nil, fmt.Errorf("executable %q must be a file name in the plugins directory", name)
+	}
+	path := filepath.Join(dir, name)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("looking up executable: %w", err)
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		return nil, fmt.Errorf("%s is not an executable file", path)
+	}
+	return NewCommand(path, command[1:]...), nil
+}
+
+// Call sends the given Request to the plugin and returns its Response. An
+// error is returned if the command fails, if it writes an invalid Response,
+// or if the Response carries an error message.
+func (c *Command) Call(ctx context.Context, request Request) (Response, error) {
+	request.APIVersion = APIVersion
+	input, err := json.Marshal(request)
+	if err != nil {
+		return Response{}, fmt.Errorf("encoding request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.path, c.args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return Response{}, fmt.Errorf("calling %s: timed out after %s", request.Method, c.timeout)
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return Response{}, fmt.Errorf("calling %s: %w: %s", request.Method, err, message)
+		}
+		return Response{}, fmt.Errorf("calling %s: %w", request.Method, err)
+	}
+
+	var response Response
+	err = json.Unmarshal(stdout.Bytes(), &response)
+	if err != nil {
+		return Response{}, fmt.Errorf("calling %s: decoding response: %w", request.Method, err)
+	}
+	if response.APIVersion != APIVersion {
+		return Response{}, fmt.Errorf("calling %s: unsupported response apiVersion: %q", request.Method, response.APIVersion)
+	}
+	if response.Error != "" {
+		return Response{}, fmt.Errorf("calling %s: %s", request.Method, response.Error)
+	}
+	return response, nil
+}
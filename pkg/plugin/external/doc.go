@@ -0,0 +1,10 @@
+// Package external provides vulnerability scanner and configuration checker
+// plugins, which delegate to out-of-tree executables. This allows running
+// scanners that are not compiled into Starboard.
+//
+// Starboard calls an external plugin by executing its command for each method
+// of the vulnerabilityreport.Plugin or configauditreport.Plugin interface. The
+// Request is written to the standard input of the command, and the Response
+// is read from its standard output. Both are encoded as JSON and tagged with
+// APIVersion, which identifies the version of the protocol.
+package external
@@ -0,0 +1,326 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type plugin struct {
+	name    string
+	command *Command
+	clock   ext.Clock
+}
+
+// Init calls the plugin's Init method and ensures the default configuration
+// returned by the plugin.
+func (p *plugin) Init(ctx starboard.PluginContext) error {
+	response, err := p.command.Call(context.Background(), Request{
+		Method:  MethodInit,
+		Context: p.newContext(ctx),
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.EnsureConfig(starboard.PluginConfig{
+		Data: response.Config,
+	})
+}
+
+// getScanJobSpec calls the plugin's GetScanJobSpec method with the given
+// object encoded as JSON.
+func (p *plugin) getScanJobSpec(ctx starboard.PluginContext, obj client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	request, err := p.newRequestWithConfig(ctx, MethodGetScanJobSpec)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	request.Object, err = json.Marshal(obj)
+	if err != nil {
+		return corev1.PodSpec{}, nil, fmt.Errorf("encoding object: %w", err)
+	}
+	request.Credentials = credentials
+
+	response, err := p.command.Call(context.Background(), request)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	if response.PodSpec == nil {
+		return corev1.PodSpec{}, nil, fmt.Errorf("calling %s: podSpec not set", MethodGetScanJobSpec)
+	}
+	return *response.PodSpec, response.Secrets, nil
+}
+
+func (p *plugin) newContext(ctx starboard.PluginContext) Context {
+	return Context{
+		Name:               ctx.GetName(),
+		Namespace:          ctx.GetNamespace(),
+		ServiceAccountName: ctx.GetServiceAccountName(),
+		StarboardConfig:    ctx.GetStarboardConfig(),
+	}
+}
+
+func (p *plugin) newRequestWithConfig(ctx starboard.PluginContext, method Method) (Request, error) {
+	config, err := ctx.GetConfig()
+	if err != nil {
+		return Request{}, err
+	}
+	request := Request{
+		Method:  method,
+		Context: p.newContext(ctx),
+	}
+	request.Context.Config = config.Data
+	request.Context.SecretData = config.SecretData
+	return request, nil
+}
+
+func (p *plugin) newRequestWithLogs(ctx starboard.PluginContext, method Method, logsReader io.ReadCloser) (Request, error) {
+	request, err := p.newRequestWithConfig(ctx, method)
+	if err != nil {
+		return Request{}, err
+	}
+	logs, err := io.ReadAll(logsReader)
+	if err != nil {
+		return Request{}, fmt.Errorf("reading logs: %w", err)
+	}
+	request.Logs = string(logs)
+	return request, nil
+}
+
+type vulnerabilityPlugin struct {
+	plugin
+}
+
+// NewVulnerabilityPlugin constructs a new vulnerabilityreport.Plugin with the
+// given name, which delegates to the given Command.
+func NewVulnerabilityPlugin(name string, command *Command, clock ext.Clock) vulnerabilityreport.Plugin {
+	return &vulnerabilityPlugin{
+		plugin: plugin{
+			name:    name,
+			command: command,
+			clock:   clock,
+		},
+	}
+}
+
+func (p *vulnerabilityPlugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	return p.getScanJobSpec(ctx, workload, credentials)
+}
+
+// ParseVulnerabilityReportData calls the plugin's ParseVulnerabilityReportData
+// method with logs read from the given logsReader. The summary is computed
+// from returned vulnerabilities.
+func (p *vulnerabilityPlugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	request, err := p.newRequestWithLogs(ctx, MethodParseVulnerabilityReportData, logsReader)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	request.ImageRef = imageRef
+
+	response, err := p.command.Call(context.Background(), request)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	if response.VulnerabilityReport == nil {
+		return v1alpha1.VulnerabilityReportData{}, fmt.Errorf("calling %s: vulnerabilityReport not set", MethodParseVulnerabilityReportData)
+	}
+	data := *response.VulnerabilityReport
+	if data.UpdateTimestamp.IsZero() {
+		data.UpdateTimestamp = metav1.NewTime(p.clock.Now())
+	}
+	if data.Vulnerabilities == nil {
+		data.Vulnerabilities = []v1alpha1.Vulnerability{}
+	}
+	data.Summary = v1alpha1.VulnerabilitySummaryFromVulnerabilities(data.Vulnerabilities)
+	return data, nil
+}
+
+type configAuditPlugin struct {
+	plugin
+
+	once           sync.Once
+	containerName  string
+	supportedKinds []kube.Kind
+	err            error
+
+	mu sync.Mutex
+	// configKey identifies the configuration that cached responses of the
+	// ConfigHash and IsApplicable methods were returned for.
+	configKey string
+	responses map[string]Response
+}
+
+// NewConfigAuditPlugin constructs a new configauditreport.Plugin with the
+// given name, which delegates to the given Command.
+func NewConfigAuditPlugin(name string, command *Command, clock ext.Clock) configauditreport.Plugin {
+	return &configAuditPlugin{
+		plugin: plugin{
+			name:    name,
+			command: command,
+			clock:   clock,
+		},
+	}
+}
+
+// Init calls the plugin's Init method. It also calls GetContainerName and
+// SupportedKinds methods, whose results are cached, so that the plugin fails
+// early if they return an error.
+func (p *configAuditPlugin) Init(ctx starboard.PluginContext) error {
+	err := p.plugin.Init(ctx)
+	if err != nil {
+		return err
+	}
+	return p.describe()
+}
+
+func (p *configAuditPlugin) GetScanJobSpec(ctx starboard.PluginContext, obj client.Object) (corev1.PodSpec, []*corev1.Secret, error) {
+	return p.getScanJobSpec(ctx, obj, nil)
+}
+
+// ParseConfigAuditReportData calls the plugin's ParseConfigAuditReportData
+// method with logs read from the given logsReader. The summary is computed
+// from returned checks.
+func (p *configAuditPlugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsReader io.ReadCloser) (v1alpha1.ConfigAuditReportData, error) {
+	request, err := p.newRequestWithLogs(ctx, MethodParseConfigAuditReportData, logsReader)
+	if err != nil {
+		return v1alpha1.ConfigAuditReportData{}, err
+	}
+
+	response, err := p.command.Call(context.Background(), request)
+	if err != nil {
+		return v1alpha1.ConfigAuditReportData{}, err
+	}
+	if response.ConfigAuditReport == nil {
+		return v1alpha1.ConfigAuditReportData{}, fmt.Errorf("calling %s: configAuditReport not set", MethodParseConfigAuditReportData)
+	}
+	data := *response.ConfigAuditReport
+	if data.UpdateTimestamp.IsZero() {
+		data.UpdateTimestamp = metav1.NewTime(p.clock.Now())
+	}
+	if data.Checks == nil {
+		data.Checks = []v1alpha1.Check{}
+	}
+	data.Summary = v1alpha1.ConfigAuditSummaryFromChecks(data.Checks)
+	return data, nil
+}
+
+// GetContainerName returns the container name returned by the plugin's
+// GetContainerName method, or an empty string if the method failed.
+func (p *configAuditPlugin) GetContainerName() string {
+	_ = p.describe()
+	return p.containerName
+}
+
+// SupportedKinds returns kinds returned by the plugin's SupportedKinds
+// method, or nil if the method failed.
+func (p *configAuditPlugin) SupportedKinds() []kube.Kind {
+	_ = p.describe()
+	return p.supportedKinds
+}
+
+// ConfigHash calls the plugin's ConfigHash method for the given kind.
+func (p *configAuditPlugin) ConfigHash(ctx starboard.PluginContext, kind kube.Kind) (string, error) {
+	response, err := p.callByKind(ctx, MethodConfigHash, kind)
+	if err != nil {
+		return "", err
+	}
+	return response.ConfigHash, nil
+}
+
+// IsApplicable calls the plugin's IsApplicable method for the kind of the
+// given object.
+func (p *configAuditPlugin) IsApplicable(ctx starboard.PluginContext, obj client.Object) (bool, string, error) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		return false, "", errors.New("object kind must not be nil")
+	}
+	response, err := p.callByKind(ctx, MethodIsApplicable, kube.Kind(kind))
+	if err != nil {
+		return false, "", err
+	}
+	return response.Applicable, response.Reason, nil
+}
+
+// callByKind calls the given method of the plugin for the given kind. The
+// response is cached until the configuration of the plugin changes, so that
+// the plugin's command is not executed on each reconciliation.
+func (p *configAuditPlugin) callByKind(ctx starboard.PluginContext, method Method, kind kube.Kind) (Response, error) {
+	request, err := p.newRequestWithConfig(ctx, method)
+	if err != nil {
+		return Response{}, err
+	}
+	request.Kind = string(kind)
+	configKey := kube.ComputeHash(request.Context)
+	responseKey := string(method) + "/" + string(kind)
+
+	p.mu.Lock()
+	if p.configKey != configKey {
+		p.configKey = configKey
+		p.responses = make(map[string]Response)
+	}
+	response, ok := p.responses[responseKey]
+	p.mu.Unlock()
+	if ok {
+		return response, nil
+	}
+
+	response, err = p.command.Call(context.Background(), request)
+	if err != nil {
+		return Response{}, err
+	}
+
+	p.mu.Lock()
+	if p.configKey == configKey {
+		p.responses[responseKey] = response
+	}
+	p.mu.Unlock()
+	return response, nil
+}
+
+// describe calls the plugin's GetContainerName and SupportedKinds methods
+// once, because the corresponding methods of configauditreport.Plugin are
+// not passed the plugin context and cannot return errors.
+func (p *configAuditPlugin) describe() error {
+	p.once.Do(func() {
+		ctx := Context{Name: p.name}
+		response, err := p.command.Call(context.Background(), Request{
+			Method:  MethodGetContainerName,
+			Context: ctx,
+		})
+		if err != nil {
+			p.err = err
+			return
+		}
+		if response.ContainerName == "" {
+			p.err = fmt.Errorf("calling %s: containerName not set", MethodGetContainerName)
+			return
+		}
+		p.containerName = response.ContainerName
+
+		response, err = p.command.Call(context.Background(), Request{
+			Method:  MethodSupportedKinds,
+			Context: ctx,
+		})
+		if err != nil {
+			p.err = err
+			return
+		}
+		for _, kind := range response.SupportedKinds {
+			p.supportedKinds = append(p.supportedKinds, kube.Kind(kind))
+		}
+	})
+	return p.err
+}
@@ -0,0 +1,370 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/plugin/external"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	helperEnv      = "STARBOARD_TEST_EXTERNAL_PLUGIN"
+	helperCallsEnv = "STARBOARD_TEST_EXTERNAL_PLUGIN_CALLS"
+)
+
+var fixedTime = time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+
+// TestHelperProcess isn't a real test. It's used as the executable of the
+// external plugin called by other tests.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv(helperEnv) != "1" {
+		return
+	}
+	var request external.Request
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		fmt.Fprintf(os.Stderr, "decoding request: %v", err)
+		os.Exit(1)
+	}
+	if request.APIVersion != external.APIVersion {
+		fmt.Fprintf(os.Stderr, "unsupported apiVersion: %s", request.APIVersion)
+		os.Exit(1)
+	}
+	if calls := os.Getenv(helperCallsEnv); calls != "" {
+		f, err := os.OpenFile(calls, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "recording call: %v", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(f, request.Method, request.Kind)
+		_ = f.Close()
+	}
+
+	response := external.Response{APIVersion: external.APIVersion}
+	switch request.Method {
+	case external.MethodInit:
+		response.Config = map[string]string{"acme.severity": "HIGH"}
+	case external.MethodGetScanJobSpec:
+		var obj metav1.PartialObjectMetadata
+		_ = json.Unmarshal(request.Object, &obj)
+		response.PodSpec = &corev1.PodSpec{
+			ServiceAccountName: request.Context.ServiceAccountName,
+			Containers: []corev1.Container{
+				{
+					Name:  "acme",
+					Image: "acme/scanner:" + request.Context.Config["acme.version"],
+					Args:  []string{obj.Kind + "/" + obj.Name},
+				},
+			},
+		}
+		for container, auth := range request.Credentials {
+			response.Secrets = append(response.Secrets, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "acme-" + container},
+				StringData: map[string]string{"username": auth.Username},
+			})
+		}
+	case external.MethodParseVulnerabilityReportData:
+		var vulnerabilities []v1alpha1.Vulnerability
+		_ = json.Unmarshal([]byte(request.Logs), &vulnerabilities)
+		response.VulnerabilityReport = &v1alpha1.VulnerabilityReportData{
+			Scanner:         v1alpha1.Scanner{Name: "Acme"},
+			Artifact:        v1alpha1.Artifact{Repository: request.ImageRef},
+			Vulnerabilities: vulnerabilities,
+		}
+	case external.MethodParseConfigAuditReportData:
+		var checks []v1alpha1.Check
+		_ = json.Unmarshal([]byte(request.Logs), &checks)
+		response.ConfigAuditReport = &v1alpha1.ConfigAuditReportData{
+			Scanner: v1alpha1.Scanner{Name: "Acme"},
+			Checks:  checks,
+		}
+	case external.MethodGetContainerName:
+		response.ContainerName = "acme"
+	case external.MethodSupportedKinds:
+		response.SupportedKinds = []string{"Pod", "Deployment"}
+	case external.MethodConfigHash:
+		if request.Kind == "Unknown" {
+			fmt.Fprint(os.Stderr, "unsupported kind")
+			os.Exit(2)
+		}
+		response.ConfigHash = request.Kind + ":" + request.Context.Config["acme.severity"]
+	case external.MethodIsApplicable:
+		response.Applicable = request.Kind == "Pod"
+		if !response.Applicable {
+			response.Reason = "kind " + request.Kind + " is not supported"
+		}
+	default:
+		response.Error = "unsupported method: " + string(request.Method)
+	}
+	_ = json.NewEncoder(os.Stdout).Encode(response)
+	os.Exit(0)
+}
+
+func newCommand(t *testing.T) *external.Command {
+	t.Helper()
+	t.Setenv(helperEnv, "1")
+	return external.NewCommand(os.Args[0], "-test.run=TestHelperProcess", "--")
+}
+
+func newPluginContext(t *testing.T, objects ...client.Object) starboard.PluginContext {
+	t.Helper()
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+	return starboard.NewPluginContext().
+		WithName("Acme").
+		WithNamespace("starboard-system").
+		WithServiceAccountName("starboard").
+		WithClient(c).
+		Get()
+}
+
+var pluginConfig = &corev1.ConfigMap{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "starboard-acme-config",
+		Namespace: "starboard-system",
+	},
+	Data: map[string]string{
+		"acme.version":  "1.0",
+		"acme.severity": "CRITICAL",
+	},
+}
+
+var pod = &corev1.Pod{
+	TypeMeta: metav1.TypeMeta{
+		Kind:       "Pod",
+		APIVersion: "v1",
+	},
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "nginx",
+		Namespace: "default",
+		Labels:    map[string]string{"acme": "true"},
+	},
+}
+
+func TestVulnerabilityPlugin(t *testing.T) {
+	plugin := external.NewVulnerabilityPlugin("Acme", newCommand(t), ext.NewFixedClock(fixedTime))
+
+	t.Run("Init", func(t *testing.T) {
+		pluginContext := newPluginContext(t)
+		require.NoError(t, plugin.Init(pluginContext))
+
+		config, err := pluginContext.GetConfig()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"acme.severity": "HIGH"}, config.Data)
+	})
+
+	t.Run("GetScanJobSpec", func(t *testing.T) {
+		podSpec, secrets, err := plugin.GetScanJobSpec(newPluginContext(t, pluginConfig), pod, map[string]docker.Auth{
+			"nginx": {Username: "robot"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, corev1.PodSpec{
+			ServiceAccountName: "starboard",
+			Containers: []corev1.Container{
+				{
+					Name:  "acme",
+					Image: "acme/scanner:1.0",
+					Args:  []string{"Pod/nginx"},
+				},
+			},
+		}, podSpec)
+		require.Len(t, secrets, 1)
+		assert.Equal(t, "acme-nginx", secrets[0].Name)
+		assert.Equal(t, map[string]string{"username": "robot"}, secrets[0].StringData)
+	})
+
+	t.Run("ParseVulnerabilityReportData", func(t *testing.T) {
+		logs := `[{"vulnerabilityID":"CVE-2022-0001","severity":"HIGH"},{"vulnerabilityID":"CVE-2022-0002","severity":"LOW"}]`
+		data, err := plugin.ParseVulnerabilityReportData(newPluginContext(t, pluginConfig), "nginx:1.16",
+			io.NopCloser(strings.NewReader(logs)))
+		require.NoError(t, err)
+		assert.Equal(t, metav1.NewTime(fixedTime), data.UpdateTimestamp)
+		assert.Equal(t, v1alpha1.Scanner{Name: "Acme"}, data.Scanner)
+		assert.Equal(t, "nginx:1.16", data.Artifact.Repository)
+		assert.Len(t, data.Vulnerabilities, 2)
+		assert.Equal(t, v1alpha1.VulnerabilitySummary{HighCount: 1, LowCount: 1}, data.Summary)
+	})
+
+	t.Run("Should return error when config is missing", func(t *testing.T) {
+		_, _, err := plugin.GetScanJobSpec(newPluginContext(t), pod, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestConfigAuditPlugin(t *testing.T) {
+	plugin := external.NewConfigAuditPlugin("Acme", newCommand(t), ext.NewFixedClock(fixedTime))
+
+	t.Run("Init", func(t *testing.T) {
+		require.NoError(t, plugin.Init(newPluginContext(t)))
+	})
+
+	t.Run("GetContainerName", func(t *testing.T) {
+		assert.Equal(t, "acme", plugin.GetContainerName())
+	})
+
+	t.Run("SupportedKinds", func(t *testing.T) {
+		assert.Equal(t, []kube.Kind{kube.KindPod, kube.KindDeployment}, plugin.SupportedKinds())
+	})
+
+	t.Run("GetScanJobSpec", func(t *testing.T) {
+		podSpec, secrets, err := plugin.GetScanJobSpec(newPluginContext(t, pluginConfig), pod)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Pod/nginx"}, podSpec.Containers[0].Args)
+		assert.Empty(t, secrets)
+	})
+
+	t.Run("ParseConfigAuditReportData", func(t *testing.T) {
+		logs := `[{"checkID":"acme-1","severity":"CRITICAL"},{"checkID":"acme-2","severity":"MEDIUM","success":true}]`
+		data, err := plugin.ParseConfigAuditReportData(newPluginContext(t, pluginConfig),
+			io.NopCloser(strings.NewReader(logs)))
+		require.NoError(t, err)
+		assert.Equal(t, metav1.NewTime(fixedTime), data.UpdateTimestamp)
+		assert.Len(t, data.Checks, 2)
+		assert.Equal(t, v1alpha1.ConfigAuditSummaryFromChecks(data.Checks), data.Summary)
+	})
+
+	t.Run("ConfigHash", func(t *testing.T) {
+		hash, err := plugin.ConfigHash(newPluginContext(t, pluginConfig), kube.KindPod)
+		require.NoError(t, err)
+		assert.Equal(t, "Pod:CRITICAL", hash)
+	})
+
+	t.Run("Should return stderr of failed command", func(t *testing.T) {
+		_, err := plugin.ConfigHash(newPluginContext(t, pluginConfig), "Unknown")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "calling ConfigHash: exit status 2: unsupported kind")
+	})
+
+	t.Run("IsApplicable", func(t *testing.T) {
+		applicable, reason, err := plugin.IsApplicable(newPluginContext(t, pluginConfig), pod)
+		require.NoError(t, err)
+		assert.True(t, applicable)
+		assert.Empty(t, reason)
+
+		applicable, reason, err = plugin.IsApplicable(newPluginContext(t, pluginConfig), &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		})
+		require.NoError(t, err)
+		assert.False(t, applicable)
+		assert.Equal(t, "kind Deployment is not supported", reason)
+
+		_, _, err = plugin.IsApplicable(newPluginContext(t, pluginConfig), &corev1.Pod{})
+		assert.EqualError(t, err, "object kind must not be nil")
+	})
+}
+
+func TestConfigAuditPlugin_Cache(t *testing.T) {
+	calls := filepath.Join(t.TempDir(), "calls")
+	t.Setenv(helperCallsEnv, calls)
+	plugin := external.NewConfigAuditPlugin("Acme", newCommand(t), ext.NewFixedClock(fixedTime))
+	pluginContext := newPluginContext(t, pluginConfig.DeepCopy())
+
+	for i := 0; i < 2; i++ {
+		hash, err := plugin.ConfigHash(pluginContext, kube.KindPod)
+		require.NoError(t, err)
+		assert.Equal(t, "Pod:CRITICAL", hash)
+		applicable, _, err := plugin.IsApplicable(pluginContext, pod)
+		require.NoError(t, err)
+		assert.True(t, applicable)
+	}
+	_, err := plugin.ConfigHash(pluginContext, kube.KindDeployment)
+	require.NoError(t, err)
+
+	config := pluginConfig.DeepCopy()
+	config.Data["acme.severity"] = "HIGH"
+	hash, err := plugin.ConfigHash(newPluginContext(t, config), kube.KindPod)
+	require.NoError(t, err)
+	assert.Equal(t, "Pod:HIGH", hash)
+
+	content, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	assert.Equal(t, "ConfigHash Pod\nIsApplicable Pod\nConfigHash Deployment\nConfigHash Pod\n", string(content))
+}
+
+func TestLookupCommand(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "acme"), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "acme.yaml"), []byte("{}"), 0644))
+
+	t.Run("Should return command in plugins directory", func(t *testing.T) {
+		command, err := external.LookupCommand(dir, []string{"acme", "--debug"})
+		require.NoError(t, err)
+		assert.NotNil(t, command)
+	})
+
+	testCases := []struct {
+		name          string
+		dir           string
+		command       []string
+		expectedError string
+	}{
+		{
+			name:          "Should return error when plugins directory is not set",
+			command:       []string{"acme"},
+			expectedError: "plugins directory is not set",
+		},
+		{
+			name:          "Should return error when executable is a path",
+			dir:           dir,
+			command:       []string{"/bin/sh", "-c", "id"},
+			expectedError: `executable "/bin/sh" must be a file name in the plugins directory`,
+		},
+		{
+			name:          "Should return error when executable is outside plugins directory",
+			dir:           dir,
+			command:       []string{"../acme"},
+			expectedError: `executable "../acme" must be a file name in the plugins directory`,
+		},
+		{
+			name:          "Should return error when executable does not exist",
+			dir:           dir,
+			command:       []string{"sh"},
+			expectedError: "looking up executable: stat " + filepath.Join(dir, "sh") + ": no such file or directory",
+		},
+		{
+			name:          "Should return error when file is not executable",
+			dir:           dir,
+			command:       []string{"acme.yaml"},
+			expectedError: filepath.Join(dir, "acme.yaml") + " is not an executable file",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := external.LookupCommand(tc.dir, tc.command)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestCommand_Call(t *testing.T) {
+	command := newCommand(t)
+
+	t.Run("Should return error of plugin", func(t *testing.T) {
+		_, err := command.Call(context.TODO(), external.Request{Method: "Unknown"})
+		assert.EqualError(t, err, "calling Unknown: unsupported method: Unknown")
+	})
+
+	t.Run("Should return error when executable does not exist", func(t *testing.T) {
+		_, err := external.NewCommand("/nonexistent/acme").Call(context.TODO(), external.Request{Method: external.MethodInit})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "calling Init:")
+	})
+}
@@ -0,0 +1,89 @@
+package external
+
+import (
+	"encoding/json"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// APIVersion is the version of the protocol between Starboard and external
+// plugins.
+const APIVersion = "plugins.starboard.aquasecurity.github.io/v1alpha1"
+
+// Method is the name of a plugin method called by Starboard.
+type Method string
+
+const (
+	MethodInit                         Method = "Init"
+	MethodGetScanJobSpec               Method = "GetScanJobSpec"
+	MethodParseVulnerabilityReportData Method = "ParseVulnerabilityReportData"
+	MethodParseConfigAuditReportData   Method = "ParseConfigAuditReportData"
+	MethodGetContainerName             Method = "GetContainerName"
+	MethodConfigHash                   Method = "ConfigHash"
+	MethodSupportedKinds               Method = "SupportedKinds"
+	MethodIsApplicable                 Method = "IsApplicable"
+)
+
+// Request is written by Starboard to the standard input of the plugin's
+// command. Only fields that apply to the called Method are set.
+type Request struct {
+	APIVersion string  `json:"apiVersion"`
+	Method     Method  `json:"method"`
+	Context    Context `json:"context"`
+
+	// Object is the Kubernetes object to be scanned.
+	Object json.RawMessage `json:"object,omitempty"`
+	// Credentials are container registry credentials keyed by the names of
+	// the Object's containers.
+	Credentials map[string]docker.Auth `json:"credentials,omitempty"`
+	// ImageRef is the reference of the container image, which the Logs
+	// relate to.
+	ImageRef string `json:"imageRef,omitempty"`
+	// Logs is the output of the scan job's container.
+	Logs string `json:"logs,omitempty"`
+	// Kind is the kind of Kubernetes objects that ConfigHash or IsApplicable
+	// is called for.
+	Kind string `json:"kind,omitempty"`
+}
+
+// Context is the plugin's execution context. It corresponds to the
+// starboard.PluginContext passed to in-tree plugins.
+type Context struct {
+	Name               string            `json:"name"`
+	Namespace          string            `json:"namespace"`
+	ServiceAccountName string            `json:"serviceAccountName"`
+	StarboardConfig    map[string]string `json:"starboardConfig,omitempty"`
+	// Config is the data of the plugin's ConfigMap. It's not set when the
+	// Init method is called.
+	Config map[string]string `json:"config,omitempty"`
+	// SecretData is the data of the plugin's Secret, if any.
+	SecretData map[string][]byte `json:"secretData,omitempty"`
+}
+
+// Response is read by Starboard from the standard output of the plugin's
+// command. Only fields that apply to the called Method must be set.
+type Response struct {
+	APIVersion string `json:"apiVersion"`
+	// Error is the message of an error returned by the plugin.
+	Error string `json:"error,omitempty"`
+
+	// Config is the default configuration returned by the Init method.
+	// Starboard creates the plugin's ConfigMap with this data unless it
+	// already exists.
+	Config map[string]string `json:"config,omitempty"`
+
+	PodSpec *corev1.PodSpec  `json:"podSpec,omitempty"`
+	Secrets []*corev1.Secret `json:"secrets,omitempty"`
+
+	VulnerabilityReport *v1alpha1.VulnerabilityReportData `json:"vulnerabilityReport,omitempty"`
+	ConfigAuditReport   *v1alpha1.ConfigAuditReportData   `json:"configAuditReport,omitempty"`
+
+	ContainerName  string   `json:"containerName,omitempty"`
+	ConfigHash     string   `json:"configHash,omitempty"`
+	SupportedKinds []string `json:"supportedKinds,omitempty"`
+
+	Applicable bool   `json:"applicable,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
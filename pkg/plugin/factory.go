@@ -7,6 +7,10 @@ import (
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/plugin/aqua"
 	"github.com/aquasecurity/starboard/pkg/plugin/conftest"
+	"github.com/aquasecurity/starboard/pkg/plugin/external"
+	"github.com/aquasecurity/starboard/pkg/plugin/generic"
+	"github.com/aquasecurity/starboard/pkg/plugin/grype"
+	"github.com/aquasecurity/starboard/pkg/plugin/harbor"
 	"github.com/aquasecurity/starboard/pkg/plugin/polaris"
 	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
 	"github.com/aquasecurity/starboard/pkg/starboard"
@@ -17,8 +21,11 @@ import (
 const (
 	Trivy    starboard.Scanner = "Trivy"
 	Aqua     starboard.Scanner = "Aqua"
+	Grype    starboard.Scanner = "Grype"
+	Harbor   starboard.Scanner = "Harbor"
 	Polaris  starboard.Scanner = "Polaris"
 	Conftest starboard.Scanner = "Conftest"
+	Generic  starboard.Scanner = "Generic"
 )
 
 type Resolver struct {
@@ -27,6 +34,7 @@ type Resolver struct {
 	namespace          string
 	serviceAccountName string
 	client             client.Client
+	pluginsDir         string
 }
 
 func NewResolver() *Resolver {
@@ -58,18 +66,36 @@ func (r *Resolver) WithClient(client client.Client) *Resolver {
 	return r
 }
 
+// WithPluginsDir sets the directory of out-of-tree plugin executables.
+// Out-of-tree plugins are disabled unless it's set.
+func (r *Resolver) WithPluginsDir(dir string) *Resolver {
+	r.pluginsDir = dir
+	return r
+}
+
 // GetVulnerabilityPlugin is a factory method that instantiates the vulnerabilityreport.Plugin.
 //
 // Starboard currently supports Trivy scanner in Standalone and ClientServer
-// mode, and Aqua Enterprise scanner.
+// mode, Aqua Enterprise scanner, Grype scanner, and any scanner adapter that
+// implements the Harbor Scanner Adapter API. The Generic plugin runs any
+// scanner defined in its ConfigMap.
 //
-// You could add your own scanner by implementing the vulnerabilityreport.Plugin interface.
+// You could add your own scanner by implementing the vulnerabilityreport.Plugin
+// interface, or by configuring an out-of-tree plugin installed in the plugins
+// directory with the plugins.<scanner>.command key.
 func (r *Resolver) GetVulnerabilityPlugin() (vulnerabilityreport.Plugin, starboard.PluginContext, error) {
 	scanner, err := r.config.GetVulnerabilityReportsScanner()
 	if err != nil {
 		return nil, nil, err
 	}
+	return r.GetVulnerabilityPluginForScanner(scanner)
+}
 
+// GetVulnerabilityPluginForScanner is similar to GetVulnerabilityPlugin except
+// it instantiates the vulnerabilityreport.Plugin for the given scanner, which
+// is one of the scanners returned by starboard.ConfigData's
+// GetVulnerabilityReportsScanners.
+func (r *Resolver) GetVulnerabilityPluginForScanner(scanner starboard.Scanner) (vulnerabilityreport.Plugin, starboard.PluginContext, error) {
 	pluginContext := starboard.NewPluginContext().
 		WithName(string(scanner)).
 		WithNamespace(r.namespace).
@@ -83,15 +109,31 @@ func (r *Resolver) GetVulnerabilityPlugin() (vulnerabilityreport.Plugin, starboa
 		return trivy.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator(), r.client), pluginContext, nil
 	case Aqua:
 		return aqua.NewPlugin(ext.NewGoogleUUIDGenerator(), r.buildInfo), pluginContext, nil
+	case Grype:
+		return grype.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator()), pluginContext, nil
+	case Harbor:
+		return harbor.NewPlugin(ext.NewSystemClock()), pluginContext, nil
+	case Generic:
+		return generic.NewVulnerabilityPlugin(ext.NewSystemClock()), pluginContext, nil
+	}
+	if command, ok := r.config.GetPluginCommand(scanner); ok {
+		pluginCommand, err := external.LookupCommand(r.pluginsDir, command)
+		if err != nil {
+			return nil, nil, fmt.Errorf("out-of-tree vulnerability scanner plugin %s: %w", scanner, err)
+		}
+		return external.NewVulnerabilityPlugin(string(scanner), pluginCommand, ext.NewSystemClock()), pluginContext, nil
 	}
 	return nil, nil, fmt.Errorf("unsupported vulnerability scanner plugin: %s", scanner)
 }
 
 // GetConfigAuditPlugin is a factory method that instantiates the configauditreport.Plugin.
 //
-// Starboard supports Polaris and Conftest as configuration auditing tools.
+// Starboard supports Polaris and Conftest as configuration auditing tools, and
+// the Generic plugin, which runs any tool defined in its ConfigMap.
 //
-// You could add your own scanner by implementing the configauditreport.Plugin interface.
+// You could add your own scanner by implementing the configauditreport.Plugin
+// interface, or by configuring an out-of-tree plugin installed in the plugins
+// directory with the plugins.<scanner>.command key.
 func (r *Resolver) GetConfigAuditPlugin() (configauditreport.Plugin, starboard.PluginContext, error) {
 	scanner, err := r.config.GetConfigAuditReportsScanner()
 	if err != nil {
@@ -103,6 +145,7 @@ func (r *Resolver) GetConfigAuditPlugin() (configauditreport.Plugin, starboard.P
 		WithNamespace(r.namespace).
 		WithServiceAccountName(r.serviceAccountName).
 		WithClient(r.client).
+		WithStarboardConfig(r.config).
 		Get()
 
 	switch scanner {
@@ -110,6 +153,15 @@ func (r *Resolver) GetConfigAuditPlugin() (configauditreport.Plugin, starboard.P
 		return polaris.NewPlugin(ext.NewSystemClock()), pluginContext, nil
 	case Conftest:
 		return conftest.NewPlugin(ext.NewGoogleUUIDGenerator(), ext.NewSystemClock()), pluginContext, nil
+	case Generic:
+		return generic.NewConfigAuditPlugin(ext.NewSystemClock()), pluginContext, nil
+	}
+	if command, ok := r.config.GetPluginCommand(scanner); ok {
+		pluginCommand, err := external.LookupCommand(r.pluginsDir, command)
+		if err != nil {
+			return nil, nil, fmt.Errorf("out-of-tree configuration audit scanner plugin %s: %w", scanner, err)
+		}
+		return external.NewConfigAuditPlugin(string(scanner), pluginCommand, ext.NewSystemClock()), pluginContext, nil
 	}
 	return nil, nil, fmt.Errorf("unsupported configuration audit scanner plugin: %s", scanner)
 }
@@ -0,0 +1,388 @@
+package generic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	keyPrefixConfigAudit   = "generic.configAudit."
+	keyPrefixVulnerability = "generic.vulnerability."
+)
+
+const (
+	keyImageRef              = "imageRef"
+	keyCommand               = "command"
+	keyArgs                  = "args"
+	keyKinds                 = "kinds"
+	keyScannerName           = "scanner.name"
+	keyScannerVendor         = "scanner.vendor"
+	keyOutputItems           = "output.items"
+	keyOutputSeverityMap     = "output.severityMap"
+	keyOutputDefaultSeverity = "output.defaultSeverity"
+	keyOutputSuccessValue    = "output.successValue"
+	keyPrefixOutputField     = "output."
+)
+
+const (
+	fieldID               = "id"
+	fieldTitle            = "title"
+	fieldDescription      = "description"
+	fieldSeverity         = "severity"
+	fieldCategory         = "category"
+	fieldMessages         = "messages"
+	fieldRemediation      = "remediation"
+	fieldSuccess          = "success"
+	fieldResource         = "resource"
+	fieldInstalledVersion = "installedVersion"
+	fieldFixedVersion     = "fixedVersion"
+	fieldPrimaryLink      = "primaryLink"
+	fieldLinks            = "links"
+)
+
+var (
+	configAuditFields = []string{
+		fieldID,
+		fieldTitle,
+		fieldDescription,
+		fieldSeverity,
+		fieldCategory,
+		fieldMessages,
+		fieldRemediation,
+		fieldSuccess,
+	}
+	vulnerabilityFields = []string{
+		fieldID,
+		fieldTitle,
+		fieldDescription,
+		fieldSeverity,
+		fieldResource,
+		fieldInstalledVersion,
+		fieldFixedVersion,
+		fieldPrimaryLink,
+		fieldLinks,
+	}
+)
+
+// TemplateData is passed to templates of the command and arguments of the
+// scan job's container, e.g. {{ .Name }}.
+type TemplateData struct {
+	// Kind is the kind of the scanned workload, e.g. Deployment.
+	Kind string
+	// Name is the name of the scanned workload.
+	Name string
+	// Namespace is the namespace of the scanned workload.
+	Namespace string
+	// WorkloadFile is the path of the file that holds the YAML manifest of
+	// the scanned workload. It's only set for the configuration checker.
+	WorkloadFile string
+	// ContainerName is the name of the workload's container whose image is
+	// scanned. It's only set for the vulnerability scanner.
+	ContainerName string
+	// Image is the reference of the scanned container image. It's only set
+	// for the vulnerability scanner.
+	Image string
+}
+
+// Config defines configuration params for this plugin. Keys of the
+// configuration checker and the vulnerability scanner are prefixed with
+// generic.configAudit. and generic.vulnerability. respectively, so both can
+// be configured in the same ConfigMap.
+type Config struct {
+	starboard.PluginConfig
+	prefix string
+}
+
+func (c Config) key(name string) string {
+	return c.prefix + name
+}
+
+// GetImageRef returns the container image reference of the scanner.
+func (c Config) GetImageRef() (string, error) {
+	return c.GetRequiredData(c.key(keyImageRef))
+}
+
+// GetCommand returns templates of the scan job container's command, which
+// is specified as a JSON array of strings. It returns nil if the command is
+// not set, i.e. the entrypoint of the image is used.
+func (c Config) GetCommand() ([]*template.Template, error) {
+	return c.getTemplates(c.key(keyCommand))
+}
+
+// GetArgs returns templates of the scan job container's arguments, which
+// are specified as a JSON array of strings.
+func (c Config) GetArgs() ([]*template.Template, error) {
+	return c.getTemplates(c.key(keyArgs))
+}
+
+func (c Config) getTemplates(key string) ([]*template.Template, error) {
+	value, ok := c.Data[key]
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var texts []string
+	err := json.Unmarshal([]byte(value), &texts)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: expected JSON array of strings: %w", key, err)
+	}
+	templates := make([]*template.Template, len(texts))
+	for i, text := range texts {
+		templates[i], err = template.New(key).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", key, err)
+		}
+		// Execute the template once to report references to unknown
+		// fields as configuration errors.
+		if _, err = execute(templates[i], TemplateData{}); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", key, err)
+		}
+	}
+	return templates, nil
+}
+
+// GetKinds returns kinds of workloads scanned by the configuration checker,
+// which are specified as a comma separated list. It returns nil if all
+// supported kinds are scanned.
+func (c Config) GetKinds() []string {
+	var kinds []string
+	for _, kind := range strings.Split(c.Data[c.key(keyKinds)], ",") {
+		if kind = strings.TrimSpace(kind); kind != "" {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// GetScanner returns the name and the vendor of the scanner, which default
+// to Generic and Starboard. The version is read from the tag of the scanner
+// image.
+func (c Config) GetScanner() (v1alpha1.Scanner, error) {
+	imageRef, err := c.GetImageRef()
+	if err != nil {
+		return v1alpha1.Scanner{}, err
+	}
+	version, err := starboard.GetVersionFromImageRef(imageRef)
+	if err != nil {
+		return v1alpha1.Scanner{}, fmt.Errorf("getting version from image ref: %w", err)
+	}
+	scanner := v1alpha1.Scanner{
+		Name:    Plugin,
+		Vendor:  "Starboard",
+		Version: version,
+	}
+	if name := c.Data[c.key(keyScannerName)]; name != "" {
+		scanner.Name = name
+	}
+	if vendor := c.Data[c.key(keyScannerVendor)]; vendor != "" {
+		scanner.Vendor = vendor
+	}
+	return scanner, nil
+}
+
+// GetMapping returns the Mapping of the scanner's output to the given fields.
+// The output.items and output.id expressions are required.
+func (c Config) GetMapping(fields []string) (*Mapping, error) {
+	itemsKey := c.key(keyOutputItems)
+	itemsExpression, err := c.GetRequiredData(itemsKey)
+	if err != nil {
+		return nil, err
+	}
+	items, err := parseJSONPath(itemsKey, itemsExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := &Mapping{
+		items:           items,
+		fields:          make(map[string]*jsonpath.JSONPath),
+		severityMap:     make(map[string]v1alpha1.Severity),
+		defaultSeverity: v1alpha1.SeverityUnknown,
+		successValue:    "true",
+	}
+	for _, field := range fields {
+		key := c.key(keyPrefixOutputField + field)
+		expression, ok := c.Data[key]
+		if !ok {
+			continue
+		}
+		mapping.fields[field], err = parseJSONPath(key, expression)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := mapping.fields[fieldID]; !ok {
+		return nil, fmt.Errorf("property %s not set", c.key(keyPrefixOutputField+fieldID))
+	}
+
+	if value, ok := c.Data[c.key(keyOutputDefaultSeverity)]; ok {
+		mapping.defaultSeverity, err = v1alpha1.StringToSeverity(strings.ToUpper(value))
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", c.key(keyOutputDefaultSeverity), err)
+		}
+	}
+	if value := c.Data[c.key(keyOutputSeverityMap)]; strings.TrimSpace(value) != "" {
+		for _, entry := range strings.Split(value, ",") {
+			from, to, ok := cut(entry, "=")
+			if !ok {
+				return nil, fmt.Errorf("parsing %s: expected <value>=<severity>, got %q", c.key(keyOutputSeverityMap), entry)
+			}
+			severity, err := v1alpha1.StringToSeverity(strings.ToUpper(strings.TrimSpace(to)))
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", c.key(keyOutputSeverityMap), err)
+			}
+			mapping.severityMap[strings.ToUpper(strings.TrimSpace(from))] = severity
+		}
+	}
+	if value, ok := c.Data[c.key(keyOutputSuccessValue)]; ok {
+		mapping.successValue = value
+	}
+	return mapping, nil
+}
+
+// Mapping extracts findings from the JSON output of the scanner. Expressions
+// of fields are evaluated against each item selected by the items expression.
+type Mapping struct {
+	items           *jsonpath.JSONPath
+	fields          map[string]*jsonpath.JSONPath
+	severityMap     map[string]v1alpha1.Severity
+	defaultSeverity v1alpha1.Severity
+	successValue    string
+}
+
+// Items returns items selected from the given output. Arrays are flattened,
+// so both {.results} and {.results[*]} select the same items.
+func (m *Mapping) Items(output interface{}) ([]interface{}, error) {
+	return findValues(m.items, output)
+}
+
+// String returns the first value of the given field, or an empty string if
+// the field is not mapped or its expression does not match.
+func (m *Mapping) String(item interface{}, field string) (string, error) {
+	values, err := m.Strings(item, field)
+	if err != nil || len(values) == 0 {
+		return "", err
+	}
+	return values[0], nil
+}
+
+// Strings returns all values of the given field.
+func (m *Mapping) Strings(item interface{}, field string) ([]string, error) {
+	expression, ok := m.fields[field]
+	if !ok {
+		return nil, nil
+	}
+	values, err := findValues(expression, item)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating %s: %w", field, err)
+	}
+	var strs []string
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		strs = append(strs, fmt.Sprint(value))
+	}
+	return strs, nil
+}
+
+// Severity returns the severity of the given item. Values of the severity
+// field are converted with the severity map, or used as is if they are valid
+// severities. Otherwise, the default severity is returned.
+func (m *Mapping) Severity(item interface{}) (v1alpha1.Severity, error) {
+	value, err := m.String(item, fieldSeverity)
+	if err != nil {
+		return "", err
+	}
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if severity, ok := m.severityMap[value]; ok {
+		return severity, nil
+	}
+	if severity, err := v1alpha1.StringToSeverity(value); err == nil {
+		return severity, nil
+	}
+	return m.defaultSeverity, nil
+}
+
+// Success returns true if the value of the success field equals the
+// configured success value, which defaults to true. Items are failed checks
+// if the success field is not mapped.
+func (m *Mapping) Success(item interface{}) (bool, error) {
+	if _, ok := m.fields[fieldSuccess]; !ok {
+		return false, nil
+	}
+	value, err := m.String(item, fieldSuccess)
+	if err != nil {
+		return false, err
+	}
+	return value == m.successValue, nil
+}
+
+func parseJSONPath(key, expression string) (*jsonpath.JSONPath, error) {
+	jp := jsonpath.New(key).AllowMissingKeys(true)
+	err := jp.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", key, err)
+	}
+	return jp, nil
+}
+
+func findValues(jp *jsonpath.JSONPath, data interface{}) ([]interface{}, error) {
+	results, err := jp.FindResults(data)
+	if err != nil {
+		return nil, err
+	}
+	var values []interface{}
+	for _, result := range results {
+		for _, value := range result {
+			if !value.IsValid() || !value.CanInterface() {
+				continue
+			}
+			if array, ok := value.Interface().([]interface{}); ok {
+				values = append(values, array...)
+				continue
+			}
+			values = append(values, value.Interface())
+		}
+	}
+	return values, nil
+}
+
+func execute(tmpl *template.Template, data TemplateData) (string, error) {
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func executeAll(templates []*template.Template, data TemplateData) ([]string, error) {
+	if templates == nil {
+		return nil, nil
+	}
+	values := make([]string, len(templates))
+	for i, tmpl := range templates {
+		value, err := execute(tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// cut slices s around the first instance of sep. It's a replacement for
+// strings.Cut, which is not available in Go 1.17.
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,6 @@
+// Package generic provides a configuration checker and a vulnerability
+// scanner plugin, which are defined entirely in the plugin's ConfigMap. The
+// ConfigMap specifies the container image of a scanner, its templated command
+// and arguments, and JSONPath expressions that map the scanner's JSON output
+// to v1alpha1.Check or v1alpha1.Vulnerability objects.
+package generic
@@ -0,0 +1,516 @@
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Plugin the name of this plugin.
+	Plugin = "Generic"
+)
+
+const (
+	containerName        = "generic"
+	workloadKey          = "starboard.workload.yaml"
+	workloadDir          = "/starboard"
+	workloadFile         = workloadDir + "/workload.yaml"
+	tmpVolumeName        = "tmp"
+	defaultCheckCategory = "Security"
+)
+
+var (
+	supportedKinds = []kube.Kind{
+		kube.KindPod,
+		kube.KindDeployment,
+		kube.KindReplicaSet,
+		kube.KindReplicationController,
+		kube.KindStatefulSet,
+		kube.KindDaemonSet,
+		kube.KindCronJob,
+		kube.KindJob,
+	}
+)
+
+type plugin struct {
+	clock  ext.Clock
+	prefix string
+	fields []string
+}
+
+// Init ensures the plugin's ConfigMap and validates the configuration, so
+// that an invalid image reference, template or output mapping fails fast.
+func (p *plugin) Init(ctx starboard.PluginContext) error {
+	err := ctx.EnsureConfig(starboard.PluginConfig{})
+	if err != nil {
+		return err
+	}
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = config.GetScanner(); err != nil {
+		return err
+	}
+	if _, err = config.GetCommand(); err != nil {
+		return err
+	}
+	if _, err = config.GetArgs(); err != nil {
+		return err
+	}
+	_, err = config.GetMapping(p.fields)
+	return err
+}
+
+func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
+	pluginConfig, err := ctx.GetConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("getting config: %w", err)
+	}
+	return Config{PluginConfig: pluginConfig, prefix: p.prefix}, nil
+}
+
+// newContainer returns the scan job's container with the given name, which
+// runs the scanner image with the command and arguments rendered from
+// templates.
+func (p *plugin) newContainer(config Config, name string, data TemplateData) (corev1.Container, error) {
+	imageRef, err := config.GetImageRef()
+	if err != nil {
+		return corev1.Container{}, err
+	}
+	commandTemplates, err := config.GetCommand()
+	if err != nil {
+		return corev1.Container{}, err
+	}
+	command, err := executeAll(commandTemplates, data)
+	if err != nil {
+		return corev1.Container{}, fmt.Errorf("rendering command: %w", err)
+	}
+	argsTemplates, err := config.GetArgs()
+	if err != nil {
+		return corev1.Container{}, err
+	}
+	args, err := executeAll(argsTemplates, data)
+	if err != nil {
+		return corev1.Container{}, fmt.Errorf("rendering args: %w", err)
+	}
+	return corev1.Container{
+		Name:                     name,
+		Image:                    imageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Command:                  command,
+		Args:                     args,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      tmpVolumeName,
+				MountPath: "/tmp",
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:               pointer.BoolPtr(false),
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"all"},
+			},
+			ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+		},
+	}, nil
+}
+
+func (p *plugin) newPodSpec(ctx starboard.PluginContext, containers []corev1.Container, volumes ...corev1.Volume) corev1.PodSpec {
+	return corev1.PodSpec{
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           ctx.GetServiceAccountName(),
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Volumes: append([]corev1.Volume{
+			{
+				Name: tmpVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						Medium: corev1.StorageMediumDefault,
+					},
+				},
+			},
+		}, volumes...),
+		Containers:      containers,
+		SecurityContext: &corev1.PodSecurityContext{},
+	}
+}
+
+// parseOutput decodes the JSON output of the scanner and returns items
+// selected by the output mapping.
+func (p *plugin) parseOutput(config Config, logsReader io.ReadCloser) (*Mapping, []interface{}, error) {
+	mapping, err := config.GetMapping(p.fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	var output interface{}
+	err = json.NewDecoder(logsReader).Decode(&output)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding scanner output: %w", err)
+	}
+	items, err := mapping.Items(output)
+	if err != nil {
+		return nil, nil, fmt.Errorf("evaluating items: %w", err)
+	}
+	return mapping, items, nil
+}
+
+type configAuditPlugin struct {
+	plugin
+}
+
+// NewConfigAuditPlugin constructs a new configauditreport.Plugin, which runs
+// the configuration checker specified by the generic.configAudit. keys of
+// the plugin's ConfigMap.
+func NewConfigAuditPlugin(clock ext.Clock) configauditreport.Plugin {
+	return &configAuditPlugin{
+		plugin: plugin{
+			clock:  clock,
+			prefix: keyPrefixConfigAudit,
+			fields: configAuditFields,
+		},
+	}
+}
+
+func (p *configAuditPlugin) SupportedKinds() []kube.Kind {
+	return supportedKinds
+}
+
+// IsApplicable returns true if the kind of the given object is one of the
+// configured kinds, or if kinds are not configured.
+func (p *configAuditPlugin) IsApplicable(ctx starboard.PluginContext, obj client.Object) (bool, string, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return false, "", err
+	}
+	kinds := config.GetKinds()
+	if len(kinds) == 0 {
+		return true, "", nil
+	}
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	for _, k := range kinds {
+		if k == kind {
+			return true, "", nil
+		}
+	}
+	return false, fmt.Sprintf("kind %s is not configured", kind), nil
+}
+
+// ConfigHash returns the hash of all settings of the configuration checker.
+func (p *configAuditPlugin) ConfigHash(ctx starboard.PluginContext, _ kube.Kind) (string, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return "", err
+	}
+	data := make(map[string]string)
+	for key, value := range config.Data {
+		if strings.HasPrefix(key, p.prefix) {
+			data[key] = value
+		}
+	}
+	return kube.ComputeHash(data), nil
+}
+
+func (p *configAuditPlugin) GetContainerName() string {
+	return containerName
+}
+
+// GetScanJobSpec returns the spec of the scan job's pod. The YAML manifest of
+// the given workload is mounted from a Secret as a file, whose path is passed
+// to templates as the WorkloadFile field.
+func (p *configAuditPlugin) GetScanJobSpec(ctx starboard.PluginContext, obj client.Object) (corev1.PodSpec, []*corev1.Secret, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	workloadAsYAML, err := yaml.Marshal(obj)
+	if err != nil {
+		return corev1.PodSpec{}, nil, fmt.Errorf("marshalling workload: %w", err)
+	}
+
+	container, err := p.newContainer(config, containerName, TemplateData{
+		Kind:         obj.GetObjectKind().GroupVersionKind().Kind,
+		Name:         obj.GetName(),
+		Namespace:    obj.GetNamespace(),
+		WorkloadFile: workloadFile,
+	})
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	secretName := configauditreport.GetScanJobName(obj) + "-volume"
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      secretName,
+		MountPath: workloadDir,
+		ReadOnly:  true,
+	})
+
+	volume := corev1.Volume{
+		Name: secretName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  workloadKey,
+						Path: "workload.yaml",
+					},
+				},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: ctx.GetNamespace(),
+		},
+		StringData: map[string]string{
+			workloadKey: string(workloadAsYAML),
+		},
+	}
+	return p.newPodSpec(ctx, []corev1.Container{container}, volume), []*corev1.Secret{secret}, nil
+}
+
+// ParseConfigAuditReportData maps items of the scanner's JSON output to
+// v1alpha1.Check objects.
+func (p *configAuditPlugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsReader io.ReadCloser) (v1alpha1.ConfigAuditReportData, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return v1alpha1.ConfigAuditReportData{}, err
+	}
+	scanner, err := config.GetScanner()
+	if err != nil {
+		return v1alpha1.ConfigAuditReportData{}, err
+	}
+	mapping, items, err := p.parseOutput(config, logsReader)
+	if err != nil {
+		return v1alpha1.ConfigAuditReportData{}, err
+	}
+
+	checks := make([]v1alpha1.Check, 0, len(items))
+	for _, item := range items {
+		check, err := p.toCheck(mapping, item)
+		if err != nil {
+			return v1alpha1.ConfigAuditReportData{}, err
+		}
+		checks = append(checks, check)
+	}
+
+	return v1alpha1.ConfigAuditReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner:         scanner,
+		Summary:         v1alpha1.ConfigAuditSummaryFromChecks(checks),
+		Checks:          checks,
+		// TODO Deprecate PodChecks and ContainerChecks in 0.12+
+		PodChecks:       checks,
+		ContainerChecks: map[string][]v1alpha1.Check{},
+	}, nil
+}
+
+func (p *configAuditPlugin) toCheck(mapping *Mapping, item interface{}) (v1alpha1.Check, error) {
+	var check v1alpha1.Check
+	var err error
+	for field, value := range map[string]*string{
+		fieldID:          &check.ID,
+		fieldTitle:       &check.Title,
+		fieldDescription: &check.Description,
+		fieldCategory:    &check.Category,
+		fieldRemediation: &check.Remediation,
+	} {
+		if *value, err = mapping.String(item, field); err != nil {
+			return v1alpha1.Check{}, err
+		}
+	}
+	if check.Category == "" {
+		check.Category = defaultCheckCategory
+	}
+	if check.Messages, err = mapping.Strings(item, fieldMessages); err != nil {
+		return v1alpha1.Check{}, err
+	}
+	if check.Severity, err = mapping.Severity(item); err != nil {
+		return v1alpha1.Check{}, err
+	}
+	if check.Success, err = mapping.Success(item); err != nil {
+		return v1alpha1.Check{}, err
+	}
+	return check, nil
+}
+
+type vulnerabilityPlugin struct {
+	plugin
+}
+
+// NewVulnerabilityPlugin constructs a new vulnerabilityreport.Plugin, which
+// runs the vulnerability scanner specified by the generic.vulnerability. keys
+// of the plugin's ConfigMap.
+func NewVulnerabilityPlugin(clock ext.Clock) vulnerabilityreport.Plugin {
+	return &vulnerabilityPlugin{
+		plugin: plugin{
+			clock:  clock,
+			prefix: keyPrefixVulnerability,
+			fields: vulnerabilityFields,
+		},
+	}
+}
+
+// GetScanJobSpec returns the spec of the scan job's pod with one container
+// for each container of the given workload. Registry credentials are passed
+// to the scanner as the STARBOARD_REGISTRY_USERNAME and
+// STARBOARD_REGISTRY_PASSWORD environment variables.
+func (p *vulnerabilityPlugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	spec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	var secret *corev1.Secret
+	var secrets []*corev1.Secret
+	if len(credentials) > 0 {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: vulnerabilityreport.RegistryCredentialsSecretName(workload),
+			},
+			Data: kube.AggregateImagePullSecretsData(kube.GetContainerImagesFromPodSpec(spec), credentials),
+		}
+		secrets = append(secrets, secret)
+	}
+
+	var containers []corev1.Container
+	for _, c := range kube.GetContainers(spec) {
+		container, err := p.newContainer(config, c.Name, TemplateData{
+			Kind:          workload.GetObjectKind().GroupVersionKind().Kind,
+			Name:          workload.GetName(),
+			Namespace:     workload.GetNamespace(),
+			ContainerName: c.Name,
+			Image:         c.Image,
+		})
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+		if _, ok := credentials[c.Name]; ok && secret != nil {
+			container.Env = append(container.Env,
+				newEnvVarFromSecret("STARBOARD_REGISTRY_USERNAME", secret.Name, c.Name+".username"),
+				newEnvVarFromSecret("STARBOARD_REGISTRY_PASSWORD", secret.Name, c.Name+".password"),
+			)
+		}
+		containers = append(containers, container)
+	}
+
+	return p.newPodSpec(ctx, containers), secrets, nil
+}
+
+// ParseVulnerabilityReportData maps items of the scanner's JSON output to
+// v1alpha1.Vulnerability objects.
+func (p *vulnerabilityPlugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	scanner, err := config.GetScanner()
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	mapping, items, err := p.parseOutput(config, logsReader)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0, len(items))
+	for _, item := range items {
+		vulnerability, err := p.toVulnerability(mapping, item)
+		if err != nil {
+			return v1alpha1.VulnerabilityReportData{}, err
+		}
+		vulnerabilities = append(vulnerabilities, vulnerability)
+	}
+
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	artifact := v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+	}
+	switch t := ref.(type) {
+	case name.Tag:
+		artifact.Tag = t.TagStr()
+	case name.Digest:
+		artifact.Digest = t.DigestStr()
+	}
+
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner:         scanner,
+		Registry: v1alpha1.Registry{
+			Server: ref.Context().RegistryStr(),
+		},
+		Artifact:        artifact,
+		Summary:         v1alpha1.VulnerabilitySummaryFromVulnerabilities(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}, nil
+}
+
+func (p *vulnerabilityPlugin) toVulnerability(mapping *Mapping, item interface{}) (v1alpha1.Vulnerability, error) {
+	var vulnerability v1alpha1.Vulnerability
+	var err error
+	for field, value := range map[string]*string{
+		fieldID:               &vulnerability.VulnerabilityID,
+		fieldTitle:            &vulnerability.Title,
+		fieldDescription:      &vulnerability.Description,
+		fieldResource:         &vulnerability.Resource,
+		fieldInstalledVersion: &vulnerability.InstalledVersion,
+		fieldFixedVersion:     &vulnerability.FixedVersion,
+		fieldPrimaryLink:      &vulnerability.PrimaryLink,
+	} {
+		if *value, err = mapping.String(item, field); err != nil {
+			return v1alpha1.Vulnerability{}, err
+		}
+	}
+	if vulnerability.Severity, err = mapping.Severity(item); err != nil {
+		return v1alpha1.Vulnerability{}, err
+	}
+	if vulnerability.Links, err = mapping.Strings(item, fieldLinks); err != nil {
+		return v1alpha1.Vulnerability{}, err
+	}
+	if vulnerability.Links == nil {
+		vulnerability.Links = []string{}
+	}
+	return vulnerability, nil
+}
+
+func newEnvVarFromSecret(envName, secretName, secretKey string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: envName,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: secretKey,
+			},
+		},
+	}
+}
@@ -0,0 +1,401 @@
+package generic_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/plugin/generic"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var fixedTime = time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+
+// kubeScoreConfig maps the JSON output of kube-score, where each object has
+// checks graded 1 (critical), 5 (warning), or 10 (ok).
+var kubeScoreConfig = map[string]string{
+	"generic.configAudit.imageRef":                  "zegl/kube-score:v1.14.0",
+	"generic.configAudit.command":                   `["kube-score"]`,
+	"generic.configAudit.args":                      `["score", "--output-format", "json", "{{ .WorkloadFile }}"]`,
+	"generic.configAudit.scanner.name":              "kube-score",
+	"generic.configAudit.scanner.vendor":            "zegl",
+	"generic.configAudit.output.items":              "{[*].checks[*]}",
+	"generic.configAudit.output.id":                 "{.check.id}",
+	"generic.configAudit.output.title":              "{.check.name}",
+	"generic.configAudit.output.description":        "{.check.comment}",
+	"generic.configAudit.output.severity":           "{.grade}",
+	"generic.configAudit.output.severityMap":        "1=CRITICAL,5=MEDIUM,10=NONE",
+	"generic.configAudit.output.messages":           "{.comments[*].summary}",
+	"generic.configAudit.output.success":            "{.grade}",
+	"generic.configAudit.output.successValue":       "10",
+	"generic.vulnerability.imageRef":                "acme/scanner:0.1.0",
+	"generic.vulnerability.args":                    `["--image", "{{ .Image }}", "--user", "$(STARBOARD_REGISTRY_USERNAME)"]`,
+	"generic.vulnerability.output.items":            "{.results}",
+	"generic.vulnerability.output.id":               "{.id}",
+	"generic.vulnerability.output.severity":         "{.severity}",
+	"generic.vulnerability.output.resource":         "{.package.name}",
+	"generic.vulnerability.output.installedVersion": "{.package.version}",
+	"generic.vulnerability.output.fixedVersion":     "{.fix}",
+	"generic.vulnerability.output.links":            "{.urls}",
+	"generic.vulnerability.output.primaryLink":      "{.urls[0]}",
+}
+
+const kubeScoreOutput = `[
+  {
+    "object_name": "nginx",
+    "checks": [
+      {
+        "check": {"name": "Container Resources", "id": "container-resources", "comment": "Makes sure that all pods have resource limits and requests set."},
+        "grade": 1,
+        "comments": [
+          {"path": "nginx", "summary": "CPU limit is not set"},
+          {"path": "nginx", "summary": "Memory limit is not set"}
+        ]
+      },
+      {
+        "check": {"name": "Pod Probes", "id": "pod-probes", "comment": "Makes sure that all Pods have safe probe configurations"},
+        "grade": 5,
+        "comments": [
+          {"path": "", "summary": "Container is missing a readinessProbe"}
+        ]
+      },
+      {
+        "check": {"name": "Container Image Tag", "id": "container-image-tag", "comment": "Makes sure that a explicit non-latest tag is used"},
+        "grade": 10,
+        "comments": []
+      }
+    ]
+  }
+]`
+
+const vulnerabilityOutput = `{
+  "results": [
+    {
+      "id": "CVE-2022-0001",
+      "severity": "high",
+      "package": {"name": "openssl", "version": "1.1.1k"},
+      "fix": "1.1.1n",
+      "urls": ["https://avd.aquasec.com/nvd/cve-2022-0001", "https://nvd.nist.gov/vuln/detail/CVE-2022-0001"]
+    },
+    {
+      "id": "CVE-2022-0002",
+      "severity": "negligible",
+      "package": {"name": "zlib", "version": "1.2.11"}
+    }
+  ]
+}`
+
+func newPluginContext(data map[string]string) starboard.PluginContext {
+	builder := fake.NewClientBuilder().WithScheme(starboard.NewScheme())
+	if data != nil {
+		builder = builder.WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-generic-config",
+				Namespace: "starboard-system",
+			},
+			Data: data,
+		})
+	}
+	return starboard.NewPluginContext().
+		WithName(generic.Plugin).
+		WithNamespace("starboard-system").
+		WithServiceAccountName("starboard-sa").
+		WithClient(builder.Build()).
+		Get()
+}
+
+func withConfig(overrides map[string]string) map[string]string {
+	data := make(map[string]string)
+	for key, value := range kubeScoreConfig {
+		data[key] = value
+	}
+	for key, value := range overrides {
+		if value == "" {
+			delete(data, key)
+			continue
+		}
+		data[key] = value
+	}
+	return data
+}
+
+var deployment = &appsv1.Deployment{
+	TypeMeta: metav1.TypeMeta{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+	},
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "nginx",
+		Namespace: "prod",
+	},
+	Spec: appsv1.DeploymentSpec{
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "nginx",
+						Image: "nginx:1.16",
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestPlugin_Init(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        map[string]string
+		expectedError string
+	}{
+		{
+			name:   "Should accept valid config",
+			config: kubeScoreConfig,
+		},
+		{
+			name:          "Should return error when config is missing",
+			expectedError: "property generic.configAudit.imageRef not set",
+		},
+		{
+			name:          "Should return error when items expression is missing",
+			config:        withConfig(map[string]string{"generic.configAudit.output.items": ""}),
+			expectedError: "property generic.configAudit.output.items not set",
+		},
+		{
+			name:          "Should return error when id expression is missing",
+			config:        withConfig(map[string]string{"generic.configAudit.output.id": ""}),
+			expectedError: "property generic.configAudit.output.id not set",
+		},
+		{
+			name:          "Should return error when JSONPath expression is invalid",
+			config:        withConfig(map[string]string{"generic.configAudit.output.title": "{.check.name"}),
+			expectedError: "parsing generic.configAudit.output.title: unclosed action",
+		},
+		{
+			name:          "Should return error when args is not JSON array",
+			config:        withConfig(map[string]string{"generic.configAudit.args": "score {{ .WorkloadFile }}"}),
+			expectedError: "parsing generic.configAudit.args: expected JSON array of strings: invalid character 's' looking for beginning of value",
+		},
+		{
+			name:          "Should return error when template refers to unknown field",
+			config:        withConfig(map[string]string{"generic.configAudit.args": `["{{ .Workload }}"]`}),
+			expectedError: `parsing generic.configAudit.args: template: generic.configAudit.args:1:3: executing "generic.configAudit.args" at <.Workload>: can't evaluate field Workload in type generic.TemplateData`,
+		},
+		{
+			name:          "Should return error when severity map is invalid",
+			config:        withConfig(map[string]string{"generic.configAudit.output.severityMap": "1=SEVERE"}),
+			expectedError: "parsing generic.configAudit.output.severityMap: unrecognized name literal: SEVERE",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := generic.NewConfigAuditPlugin(ext.NewFixedClock(fixedTime)).Init(newPluginContext(tc.config))
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Should validate vulnerability scanner config", func(t *testing.T) {
+		err := generic.NewVulnerabilityPlugin(ext.NewFixedClock(fixedTime)).Init(newPluginContext(
+			withConfig(map[string]string{"generic.vulnerability.imageRef": ""})))
+		assert.EqualError(t, err, "property generic.vulnerability.imageRef not set")
+	})
+}
+
+func TestConfigAuditPlugin_GetScanJobSpec(t *testing.T) {
+	plugin := generic.NewConfigAuditPlugin(ext.NewFixedClock(fixedTime))
+	podSpec, secrets, err := plugin.GetScanJobSpec(newPluginContext(kubeScoreConfig), deployment)
+	require.NoError(t, err)
+
+	require.Len(t, podSpec.Containers, 1)
+	container := podSpec.Containers[0]
+	assert.Equal(t, "generic", container.Name)
+	assert.Equal(t, "zegl/kube-score:v1.14.0", container.Image)
+	assert.Equal(t, []string{"kube-score"}, container.Command)
+	assert.Equal(t, []string{"score", "--output-format", "json", "/starboard/workload.yaml"}, container.Args)
+	assert.Equal(t, "starboard-sa", podSpec.ServiceAccountName)
+	assert.Equal(t, corev1.RestartPolicyNever, podSpec.RestartPolicy)
+
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "starboard-system", secrets[0].Namespace)
+	assert.Contains(t, secrets[0].StringData["starboard.workload.yaml"], "name: nginx")
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      secrets[0].Name,
+		MountPath: "/starboard",
+		ReadOnly:  true,
+	})
+}
+
+func TestConfigAuditPlugin_ParseConfigAuditReportData(t *testing.T) {
+	plugin := generic.NewConfigAuditPlugin(ext.NewFixedClock(fixedTime))
+	data, err := plugin.ParseConfigAuditReportData(newPluginContext(kubeScoreConfig),
+		io.NopCloser(strings.NewReader(kubeScoreOutput)))
+	require.NoError(t, err)
+
+	checks := []v1alpha1.Check{
+		{
+			ID:          "container-resources",
+			Title:       "Container Resources",
+			Description: "Makes sure that all pods have resource limits and requests set.",
+			Severity:    v1alpha1.SeverityCritical,
+			Category:    "Security",
+			Messages:    []string{"CPU limit is not set", "Memory limit is not set"},
+		},
+		{
+			ID:          "pod-probes",
+			Title:       "Pod Probes",
+			Description: "Makes sure that all Pods have safe probe configurations",
+			Severity:    v1alpha1.SeverityMedium,
+			Category:    "Security",
+			Messages:    []string{"Container is missing a readinessProbe"},
+		},
+		{
+			ID:          "container-image-tag",
+			Title:       "Container Image Tag",
+			Description: "Makes sure that a explicit non-latest tag is used",
+			Severity:    v1alpha1.SeverityNone,
+			Category:    "Security",
+			Success:     true,
+		},
+	}
+	assert.Equal(t, v1alpha1.ConfigAuditReportData{
+		UpdateTimestamp: metav1.NewTime(fixedTime),
+		Scanner: v1alpha1.Scanner{
+			Name:    "kube-score",
+			Vendor:  "zegl",
+			Version: "v1.14.0",
+		},
+		Summary:         v1alpha1.ConfigAuditSummaryFromChecks(checks),
+		Checks:          checks,
+		PodChecks:       checks,
+		ContainerChecks: map[string][]v1alpha1.Check{},
+	}, data)
+}
+
+func TestConfigAuditPlugin_IsApplicable(t *testing.T) {
+	plugin := generic.NewConfigAuditPlugin(ext.NewFixedClock(fixedTime))
+
+	applicable, reason, err := plugin.IsApplicable(newPluginContext(kubeScoreConfig), deployment)
+	require.NoError(t, err)
+	assert.True(t, applicable)
+	assert.Empty(t, reason)
+
+	applicable, reason, err = plugin.IsApplicable(newPluginContext(withConfig(map[string]string{
+		"generic.configAudit.kinds": "Pod, StatefulSet",
+	})), deployment)
+	require.NoError(t, err)
+	assert.False(t, applicable)
+	assert.Equal(t, "kind Deployment is not configured", reason)
+}
+
+func TestConfigAuditPlugin_ConfigHash(t *testing.T) {
+	plugin := generic.NewConfigAuditPlugin(ext.NewFixedClock(fixedTime))
+
+	hash, err := plugin.ConfigHash(newPluginContext(kubeScoreConfig), kube.KindDeployment)
+	require.NoError(t, err)
+
+	t.Run("Should not change when vulnerability scanner config changes", func(t *testing.T) {
+		other, err := plugin.ConfigHash(newPluginContext(withConfig(map[string]string{
+			"generic.vulnerability.imageRef": "acme/scanner:0.2.0",
+		})), kube.KindDeployment)
+		require.NoError(t, err)
+		assert.Equal(t, hash, other)
+	})
+
+	t.Run("Should change when config audit config changes", func(t *testing.T) {
+		other, err := plugin.ConfigHash(newPluginContext(withConfig(map[string]string{
+			"generic.configAudit.imageRef": "zegl/kube-score:v1.15.0",
+		})), kube.KindDeployment)
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+}
+
+func TestVulnerabilityPlugin_GetScanJobSpec(t *testing.T) {
+	plugin := generic.NewVulnerabilityPlugin(ext.NewFixedClock(fixedTime))
+	podSpec, secrets, err := plugin.GetScanJobSpec(newPluginContext(kubeScoreConfig), deployment, map[string]docker.Auth{
+		"nginx": {Username: "robot", Password: "s3cr3t"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, secrets, 1)
+	assert.Equal(t, map[string][]byte{
+		"nginx.username": []byte("robot"),
+		"nginx.password": []byte("s3cr3t"),
+	}, secrets[0].Data)
+
+	require.Len(t, podSpec.Containers, 1)
+	container := podSpec.Containers[0]
+	assert.Equal(t, "nginx", container.Name)
+	assert.Equal(t, "acme/scanner:0.1.0", container.Image)
+	assert.Nil(t, container.Command)
+	assert.Equal(t, []string{"--image", "nginx:1.16", "--user", "$(STARBOARD_REGISTRY_USERNAME)"}, container.Args)
+	require.Len(t, container.Env, 2)
+	assert.Equal(t, "STARBOARD_REGISTRY_USERNAME", container.Env[0].Name)
+	assert.Equal(t, &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: secrets[0].Name},
+		Key:                  "nginx.username",
+	}, container.Env[0].ValueFrom.SecretKeyRef)
+	assert.Equal(t, "STARBOARD_REGISTRY_PASSWORD", container.Env[1].Name)
+}
+
+func TestVulnerabilityPlugin_ParseVulnerabilityReportData(t *testing.T) {
+	plugin := generic.NewVulnerabilityPlugin(ext.NewFixedClock(fixedTime))
+	data, err := plugin.ParseVulnerabilityReportData(newPluginContext(withConfig(map[string]string{
+		"generic.vulnerability.output.defaultSeverity": "LOW",
+	})), "docker.io/library/nginx:1.16", io.NopCloser(strings.NewReader(vulnerabilityOutput)))
+	require.NoError(t, err)
+
+	vulnerabilities := []v1alpha1.Vulnerability{
+		{
+			VulnerabilityID:  "CVE-2022-0001",
+			Resource:         "openssl",
+			InstalledVersion: "1.1.1k",
+			FixedVersion:     "1.1.1n",
+			Severity:         v1alpha1.SeverityHigh,
+			PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2022-0001",
+			Links: []string{
+				"https://avd.aquasec.com/nvd/cve-2022-0001",
+				"https://nvd.nist.gov/vuln/detail/CVE-2022-0001",
+			},
+		},
+		{
+			VulnerabilityID:  "CVE-2022-0002",
+			Resource:         "zlib",
+			InstalledVersion: "1.2.11",
+			Severity:         v1alpha1.SeverityLow,
+			Links:            []string{},
+		},
+	}
+	assert.Equal(t, v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(fixedTime),
+		Scanner: v1alpha1.Scanner{
+			Name:    "Generic",
+			Vendor:  "Starboard",
+			Version: "0.1.0",
+		},
+		Registry: v1alpha1.Registry{
+			Server: "index.docker.io",
+		},
+		Artifact: v1alpha1.Artifact{
+			Repository: "library/nginx",
+			Tag:        "1.16",
+		},
+		Summary:         v1alpha1.VulnerabilitySummaryFromVulnerabilities(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}, data)
+}
@@ -0,0 +1,2 @@
+// Package grype provides primitives for working with Grype.
+package grype
@@ -0,0 +1,63 @@
+package grype
+
+// ScanReport is the report printed by Grype with the --output json flag.
+type ScanReport struct {
+	Matches    []Match    `json:"matches"`
+	Descriptor Descriptor `json:"descriptor"`
+}
+
+// Match is a vulnerability found in a package of the scanned image.
+type Match struct {
+	Vulnerability          Vulnerability           `json:"vulnerability"`
+	RelatedVulnerabilities []VulnerabilityMetadata `json:"relatedVulnerabilities"`
+	Artifact               Package                 `json:"artifact"`
+}
+
+// VulnerabilityMetadata describes a vulnerability as published by the given
+// data source, e.g. NVD or a Linux distribution security tracker.
+type VulnerabilityMetadata struct {
+	ID          string   `json:"id"`
+	DataSource  string   `json:"dataSource"`
+	Namespace   string   `json:"namespace"`
+	Severity    string   `json:"severity"`
+	URLs        []string `json:"urls"`
+	Description string   `json:"description"`
+	CVSS        []CVSS   `json:"cvss"`
+}
+
+// Vulnerability is the VulnerabilityMetadata of a Match with the versions in
+// which it was fixed.
+type Vulnerability struct {
+	VulnerabilityMetadata
+	Fix Fix `json:"fix"`
+}
+
+type Fix struct {
+	Versions []string `json:"versions"`
+	State    string   `json:"state"`
+}
+
+type CVSS struct {
+	Version string      `json:"version"`
+	Vector  string      `json:"vector"`
+	Metrics CVSSMetrics `json:"metrics"`
+}
+
+type CVSSMetrics struct {
+	BaseScore float64 `json:"baseScore"`
+}
+
+// Package is a package of the scanned image, e.g. an OS package or an
+// application dependency.
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+	PURL    string `json:"purl"`
+}
+
+// Descriptor describes the Grype binary that generated a ScanReport.
+type Descriptor struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
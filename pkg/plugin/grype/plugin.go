@@ -0,0 +1,573 @@
+package grype
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Plugin the name of this plugin.
+	Plugin = "Grype"
+)
+
+const (
+	keyGrypeImageRef               = "grype.imageRef"
+	keyGrypeDBUpdateURL            = "grype.dbUpdateURL"
+	keyGrypeOnlyFixed              = "grype.onlyFixed"
+	keyGrypeInsecureRegistryPrefix = "grype.insecureRegistry."
+	keyGrypeNonSslRegistryPrefix   = "grype.nonSslRegistry."
+	keyGrypeHTTPProxy              = "grype.httpProxy"
+	keyGrypeHTTPSProxy             = "grype.httpsProxy"
+	keyGrypeNoProxy                = "grype.noProxy"
+
+	keyResourcesRequestsCPU    = "grype.resources.requests.cpu"
+	keyResourcesRequestsMemory = "grype.resources.requests.memory"
+	keyResourcesLimitsCPU      = "grype.resources.limits.cpu"
+	keyResourcesLimitsMemory   = "grype.resources.limits.memory"
+)
+
+const (
+	tmpVolumeName = "tmp"
+	dbCacheDir    = "/tmp/grype/db"
+)
+
+// Config defines configuration params for this plugin.
+type Config struct {
+	starboard.PluginConfig
+}
+
+// GetImageRef returns upstream Grype container image reference.
+func (c Config) GetImageRef() (string, error) {
+	return c.GetRequiredData(keyGrypeImageRef)
+}
+
+// OnlyFixed returns true if Grype should report only vulnerabilities that
+// have been fixed.
+func (c Config) OnlyFixed() bool {
+	_, ok := c.Data[keyGrypeOnlyFixed]
+	return ok
+}
+
+func (c Config) GetInsecureRegistries() map[string]bool {
+	insecureRegistries := make(map[string]bool)
+	for key, val := range c.Data {
+		if strings.HasPrefix(key, keyGrypeInsecureRegistryPrefix) {
+			insecureRegistries[val] = true
+		}
+	}
+
+	return insecureRegistries
+}
+
+func (c Config) GetNonSSLRegistries() map[string]bool {
+	nonSSLRegistries := make(map[string]bool)
+	for key, val := range c.Data {
+		if strings.HasPrefix(key, keyGrypeNonSslRegistryPrefix) {
+			nonSSLRegistries[val] = true
+		}
+	}
+
+	return nonSSLRegistries
+}
+
+// GetResourceRequirements creates ResourceRequirements from the Config.
+func (c Config) GetResourceRequirements() (corev1.ResourceRequirements, error) {
+	requirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+
+	err := c.setResourceLimit(keyResourcesRequestsCPU, &requirements.Requests, corev1.ResourceCPU)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesRequestsMemory, &requirements.Requests, corev1.ResourceMemory)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesLimitsCPU, &requirements.Limits, corev1.ResourceCPU)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesLimitsMemory, &requirements.Limits, corev1.ResourceMemory)
+	if err != nil {
+		return requirements, err
+	}
+
+	return requirements, nil
+}
+
+func (c Config) setResourceLimit(configKey string, k8sResourceList *corev1.ResourceList, k8sResourceName corev1.ResourceName) error {
+	if value, found := c.Data[configKey]; found {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("parsing resource definition %s: %s %w", configKey, value, err)
+		}
+
+		(*k8sResourceList)[k8sResourceName] = quantity
+	}
+	return nil
+}
+
+type plugin struct {
+	clock       ext.Clock
+	idGenerator ext.IDGenerator
+}
+
+// NewPlugin constructs a new vulnerabilityreport.Plugin, which is using an
+// upstream Grype container image to scan Kubernetes workloads.
+//
+// Grype pulls scanned images directly from their registries, therefore
+// private registries are supported by passing docker.Auth credentials to the
+// scan job as environment variables.
+func NewPlugin(clock ext.Clock, idGenerator ext.IDGenerator) vulnerabilityreport.Plugin {
+	return &plugin{
+		clock:       clock,
+		idGenerator: idGenerator,
+	}
+}
+
+// Init ensures the default Config required by this plugin.
+func (p *plugin) Init(ctx starboard.PluginContext) error {
+	return ctx.EnsureConfig(starboard.PluginConfig{
+		Data: map[string]string{
+			keyGrypeImageRef: "docker.io/anchore/grype:v0.38.0",
+
+			keyResourcesRequestsCPU:    "100m",
+			keyResourcesRequestsMemory: "100M",
+			keyResourcesLimitsCPU:      "500m",
+			keyResourcesLimitsMemory:   "1G",
+		},
+	})
+}
+
+// GetScanJobSpec returns the spec of the pod with the init container
+// responsible for downloading the latest Grype vulnerability database and
+// storing it to the emptyDir volume shared with main containers:
+//
+//     grype db update
+//
+// The number of main containers correspond to the number of containers
+// defined for the scanned workload. Each container pulls the image directly
+// from its registry and scans it without updating the database:
+//
+//     grype registry:<container image> --output json --quiet
+func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	var secret *corev1.Secret
+	var secrets []*corev1.Secret
+
+	spec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	if len(credentials) > 0 {
+		secret = p.newSecretWithAggregateImagePullCredentials(workload, spec, credentials)
+		secrets = append(secrets, secret)
+	}
+
+	grypeImageRef, err := config.GetImageRef()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	grypeConfigName := starboard.GetPluginConfigMapName(Plugin)
+
+	requirements, err := config.GetResourceRequirements()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      tmpVolumeName,
+			ReadOnly:  false,
+			MountPath: "/tmp",
+		},
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: tmpVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumDefault,
+				},
+			},
+		},
+	}
+
+	securityContext := &corev1.SecurityContext{
+		Privileged:               pointer.BoolPtr(false),
+		AllowPrivilegeEscalation: pointer.BoolPtr(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"all"},
+		},
+		ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+	}
+
+	initContainer := corev1.Container{
+		Name:                     p.idGenerator.GenerateID(),
+		Image:                    grypeImageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Env: append(p.newCommonEnv(grypeConfigName),
+			constructEnvVarSourceFromConfigMap("GRYPE_DB_UPDATE_URL", grypeConfigName, keyGrypeDBUpdateURL),
+		),
+		Command: []string{
+			"/grype",
+		},
+		Args: []string{
+			"db",
+			"update",
+		},
+		Resources:       requirements,
+		VolumeMounts:    volumeMounts,
+		SecurityContext: securityContext,
+	}
+
+	var containers []corev1.Container
+
+	for _, c := range kube.GetContainers(spec) {
+		env := append(p.newCommonEnv(grypeConfigName), corev1.EnvVar{
+			Name:  "GRYPE_DB_AUTO_UPDATE",
+			Value: "false",
+		})
+
+		ref, err := name.ParseReference(c.Image)
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+
+		if _, ok := credentials[c.Name]; ok && secret != nil {
+			registryUsernameKey := fmt.Sprintf("%s.username", c.Name)
+			registryPasswordKey := fmt.Sprintf("%s.password", c.Name)
+
+			env = append(env, corev1.EnvVar{
+				Name:  "GRYPE_REGISTRY_AUTH_AUTHORITY",
+				Value: ref.Context().RegistryStr(),
+			}, corev1.EnvVar{
+				Name: "GRYPE_REGISTRY_AUTH_USERNAME",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secret.Name,
+						},
+						Key: registryUsernameKey,
+					},
+				},
+			}, corev1.EnvVar{
+				Name: "GRYPE_REGISTRY_AUTH_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secret.Name,
+						},
+						Key: registryPasswordKey,
+					},
+				},
+			})
+		}
+
+		if config.GetInsecureRegistries()[ref.Context().RegistryStr()] {
+			env = append(env, corev1.EnvVar{
+				Name:  "GRYPE_REGISTRY_INSECURE_SKIP_TLS_VERIFY",
+				Value: "true",
+			})
+		}
+
+		if config.GetNonSSLRegistries()[ref.Context().RegistryStr()] {
+			env = append(env, corev1.EnvVar{
+				Name:  "GRYPE_REGISTRY_INSECURE_USE_HTTP",
+				Value: "true",
+			})
+		}
+
+		args := []string{
+			fmt.Sprintf("registry:%s", c.Image),
+			"--output",
+			"json",
+			"--quiet",
+		}
+		if config.OnlyFixed() {
+			args = append(args, "--only-fixed")
+		}
+
+		containers = append(containers, corev1.Container{
+			Name:                     c.Name,
+			Image:                    grypeImageRef,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			Env:                      env,
+			Command: []string{
+				"/grype",
+			},
+			Args:            args,
+			Resources:       requirements,
+			VolumeMounts:    volumeMounts,
+			SecurityContext: securityContext,
+		})
+	}
+
+	return corev1.PodSpec{
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           ctx.GetServiceAccountName(),
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Volumes:                      volumes,
+		InitContainers:               []corev1.Container{initContainer},
+		Containers:                   containers,
+		SecurityContext:              &corev1.PodSecurityContext{},
+	}, secrets, nil
+}
+
+// newCommonEnv returns environment variables shared by the init container and
+// main containers, which point Grype to the database stored in the emptyDir
+// volume and configure proxy settings.
+func (p *plugin) newCommonEnv(grypeConfigName string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:  "GRYPE_DB_CACHE_DIR",
+			Value: dbCacheDir,
+		},
+		{
+			Name:  "GRYPE_CHECK_FOR_APP_UPDATE",
+			Value: "false",
+		},
+		{
+			Name:  "TMPDIR",
+			Value: "/tmp",
+		},
+		constructEnvVarSourceFromConfigMap("HTTP_PROXY", grypeConfigName, keyGrypeHTTPProxy),
+		constructEnvVarSourceFromConfigMap("HTTPS_PROXY", grypeConfigName, keyGrypeHTTPSProxy),
+		constructEnvVarSourceFromConfigMap("NO_PROXY", grypeConfigName, keyGrypeNoProxy),
+	}
+}
+
+func (p *plugin) newSecretWithAggregateImagePullCredentials(obj client.Object, spec corev1.PodSpec, credentials map[string]docker.Auth) *corev1.Secret {
+	containerImages := kube.GetContainerImagesFromPodSpec(spec)
+	secretData := kube.AggregateImagePullSecretsData(containerImages, credentials)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: vulnerabilityreport.RegistryCredentialsSecretName(obj),
+		},
+		Data: secretData,
+	}
+}
+
+func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	var report ScanReport
+	err = json.NewDecoder(logsReader).Decode(&report)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
+
+	for _, match := range report.Matches {
+		links := make([]string, 0)
+		links = append(links, match.Vulnerability.URLs...)
+		vulnerabilities = append(vulnerabilities, v1alpha1.Vulnerability{
+			VulnerabilityID:  match.Vulnerability.ID,
+			Resource:         match.Artifact.Name,
+			InstalledVersion: match.Artifact.Version,
+			FixedVersion:     strings.Join(match.Vulnerability.Fix.Versions, ", "),
+			Severity:         toSeverity(match.Vulnerability.Severity),
+			Title:            toTitle(match.Description()),
+			Description:      match.Description(),
+			PrimaryLink:      match.Vulnerability.DataSource,
+			Links:            links,
+			Score:            GetScoreFromCVSS(match),
+		})
+	}
+
+	registry, artifact, err := p.parseImageRef(imageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	version := strings.TrimPrefix(report.Descriptor.Version, "v")
+	if version == "" {
+		grypeImageRef, err := config.GetImageRef()
+		if err != nil {
+			return v1alpha1.VulnerabilityReportData{}, err
+		}
+
+		version, err = starboard.GetVersionFromImageRef(grypeImageRef)
+		if err != nil {
+			return v1alpha1.VulnerabilityReportData{}, err
+		}
+	}
+
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:    "Grype",
+			Vendor:  "Anchore",
+			Version: version,
+		},
+		Registry:        registry,
+		Artifact:        artifact,
+		Summary:         p.toSummary(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}, nil
+}
+
+func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
+	pluginConfig, err := ctx.GetConfig()
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{PluginConfig: pluginConfig}, nil
+}
+
+func (p *plugin) toSummary(vulnerabilities []v1alpha1.Vulnerability) v1alpha1.VulnerabilitySummary {
+	var vs v1alpha1.VulnerabilitySummary
+	for _, v := range vulnerabilities {
+		switch v.Severity {
+		case v1alpha1.SeverityCritical:
+			vs.CriticalCount++
+		case v1alpha1.SeverityHigh:
+			vs.HighCount++
+		case v1alpha1.SeverityMedium:
+			vs.MediumCount++
+		case v1alpha1.SeverityLow:
+			vs.LowCount++
+		default:
+			vs.UnknownCount++
+		}
+	}
+	return vs
+}
+
+func (p *plugin) parseImageRef(imageRef string) (v1alpha1.Registry, v1alpha1.Artifact, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.Registry{}, v1alpha1.Artifact{}, err
+	}
+	registry := v1alpha1.Registry{
+		Server: ref.Context().RegistryStr(),
+	}
+	artifact := v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+	}
+	switch t := ref.(type) {
+	case name.Tag:
+		artifact.Tag = t.TagStr()
+	case name.Digest:
+		artifact.Digest = t.DigestStr()
+	}
+	return registry, artifact, nil
+}
+
+// Description returns the description of the matched vulnerability, or the
+// description published by a related data source, e.g. NVD, if the former is
+// blank.
+func (m Match) Description() string {
+	if m.Vulnerability.Description != "" {
+		return m.Vulnerability.Description
+	}
+	for _, related := range m.RelatedVulnerabilities {
+		if related.Description != "" {
+			return related.Description
+		}
+	}
+	return ""
+}
+
+// GetScoreFromCVSS returns the CVSS v3 base score of the matched vulnerability
+// as published by its data source, falling back to the score published by
+// NVD in related vulnerabilities.
+func GetScoreFromCVSS(match Match) *float64 {
+	if score := getV3BaseScore(match.Vulnerability.CVSS); score != nil {
+		return score
+	}
+	for _, related := range match.RelatedVulnerabilities {
+		if related.Namespace != "nvd" {
+			continue
+		}
+		if score := getV3BaseScore(related.CVSS); score != nil {
+			return score
+		}
+	}
+	return nil
+}
+
+func getV3BaseScore(cvss []CVSS) *float64 {
+	for _, c := range cvss {
+		if strings.HasPrefix(c.Version, "3") {
+			score := c.Metrics.BaseScore
+			return &score
+		}
+	}
+	return nil
+}
+
+// toSeverity maps Grype severities, i.e. Unknown, Negligible, Low, Medium,
+// High, and Critical, to v1alpha1.Severity. Negligible is reported as Low,
+// because VulnerabilityReports do not have a lower severity level.
+func toSeverity(severity string) v1alpha1.Severity {
+	switch strings.ToUpper(severity) {
+	case string(v1alpha1.SeverityCritical):
+		return v1alpha1.SeverityCritical
+	case string(v1alpha1.SeverityHigh):
+		return v1alpha1.SeverityHigh
+	case string(v1alpha1.SeverityMedium):
+		return v1alpha1.SeverityMedium
+	case string(v1alpha1.SeverityLow), "NEGLIGIBLE":
+		return v1alpha1.SeverityLow
+	default:
+		return v1alpha1.SeverityUnknown
+	}
+}
+
+// toTitle returns the first sentence of the given description, because Grype
+// does not report short titles of vulnerabilities.
+func toTitle(description string) string {
+	if i := strings.Index(description, ". "); i >= 0 {
+		return description[:i]
+	}
+	return strings.TrimSuffix(description, ".")
+}
+
+func constructEnvVarSourceFromConfigMap(envName, configName, configKey string) (res corev1.EnvVar) {
+	res = corev1.EnvVar{
+		Name: envName,
+		ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: configName,
+				},
+				Key:      configKey,
+				Optional: pointer.BoolPtr(true),
+			},
+		},
+	}
+	return
+}
@@ -0,0 +1,395 @@
+package grype_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin/grype"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	fixedTime  = time.Now()
+	fixedClock = ext.NewFixedClock(fixedTime)
+)
+
+func TestConfig_GetImageRef(t *testing.T) {
+	testCases := []struct {
+		name             string
+		configData       grype.Config
+		expectedError    string
+		expectedImageRef string
+	}{
+		{
+			name:          "Should return error",
+			configData:    grype.Config{PluginConfig: starboard.PluginConfig{}},
+			expectedError: "property grype.imageRef not set",
+		},
+		{
+			name: "Should return image reference from config data",
+			configData: grype.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"grype.imageRef": "docker.io/anchore/grype:v0.38.0",
+				},
+			}},
+			expectedImageRef: "docker.io/anchore/grype:v0.38.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRef, err := tc.configData.GetImageRef()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedImageRef, imageRef)
+			}
+		})
+	}
+}
+
+func TestPlugin_Init(t *testing.T) {
+	client := fake.NewClientBuilder().WithObjects().Build()
+
+	instance := grype.NewPlugin(fixedClock, ext.NewSimpleIDGenerator())
+
+	pluginContext := starboard.NewPluginContext().
+		WithName(grype.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(client).
+		Get()
+	err := instance.Init(pluginContext)
+	require.NoError(t, err)
+
+	var cm corev1.ConfigMap
+	err = client.Get(context.Background(), types.NamespacedName{
+		Namespace: "starboard-ns",
+		Name:      "starboard-grype-config",
+	}, &cm)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"grype.imageRef": "docker.io/anchore/grype:v0.38.0",
+
+		"grype.resources.requests.cpu":    "100m",
+		"grype.resources.requests.memory": "100M",
+		"grype.resources.limits.cpu":      "500m",
+		"grype.resources.limits.memory":   "1G",
+	}, cm.Data)
+}
+
+func configMapEnv(name, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "starboard-grype-config",
+				},
+				Key:      key,
+				Optional: pointer.BoolPtr(true),
+			},
+		},
+	}
+}
+
+func secretEnv(name, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "scan-vulnerabilityreport-788f94c88c-regcred",
+				},
+				Key: key,
+			},
+		},
+	}
+}
+
+func TestPlugin_GetScanJobSpec(t *testing.T) {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "starboard-grype-config",
+			Namespace: "starboard-ns",
+		},
+		Data: map[string]string{
+			"grype.imageRef":                  "docker.io/anchore/grype:v0.38.0",
+			"grype.onlyFixed":                 "true",
+			"grype.insecureRegistry.harbor":   "harbor.example.com",
+			"grype.nonSslRegistry.local":      "registry.local:5000",
+			"grype.resources.requests.cpu":    "100m",
+			"grype.resources.requests.memory": "100M",
+			"grype.resources.limits.cpu":      "500m",
+			"grype.resources.limits.memory":   "1G",
+		},
+	}
+	workload := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "nginx",
+							Image: "harbor.example.com/library/nginx:1.16",
+						},
+						{
+							Name:  "sidecar",
+							Image: "registry.local:5000/sidecar:1.0",
+						},
+					},
+				},
+			},
+		},
+	}
+	credentials := map[string]docker.Auth{
+		"nginx": {
+			Username: "admin",
+			Password: "Harbor12345",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(config).Build()
+	pluginContext := starboard.NewPluginContext().
+		WithName(grype.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeClient).
+		Get()
+	instance := grype.NewPlugin(fixedClock, ext.NewSimpleIDGenerator())
+
+	jobSpec, secrets, err := instance.GetScanJobSpec(pluginContext, workload, credentials)
+	require.NoError(t, err)
+
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "scan-vulnerabilityreport-788f94c88c-regcred", secrets[0].Name)
+	assert.Equal(t, map[string][]byte{
+		"nginx.username": []byte("admin"),
+		"nginx.password": []byte("Harbor12345"),
+	}, secrets[0].Data)
+
+	commonEnv := func() []corev1.EnvVar {
+		return []corev1.EnvVar{
+			{Name: "GRYPE_DB_CACHE_DIR", Value: "/tmp/grype/db"},
+			{Name: "GRYPE_CHECK_FOR_APP_UPDATE", Value: "false"},
+			{Name: "TMPDIR", Value: "/tmp"},
+			configMapEnv("HTTP_PROXY", "grype.httpProxy"),
+			configMapEnv("HTTPS_PROXY", "grype.httpsProxy"),
+			configMapEnv("NO_PROXY", "grype.noProxy"),
+		}
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("100M"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("1G"),
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "tmp",
+			MountPath: "/tmp",
+		},
+	}
+	securityContext := &corev1.SecurityContext{
+		Privileged:               pointer.BoolPtr(false),
+		AllowPrivilegeEscalation: pointer.BoolPtr(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"all"},
+		},
+		ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+	}
+
+	assert.Equal(t, corev1.PodSpec{
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           "starboard-sa",
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Volumes: []corev1.Volume{
+			{
+				Name: "tmp",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						Medium: corev1.StorageMediumDefault,
+					},
+				},
+			},
+		},
+		InitContainers: []corev1.Container{
+			{
+				Name:                     "00000000-0000-0000-0000-000000000001",
+				Image:                    "docker.io/anchore/grype:v0.38.0",
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				Env:                      append(commonEnv(), configMapEnv("GRYPE_DB_UPDATE_URL", "grype.dbUpdateURL")),
+				Command:                  []string{"/grype"},
+				Args:                     []string{"db", "update"},
+				Resources:                resources,
+				VolumeMounts:             volumeMounts,
+				SecurityContext:          securityContext,
+			},
+		},
+		Containers: []corev1.Container{
+			{
+				Name:                     "nginx",
+				Image:                    "docker.io/anchore/grype:v0.38.0",
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				Env: append(commonEnv(),
+					corev1.EnvVar{Name: "GRYPE_DB_AUTO_UPDATE", Value: "false"},
+					corev1.EnvVar{Name: "GRYPE_REGISTRY_AUTH_AUTHORITY", Value: "harbor.example.com"},
+					secretEnv("GRYPE_REGISTRY_AUTH_USERNAME", "nginx.username"),
+					secretEnv("GRYPE_REGISTRY_AUTH_PASSWORD", "nginx.password"),
+					corev1.EnvVar{Name: "GRYPE_REGISTRY_INSECURE_SKIP_TLS_VERIFY", Value: "true"},
+				),
+				Command: []string{"/grype"},
+				Args: []string{
+					"registry:harbor.example.com/library/nginx:1.16",
+					"--output",
+					"json",
+					"--quiet",
+					"--only-fixed",
+				},
+				Resources:       resources,
+				VolumeMounts:    volumeMounts,
+				SecurityContext: securityContext,
+			},
+			{
+				Name:                     "sidecar",
+				Image:                    "docker.io/anchore/grype:v0.38.0",
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				Env: append(commonEnv(),
+					corev1.EnvVar{Name: "GRYPE_DB_AUTO_UPDATE", Value: "false"},
+					corev1.EnvVar{Name: "GRYPE_REGISTRY_INSECURE_USE_HTTP", Value: "true"},
+				),
+				Command: []string{"/grype"},
+				Args: []string{
+					"registry:registry.local:5000/sidecar:1.0",
+					"--output",
+					"json",
+					"--quiet",
+					"--only-fixed",
+				},
+				Resources:       resources,
+				VolumeMounts:    volumeMounts,
+				SecurityContext: securityContext,
+			},
+		},
+		SecurityContext: &corev1.PodSecurityContext{},
+	}, jobSpec)
+}
+
+func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "starboard-grype-config",
+			Namespace: "starboard-ns",
+		},
+		Data: map[string]string{
+			"grype.imageRef": "docker.io/anchore/grype:v0.38.0",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(config).Build()
+	pluginContext := starboard.NewPluginContext().
+		WithName(grype.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeClient).
+		Get()
+	instance := grype.NewPlugin(fixedClock, ext.NewSimpleIDGenerator())
+
+	t.Run("Should convert vulnerability report in JSON format", func(t *testing.T) {
+		file, err := os.Open("testdata/alpine-3.10.2.json")
+		require.NoError(t, err)
+		defer func() {
+			_ = file.Close()
+		}()
+
+		report, err := instance.ParseVulnerabilityReportData(pluginContext, "alpine:3.10.2", file)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(fixedTime),
+			Scanner: v1alpha1.Scanner{
+				Name:    "Grype",
+				Vendor:  "Anchore",
+				Version: "0.38.0",
+			},
+			Registry: v1alpha1.Registry{
+				Server: "index.docker.io",
+			},
+			Artifact: v1alpha1.Artifact{
+				Repository: "library/alpine",
+				Tag:        "3.10.2",
+			},
+			Summary: v1alpha1.VulnerabilitySummary{
+				MediumCount: 1,
+				LowCount:    2,
+			},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2019-1549",
+					Resource:         "libcrypto1.1",
+					InstalledVersion: "1.1.1c-r0",
+					FixedVersion:     "1.1.1d-r0",
+					Severity:         v1alpha1.SeverityMedium,
+					Title:            "OpenSSL 1.1.1 introduced a rewritten random number generator (RNG)",
+					Description:      "OpenSSL 1.1.1 introduced a rewritten random number generator (RNG). This was intended to include protection in the event of a fork() system call.",
+					PrimaryLink:      "http://secdb.alpinelinux.org/v3.10/main.json",
+					Links:            []string{"http://secdb.alpinelinux.org/v3.10/main.json"},
+					Score:            pointer.Float64(5.3),
+				},
+				{
+					VulnerabilityID:  "CVE-2019-1547",
+					Resource:         "libssl1.1",
+					InstalledVersion: "1.1.1c-r0",
+					FixedVersion:     "1.1.1d-r0",
+					Severity:         v1alpha1.SeverityLow,
+					Title:            "Normally in OpenSSL EC groups always have a co-factor present and this is used in side channel resistant code paths",
+					Description:      "Normally in OpenSSL EC groups always have a co-factor present and this is used in side channel resistant code paths.",
+					PrimaryLink:      "http://secdb.alpinelinux.org/v3.10/main.json",
+					Links:            []string{"http://secdb.alpinelinux.org/v3.10/main.json"},
+					Score:            pointer.Float64(3.3),
+				},
+				{
+					VulnerabilityID:  "CVE-2021-36159",
+					Resource:         "apk-tools",
+					InstalledVersion: "2.10.4-r2",
+					Severity:         v1alpha1.SeverityLow,
+					PrimaryLink:      "http://secdb.alpinelinux.org/v3.10/main.json",
+					Links:            []string{},
+				},
+			},
+		}, report)
+	})
+
+	t.Run("Should return error when image reference cannot be parsed", func(t *testing.T) {
+		_, err := instance.ParseVulnerabilityReportData(pluginContext, ":", io.NopCloser(strings.NewReader("{}")))
+		assert.EqualError(t, err, "could not parse reference: :")
+	})
+}
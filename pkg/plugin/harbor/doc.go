@@ -0,0 +1,4 @@
+// Package harbor provides a vulnerability scanner plugin, which scans
+// container images with any remote scanner adapter that implements the Harbor
+// Scanner Adapter API v1.0, e.g. the Trivy adapter or the Clair adapter.
+package harbor
@@ -0,0 +1,304 @@
+package harbor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	harborapi "github.com/aquasecurity/starboard/pkg/harbor"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Plugin the name of this plugin.
+	Plugin = "Harbor"
+)
+
+const (
+	keyHarborURL              = "harbor.url"
+	keyHarborPollInterval     = "harbor.pollInterval"
+	keyHarborNonSslRegistry   = "harbor.nonSslRegistry."
+	keyHarborAuthorization    = "harbor.authorization"
+	defaultHarborPollInterval = 5 * time.Second
+)
+
+// Config defines configuration params for this plugin.
+type Config struct {
+	starboard.PluginConfig
+}
+
+// GetURL returns the base URL of the remote scanner adapter, e.g.
+// http://harbor-scanner-trivy.harbor:8080.
+func (c Config) GetURL() (string, error) {
+	return c.GetRequiredData(keyHarborURL)
+}
+
+// GetAuthorization returns the value of the HTTP Authorization header sent
+// to the scanner adapter, e.g. Bearer <token>. It's read from the plugin's
+// Secret and might be empty.
+func (c Config) GetAuthorization() string {
+	return string(c.SecretData[keyHarborAuthorization])
+}
+
+// GetPollInterval returns the interval of polling the scanner adapter for a
+// report, unless the adapter suggests it with the Refresh-After header.
+func (c Config) GetPollInterval() (time.Duration, error) {
+	value, ok := c.Data[keyHarborPollInterval]
+	if !ok {
+		return defaultHarborPollInterval, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", keyHarborPollInterval, err)
+	}
+	return interval, nil
+}
+
+// GetNonSSLRegistries returns registries, which are passed to the scanner
+// adapter with the http scheme instead of https.
+func (c Config) GetNonSSLRegistries() map[string]bool {
+	nonSSLRegistries := make(map[string]bool)
+	for key, val := range c.Data {
+		if strings.HasPrefix(key, keyHarborNonSslRegistry) {
+			nonSSLRegistries[val] = true
+		}
+	}
+	return nonSSLRegistries
+}
+
+type plugin struct {
+	clock ext.Clock
+}
+
+// NewPlugin constructs a new vulnerabilityreport.Plugin, which scans
+// container images with a remote scanner adapter that implements the Harbor
+// Scanner Adapter API v1.0.
+//
+// The plugin does not create scan jobs. Instead, it implements the
+// vulnerabilityreport.ImageScannerPlugin interface, submits the image
+// reference and registry credentials to the adapter, and polls the adapter
+// until the report is ready.
+func NewPlugin(clock ext.Clock) vulnerabilityreport.Plugin {
+	return &plugin{
+		clock: clock,
+	}
+}
+
+// Init ensures the default Config required by this plugin. The URL of the
+// scanner adapter must be configured with the harbor.url key.
+func (p *plugin) Init(ctx starboard.PluginContext) error {
+	return ctx.EnsureConfig(starboard.PluginConfig{
+		Data: map[string]string{
+			keyHarborPollInterval: defaultHarborPollInterval.String(),
+		},
+	})
+}
+
+// GetScanJobSpec returns an error, because images are scanned by the remote
+// scanner adapter returned by GetImageScanner.
+func (p *plugin) GetScanJobSpec(_ starboard.PluginContext, _ client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	return corev1.PodSpec{}, nil, fmt.Errorf("%s plugin does not support scan jobs", Plugin)
+}
+
+// ParseVulnerabilityReportData converts the report in the Harbor format
+// read from the given logsReader.
+func (p *plugin) ParseVulnerabilityReportData(_ starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	var report harborapi.VulnerabilityReport
+	err := json.NewDecoder(logsReader).Decode(&report)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	return p.toReportData(imageRef, report)
+}
+
+// GetImageScanner returns the vulnerabilityreport.ImageScanner, which scans
+// container images with the scanner adapter configured in the given context.
+func (p *plugin) GetImageScanner(ctx starboard.PluginContext) (vulnerabilityreport.ImageScanner, error) {
+	return &scanner{plugin: p, ctx: ctx}, nil
+}
+
+type scanner struct {
+	*plugin
+	ctx starboard.PluginContext
+}
+
+// Scan submits the given image to the scanner adapter and waits until the
+// report is ready or the given context is done.
+func (s *scanner) Scan(ctx context.Context, imageRef string, credentials *docker.Auth) (v1alpha1.VulnerabilityReportData, error) {
+	pluginConfig, err := s.ctx.GetConfig()
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	config := Config{PluginConfig: pluginConfig}
+	url, err := config.GetURL()
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	pollInterval, err := config.GetPollInterval()
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	scanRequest, err := NewScanRequest(imageRef, credentials, config.GetNonSSLRegistries())
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	c := harborapi.NewClient(url, config.GetAuthorization())
+	scanResponse, err := c.Scan(ctx, scanRequest)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, fmt.Errorf("submitting scan request: %w", err)
+	}
+
+	for {
+		report, refreshAfter, err := c.GetReport(ctx, scanResponse.ID)
+		if err == nil {
+			return s.toReportData(imageRef, report)
+		}
+		if !errors.Is(err, harborapi.ErrReportNotReady) {
+			return v1alpha1.VulnerabilityReportData{}, fmt.Errorf("getting scan report: %w", err)
+		}
+		if refreshAfter <= 0 {
+			refreshAfter = pollInterval
+		}
+		select {
+		case <-ctx.Done():
+			return v1alpha1.VulnerabilityReportData{}, fmt.Errorf("waiting for scan report: %w", ctx.Err())
+		case <-time.After(refreshAfter):
+		}
+	}
+}
+
+// NewScanRequest returns the request to scan the given image, which is pulled
+// from its registry with optional credentials. Registries in nonSSLRegistries
+// are passed with the http scheme.
+func NewScanRequest(imageRef string, credentials *docker.Auth, nonSSLRegistries map[string]bool) (harborapi.ScanRequest, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return harborapi.ScanRequest{}, err
+	}
+	server := ref.Context().RegistryStr()
+	scheme := "https"
+	if nonSSLRegistries[server] {
+		scheme = "http"
+	}
+	scanRequest := harborapi.ScanRequest{
+		Registry: harborapi.Registry{
+			URL: scheme + "://" + server,
+		},
+		Artifact: harborapi.Artifact{
+			Repository: ref.Context().RepositoryStr(),
+			MimeType:   harborapi.MimeTypeDockerImageManifest,
+		},
+	}
+	switch r := ref.(type) {
+	case name.Digest:
+		scanRequest.Artifact.Digest = r.DigestStr()
+	case name.Tag:
+		scanRequest.Artifact.Tag = r.TagStr()
+	}
+	if credentials != nil {
+		username, password := credentials.Username, credentials.Password
+		if username == "" && credentials.Auth != "" {
+			username, password, err = credentials.Auth.Decode()
+			if err != nil {
+				return harborapi.ScanRequest{}, err
+			}
+		}
+		if username != "" {
+			scanRequest.Registry.Authorization = "Basic " + string(docker.NewBasicAuth(username, password))
+		}
+	}
+	return scanRequest, nil
+}
+
+func (p *plugin) toReportData(imageRef string, report harborapi.VulnerabilityReport) (v1alpha1.VulnerabilityReportData, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	artifact := v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+		Digest:     report.Artifact.Digest,
+		Tag:        report.Artifact.Tag,
+	}
+	switch r := ref.(type) {
+	case name.Digest:
+		artifact.Digest = r.DigestStr()
+	case name.Tag:
+		artifact.Tag = r.TagStr()
+	}
+
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0, len(report.Vulnerabilities))
+	for _, item := range report.Vulnerabilities {
+		links := item.Links
+		if links == nil {
+			links = []string{}
+		}
+		var primaryLink string
+		if len(links) > 0 {
+			primaryLink = links[0]
+		}
+		vulnerabilities = append(vulnerabilities, v1alpha1.Vulnerability{
+			VulnerabilityID:  item.ID,
+			Resource:         item.Package,
+			InstalledVersion: item.Version,
+			FixedVersion:     item.FixVersion,
+			Severity:         toSeverity(item.Severity),
+			Title:            firstLine(item.Description),
+			Description:      item.Description,
+			PrimaryLink:      primaryLink,
+			Links:            links,
+		})
+	}
+
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:    report.Scanner.Name,
+			Vendor:  report.Scanner.Vendor,
+			Version: report.Scanner.Version,
+		},
+		Registry: v1alpha1.Registry{
+			Server: ref.Context().RegistryStr(),
+		},
+		Artifact:        artifact,
+		Summary:         v1alpha1.VulnerabilitySummaryFromVulnerabilities(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}, nil
+}
+
+// toSeverity converts the given Harbor severity. Negligible vulnerabilities
+// are reported with the LOW severity, similarly to the Grype plugin.
+func toSeverity(severity harborapi.Severity) v1alpha1.Severity {
+	switch severity {
+	case harborapi.SeverityCritical:
+		return v1alpha1.SeverityCritical
+	case harborapi.SeverityHigh:
+		return v1alpha1.SeverityHigh
+	case harborapi.SeverityMedium:
+		return v1alpha1.SeverityMedium
+	case harborapi.SeverityLow, harborapi.SeverityNegligible:
+		return v1alpha1.SeverityLow
+	default:
+		return v1alpha1.SeverityUnknown
+	}
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
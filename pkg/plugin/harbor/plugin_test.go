@@ -0,0 +1,276 @@
+package harbor_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	harborapi "github.com/aquasecurity/starboard/pkg/harbor"
+	"github.com/aquasecurity/starboard/pkg/plugin/harbor"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var fixedTime = time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+
+const harborReport = `{
+  "generated_at": "2022-06-01T09:00:00Z",
+  "artifact": {
+    "repository": "library/nginx",
+    "digest": "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+  },
+  "scanner": {
+    "name": "Trivy",
+    "vendor": "Aqua Security",
+    "version": "v0.28.0"
+  },
+  "severity": "High",
+  "vulnerabilities": [
+    {
+      "id": "CVE-2019-1549",
+      "package": "libcrypto1.1",
+      "version": "1.1.1c-r0",
+      "fix_version": "1.1.1d-r0",
+      "severity": "High",
+      "description": "openssl: information disclosure in fork()\nOpenSSL 1.1.1 introduced a rewritten random number generator.",
+      "links": [
+        "https://avd.aquasec.com/nvd/cve-2019-1549",
+        "https://nvd.nist.gov/vuln/detail/CVE-2019-1549"
+      ]
+    },
+    {
+      "id": "CVE-2019-14697",
+      "package": "musl",
+      "version": "1.1.22-r3",
+      "severity": "Negligible",
+      "description": "musl libc through 1.1.23 has an x87 floating-point stack adjustment imbalance."
+    }
+  ]
+}`
+
+var expectedReportData = v1alpha1.VulnerabilityReportData{
+	UpdateTimestamp: metav1.NewTime(fixedTime),
+	Scanner: v1alpha1.Scanner{
+		Name:    "Trivy",
+		Vendor:  "Aqua Security",
+		Version: "v0.28.0",
+	},
+	Registry: v1alpha1.Registry{
+		Server: "core.harbor.domain",
+	},
+	Artifact: v1alpha1.Artifact{
+		Repository: "library/nginx",
+		Digest:     "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+	},
+	Summary: v1alpha1.VulnerabilitySummary{
+		HighCount: 1,
+		LowCount:  1,
+	},
+	Vulnerabilities: []v1alpha1.Vulnerability{
+		{
+			VulnerabilityID:  "CVE-2019-1549",
+			Resource:         "libcrypto1.1",
+			InstalledVersion: "1.1.1c-r0",
+			FixedVersion:     "1.1.1d-r0",
+			Severity:         v1alpha1.SeverityHigh,
+			Title:            "openssl: information disclosure in fork()",
+			Description:      "openssl: information disclosure in fork()\nOpenSSL 1.1.1 introduced a rewritten random number generator.",
+			PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2019-1549",
+			Links: []string{
+				"https://avd.aquasec.com/nvd/cve-2019-1549",
+				"https://nvd.nist.gov/vuln/detail/CVE-2019-1549",
+			},
+		},
+		{
+			VulnerabilityID:  "CVE-2019-14697",
+			Resource:         "musl",
+			InstalledVersion: "1.1.22-r3",
+			Severity:         v1alpha1.SeverityLow,
+			Title:            "musl libc through 1.1.23 has an x87 floating-point stack adjustment imbalance.",
+			Description:      "musl libc through 1.1.23 has an x87 floating-point stack adjustment imbalance.",
+			Links:            []string{},
+		},
+	},
+}
+
+const imageRef = "core.harbor.domain/library/nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+
+func newPluginContext(t *testing.T, objects ...client.Object) starboard.PluginContext {
+	t.Helper()
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+	return starboard.NewPluginContext().
+		WithName(harbor.Plugin).
+		WithNamespace("starboard-system").
+		WithClient(c).
+		Get()
+}
+
+func TestPlugin_Init(t *testing.T) {
+	pluginContext := newPluginContext(t)
+	err := harbor.NewPlugin(ext.NewFixedClock(fixedTime)).Init(pluginContext)
+	require.NoError(t, err)
+
+	config, err := pluginContext.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"harbor.pollInterval": "5s"}, config.Data)
+}
+
+func TestPlugin_GetImageScanner(t *testing.T) {
+	var scanRequest harborapi.ScanRequest
+	var reportRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer s3cr3t", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/scan":
+			assert.Equal(t, harborapi.MimeTypeScanRequest, r.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&scanRequest))
+			if scanRequest.Artifact.Repository != "library/nginx" {
+				w.Header().Set("Content-Type", harborapi.MimeTypeError)
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"error":{"message":"repository not found"}}`))
+				return
+			}
+			w.Header().Set("Content-Type", harborapi.MimeTypeScanResponse)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"id":"scan-1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/scan/scan-1/report":
+			reportRequests++
+			if reportRequests == 1 {
+				w.Header().Set("Refresh-After", "0")
+				w.WriteHeader(http.StatusFound)
+				return
+			}
+			assert.Equal(t, harborapi.MimeTypeHarborReport, r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", harborapi.MimeTypeHarborReport)
+			_, _ = w.Write([]byte(harborReport))
+		default:
+			w.Header().Set("Content-Type", harborapi.MimeTypeError)
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	pluginContext := newPluginContext(t,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-harbor-config",
+				Namespace: "starboard-system",
+			},
+			Data: map[string]string{
+				"harbor.url":          server.URL,
+				"harbor.pollInterval": "10ms",
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-harbor-config",
+				Namespace: "starboard-system",
+			},
+			Data: map[string][]byte{
+				"harbor.authorization": []byte("Bearer s3cr3t"),
+			},
+		},
+	)
+
+	plugin := harbor.NewPlugin(ext.NewFixedClock(fixedTime))
+	imageScannerPlugin, ok := plugin.(vulnerabilityreport.ImageScannerPlugin)
+	require.True(t, ok)
+	scanner, err := imageScannerPlugin.GetImageScanner(pluginContext)
+	require.NoError(t, err)
+
+	data, err := scanner.Scan(context.TODO(), imageRef, &docker.Auth{Username: "robot", Password: "s3cr3t"})
+	require.NoError(t, err)
+	assert.Equal(t, expectedReportData, data)
+	assert.Equal(t, 2, reportRequests)
+	assert.Equal(t, harborapi.ScanRequest{
+		Registry: harborapi.Registry{
+			URL:           "https://core.harbor.domain",
+			Authorization: "Basic cm9ib3Q6czNjcjN0",
+		},
+		Artifact: harborapi.Artifact{
+			Repository: "library/nginx",
+			Digest:     "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+			MimeType:   harborapi.MimeTypeDockerImageManifest,
+		},
+	}, scanRequest)
+
+	t.Run("Should return error message of adapter", func(t *testing.T) {
+		_, err := scanner.Scan(context.TODO(), "core.harbor.domain/library/unknown:1.16", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "submitting scan request: unexpected response status: 422 Unprocessable Entity: repository not found")
+	})
+}
+
+func TestPlugin_GetScanJobSpec(t *testing.T) {
+	_, _, err := harbor.NewPlugin(ext.NewFixedClock(fixedTime)).GetScanJobSpec(newPluginContext(t), &corev1.Pod{}, nil)
+	assert.EqualError(t, err, "Harbor plugin does not support scan jobs")
+}
+
+func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
+	data, err := harbor.NewPlugin(ext.NewFixedClock(fixedTime)).ParseVulnerabilityReportData(newPluginContext(t),
+		imageRef, io.NopCloser(strings.NewReader(harborReport)))
+	require.NoError(t, err)
+	assert.Equal(t, expectedReportData, data)
+}
+
+func TestNewScanRequest(t *testing.T) {
+	testCases := []struct {
+		name             string
+		imageRef         string
+		credentials      *docker.Auth
+		nonSSLRegistries map[string]bool
+		expected         harborapi.ScanRequest
+	}{
+		{
+			name:     "Should use tag of Docker Hub image",
+			imageRef: "nginx:1.16",
+			expected: harborapi.ScanRequest{
+				Registry: harborapi.Registry{URL: "https://index.docker.io"},
+				Artifact: harborapi.Artifact{
+					Repository: "library/nginx",
+					Tag:        "1.16",
+					MimeType:   harborapi.MimeTypeDockerImageManifest,
+				},
+			},
+		},
+		{
+			name:             "Should use http scheme for non-SSL registry",
+			imageRef:         "registry.local:5000/app@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+			credentials:      &docker.Auth{Auth: docker.NewBasicAuth("user", "pass")},
+			nonSSLRegistries: map[string]bool{"registry.local:5000": true},
+			expected: harborapi.ScanRequest{
+				Registry: harborapi.Registry{
+					URL:           "http://registry.local:5000",
+					Authorization: "Basic dXNlcjpwYXNz",
+				},
+				Artifact: harborapi.Artifact{
+					Repository: "app",
+					Digest:     "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+					MimeType:   harborapi.MimeTypeDockerImageManifest,
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scanRequest, err := harbor.NewScanRequest(tc.imageRef, tc.credentials, tc.nonSSLRegistries)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, scanRequest)
+		})
+	}
+}
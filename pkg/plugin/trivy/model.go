@@ -1,16 +1,68 @@
 package trivy
 
 import (
+	"fmt"
+
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 )
 
 type ScanResult struct {
 	Target          string          `json:"Target"`
+	Type            string          `json:"Type"`
 	Vulnerabilities []Vulnerability `json:"Vulnerabilities"`
+	Packages        []Package       `json:"Packages"`
 }
 
 type ScanReport struct {
-	Results []ScanResult `json:"Results"`
+	Metadata Metadata     `json:"Metadata"`
+	Results  []ScanResult `json:"Results"`
+}
+
+type Metadata struct {
+	OS *OS `json:"OS"`
+}
+
+type OS struct {
+	Family string `json:"Family"`
+	Name   string `json:"Name"`
+}
+
+// Package is an OS package or application dependency listed by Trivy with
+// the --list-all-pkgs flag.
+type Package struct {
+	Name    string `json:"Name"`
+	Version string `json:"Version"`
+	Release string `json:"Release"`
+	Epoch   int    `json:"Epoch"`
+	// License is reported by Trivy versions before v0.26.0, which replaced
+	// it with Licenses.
+	License  string   `json:"License"`
+	Licenses []string `json:"Licenses"`
+}
+
+// FullVersion returns the version of this package including the epoch and
+// release, if any, e.g. 1:2.2.4-10.el8.
+func (p Package) FullVersion() string {
+	version := p.Version
+	if p.Release != "" {
+		version = fmt.Sprintf("%s-%s", version, p.Release)
+	}
+	if p.Epoch != 0 {
+		version = fmt.Sprintf("%d:%s", p.Epoch, version)
+	}
+	return version
+}
+
+// GetLicenses returns licenses of this package regardless of the version of
+// Trivy that listed it.
+func (p Package) GetLicenses() []string {
+	if len(p.Licenses) > 0 {
+		return p.Licenses
+	}
+	if p.License != "" {
+		return []string{p.License}
+	}
+	return nil
 }
 
 type Vulnerability struct {
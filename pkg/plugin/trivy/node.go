@@ -0,0 +1,262 @@
+package trivy
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	nodeScanContainerName = "node-scanner"
+	hostRootVolumeName    = "host-root"
+	hostRootMountPath     = "/hostfs"
+)
+
+// nodeSkipDirs are directories of the host root file system, which are not
+// scanned, because they're either virtual file systems or contain layers of
+// container images, which are scanned by workload scan jobs.
+var nodeSkipDirs = []string{
+	"/proc",
+	"/sys",
+	"/dev",
+	"/run",
+	"/var/lib/containerd",
+	"/var/lib/docker",
+	"/var/lib/kubelet",
+}
+
+// GetNodeScanJobSpec describes the pod that scans the root file system of the
+// given node. The root file system is mounted read-only from the host and the
+// pod is explicitly scheduled on the node.
+//
+// Similarly to the Standalone mode there is the init container responsible
+// for downloading the Trivy DB to the emptyDir volume shared with the main
+// container, which runs the following Trivy command:
+//
+//     trivy --skip-update --cache-dir /var/starboard/trivy-db --quiet \
+//       rootfs --format json --skip-dirs /hostfs/proc,... /hostfs
+func (p *plugin) GetNodeScanJobSpec(ctx starboard.PluginContext, node corev1.Node) (corev1.PodSpec, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return corev1.PodSpec{}, err
+	}
+
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return corev1.PodSpec{}, err
+	}
+
+	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
+
+	dbRepository, err := config.GetDBRepository()
+	if err != nil {
+		return corev1.PodSpec{}, err
+	}
+
+	requirements, err := config.GetResourceRequirements()
+	if err != nil {
+		return corev1.PodSpec{}, err
+	}
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      FsSharedVolumeName,
+			ReadOnly:  false,
+			MountPath: "/var/starboard",
+		},
+	}
+
+	initContainerDB := corev1.Container{
+		Name:                     p.idGenerator.GenerateID(),
+		Image:                    trivyImageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Env: []corev1.EnvVar{
+			constructEnvVarSourceFromConfigMap("HTTP_PROXY", trivyConfigName, keyTrivyHTTPProxy),
+			constructEnvVarSourceFromConfigMap("HTTPS_PROXY", trivyConfigName, keyTrivyHTTPSProxy),
+			constructEnvVarSourceFromConfigMap("NO_PROXY", trivyConfigName, keyTrivyNoProxy),
+			{
+				Name: "GITHUB_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: trivyConfigName,
+						},
+						Key:      keyTrivyGitHubToken,
+						Optional: pointer.BoolPtr(true),
+					},
+				},
+			},
+		},
+		Command: []string{
+			"trivy",
+		},
+		Args: []string{
+			"--download-db-only",
+			"--cache-dir",
+			"/var/starboard/trivy-db",
+			"--db-repository",
+			dbRepository,
+		},
+		Resources:    requirements,
+		VolumeMounts: volumeMounts,
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: FsSharedVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumDefault,
+				},
+			},
+		},
+		{
+			Name: hostRootVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: "/",
+				},
+			},
+		},
+	}
+
+	env := []corev1.EnvVar{
+		constructEnvVarSourceFromConfigMap("TRIVY_SEVERITY", trivyConfigName, keyTrivySeverity),
+		constructEnvVarSourceFromConfigMap("HTTP_PROXY", trivyConfigName, keyTrivyHTTPProxy),
+		constructEnvVarSourceFromConfigMap("HTTPS_PROXY", trivyConfigName, keyTrivyHTTPSProxy),
+		constructEnvVarSourceFromConfigMap("NO_PROXY", trivyConfigName, keyTrivyNoProxy),
+	}
+	if config.IgnoreUnfixed() {
+		env = append(env, constructEnvVarSourceFromConfigMap("TRIVY_IGNORE_UNFIXED",
+			trivyConfigName, keyTrivyIgnoreUnfixed))
+	}
+
+	skipDirs := make([]string, len(nodeSkipDirs))
+	for i, dir := range nodeSkipDirs {
+		skipDirs[i] = hostRootMountPath + dir
+	}
+
+	container := corev1.Container{
+		Name:                     nodeScanContainerName,
+		Image:                    trivyImageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Env:                      env,
+		Command: []string{
+			"trivy",
+		},
+		Args: []string{
+			"--skip-update",
+			"--cache-dir",
+			"/var/starboard/trivy-db",
+			"--quiet",
+			"rootfs",
+			"--format",
+			"json",
+			"--skip-dirs",
+			strings.Join(skipDirs, ","),
+			hostRootMountPath,
+		},
+		Resources: requirements,
+		VolumeMounts: append(volumeMounts, corev1.VolumeMount{
+			Name:      hostRootVolumeName,
+			ReadOnly:  true,
+			MountPath: hostRootMountPath,
+		}),
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:               pointer.BoolPtr(false),
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"all"},
+			},
+			ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+			// Trivy needs to run as root user to read all files of the host
+			// root file system.
+			RunAsUser: pointer.Int64(0),
+		},
+	}
+
+	return corev1.PodSpec{
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           ctx.GetServiceAccountName(),
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		NodeName:                     node.Name,
+		Volumes:                      volumes,
+		InitContainers:               []corev1.Container{initContainerDB},
+		Containers:                   []corev1.Container{container},
+		SecurityContext:              &corev1.PodSecurityContext{},
+	}, nil
+}
+
+// ParseNodeVulnerabilityReportData converts vulnerabilities found by Trivy
+// in the root file system of the given node. The node has no image
+// reference, therefore the artifact is identified by the OS image of the
+// node, or by its name if the OS image is not reported by the kubelet.
+func (p *plugin) ParseNodeVulnerabilityReportData(ctx starboard.PluginContext, node corev1.Node, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	var reports ScanReport
+	err = json.NewDecoder(logsReader).Decode(&reports)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
+
+	for _, report := range reports.Results {
+		for _, sr := range report.Vulnerabilities {
+			vulnerabilities = append(vulnerabilities, v1alpha1.Vulnerability{
+				VulnerabilityID:  sr.VulnerabilityID,
+				Resource:         sr.PkgName,
+				InstalledVersion: sr.InstalledVersion,
+				FixedVersion:     sr.FixedVersion,
+				Severity:         sr.Severity,
+				Title:            sr.Title,
+				PrimaryLink:      sr.PrimaryURL,
+				Links:            []string{},
+				Score:            GetScoreFromCVSS(sr.Cvss),
+			})
+		}
+	}
+
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	version, err := starboard.GetVersionFromImageRef(trivyImageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	artifact := v1alpha1.Artifact{Repository: node.Status.NodeInfo.OSImage}
+	if artifact.Repository == "" {
+		artifact.Repository = node.Name
+	}
+
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:    "Trivy",
+			Vendor:  "Aqua Security",
+			Version: version,
+		},
+		Artifact:        artifact,
+		Summary:         p.toSummary(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}, nil
+}
+
+// GetNodeScanContainerName returns the name of the container that runs the
+// Trivy rootfs command.
+func (p *plugin) GetNodeScanContainerName() string {
+	return nodeScanContainerName
+}
@@ -0,0 +1,130 @@
+package trivy_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newNodePlugin(t *testing.T) (vulnerabilityreport.NodePlugin, starboard.PluginContext) {
+	t.Helper()
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-trivy-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"trivy.imageRef":     "docker.io/aquasec/trivy:0.25.2",
+				"trivy.dbRepository": defaultDBRepository,
+			},
+		},
+	).Build()
+	pluginContext := starboard.NewPluginContext().
+		WithName(trivy.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeClient).
+		Get()
+	instance, ok := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeClient).(vulnerabilityreport.NodePlugin)
+	require.True(t, ok, "Trivy plugin must implement NodePlugin")
+	return instance, pluginContext
+}
+
+func TestPlugin_GetNodeScanJobSpec(t *testing.T) {
+	instance, pluginContext := newNodePlugin(t)
+	node := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kind-control-plane",
+		},
+	}
+
+	spec, err := instance.GetNodeScanJobSpec(pluginContext, node)
+	require.NoError(t, err)
+
+	assert.Equal(t, "kind-control-plane", spec.NodeName)
+	assert.Equal(t, "starboard-sa", spec.ServiceAccountName)
+	require.Len(t, spec.InitContainers, 1)
+	assert.Equal(t, []string{
+		"--download-db-only",
+		"--cache-dir", "/var/starboard/trivy-db",
+		"--db-repository", defaultDBRepository,
+	}, spec.InitContainers[0].Args)
+
+	require.Len(t, spec.Containers, 1)
+	container := spec.Containers[0]
+	assert.Equal(t, instance.GetNodeScanContainerName(), container.Name)
+	assert.Equal(t, "docker.io/aquasec/trivy:0.25.2", container.Image)
+	assert.Equal(t, []string{
+		"--skip-update",
+		"--cache-dir", "/var/starboard/trivy-db",
+		"--quiet",
+		"rootfs",
+		"--format", "json",
+		"--skip-dirs", "/hostfs/proc,/hostfs/sys,/hostfs/dev,/hostfs/run,/hostfs/var/lib/containerd,/hostfs/var/lib/docker,/hostfs/var/lib/kubelet",
+		"/hostfs",
+	}, container.Args)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "host-root",
+		ReadOnly:  true,
+		MountPath: "/hostfs",
+	})
+	assert.Contains(t, spec.Volumes, corev1.Volume{
+		Name: "host-root",
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: "/",
+			},
+		},
+	})
+}
+
+func TestPlugin_ParseNodeVulnerabilityReportData(t *testing.T) {
+	instance, pluginContext := newNodePlugin(t)
+
+	t.Run("Should use OS image of node as artifact", func(t *testing.T) {
+		node := corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kind-control-plane",
+			},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{
+					OSImage: "Ubuntu 21.10",
+				},
+			},
+		}
+		report, err := instance.ParseNodeVulnerabilityReportData(pluginContext, node,
+			io.NopCloser(strings.NewReader(sampleReportAsString)))
+		require.NoError(t, err)
+		assert.Equal(t, metav1.NewTime(fixedTime), report.UpdateTimestamp)
+		assert.Equal(t, v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.25.2"}, report.Scanner)
+		assert.Equal(t, v1alpha1.Registry{}, report.Registry)
+		assert.Equal(t, v1alpha1.Artifact{Repository: "Ubuntu 21.10"}, report.Artifact)
+		assert.Equal(t, sampleReport.Vulnerabilities, report.Vulnerabilities)
+		assert.Equal(t, sampleReport.Summary, report.Summary)
+	})
+
+	t.Run("Should use node name as artifact when OS image is not reported", func(t *testing.T) {
+		node := corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kind-worker",
+			},
+		}
+		report, err := instance.ParseNodeVulnerabilityReportData(pluginContext, node,
+			io.NopCloser(strings.NewReader("null")))
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.Artifact{Repository: "kind-worker"}, report.Artifact)
+		assert.Empty(t, report.Vulnerabilities)
+	})
+}
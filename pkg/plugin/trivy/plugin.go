@@ -486,7 +486,7 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 		})
 	}
 
-	for _, c := range spec.Containers {
+	for _, c := range kube.GetContainers(spec) {
 
 		env := []corev1.EnvVar{
 			{
@@ -649,6 +649,17 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 			return corev1.PodSpec{}, nil, err
 		}
 
+		args := []string{
+			"--cache-dir",
+			"/tmp/trivy/.cache",
+			"--quiet",
+			"image",
+			"--skip-update",
+			"--format",
+			"json",
+		}
+		args = append(p.appendListAllPkgsArg(ctx, args), optionalMirroredImage)
+
 		containers = append(containers, corev1.Container{
 			Name:                     c.Name,
 			Image:                    trivyImageRef,
@@ -658,16 +669,7 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 			Command: []string{
 				"trivy",
 			},
-			Args: []string{
-				"--cache-dir",
-				"/tmp/trivy/.cache",
-				"--quiet",
-				"image",
-				"--skip-update",
-				"--format",
-				"json",
-				optionalMirroredImage,
-			},
+			Args:         args,
 			Resources:    resourceRequirements,
 			VolumeMounts: volumeMounts,
 			SecurityContext: &corev1.SecurityContext{
@@ -730,7 +732,7 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 
 	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
 
-	for _, container := range spec.Containers {
+	for _, container := range kube.GetContainers(spec) {
 
 		env := []corev1.EnvVar{
 			{
@@ -955,6 +957,16 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 			return corev1.PodSpec{}, nil, err
 		}
 
+		args := []string{
+			"--quiet",
+			"client",
+			"--format",
+			"json",
+			"--remote",
+			trivyServerURL,
+		}
+		args = append(p.appendListAllPkgsArg(ctx, args), optionalMirroredImage)
+
 		containers = append(containers, corev1.Container{
 			Name:                     container.Name,
 			Image:                    trivyImageRef,
@@ -964,15 +976,7 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 			Command: []string{
 				"trivy",
 			},
-			Args: []string{
-				"--quiet",
-				"client",
-				"--format",
-				"json",
-				"--remote",
-				trivyServerURL,
-				optionalMirroredImage,
-			},
+			Args:         args,
 			VolumeMounts: volumeMounts,
 			Resources:    requirements,
 		})
@@ -1129,7 +1133,7 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 		})
 	}
 
-	for _, c := range spec.Containers {
+	for _, c := range kube.GetContainers(spec) {
 
 		env := []corev1.EnvVar{
 			constructEnvVarSourceFromConfigMap("TRIVY_SEVERITY", trivyConfigName, keyTrivySeverity),
@@ -1159,6 +1163,18 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 		if err != nil {
 			return corev1.PodSpec{}, nil, err
 		}
+
+		args := []string{
+			"--skip-update",
+			"--cache-dir",
+			"/var/starboard/trivy-db",
+			"--quiet",
+			"fs",
+			"--format",
+			"json",
+		}
+		args = append(p.appendListAllPkgsArg(ctx, args), "/")
+
 		containers = append(containers, corev1.Container{
 			Name:                     c.Name,
 			Image:                    c.Image,
@@ -1168,16 +1184,7 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 			Command: []string{
 				SharedVolumeLocationOfTrivy,
 			},
-			Args: []string{
-				"--skip-update",
-				"--cache-dir",
-				"/var/starboard/trivy-db",
-				"--quiet",
-				"fs",
-				"--format",
-				"json",
-				"/",
-			},
+			Args:         args,
 			Resources:    resourceRequirements,
 			VolumeMounts: volumeMounts,
 			// Todo review security Context which is better for trivy fs scan
@@ -1213,6 +1220,16 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 	return podSpec, secrets, nil
 }
 
+// appendListAllPkgsArg appends the flag to list all packages in addition to
+// vulnerabilities, which is required by ParseSbomReportData, if SBOM reports
+// are enabled.
+func (p *plugin) appendListAllPkgsArg(ctx starboard.PluginContext, args []string) []string {
+	if ctx.GetStarboardConfig().SbomReportsEnabled() {
+		args = append(args, "--list-all-pkgs")
+	}
+	return args
+}
+
 func (p *plugin) appendTrivyInsecureEnv(config Config, image string, env []corev1.EnvVar) ([]corev1.EnvVar, error) {
 	ref, err := name.ParseReference(image)
 	if err != nil {
@@ -1304,6 +1321,64 @@ func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, image
 	}, nil
 }
 
+// ParseSbomReportData converts packages listed by Trivy with the
+// --list-all-pkgs flag to SBOM components.
+func (p *plugin) ParseSbomReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.SbomReportData, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return v1alpha1.SbomReportData{}, err
+	}
+	var reports ScanReport
+	err = json.NewDecoder(logsReader).Decode(&reports)
+	if err != nil {
+		return v1alpha1.SbomReportData{}, err
+	}
+	components := make([]v1alpha1.Component, 0)
+
+	for _, report := range reports.Results {
+		for _, pkg := range report.Packages {
+			version := pkg.FullVersion()
+			components = append(components, v1alpha1.Component{
+				Name:       pkg.Name,
+				Version:    version,
+				PackageURL: packageURL(report.Type, reports.Metadata.OS, pkg.Name, version),
+				Licenses:   pkg.GetLicenses(),
+				Target:     report.Target,
+			})
+		}
+	}
+
+	registry, artifact, err := p.parseImageRef(imageRef)
+	if err != nil {
+		return v1alpha1.SbomReportData{}, err
+	}
+
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return v1alpha1.SbomReportData{}, err
+	}
+
+	version, err := starboard.GetVersionFromImageRef(trivyImageRef)
+	if err != nil {
+		return v1alpha1.SbomReportData{}, err
+	}
+
+	return v1alpha1.SbomReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:    "Trivy",
+			Vendor:  "Aqua Security",
+			Version: version,
+		},
+		Registry: registry,
+		Artifact: artifact,
+		Summary: v1alpha1.SbomSummary{
+			ComponentsCount: len(components),
+		},
+		Components: components,
+	}, nil
+}
+
 func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
 	pluginConfig, err := ctx.GetConfig()
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
@@ -3760,3 +3761,235 @@ func TestGetMirroredImage(t *testing.T) {
 		})
 	}
 }
+
+func TestPlugin_GetScanJobSpec_SbomReportsEnabled(t *testing.T) {
+	workload := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicaSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "nginx",
+							Image: "nginx:1.16",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		config       map[string]string
+		expectedArgs []string
+	}{
+		{
+			name: "Standalone mode",
+			config: map[string]string{
+				"trivy.imageRef":     "docker.io/aquasec/trivy:0.25.2",
+				"trivy.mode":         string(trivy.Standalone),
+				"trivy.dbRepository": defaultDBRepository,
+			},
+			expectedArgs: []string{
+				"--cache-dir", "/tmp/trivy/.cache",
+				"--quiet",
+				"image",
+				"--skip-update",
+				"--format", "json",
+				"--list-all-pkgs",
+				"nginx:1.16",
+			},
+		},
+		{
+			name: "ClientServer mode",
+			config: map[string]string{
+				"trivy.imageRef":  "docker.io/aquasec/trivy:0.25.2",
+				"trivy.mode":      string(trivy.ClientServer),
+				"trivy.serverURL": "http://trivy.trivy:4954",
+			},
+			expectedArgs: []string{
+				"--quiet",
+				"client",
+				"--format",
+				"json",
+				"--remote",
+				"http://trivy.trivy:4954",
+				"--list-all-pkgs",
+				"nginx:1.16",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeclient := fake.NewClientBuilder().WithObjects(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "starboard-trivy-config",
+						Namespace: "starboard-ns",
+					},
+					Data: tc.config,
+				},
+			).Build()
+			pluginContext := starboard.NewPluginContext().
+				WithName(trivy.Plugin).
+				WithNamespace("starboard-ns").
+				WithServiceAccountName("starboard-sa").
+				WithClient(fakeclient).
+				WithStarboardConfig(starboard.ConfigData{
+					starboard.KeySbomReportsEnabled: "true",
+				}).
+				Get()
+			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeclient)
+			jobSpec, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+			require.NoError(t, err)
+			require.Len(t, jobSpec.Containers, 1)
+			assert.Equal(t, tc.expectedArgs, jobSpec.Containers[0].Args)
+		})
+	}
+}
+
+func TestPlugin_ParseSbomReportData(t *testing.T) {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "starboard-trivy-config",
+			Namespace: "starboard-ns",
+		},
+		Data: map[string]string{
+			"trivy.imageRef": "aquasec/trivy:0.25.2",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(config).Build()
+	ctx := starboard.NewPluginContext().
+		WithName("Trivy").
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeClient).
+		Get()
+	instance, ok := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeClient).(vulnerabilityreport.SbomPlugin)
+	require.True(t, ok, "Trivy plugin should implement SbomPlugin")
+
+	input := `{
+  "Metadata": {"OS": {"Family": "debian", "Name": "11.3"}},
+  "Results": [
+    {
+      "Target": "app:1.0 (debian 11.3)",
+      "Type": "debian",
+      "Packages": [
+        {"Name": "libc6", "Version": "2.31-13+deb11u3", "Licenses": ["LGPL-2.1", "GPL-2.0"]},
+        {"Name": "bsdutils", "Version": "2.36.1", "Release": "8+deb11u1", "Epoch": 1}
+      ]
+    },
+    {
+      "Target": "app/package-lock.json",
+      "Type": "npm",
+      "Packages": [
+        {"Name": "@babel/core", "Version": "7.18.2", "License": "MIT"}
+      ]
+    },
+    {
+      "Target": "app/requirements.txt",
+      "Type": "pip",
+      "Packages": [
+        {"Name": "Flask_Cors", "Version": "3.0.10"}
+      ]
+    },
+    {
+      "Target": "app/app.jar",
+      "Type": "jar",
+      "Packages": [
+        {"Name": "org.apache.logging.log4j:log4j-core", "Version": "2.17.1"}
+      ]
+    },
+    {
+      "Target": "app/bin/server",
+      "Type": "gobinary",
+      "Packages": [
+        {"Name": "github.com/aquasecurity/starboard", "Version": "v0.15.4"}
+      ]
+    },
+    {
+      "Target": "app/unknown.lock",
+      "Type": "unknown",
+      "Packages": [
+        {"Name": "foo", "Version": "1.0"}
+      ]
+    }
+  ]
+}`
+
+	report, err := instance.ParseSbomReportData(ctx, "core.harbor.domain/library/app:1.0", io.NopCloser(strings.NewReader(input)))
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.SbomReportData{
+		UpdateTimestamp: metav1.NewTime(fixedTime),
+		Scanner: v1alpha1.Scanner{
+			Name:    "Trivy",
+			Vendor:  "Aqua Security",
+			Version: "0.25.2",
+		},
+		Registry: v1alpha1.Registry{
+			Server: "core.harbor.domain",
+		},
+		Artifact: v1alpha1.Artifact{
+			Repository: "library/app",
+			Tag:        "1.0",
+		},
+		Summary: v1alpha1.SbomSummary{
+			ComponentsCount: 7,
+		},
+		Components: []v1alpha1.Component{
+			{
+				Name:       "libc6",
+				Version:    "2.31-13+deb11u3",
+				PackageURL: "pkg:deb/debian/libc6@2.31-13%2Bdeb11u3?distro=debian-11.3",
+				Licenses:   []string{"LGPL-2.1", "GPL-2.0"},
+				Target:     "app:1.0 (debian 11.3)",
+			},
+			{
+				Name:       "bsdutils",
+				Version:    "1:2.36.1-8+deb11u1",
+				PackageURL: "pkg:deb/debian/bsdutils@1%3A2.36.1-8%2Bdeb11u1?distro=debian-11.3",
+				Target:     "app:1.0 (debian 11.3)",
+			},
+			{
+				Name:       "@babel/core",
+				Version:    "7.18.2",
+				PackageURL: "pkg:npm/%40babel/core@7.18.2",
+				Licenses:   []string{"MIT"},
+				Target:     "app/package-lock.json",
+			},
+			{
+				Name:       "Flask_Cors",
+				Version:    "3.0.10",
+				PackageURL: "pkg:pypi/flask-cors@3.0.10",
+				Target:     "app/requirements.txt",
+			},
+			{
+				Name:       "org.apache.logging.log4j:log4j-core",
+				Version:    "2.17.1",
+				PackageURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.17.1",
+				Target:     "app/app.jar",
+			},
+			{
+				Name:       "github.com/aquasecurity/starboard",
+				Version:    "v0.15.4",
+				PackageURL: "pkg:golang/github.com/aquasecurity/starboard@v0.15.4",
+				Target:     "app/bin/server",
+			},
+			{
+				Name:    "foo",
+				Version: "1.0",
+				Target:  "app/unknown.lock",
+			},
+		},
+	}, report)
+}
@@ -0,0 +1,110 @@
+package trivy
+
+import (
+	"net/url"
+	"strings"
+)
+
+// osPackageTypes maps types of Trivy results for OS packages to purl types.
+var osPackageTypes = map[string]string{
+	"alpine":                       "apk",
+	"debian":                       "deb",
+	"ubuntu":                       "deb",
+	"redhat":                       "rpm",
+	"centos":                       "rpm",
+	"rocky":                        "rpm",
+	"alma":                         "rpm",
+	"amazon":                       "rpm",
+	"oracle":                       "rpm",
+	"fedora":                       "rpm",
+	"photon":                       "rpm",
+	"opensuse.leap":                "rpm",
+	"suse linux enterprise server": "rpm",
+	"cbl-mariner":                  "rpm",
+}
+
+// langPackageTypes maps types of Trivy results for application dependencies
+// to purl types.
+var langPackageTypes = map[string]string{
+	"npm":         "npm",
+	"yarn":        "npm",
+	"node-pkg":    "npm",
+	"pip":         "pypi",
+	"pipenv":      "pypi",
+	"poetry":      "pypi",
+	"python-pkg":  "pypi",
+	"bundler":     "gem",
+	"gemspec":     "gem",
+	"cargo":       "cargo",
+	"composer":    "composer",
+	"gomod":       "golang",
+	"gobinary":    "golang",
+	"jar":         "maven",
+	"pom":         "maven",
+	"gradle":      "maven",
+	"nuget":       "nuget",
+	"dotnet-core": "nuget",
+	"conan":       "conan",
+}
+
+// packageURL returns the package URL (purl) of the package with the given
+// name and version listed in the Trivy result of the given type. An empty
+// string is returned for unsupported result types.
+// See https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst
+func packageURL(resultType string, os *OS, name, version string) string {
+	if purlType, ok := osPackageTypes[resultType]; ok {
+		if os == nil {
+			return ""
+		}
+		purl := "pkg:" + purlType + "/" + purlEscape(namespaceOf(os.Family)) + "/" + purlEscape(name) + "@" + purlEscape(version)
+		return purl + "?distro=" + purlEscape(os.Family+"-"+os.Name)
+	}
+
+	purlType, ok := langPackageTypes[resultType]
+	if !ok {
+		return ""
+	}
+
+	var namespace string
+	switch purlType {
+	case "npm":
+		if strings.HasPrefix(name, "@") {
+			if i := strings.Index(name, "/"); i > 0 {
+				namespace, name = name[:i], name[i+1:]
+			}
+		}
+	case "pypi":
+		name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	case "composer", "golang":
+		if i := strings.LastIndex(name, "/"); i > 0 {
+			namespace, name = name[:i], name[i+1:]
+		}
+	case "maven":
+		if i := strings.Index(name, ":"); i > 0 {
+			namespace, name = name[:i], name[i+1:]
+		}
+	}
+
+	purl := "pkg:" + purlType + "/"
+	if namespace != "" {
+		var segments []string
+		for _, segment := range strings.Split(namespace, "/") {
+			segments = append(segments, purlEscape(segment))
+		}
+		purl += strings.Join(segments, "/") + "/"
+	}
+	return purl + purlEscape(name) + "@" + purlEscape(version)
+}
+
+// namespaceOf returns the purl namespace for the given OS family, e.g.
+// "suse linux enterprise server" is normalized to "suse".
+func namespaceOf(family string) string {
+	if i := strings.IndexAny(family, " ."); i > 0 {
+		return family[:i]
+	}
+	return family
+}
+
+func purlEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
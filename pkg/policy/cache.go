@@ -0,0 +1,328 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// maxCacheEntries is the maximum number of sets of prepared queries held by
+// a Cache. There is a set for each distinct digest of policies, i.e. roughly
+// one for each kind of resource, plus sets of policies that have been
+// replaced and are evicted as least recently used.
+const maxCacheEntries = 64
+
+// Cache holds queries prepared for policies applicable to a kind of
+// resources, keyed by the SHA256 digest of their sources and the sources of
+// libraries. Policies.Hash is not used as the key, because a collision of its
+// 32-bit value would reuse queries of other policies. Policies are parsed and
+// compiled once, and then only when they change, rather than on each
+// evaluation. It's safe for concurrent use.
+type Cache struct {
+	mu        sync.Mutex
+	entries   map[string]*cacheEntry
+	listeners []func(kind string, duration time.Duration)
+}
+
+type cacheEntry struct {
+	once     sync.Once
+	prepared *preparedPolicies
+	err      error
+	lastUsed time.Time
+}
+
+// NewCache constructs a new empty Cache.
+func NewCache() *Cache {
+	return &Cache{
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// OnCompile registers a function that is called with the kind of resources
+// and the duration whenever policies are compiled and cached.
+func (c *Cache) OnCompile(listener func(kind string, duration time.Duration)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, listener)
+}
+
+// Len returns the number of sets of prepared queries held by this Cache.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *Cache) get(ctx context.Context, p *Policies, kind string) (*preparedPolicies, error) {
+	modules, err := p.ModulesByKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	hash := dataDigest(modules)
+
+	c.mu.Lock()
+	entry, ok := c.entries[hash]
+	if !ok {
+		c.evict()
+		entry = &cacheEntry{}
+		c.entries[hash] = entry
+	}
+	entry.lastUsed = time.Now()
+	listeners := c.listeners
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		start := time.Now()
+		entry.prepared, entry.err = p.prepare(ctx, kind)
+		if entry.err != nil {
+			return
+		}
+		for _, listener := range listeners {
+			listener(kind, time.Since(start))
+		}
+	})
+	if entry.err != nil {
+		// Do not cache errors, which might be caused by the cancelled
+		// context rather than invalid policies.
+		c.mu.Lock()
+		if c.entries[hash] == entry {
+			delete(c.entries, hash)
+		}
+		c.mu.Unlock()
+	}
+	return entry.prepared, entry.err
+}
+
+// evict removes the least recently used entry if this Cache is full. It
+// must be called with the lock held.
+func (c *Cache) evict() {
+	if len(c.entries) < maxCacheEntries {
+		return
+	}
+	var oldestHash string
+	var oldest time.Time
+	for hash, entry := range c.entries {
+		if oldestHash == "" || entry.lastUsed.Before(oldest) {
+			oldestHash, oldest = hash, entry.lastUsed
+		}
+	}
+	delete(c.entries, oldestHash)
+}
+
+// preparedPolicies holds queries prepared for policies applicable to a kind
+// of resources. Usually all policies are evaluated with a single query,
+// unless they cannot be compiled together, e.g. because they're declared
+// in the same package. It also holds kinds of objects that policies read
+// from data.kubernetes.
+type preparedPolicies struct {
+	queries   []preparedQuery
+	dataKinds []string
+}
+
+type preparedQuery struct {
+	query    rego.PreparedEvalQuery
+	policies []string
+}
+
+// prepare parses and compiles policies applicable to the given kind along
+// with libraries, and prepares queries that evaluate them.
+func (p *Policies) prepare(ctx context.Context, kind string) (*preparedPolicies, error) {
+	policies, err := p.PoliciesByKind(kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing policies by kind: %s: %w", kind, err)
+	}
+
+	libraries := make(map[string]*ast.Module)
+	for libraryName, libraryCode := range p.Libraries() {
+		libraries[libraryName], err = ast.ParseModule(libraryName, libraryCode)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing Rego library: %s: %w", libraryName, err)
+		}
+	}
+
+	names := make([]string, 0, len(policies))
+	for policyName := range policies {
+		names = append(names, policyName)
+	}
+	sort.Strings(names)
+
+	parsedPolicies := make(map[string]*ast.Module)
+	packages := make(map[string]bool)
+	distinctPackages := true
+	for _, policyName := range names {
+		parsedPolicy, err := ast.ParseModule(policyName, policies[policyName])
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing Rego policy: %s: %w", policyName, err)
+		}
+		parsedPolicies[policyName] = parsedPolicy
+		packagePath := parsedPolicy.Package.Path.String()
+		if packages[packagePath] {
+			distinctPackages = false
+		}
+		packages[packagePath] = true
+	}
+
+	modules := make(map[string]*ast.Module, len(libraries)+len(parsedPolicies))
+	for name, module := range libraries {
+		modules[name] = module
+	}
+	for name, module := range parsedPolicies {
+		modules[name] = module
+	}
+	prepared := &preparedPolicies{
+		dataKinds: dataKinds(modules),
+	}
+	if len(names) == 0 {
+		return prepared, nil
+	}
+
+	// Try to evaluate all policies with a single query. Policies declared
+	// in the same package would be merged by the compiler, therefore they
+	// are evaluated separately as well as policies that fail to compile
+	// together.
+	if distinctPackages {
+		query, err := prepareQuery(ctx, libraries, parsedPolicies, names)
+		if err == nil {
+			prepared.queries = append(prepared.queries, query)
+			return prepared, nil
+		}
+	}
+	for _, policyName := range names {
+		query, err := prepareQuery(ctx, libraries, parsedPolicies, []string{policyName})
+		if err != nil {
+			return nil, fmt.Errorf("failed compiling Rego policy: %s: %w", policyName, err)
+		}
+		prepared.queries = append(prepared.queries, query)
+	}
+	return prepared, nil
+}
+
+func prepareQuery(ctx context.Context, libraries, parsedPolicies map[string]*ast.Module, names []string) (preparedQuery, error) {
+	modules := make(map[string]*ast.Module, len(libraries)+len(names))
+	for libraryName, library := range libraries {
+		modules[libraryName] = library
+	}
+
+	// Rules are collected with comprehensions, which evaluate to empty
+	// arrays rather than undefined if a policy does not define the rule.
+	expressions := make([]string, 0, 3*len(names))
+	for i, policyName := range names {
+		parsedPolicy := parsedPolicies[policyName]
+		modules[policyName] = parsedPolicy
+		packagePath := parsedPolicy.Package.Path.String()
+		expressions = append(expressions,
+			fmt.Sprintf("%s%d = [x | x := %s.__rego_metadata__]", varMetadata, i, packagePath),
+			fmt.Sprintf("%s%d = [x | x := %s.deny[_]]", ruleDeny, i, packagePath),
+			fmt.Sprintf("%s%d = [x | x := %s.warn[_]]", ruleWarn, i, packagePath),
+		)
+	}
+
+	compiler := ast.NewCompiler()
+	compiler.Compile(modules)
+	if compiler.Failed() {
+		return preparedQuery{}, compiler.Errors
+	}
+
+	query, err := rego.New(
+		rego.Compiler(compiler),
+		rego.Store(newInventoryStore()),
+		rego.Query(strings.Join(expressions, "; ")),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return preparedQuery{}, err
+	}
+	return preparedQuery{
+		query:    query,
+		policies: names,
+	}, nil
+}
+
+func (p *preparedPolicies) eval(ctx context.Context, input interface{}) (Results, error) {
+	var results Results
+	for _, q := range p.queries {
+		rs, err := q.query.Eval(ctx, rego.EvalInput(input))
+		if err != nil {
+			return nil, fmt.Errorf("failed evaluating Rego policies: %s: %w", strings.Join(q.policies, ", "), err)
+		}
+		if len(rs) == 0 {
+			return nil, fmt.Errorf("failed evaluating Rego policies: %s: undefined result", strings.Join(q.policies, ", "))
+		}
+		bindings := rs[0].Bindings
+		for i, policyName := range q.policies {
+			result, err := newResult(policyName,
+				bindings[fmt.Sprintf("%s%d", varMetadata, i)],
+				bindings[fmt.Sprintf("%s%d", ruleDeny, i)],
+				bindings[fmt.Sprintf("%s%d", ruleWarn, i)])
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// newResult converts values of the metadata, deny and warn rules of a policy
+// to Result. The policy fails if deny rules, or otherwise warn rules, return
+// objects with messages.
+func newResult(policyName string, metadata, deny, warn interface{}) (Result, error) {
+	values, ok := metadata.([]interface{})
+	if !ok || len(values) == 0 {
+		return Result{}, fmt.Errorf("failed parsing policy metadata: %s", policyName)
+	}
+	metadataValues, ok := values[0].(map[string]interface{})
+	if !ok {
+		return Result{}, fmt.Errorf("failed parsing policy metadata: %s", policyName)
+	}
+	md, err := NewMetadata(metadataValues)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed parsing policy metadata: %s: %w", policyName, err)
+	}
+
+	for _, rule := range []struct {
+		name   string
+		values interface{}
+	}{
+		{name: ruleDeny, values: deny},
+		{name: ruleWarn, values: warn},
+	} {
+		ruleValues, ok := objectValues(rule.values)
+		if !ok {
+			continue
+		}
+		results, err := valuesToResults(md, ruleValues)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed parsing %s rule result: %s: %w", rule.name, policyName, err)
+		}
+		return results[0], nil
+	}
+
+	return Result{
+		Metadata: md,
+		Success:  true,
+	}, nil
+}
+
+// objectValues returns true if the given value is a non-empty array of
+// objects.
+func objectValues(value interface{}) ([]map[string]interface{}, bool) {
+	values, ok := value.([]interface{})
+	if !ok || len(values) == 0 {
+		return nil, false
+	}
+	objects := make([]map[string]interface{}, len(values))
+	for i, v := range values {
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		objects[i] = object
+	}
+	return objects, true
+}
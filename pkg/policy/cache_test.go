@@ -0,0 +1,276 @@
+package policy_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard"
+	"github.com/aquasecurity/starboard/pkg/policy"
+	. "github.com/onsi/gomega"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	regoPrivileged = `package appshield.kubernetes.KSV017
+
+__rego_metadata__ := {
+	"id": "KSV017",
+	"title": "Privileged container",
+	"description": "Privileged containers share namespaces with the host system",
+	"severity": "HIGH",
+	"type": "Kubernetes Security Check"
+}
+
+deny[res] {
+	input.spec.template.spec.containers[_].securityContext.privileged
+	res := {"msg": "Container should not be privileged"}
+}
+`
+	regoLatestTag = `package appshield.kubernetes.KSV013
+
+__rego_metadata__ := {
+	"id": "KSV013",
+	"title": "Image tag ':latest' used",
+	"description": "It is best to avoid using the ':latest' image tag",
+	"severity": "LOW",
+	"type": "Kubernetes Security Check"
+}
+
+warn[res] {
+	endswith(input.spec.template.spec.containers[_].image, ":latest")
+	res := {"msg": "Container should not use the latest tag"}
+}
+`
+)
+
+func newDeployment(name, image string, privileged bool) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: image,
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: &privileged,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPolicies_EvalWithCache(t *testing.T) {
+	data := map[string]string{
+		"policy.privileged.rego":  regoPrivileged,
+		"policy.privileged.kinds": "Workload",
+		"policy.latest_tag.rego":  regoLatestTag,
+		"policy.latest_tag.kinds": "Deployment",
+	}
+
+	privileged := policy.Result{
+		Metadata: policy.Metadata{
+			ID:          "KSV017",
+			Title:       "Privileged container",
+			Description: "Privileged containers share namespaces with the host system",
+			Severity:    "HIGH",
+			Type:        "Kubernetes Security Check",
+		},
+	}
+	latestTag := policy.Result{
+		Metadata: policy.Metadata{
+			ID:          "KSV013",
+			Title:       "Image tag ':latest' used",
+			Description: "It is best to avoid using the ':latest' image tag",
+			Severity:    "LOW",
+			Type:        "Kubernetes Security Check",
+		},
+	}
+
+	g := NewGomegaWithT(t)
+	cache := policy.NewCache()
+	var compiled []string
+	cache.OnCompile(func(kind string, _ time.Duration) {
+		compiled = append(compiled, kind)
+	})
+
+	results, err := policy.NewPolicies(data).WithCache(cache).
+		Eval(context.TODO(), newDeployment("nginx", "nginx:latest", true))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(results).To(ConsistOf(
+		policy.Result{Metadata: privileged.Metadata, Messages: []string{"Container should not be privileged"}},
+		policy.Result{Metadata: latestTag.Metadata, Messages: []string{"Container should not use the latest tag"}},
+	))
+
+	results, err = policy.NewPolicies(data).WithCache(cache).
+		Eval(context.TODO(), newDeployment("redis", "redis:6", false))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(results).To(ConsistOf(
+		policy.Result{Metadata: privileged.Metadata, Success: true},
+		policy.Result{Metadata: latestTag.Metadata, Success: true},
+	))
+	g.Expect(compiled).To(Equal([]string{"Deployment"}))
+	g.Expect(cache.Len()).To(Equal(1))
+
+	t.Run("Should return same results as without cache", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		deployment := newDeployment("nginx", "nginx:latest", false)
+		expected, err := policy.NewPolicies(data).Eval(context.TODO(), deployment)
+		g.Expect(err).ToNot(HaveOccurred())
+		actual, err := policy.NewPolicies(data).WithCache(cache).Eval(context.TODO(), deployment)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(actual).To(Equal(expected))
+	})
+
+	t.Run("Should recompile policies when they change", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		changed := map[string]string{
+			"policy.privileged.rego":  regoPrivileged,
+			"policy.privileged.kinds": "Workload",
+		}
+		results, err := policy.NewPolicies(changed).WithCache(cache).
+			Eval(context.TODO(), newDeployment("nginx", "nginx:latest", false))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(results).To(Equal(policy.Results{
+			{Metadata: privileged.Metadata, Success: true},
+		}))
+		g.Expect(compiled).To(Equal([]string{"Deployment", "Deployment"}))
+		g.Expect(cache.Len()).To(Equal(2))
+	})
+
+	t.Run("Should evaluate policies declared in the same package separately", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		samePackage := map[string]string{
+			"policy.privileged.rego":  regoPrivileged,
+			"policy.privileged.kinds": "Workload",
+			"policy.copy.rego":        regoPrivileged,
+			"policy.copy.kinds":       "Workload",
+		}
+		results, err := policy.NewPolicies(samePackage).WithCache(cache).
+			Eval(context.TODO(), newDeployment("nginx", "nginx:latest", true))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(results).To(HaveLen(2))
+		for _, result := range results {
+			g.Expect(result.Messages).To(Equal([]string{"Container should not be privileged"}))
+		}
+	})
+
+	t.Run("Should not cache policies that fail to compile", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		invalid := map[string]string{
+			"policy.invalid.rego":  "package invalid\n\ndeny[res] {\n  res := undefined_function(input)\n}\n",
+			"policy.invalid.kinds": "Workload",
+		}
+		size := cache.Len()
+		_, err := policy.NewPolicies(invalid).WithCache(cache).
+			Eval(context.TODO(), newDeployment("nginx", "nginx:latest", true))
+		g.Expect(err).To(MatchError(ContainSubstring("failed compiling Rego policy: policy.invalid.rego")))
+		g.Expect(cache.Len()).To(Equal(size))
+	})
+}
+
+// BenchmarkPolicies_Eval compares evaluating the default policies with a
+// Cache, without a Cache, and with the previous implementation, which
+// compiled and evaluated each policy separately.
+func BenchmarkPolicies_Eval(b *testing.B) {
+	cm, err := starboard.PoliciesConfigMap()
+	if err != nil {
+		b.Fatal(err)
+	}
+	deployment := newDeployment("nginx", "nginx:1.16", false)
+
+	b.Run("WithCache", func(b *testing.B) {
+		cache := policy.NewCache()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := policy.NewPolicies(cm.Data).WithCache(cache).Eval(context.TODO(), deployment)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("WithoutCache", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := policy.NewPolicies(cm.Data).Eval(context.TODO(), deployment)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("EachPolicySeparately", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			err := evalEachPolicySeparately(context.TODO(), policy.NewPolicies(cm.Data), deployment)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// evalEachPolicySeparately evaluates policies in the same way as Eval did
+// before queries were prepared and cached, i.e. it parses and compiles each
+// policy along with libraries, and then evaluates the metadata, deny, and
+// warn rules with separate queries.
+func evalEachPolicySeparately(ctx context.Context, policies *policy.Policies, resource client.Object) error {
+	modules, err := policies.PoliciesByKind(resource.GetObjectKind().GroupVersionKind().Kind)
+	if err != nil {
+		return err
+	}
+	for policyName, policyCode := range modules {
+		parsedModules := make(map[string]*ast.Module)
+		for libraryName, libraryCode := range policies.Libraries() {
+			parsedModules[libraryName], err = ast.ParseModule(libraryName, libraryCode)
+			if err != nil {
+				return err
+			}
+		}
+		parsedPolicy, err := ast.ParseModule(policyName, policyCode)
+		if err != nil {
+			return err
+		}
+		parsedModules[policyName] = parsedPolicy
+
+		compiler := ast.NewCompiler()
+		compiler.Compile(parsedModules)
+		if compiler.Failed() {
+			return compiler.Errors
+		}
+		for _, query := range []string{
+			fmt.Sprintf("md = %s.__rego_metadata__", parsedPolicy.Package.Path),
+			fmt.Sprintf("%s.deny[res]", parsedPolicy.Package.Path),
+			fmt.Sprintf("%s.warn[res]", parsedPolicy.Package.Path),
+		} {
+			_, err = rego.New(
+				rego.Compiler(compiler),
+				rego.Query(query),
+				rego.Input(resource),
+			).Eval(ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/storage"
+	"github.com/open-policy-agent/opa/storage/inmem"
+)
+
+// inventoryRoot is the name of the base document under which objects of the
+// Inventory are exposed to policies, i.e. data.kubernetes.<kind>.
+const inventoryRoot = "kubernetes"
+
+// Inventory lists Kubernetes objects, which are exposed to policies as arrays
+// of objects under data.kubernetes.<kind>, e.g. data.kubernetes.NetworkPolicy.
+// It allows policies to check an object against other objects in the cluster,
+// e.g. whether a Deployment is selected by a NetworkPolicy.
+type Inventory interface {
+	// List returns objects of the specified kind as JSON values, i.e. maps
+	// with apiVersion, kind, metadata and other fields of each object.
+	List(ctx context.Context, kind string) ([]interface{}, error)
+}
+
+// WithInventory exposes objects listed by the given Inventory to policies
+// evaluated by Eval. Without Inventory data.kubernetes is undefined.
+func (p *Policies) WithInventory(inventory Inventory) *Policies {
+	p.inventory = inventory
+	return p
+}
+
+// DataKinds returns sorted kinds of objects that policies applicable to the
+// specified kind, and libraries, read from data.kubernetes. Reports of such
+// policies depend on these objects as well as the evaluated resource.
+//
+// If a Cache is set with WithCache, kinds are computed once along with the
+// prepared queries, and then only when policies change.
+func (p *Policies) DataKinds(ctx context.Context, kind string) ([]string, error) {
+	if p.cache != nil {
+		prepared, err := p.cache.get(ctx, p, kind)
+		if err != nil {
+			return nil, err
+		}
+		return prepared.dataKinds, nil
+	}
+	modules, err := p.ModulesByKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseModules(modules)
+	if err != nil {
+		return nil, err
+	}
+	return dataKinds(parsed), nil
+}
+
+// AllDataKinds returns sorted kinds of objects that any policy or library
+// reads from data.kubernetes.
+func (p *Policies) AllDataKinds() ([]string, error) {
+	modules := make(map[string]string)
+	for key, value := range p.data {
+		if strings.HasSuffix(key, keySuffixRego) {
+			modules[key] = value
+		}
+	}
+	parsed, err := parseModules(modules)
+	if err != nil {
+		return nil, err
+	}
+	return dataKinds(parsed), nil
+}
+
+func parseModules(modules map[string]string) (map[string]*ast.Module, error) {
+	parsed := make(map[string]*ast.Module, len(modules))
+	for name, code := range modules {
+		module, err := ast.ParseModule(name, code)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing Rego module: %s: %w", name, err)
+		}
+		parsed[name] = module
+	}
+	return parsed, nil
+}
+
+func dataKinds(modules map[string]*ast.Module) []string {
+	kinds := make(map[string]bool)
+	for _, module := range modules {
+		// Refs can start with data.kubernetes or with the alias of the
+		// imported data.kubernetes document, e.g. kubernetes.NetworkPolicy.
+		root := ast.Ref{ast.DefaultRootDocument, ast.StringTerm(inventoryRoot)}
+		prefixes := []ast.Ref{root}
+		for _, imp := range module.Imports {
+			path, ok := imp.Path.Value.(ast.Ref)
+			if !ok || !path.Equal(root) {
+				continue
+			}
+			alias := ast.Var(inventoryRoot)
+			if imp.Alias != "" {
+				alias = imp.Alias
+			}
+			prefixes = append(prefixes, ast.Ref{ast.NewTerm(alias)})
+		}
+
+		ast.WalkRefs(module, func(ref ast.Ref) bool {
+			for _, prefix := range prefixes {
+				if len(ref) <= len(prefix) || !ref.HasPrefix(prefix) {
+					continue
+				}
+				if k, ok := ref[len(prefix)].Value.(ast.String); ok {
+					kinds[string(k)] = true
+				}
+			}
+			return false
+		})
+	}
+	names := make([]string, 0, len(kinds))
+	for k := range kinds {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type inventoryKey struct{}
+
+// evalInventory lists objects of each kind at most once per evaluation.
+type evalInventory struct {
+	inventory Inventory
+	mu        sync.Mutex
+	objects   map[string][]interface{}
+}
+
+func withInventory(ctx context.Context, inventory Inventory) context.Context {
+	if inventory == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, inventoryKey{}, &evalInventory{
+		inventory: inventory,
+		objects:   make(map[string][]interface{}),
+	})
+}
+
+func (i *evalInventory) list(ctx context.Context, kind string) ([]interface{}, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if objects, ok := i.objects[kind]; ok {
+		return objects, nil
+	}
+	objects, err := i.inventory.List(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	i.objects[kind] = objects
+	return objects, nil
+}
+
+// inventoryStore is a storage.Store that reads data.kubernetes from the
+// Inventory held by the context of an evaluation, so that prepared queries
+// can be shared by evaluations with different inventories. Other documents
+// are read from the in-memory store.
+type inventoryStore struct {
+	storage.Store
+}
+
+func newInventoryStore() storage.Store {
+	return &inventoryStore{Store: inmem.New()}
+}
+
+func (s *inventoryStore) Read(ctx context.Context, txn storage.Transaction, path storage.Path) (interface{}, error) {
+	if len(path) == 0 || path[0] != inventoryRoot {
+		return s.Store.Read(ctx, txn, path)
+	}
+	inventory, ok := ctx.Value(inventoryKey{}).(*evalInventory)
+	if !ok {
+		return nil, notFoundError(path)
+	}
+	if len(path) == 1 {
+		return nil, &storage.Error{
+			Code:    storage.InternalErr,
+			Message: "reading data.kubernetes is not supported, read data.kubernetes.<kind> instead",
+		}
+	}
+	objects, err := inventory.list(ctx, path[1])
+	if err != nil {
+		return nil, &storage.Error{
+			Code:    storage.InternalErr,
+			Message: fmt.Sprintf("listing %s: %v", path[1], err),
+		}
+	}
+
+	var value interface{} = objects
+	for _, key := range path[2:] {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if value, ok = v[key]; !ok {
+				return nil, notFoundError(path)
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, notFoundError(path)
+			}
+			value = v[index]
+		default:
+			return nil, notFoundError(path)
+		}
+	}
+	return value, nil
+}
+
+func notFoundError(path storage.Path) error {
+	return &storage.Error{
+		Code:    storage.NotFoundErr,
+		Message: fmt.Sprintf("%v: document does not exist", path),
+	}
+}
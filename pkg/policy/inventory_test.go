@@ -0,0 +1,197 @@
+package policy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/policy"
+	. "github.com/onsi/gomega"
+)
+
+const regoWithoutNetworkPolicy = `package appshield.kubernetes.KSV038
+
+__rego_metadata__ := {
+	"id": "KSV038",
+	"title": "Workload without NetworkPolicy",
+	"description": "Workloads should be selected by a NetworkPolicy",
+	"severity": "MEDIUM",
+	"type": "Kubernetes Security Check"
+}
+
+selected(policy) {
+	policy.metadata.namespace == input.metadata.namespace
+	labels := policy.spec.podSelector.matchLabels
+	count({k | labels[k] == input.spec.template.metadata.labels[k]}) == count(labels)
+}
+
+deny[res] {
+	count([p | p := data.kubernetes.NetworkPolicy[_]; selected(p)]) == 0
+	res := {"msg": "Deployment is not selected by any NetworkPolicy"}
+}
+`
+
+type fakeInventory struct {
+	objects map[string][]interface{}
+	calls   map[string]int
+	err     error
+}
+
+func (i *fakeInventory) List(_ context.Context, kind string) ([]interface{}, error) {
+	i.calls[kind]++
+	return i.objects[kind], i.err
+}
+
+func newNetworkPolicy(namespace string, matchLabels map[string]interface{}) interface{} {
+	return map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "NetworkPolicy",
+		"metadata": map[string]interface{}{
+			"name":      "allow-nginx",
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"podSelector": map[string]interface{}{
+				"matchLabels": matchLabels,
+			},
+		},
+	}
+}
+
+func TestPolicies_EvalWithInventory(t *testing.T) {
+	data := map[string]string{
+		"policy.without_network_policy.rego":  regoWithoutNetworkPolicy,
+		"policy.without_network_policy.kinds": "Deployment",
+	}
+	deployment := newDeployment("nginx", "nginx:1.16", false)
+	deployment.Spec.Template.Labels = map[string]string{"app": "nginx"}
+
+	testCases := []struct {
+		name     string
+		objects  []interface{}
+		expected bool
+	}{
+		{
+			name:     "Should fail when there is no NetworkPolicy",
+			expected: false,
+		},
+		{
+			name: "Should fail when NetworkPolicy does not select deployment",
+			objects: []interface{}{
+				newNetworkPolicy("default", map[string]interface{}{"app": "redis"}),
+				newNetworkPolicy("kube-system", map[string]interface{}{"app": "nginx"}),
+			},
+			expected: false,
+		},
+		{
+			name: "Should pass when NetworkPolicy selects deployment",
+			objects: []interface{}{
+				newNetworkPolicy("default", map[string]interface{}{"app": "redis"}),
+				newNetworkPolicy("default", map[string]interface{}{"app": "nginx"}),
+			},
+			expected: true,
+		},
+	}
+	cache := policy.NewCache()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			inventory := &fakeInventory{
+				objects: map[string][]interface{}{"NetworkPolicy": tc.objects},
+				calls:   make(map[string]int),
+			}
+			results, err := policy.NewPolicies(data).WithCache(cache).WithInventory(inventory).
+				Eval(context.TODO(), deployment)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(results).To(HaveLen(1))
+			g.Expect(results[0].Success).To(Equal(tc.expected))
+			g.Expect(inventory.calls).To(Equal(map[string]int{"NetworkPolicy": 1}))
+		})
+	}
+
+	t.Run("Should treat data.kubernetes as undefined without inventory", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		results, err := policy.NewPolicies(data).WithCache(cache).Eval(context.TODO(), deployment)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(results).To(HaveLen(1))
+		g.Expect(results[0].Success).To(BeFalse())
+	})
+
+	t.Run("Should return error when inventory fails", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		inventory := &fakeInventory{
+			calls: make(map[string]int),
+			err:   errors.New("kind not found"),
+		}
+		_, err := policy.NewPolicies(data).WithCache(cache).WithInventory(inventory).
+			Eval(context.TODO(), deployment)
+		g.Expect(err).To(MatchError(ContainSubstring("listing NetworkPolicy: kind not found")))
+	})
+}
+
+func TestPolicies_DataKinds(t *testing.T) {
+	g := NewGomegaWithT(t)
+	policies := policy.NewPolicies(map[string]string{
+		"library.kubernetes.rego": `package lib.kubernetes
+
+service_accounts[name] {
+	name := data.kubernetes.ServiceAccount[_].metadata.name
+}
+`,
+		"policy.without_network_policy.rego":  regoWithoutNetworkPolicy,
+		"policy.without_network_policy.kinds": "Deployment",
+		"policy.privileged.rego":              regoPrivileged,
+		"policy.privileged.kinds":             "Workload",
+		"policy.unknown_service_account.rego": `package appshield.kubernetes.KSV099
+
+import data.kubernetes as k8s
+
+deny[res] {
+	not k8s.ServiceAccount[_].metadata.name == input.subjects[_].name
+	res := {"msg": "RoleBinding references unknown ServiceAccount"}
+}
+`,
+		"policy.unknown_service_account.kinds": "RoleBinding",
+		"policy.without_pods.rego": `package appshield.kubernetes.KSV100
+
+import data.kubernetes
+
+deny[res] {
+	count(kubernetes.Pod) == 0
+	res := {"msg": "Service does not select any Pod"}
+}
+`,
+		"policy.without_pods.kinds": "Service",
+	})
+
+	kinds, err := policies.DataKinds(context.TODO(), "Deployment")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kinds).To(Equal([]string{"NetworkPolicy", "ServiceAccount"}))
+
+	kinds, err = policies.DataKinds(context.TODO(), "Pod")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kinds).To(Equal([]string{"ServiceAccount"}))
+
+	kinds, err = policies.DataKinds(context.TODO(), "Service")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kinds).To(Equal([]string{"Pod", "ServiceAccount"}))
+
+	kinds, err = policies.AllDataKinds()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kinds).To(Equal([]string{"NetworkPolicy", "Pod", "ServiceAccount"}))
+
+	// Kinds are computed along with prepared queries, which are compiled once.
+	cache := policy.NewCache()
+	compiled := 0
+	cache.OnCompile(func(_ string, _ time.Duration) {
+		compiled++
+	})
+	policies.WithCache(cache)
+	for i := 0; i < 2; i++ {
+		kinds, err = policies.DataKinds(context.TODO(), "Deployment")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(kinds).To(Equal([]string{"NetworkPolicy", "ServiceAccount"}))
+	}
+	g.Expect(compiled).To(Equal(1))
+}
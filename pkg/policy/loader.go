@@ -0,0 +1,118 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Loader holds the latest Bundle loaded from a Source and reloads it
+// periodically, so that policies can be updated without restarting the
+// operator. If reloading fails, the previously loaded Bundle is kept.
+type Loader struct {
+	source   Source
+	interval time.Duration
+	logger   logr.Logger
+
+	mu        sync.RWMutex
+	bundle    *Bundle
+	listeners []func()
+}
+
+// NewLoader constructs a new Loader, which reloads the Bundle from the given
+// Source at the specified interval.
+func NewLoader(source Source, interval time.Duration, logger logr.Logger) *Loader {
+	return &Loader{
+		source:   source,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Notify registers a function that is called whenever a Bundle with a
+// different digest is loaded.
+func (l *Loader) Notify(listener func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.listeners = append(l.listeners, listener)
+}
+
+// Load loads the Bundle from the Source and swaps it with the current one.
+// It returns true if the digest of the Bundle has changed.
+func (l *Loader) Load(ctx context.Context) (bool, error) {
+	bundle, err := l.source.Load(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	l.mu.Lock()
+	changed := l.bundle == nil || l.bundle.Digest != bundle.Digest
+	l.bundle = &bundle
+	listeners := l.listeners
+	l.mu.Unlock()
+
+	if changed {
+		for _, listener := range listeners {
+			listener()
+		}
+	}
+	return changed, nil
+}
+
+// Bundle returns the latest Bundle, or an error if it has not been loaded
+// yet.
+func (l *Loader) Bundle() (Bundle, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.bundle == nil {
+		return Bundle{}, errors.New("policies bundle not loaded yet")
+	}
+	return *l.bundle, nil
+}
+
+// Policies returns Policies defined by the given ConfigMap data and the
+// latest Bundle.
+func (l *Loader) Policies(data map[string]string) (*Policies, error) {
+	bundle, err := l.Bundle()
+	if err != nil {
+		return nil, err
+	}
+	return NewPolicies(bundle.Merge(data)), nil
+}
+
+// Start loads the Bundle and reloads it periodically until the given context
+// is cancelled. It implements manager.Runnable.
+func (l *Loader) Start(ctx context.Context) error {
+	l.reload(ctx)
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			l.reload(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Policies
+// are loaded by each replica of the operator.
+func (l *Loader) NeedLeaderElection() bool {
+	return false
+}
+
+func (l *Loader) reload(ctx context.Context) {
+	changed, err := l.Load(ctx)
+	if err != nil {
+		l.logger.Error(err, "Unable to load policies bundle")
+		return
+	}
+	if changed {
+		bundle, _ := l.Bundle()
+		l.logger.Info("Loaded policies bundle", "digest", bundle.Digest, "files", len(bundle.Data))
+	}
+}
@@ -0,0 +1,103 @@
+package policy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/policy"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+)
+
+type fakeSource struct {
+	bundle policy.Bundle
+	err    error
+}
+
+func (s *fakeSource) Load(_ context.Context) (policy.Bundle, error) {
+	return s.bundle, s.err
+}
+
+func TestLoader(t *testing.T) {
+	g := NewGomegaWithT(t)
+	source := &fakeSource{}
+	loader := policy.NewLoader(source, time.Minute, logr.Discard())
+
+	notified := 0
+	loader.Notify(func() {
+		notified++
+	})
+
+	_, err := loader.Policies(map[string]string{})
+	g.Expect(err).To(MatchError("policies bundle not loaded yet"))
+
+	source.bundle = policy.Bundle{
+		Data: map[string]string{
+			"policy.privileged.rego":  "<REGO_A>",
+			"policy.privileged.kinds": "Workload",
+		},
+		Digest: "sha256:a",
+	}
+	changed, err := loader.Load(context.TODO())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(notified).To(Equal(1))
+
+	policies, err := loader.Policies(map[string]string{
+		"policy.privileged.rego":  "<REGO_B>",
+		"policy.privileged.kinds": "Pod",
+		"library.utils.rego":      "<REGO_C>",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	modules, err := policies.ModulesByKind("Deployment")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(modules).To(Equal(map[string]string{
+		"policy.privileged.rego": "<REGO_A>",
+		"library.utils.rego":     "<REGO_C>",
+	}))
+
+	t.Run("Should not notify when digest has not changed", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		changed, err := loader.Load(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeFalse())
+		g.Expect(notified).To(Equal(1))
+	})
+
+	t.Run("Should keep previous bundle when loading fails", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		source.err = errors.New("connection refused")
+		_, err := loader.Load(context.TODO())
+		g.Expect(err).To(MatchError("connection refused"))
+		bundle, err := loader.Bundle()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(bundle.Digest).To(Equal("sha256:a"))
+		source.err = nil
+	})
+
+	t.Run("Should swap bundle and notify when digest has changed", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		source.bundle = policy.Bundle{
+			Data: map[string]string{
+				"policy.privileged.rego":  "<REGO_D>",
+				"policy.privileged.kinds": "Workload",
+			},
+			Digest: "sha256:b",
+		}
+		oldHash, err := policies.Hash("Deployment")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		changed, err := loader.Load(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeTrue())
+		g.Expect(notified).To(Equal(2))
+
+		newPolicies, err := loader.Policies(map[string]string{})
+		g.Expect(err).ToNot(HaveOccurred())
+		newHash, err := newPolicies.Hash("Deployment")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(newHash).ToNot(Equal(oldHash))
+	})
+}
@@ -4,12 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
-	"github.com/open-policy-agent/opa/ast"
-	"github.com/open-policy-agent/opa/rego"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -31,9 +31,14 @@ const (
 	varMessage = "msg"
 	// varMetadata is the name of Rego variable used to bind policy metadata.
 	varMetadata = "md"
-	// varResult is the name of Rego variable used to bind result of evaluating
-	// deny or warn rules.
-	varResult = "res"
+)
+
+const (
+	// ruleDeny is the name of Rego rule that returns failures of a policy.
+	ruleDeny = "deny"
+	// ruleWarn is the name of Rego rule that returns warnings of a policy,
+	// which are reported as failures if the deny rule does not fail.
+	ruleWarn = "warn"
 )
 
 // Metadata describes policy metadata.
@@ -112,7 +117,9 @@ func NewMessage(values map[string]interface{}) (string, error) {
 }
 
 type Policies struct {
-	data map[string]string
+	data      map[string]string
+	cache     *Cache
+	inventory Inventory
 }
 
 func NewPolicies(data map[string]string) *Policies {
@@ -121,6 +128,13 @@ func NewPolicies(data map[string]string) *Policies {
 	}
 }
 
+// WithCache makes Eval reuse queries prepared and held by the given Cache,
+// instead of parsing and compiling policies on each call.
+func (p *Policies) WithCache(cache *Cache) *Policies {
+	p.cache = cache
+	return p
+}
+
 func (p *Policies) Libraries() map[string]string {
 	libs := make(map[string]string)
 	for key, value := range p.data {
@@ -169,6 +183,29 @@ func (p *Policies) PoliciesByKind(kind string) (map[string]string, error) {
 	return policies, nil
 }
 
+// Kinds returns sorted names of kinds listed in .kinds entries of policies,
+// excluding the special values that select workloads or all kinds.
+func (p *Policies) Kinds() []string {
+	kinds := make(map[string]bool)
+	for key, value := range p.data {
+		if !strings.HasPrefix(key, keyPrefixPolicy) || !strings.HasSuffix(key, keySuffixKinds) {
+			continue
+		}
+		for _, k := range strings.Split(value, ",") {
+			if k == "" || k == kindAny || k == kindWorkload {
+				continue
+			}
+			kinds[k] = true
+		}
+	}
+	names := make([]string, 0, len(kinds))
+	for k := range kinds {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (p *Policies) Hash(kind string) (string, error) {
 	modules, err := p.ModulesByKind(kind)
 	if err != nil {
@@ -205,8 +242,11 @@ func (p *Policies) Applicable(resource client.Object) (bool, string, error) {
 
 // Eval evaluates Rego policies with Kubernetes resource client.Object as input.
 //
-// TODO(danielpacak) Compile and cache prepared queries to make Eval more efficient.
-//                   We can reuse prepared queries so long policies do not change.
+// Policies applicable to the kind of the resource are compiled along with
+// libraries and evaluated with a single query where possible. If a Cache is
+// set with WithCache, the prepared query is reused so long policies do not
+// change. Objects of the Inventory set with WithInventory are read from
+// data.kubernetes during evaluation.
 func (p *Policies) Eval(ctx context.Context, resource client.Object) (Results, error) {
 	if resource == nil {
 		return nil, fmt.Errorf("resource must not be nil")
@@ -216,134 +256,24 @@ func (p *Policies) Eval(ctx context.Context, resource client.Object) (Results, e
 		return nil, fmt.Errorf("resource kind must not be blank")
 	}
 
-	var results Results
-
-	policies, err := p.PoliciesByKind(resourceKind)
-	if err != nil {
-		return nil, fmt.Errorf("failed listing policies by kind: %s: %w", resourceKind, err)
-	}
-
-	for policyName, policyCode := range policies {
-		parsedModules := make(map[string]*ast.Module)
-
-		for libraryName, libraryCode := range p.Libraries() {
-			var parsedLibrary *ast.Module
-			parsedLibrary, err = ast.ParseModule(libraryName, libraryCode)
-			if err != nil {
-				return nil, fmt.Errorf("failed parsing Rego library: %s: %w", libraryName, err)
-			}
-			parsedModules[libraryName] = parsedLibrary
-		}
-
-		parsedPolicy, err := ast.ParseModule(policyName, policyCode)
-		if err != nil {
-			return nil, fmt.Errorf("failed parsing Rego policy: %s: %w", policyName, err)
-		}
-		parsedModules[policyName] = parsedPolicy
-
-		compiler := ast.NewCompiler()
-		compiler.Compile(parsedModules)
-		if compiler.Failed() {
-			return nil, fmt.Errorf("failed compiling Rego policy: %s: %w", policyName, compiler.Errors)
-		}
-
-		metadataQuery := fmt.Sprintf("md = %s.__rego_metadata__", parsedPolicy.Package.Path.String())
-		metadata, err := rego.New(
-			rego.Compiler(compiler),
-			rego.Query(metadataQuery),
-		).Eval(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed evaluating Rego metadata rule: %s: %w", metadataQuery, err)
-		}
-
-		metadataResult, hasMetadataResult := hasBinding(metadata, varMetadata)
-
-		if !hasMetadataResult {
-			return nil, fmt.Errorf("failed parsing policy metadata: %s", policyName)
-		}
-
-		md, err := NewMetadata(metadataResult)
-		if err != nil {
-			return nil, fmt.Errorf("failed parsing policy metadata: %s: %w", policyName, err)
-		}
-
-		denyQuery := fmt.Sprintf("%s.deny[res]", parsedPolicy.Package.Path.String())
-		deny, err := rego.New(
-			rego.Compiler(compiler),
-			rego.Query(denyQuery),
-			rego.Input(resource),
-		).Eval(ctx)
-
-		if err != nil {
-			return nil, fmt.Errorf("failed evaluating Rego deny rule: %s: %w", denyQuery, err)
-		}
-
-		denyValues, hasDenyValues := hasBindings(deny, varResult)
-		if hasDenyValues {
-			denyResults, err := valuesToResults(md, denyValues)
-			if err != nil {
-				return nil, fmt.Errorf("failed parsing deny rule result: %s: %w", denyQuery, err)
-			}
-			results = append(results, denyResults...)
-			continue
-		}
-
-		warnQuery := fmt.Sprintf("%s.warn[res]", parsedPolicy.Package.Path.String())
-		warn, err := rego.New(
-			rego.Compiler(compiler),
-			rego.Query(warnQuery),
-			rego.Input(resource),
-		).Eval(ctx)
-
-		if err != nil {
-			return nil, fmt.Errorf("failed evaluating Rego warn rule: %s: %w", warnQuery, err)
-		}
-
-		warnValues, hasWarnValues := hasBindings(warn, varResult)
-		if hasWarnValues {
-			warnResults, err := valuesToResults(md, warnValues)
-			if err != nil {
-				return nil, fmt.Errorf("failed parsing warn rule result: %s: %w", warnQuery, err)
-			}
-			results = append(results, warnResults...)
-			continue
-		}
-
-		results = append(results, Result{
-			Metadata: md,
-			Success:  true,
-		})
-	}
-
-	return results, nil
-}
-
-func hasBinding(rs rego.ResultSet, key string) (map[string]interface{}, bool) {
-	if rs == nil || len(rs) == 0 {
-		return nil, false
+	var prepared *preparedPolicies
+	var err error
+	if p.cache != nil {
+		prepared, err = p.cache.get(ctx, p, resourceKind)
+	} else {
+		prepared, err = p.prepare(ctx, resourceKind)
 	}
-	binding, ok := rs[0].Bindings[key]
-	return binding.(map[string]interface{}), ok
-}
-
-func hasBindings(rs rego.ResultSet, key string) ([]map[string]interface{}, bool) {
-	if rs == nil || len(rs) == 0 {
-		return nil, false
+	if err != nil {
+		return nil, err
 	}
-	var values []map[string]interface{}
-
-	for _, r := range rs {
-		binding, ok := r.Bindings[key]
-		if !ok {
-			continue
-		}
-		value, ok := binding.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		values = append(values, value)
+	var input interface{} = resource
+	if u, ok := resource.(*unstructured.Unstructured); ok {
+		// OPA converts structs to JSON values by reflection rather than with
+		// json.Marshaler, which would nest fields of unstructured objects
+		// under input.Object.
+		input = u.UnstructuredContent()
 	}
-	return values, len(rs) == len(values)
+	return prepared.eval(withInventory(ctx, p.inventory), input)
 }
 
 func requiredStringValue(values map[string]interface{}, key string) (string, error) {
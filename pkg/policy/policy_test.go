@@ -10,6 +10,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -72,6 +73,24 @@ func TestPolicies_PoliciesByKind(t *testing.T) {
 	})
 }
 
+func TestPolicies_Kinds(t *testing.T) {
+	g := NewGomegaWithT(t)
+	config := policy.NewPolicies(map[string]string{
+		"library.kubernetes.rego":                  "<REGO_A>",
+		"policy.access_to_host_pid.rego":           "<REGO_B>",
+		"policy.access_to_host_pid.kinds":          "Pod,ReplicaSet",
+		"policy.cpu_not_limited.rego":              "<REGO_C>",
+		"policy.cpu_not_limited.kinds":             "Workload",
+		"policy.virtual_service_without_tls.rego":  "<REGO_D>",
+		"policy.virtual_service_without_tls.kinds": "VirtualService,Gateway",
+		"policy.object_without_labels.rego":        "<REGO_E>",
+		"policy.object_without_labels.kinds":       "*",
+		"policy.gateway_without_tls.rego":          "<REGO_F>",
+		"policy.gateway_without_tls.kinds":         "Gateway",
+	})
+	g.Expect(config.Kinds()).To(Equal([]string{"Gateway", "Pod", "ReplicaSet", "VirtualService"}))
+}
+
 func TestPolicies_Applicable(t *testing.T) {
 
 	testCases := []struct {
@@ -716,6 +735,49 @@ deny[res] {
 				},
 			},
 		},
+		{
+			name: "Should eval deny rule with unstructured resource",
+			resource: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "networking.istio.io/v1beta1",
+				"kind":       "VirtualService",
+				"metadata": map[string]interface{}{
+					"name": "reviews",
+				},
+				"spec": map[string]interface{}{
+					"hosts": []interface{}{"*"},
+				},
+			}},
+			policies: map[string]string{
+				"policy.wildcard_host.kinds": "VirtualService",
+				"policy.wildcard_host.rego": `package appshield.kubernetes.KSV100
+
+__rego_metadata__ := {
+	"id": "KSV100",
+	"title": "Wildcard host",
+	"description": "VirtualService should not match any host",
+	"severity": "MEDIUM",
+	"type": "Kubernetes Security Check"
+}
+
+deny[res] {
+	input.spec.hosts[_] == "*"
+	res := {"msg": sprintf("VirtualService %s matches any host", [input.metadata.name])}
+}`,
+			},
+			results: []policy.Result{
+				{
+					Metadata: policy.Metadata{
+						ID:          "KSV100",
+						Title:       "Wildcard host",
+						Description: "VirtualService should not match any host",
+						Severity:    "MEDIUM",
+						Type:        "Kubernetes Security Check",
+					},
+					Messages: []string{"VirtualService reviews matches any host"},
+					Success:  false,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
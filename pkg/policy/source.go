@@ -0,0 +1,469 @@
+package policy
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+const (
+	schemeOCI   = "oci://"
+	schemeFile  = "file://"
+	schemeHTTP  = "http://"
+	schemeHTTPS = "https://"
+)
+
+// maxBundleSize is the maximum size in bytes of a bundle downloaded from a
+// URL or read from a single file or layer, and of all files extracted from
+// tarballs of a bundle.
+const maxBundleSize = 64 << 20
+
+// maxBundleEntries is the maximum number of entries in tarballs of a bundle.
+const maxBundleEntries = 10000
+
+// loadTimeout is the maximum time it takes to download a bundle from a URL or
+// to pull it from an OCI registry.
+const loadTimeout = 5 * time.Minute
+
+// annotationTitle is the annotation of OCI layers that holds the file name
+// of a layer pushed as a single file, e.g. with the oras CLI.
+const annotationTitle = "org.opencontainers.image.title"
+
+// Bundle is a set of policies and libraries loaded from a Source.
+type Bundle struct {
+	// Data holds policies and libraries keyed in the same way as in the
+	// starboard-policies-config ConfigMap, i.e. policy.<name>.rego,
+	// policy.<name>.kinds, and library.<name>.rego.
+	Data map[string]string
+
+	// Digest identifies the content of the bundle, e.g. the digest of the
+	// OCI manifest or the SHA256 checksum of the tarball.
+	Digest string
+}
+
+// Merge returns the given ConfigMap data with policies and libraries of
+// this bundle added. Keys of the bundle take precedence.
+func (b Bundle) Merge(data map[string]string) map[string]string {
+	merged := make(map[string]string, len(data)+len(b.Data))
+	for key, value := range data {
+		merged[key] = value
+	}
+	for key, value := range b.Data {
+		merged[key] = value
+	}
+	return merged
+}
+
+// Source loads a Bundle of policies and libraries.
+//
+// Files of a bundle are named after keys of the starboard-policies-config
+// ConfigMap, e.g. policy.privileged.rego and policy.privileged.kinds. Files
+// with other names are ignored, and so are directories that files are
+// nested in.
+type Source interface {
+	Load(ctx context.Context) (Bundle, error)
+}
+
+// NewSource constructs a Source from the given URL. The URL is either
+// oci://<image reference> of an OCI artifact, http(s)://<url> of a tarball,
+// e.g. a Git archive, or the path of a directory or a tarball file,
+// optionally prefixed with file://.
+//
+// The digest, if specified as sha256:<hex>, is verified against the digest
+// of the OCI manifest, or the SHA256 checksum of the tarball.
+func NewSource(url, digest string) (Source, error) {
+	if digest != "" && !strings.HasPrefix(digest, "sha256:") {
+		return nil, fmt.Errorf("unsupported digest: %s: expected sha256:<hex>", digest)
+	}
+	switch {
+	case strings.HasPrefix(url, schemeOCI):
+		return NewOCISource(strings.TrimPrefix(url, schemeOCI), digest)
+	case strings.HasPrefix(url, schemeHTTP), strings.HasPrefix(url, schemeHTTPS):
+		return NewTarballSource(url, digest, &http.Client{Timeout: loadTimeout}), nil
+	case strings.HasPrefix(url, schemeFile):
+		return NewFileSource(strings.TrimPrefix(url, schemeFile), digest), nil
+	case filepath.IsAbs(url):
+		return NewFileSource(url, digest), nil
+	}
+	return nil, fmt.Errorf("unsupported policies bundle URL: %s", url)
+}
+
+type ociSource struct {
+	ref    name.Reference
+	digest string
+}
+
+// NewOCISource constructs a Source that pulls the bundle from an OCI
+// artifact. Layers of the artifact are either tarballs, which are extracted,
+// or single files named with the org.opencontainers.image.title annotation.
+func NewOCISource(imageRef, digest string) (Source, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("parsing policies bundle reference: %w", err)
+	}
+	return &ociSource{
+		ref:    ref,
+		digest: digest,
+	}, nil
+}
+
+func (s *ociSource) Load(ctx context.Context) (Bundle, error) {
+	ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+	defer cancel()
+	image, err := remote.Image(s.ref,
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return Bundle{}, fmt.Errorf("pulling policies bundle: %s: %w", s.ref, err)
+	}
+	digest, err := image.Digest()
+	if err != nil {
+		return Bundle{}, fmt.Errorf("getting digest of policies bundle: %s: %w", s.ref, err)
+	}
+	err = verifyDigest(s.digest, digest.String())
+	if err != nil {
+		return Bundle{}, err
+	}
+	manifest, err := image.Manifest()
+	if err != nil {
+		return Bundle{}, fmt.Errorf("getting manifest of policies bundle: %s: %w", s.ref, err)
+	}
+
+	data := make(map[string]string)
+	limits := newBundleLimits()
+	for _, descriptor := range manifest.Layers {
+		err = s.addLayer(image, descriptor, data, limits)
+		if err != nil {
+			return Bundle{}, fmt.Errorf("reading layer of policies bundle: %s: %w", descriptor.Digest, err)
+		}
+	}
+	return Bundle{
+		Data:   data,
+		Digest: digest.String(),
+	}, nil
+}
+
+func (s *ociSource) addLayer(image v1.Image, descriptor v1.Descriptor, data map[string]string, limits *bundleLimits) error {
+	layer, err := image.LayerByDigest(descriptor.Digest)
+	if err != nil {
+		return err
+	}
+	// Compressed returns the blob as is, which is verified against the
+	// digest of the layer while it's read.
+	blob, err := layer.Compressed()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = blob.Close()
+	}()
+	if strings.Contains(string(descriptor.MediaType), "tar") {
+		return readTarball(blob, data, limits)
+	}
+	title, ok := descriptor.Annotations[annotationTitle]
+	if !ok {
+		return fmt.Errorf("unsupported layer media type: %s", descriptor.MediaType)
+	}
+	content, err := readAll(limits.reader(blob))
+	if err != nil {
+		return err
+	}
+	return addFile(data, title, content)
+}
+
+type tarballSource struct {
+	url    string
+	digest string
+	client *http.Client
+}
+
+// NewTarballSource constructs a Source that downloads the bundle as a
+// tarball, which can be gzip compressed, from the given URL, e.g. an archive
+// of a tag of a Git repository.
+func NewTarballSource(url, digest string, client *http.Client) Source {
+	return &tarballSource{
+		url:    url,
+		digest: digest,
+		client: client,
+	}
+}
+
+func (s *tarballSource) Load(ctx context.Context) (Bundle, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return Bundle{}, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("downloading policies bundle: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return Bundle{}, fmt.Errorf("downloading policies bundle: %s: unexpected status code: %d", s.url, resp.StatusCode)
+	}
+	content, err := readAll(resp.Body)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("downloading policies bundle: %s: %w", s.url, err)
+	}
+	return newTarballBundle(content, s.digest)
+}
+
+type fileSource struct {
+	path   string
+	digest string
+}
+
+// NewFileSource constructs a Source that reads the bundle from a directory,
+// e.g. a mounted volume, or from a tarball file. The digest can only be
+// verified for tarball files.
+func NewFileSource(path, digest string) Source {
+	return &fileSource{
+		path:   path,
+		digest: digest,
+	}
+}
+
+func (s *fileSource) Load(_ context.Context) (Bundle, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("reading policies bundle: %w", err)
+	}
+	if !info.IsDir() {
+		f, err := os.Open(s.path)
+		if err != nil {
+			return Bundle{}, fmt.Errorf("reading policies bundle: %w", err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		content, err := readAll(f)
+		if err != nil {
+			return Bundle{}, fmt.Errorf("reading policies bundle: %s: %w", s.path, err)
+		}
+		return newTarballBundle(content, s.digest)
+	}
+	if s.digest != "" {
+		return Bundle{}, fmt.Errorf("verifying digest of policies bundle: %s: not supported for directories", s.path)
+	}
+
+	data := make(map[string]string)
+	err = filepath.Walk(s.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// Skip hidden directories, such as .git or ..data directories
+		// of volumes mounted from ConfigMaps.
+		if info.IsDir() {
+			if path != s.path && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isBundleFile(info.Name()) {
+			return nil
+		}
+		// Follow symbolic links to files, such as keys of volumes mounted
+		// from ConfigMaps.
+		if info, err = os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return addFile(data, info.Name(), content)
+	})
+	if err != nil {
+		return Bundle{}, fmt.Errorf("reading policies bundle: %s: %w", s.path, err)
+	}
+	return Bundle{
+		Data:   data,
+		Digest: dataDigest(data),
+	}, nil
+}
+
+func newTarballBundle(content []byte, expectedDigest string) (Bundle, error) {
+	checksum := sha256.Sum256(content)
+	digest := "sha256:" + hex.EncodeToString(checksum[:])
+	err := verifyDigest(expectedDigest, digest)
+	if err != nil {
+		return Bundle{}, err
+	}
+	data := make(map[string]string)
+	err = readTarball(bytes.NewReader(content), data, newBundleLimits())
+	if err != nil {
+		return Bundle{}, fmt.Errorf("reading policies bundle tarball: %w", err)
+	}
+	return Bundle{
+		Data:   data,
+		Digest: digest,
+	}, nil
+}
+
+// readTarball adds files of the given tarball, which is gzip compressed or
+// not, to the given data. The decompressed tarball counts towards the given
+// limits, so that a small archive, e.g. a gzip bomb, cannot expand to an
+// arbitrary amount of data.
+func readTarball(r io.Reader, data map[string]string, limits *bundleLimits) error {
+	br := bufio.NewReader(r)
+	var reader io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = gr.Close()
+		}()
+		reader = gr
+	}
+	tr := tar.NewReader(limits.reader(reader))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = limits.addEntry(); err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg || !isBundleFile(path.Base(header.Name)) {
+			continue
+		}
+		content, err := readAll(tr)
+		if err != nil {
+			return err
+		}
+		err = addFile(data, header.Name, content)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func readAll(r io.Reader) ([]byte, error) {
+	content, err := ioutil.ReadAll(io.LimitReader(r, maxBundleSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxBundleSize {
+		return nil, fmt.Errorf("policies bundle exceeds %d bytes", maxBundleSize)
+	}
+	return content, nil
+}
+
+// bundleLimits holds the remaining size and number of entries of a bundle,
+// which are shared by all tarballs and layers of the bundle.
+type bundleLimits struct {
+	size    int64
+	entries int
+}
+
+func newBundleLimits() *bundleLimits {
+	return &bundleLimits{
+		size:    maxBundleSize,
+		entries: maxBundleEntries,
+	}
+}
+
+// reader returns a reader of r that fails once more bytes than the remaining
+// size of the bundle have been read.
+func (l *bundleLimits) reader(r io.Reader) io.Reader {
+	return &limitedReader{r: r, limits: l}
+}
+
+func (l *bundleLimits) addEntry() error {
+	l.entries--
+	if l.entries < 0 {
+		return fmt.Errorf("policies bundle exceeds %d entries", maxBundleEntries)
+	}
+	return nil
+}
+
+type limitedReader struct {
+	r      io.Reader
+	limits *bundleLimits
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.limits.size -= int64(n)
+	if r.limits.size < 0 {
+		return n, fmt.Errorf("policies bundle exceeds %d bytes", maxBundleSize)
+	}
+	return n, err
+}
+
+func isBundleFile(name string) bool {
+	switch {
+	case strings.HasPrefix(name, keyPrefixPolicy):
+		return strings.HasSuffix(name, keySuffixRego) || strings.HasSuffix(name, keySuffixKinds)
+	case strings.HasPrefix(name, keyPrefixLibrary):
+		return strings.HasSuffix(name, keySuffixRego)
+	}
+	return false
+}
+
+func addFile(data map[string]string, filePath string, content []byte) error {
+	key := path.Base(filePath)
+	if !isBundleFile(key) {
+		return nil
+	}
+	if _, ok := data[key]; ok {
+		return fmt.Errorf("duplicate file: %s", key)
+	}
+	value := string(content)
+	if strings.HasSuffix(key, keySuffixKinds) {
+		// Files usually end with a new line, which is not part of kinds.
+		value = strings.TrimSpace(value)
+	}
+	data[key] = value
+	return nil
+}
+
+func verifyDigest(expected, actual string) error {
+	if expected == "" || expected == actual {
+		return nil
+	}
+	return fmt.Errorf("verifying digest of policies bundle: expected %s, got %s", expected, actual)
+}
+
+// dataDigest returns the SHA256 digest of the given data, which does not
+// depend on the order of keys.
+func dataDigest(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	for _, key := range keys {
+		// Prefix keys and values with their lengths so that different data
+		// cannot be encoded the same way.
+		_, _ = fmt.Fprintf(hash, "%d:%s%d:%s", len(key), key, len(data[key]), data[key])
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil))
+}
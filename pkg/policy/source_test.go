@@ -0,0 +1,315 @@
+package policy_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/policy"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	. "github.com/onsi/gomega"
+)
+
+var bundleFiles = map[string]string{
+	"policies/policy.privileged.rego":  "package appshield.kubernetes.KSV017\n",
+	"policies/policy.privileged.kinds": "Workload\n",
+	"lib/library.kubernetes.rego":      "package lib.kubernetes\n",
+	"README.md":                        "# Policies\n",
+}
+
+var bundleData = map[string]string{
+	"policy.privileged.rego":  "package appshield.kubernetes.KSV017\n",
+	"policy.privileged.kinds": "Workload",
+	"library.kubernetes.rego": "package lib.kubernetes\n",
+}
+
+func newTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     "policies-1.0/" + name,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(content)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.WriteString(tw, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func sha256Digest(content []byte) string {
+	checksum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(checksum[:])
+}
+
+func TestNewSource(t *testing.T) {
+	testCases := []struct {
+		name          string
+		url           string
+		digest        string
+		expectedError string
+	}{
+		{name: "Should accept OCI reference", url: "oci://ghcr.io/acme/policies:1.0"},
+		{name: "Should accept HTTPS URL", url: "https://github.com/acme/policies/archive/refs/tags/v1.0.tar.gz"},
+		{name: "Should accept file URL", url: "file:///etc/starboard/policies"},
+		{name: "Should accept absolute path", url: "/etc/starboard/policies"},
+		{
+			name:          "Should return error when URL is not supported",
+			url:           "git@github.com:acme/policies.git",
+			expectedError: "unsupported policies bundle URL: git@github.com:acme/policies.git",
+		},
+		{
+			name:          "Should return error when digest is not supported",
+			url:           "oci://ghcr.io/acme/policies:1.0",
+			digest:        "md5:0cc175b9c0f1b6a831c399e269772661",
+			expectedError: "unsupported digest: md5:0cc175b9c0f1b6a831c399e269772661: expected sha256:<hex>",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			source, err := policy.NewSource(tc.url, tc.digest)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(source).ToNot(BeNil())
+		})
+	}
+}
+
+func TestFileSource(t *testing.T) {
+
+	t.Run("Should load bundle from directory", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		dir := t.TempDir()
+		for name, content := range bundleFiles {
+			g.Expect(os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755)).To(Succeed())
+			g.Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)).To(Succeed())
+		}
+		// Files of hidden directories, e.g. .git, are ignored.
+		g.Expect(os.MkdirAll(filepath.Join(dir, ".git"), 0755)).To(Succeed())
+		g.Expect(ioutil.WriteFile(filepath.Join(dir, ".git", "policy.privileged.rego"), []byte("stale"), 0644)).To(Succeed())
+
+		bundle, err := policy.NewFileSource(dir, "").Load(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(bundle.Data).To(Equal(bundleData))
+		g.Expect(bundle.Digest).To(HavePrefix("sha256:"))
+
+		again, err := policy.NewFileSource(dir, "").Load(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(again.Digest).To(Equal(bundle.Digest))
+	})
+
+	t.Run("Should compute different digests of directories with different files", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		load := func(files map[string]string) policy.Bundle {
+			dir := t.TempDir()
+			for name, content := range files {
+				g.Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)).To(Succeed())
+			}
+			bundle, err := policy.NewFileSource(dir, "").Load(context.TODO())
+			g.Expect(err).ToNot(HaveOccurred())
+			return bundle
+		}
+		// Both bundles would be encoded the same way if keys and values were
+		// only delimited.
+		one := load(map[string]string{"policy.a.rego": "a\x00policy.b.rego\x00b"})
+		two := load(map[string]string{"policy.a.rego": "a", "policy.b.rego": "b"})
+		g.Expect(one.Digest).ToNot(Equal(two.Digest))
+	})
+
+	t.Run("Should load bundle from tarball file", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		tarball := newTarball(t, bundleFiles)
+		path := filepath.Join(t.TempDir(), "policies.tar.gz")
+		g.Expect(ioutil.WriteFile(path, tarball, 0644)).To(Succeed())
+
+		bundle, err := policy.NewFileSource(path, sha256Digest(tarball)).Load(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(bundle.Data).To(Equal(bundleData))
+		g.Expect(bundle.Digest).To(Equal(sha256Digest(tarball)))
+	})
+
+	t.Run("Should return error when decompressed tarball exceeds bundle size", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		// A file of zeros, which is not a bundle file, compresses to a tiny
+		// fraction of its size.
+		tarball := newTarball(t, map[string]string{"zeros.bin": strings.Repeat("\x00", 65<<20)})
+		g.Expect(len(tarball)).To(BeNumerically("<", 1<<20))
+		path := filepath.Join(t.TempDir(), "policies.tar.gz")
+		g.Expect(ioutil.WriteFile(path, tarball, 0644)).To(Succeed())
+
+		_, err := policy.NewFileSource(path, "").Load(context.TODO())
+		g.Expect(err).To(MatchError(ContainSubstring("policies bundle exceeds 67108864 bytes")))
+	})
+
+	t.Run("Should return error when tarball exceeds number of entries", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		files := make(map[string]string)
+		for i := 0; i <= 10000; i++ {
+			files[fmt.Sprintf("%d.txt", i)] = ""
+		}
+		path := filepath.Join(t.TempDir(), "policies.tar.gz")
+		g.Expect(ioutil.WriteFile(path, newTarball(t, files), 0644)).To(Succeed())
+
+		_, err := policy.NewFileSource(path, "").Load(context.TODO())
+		g.Expect(err).To(MatchError(ContainSubstring("policies bundle exceeds 10000 entries")))
+	})
+
+	t.Run("Should return error when files are duplicated", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		dir := t.TempDir()
+		g.Expect(os.MkdirAll(filepath.Join(dir, "a"), 0755)).To(Succeed())
+		g.Expect(ioutil.WriteFile(filepath.Join(dir, "policy.privileged.rego"), []byte("a"), 0644)).To(Succeed())
+		g.Expect(ioutil.WriteFile(filepath.Join(dir, "a", "policy.privileged.rego"), []byte("b"), 0644)).To(Succeed())
+
+		_, err := policy.NewFileSource(dir, "").Load(context.TODO())
+		g.Expect(err).To(MatchError(ContainSubstring("duplicate file: policy.privileged.rego")))
+	})
+
+	t.Run("Should return error when digest is set for directory", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		dir := t.TempDir()
+		_, err := policy.NewFileSource(dir, "sha256:b1ab9f").Load(context.TODO())
+		g.Expect(err).To(MatchError("verifying digest of policies bundle: " + dir + ": not supported for directories"))
+	})
+}
+
+func TestTarballSource(t *testing.T) {
+	tarball := newTarball(t, bundleFiles)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/acme/policies/archive/v1.0.tar.gz" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(tarball)
+	}))
+	defer server.Close()
+
+	t.Run("Should load bundle and verify digest", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		source := policy.NewTarballSource(server.URL+"/acme/policies/archive/v1.0.tar.gz", sha256Digest(tarball), server.Client())
+		bundle, err := source.Load(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(bundle.Data).To(Equal(bundleData))
+		g.Expect(bundle.Digest).To(Equal(sha256Digest(tarball)))
+	})
+
+	t.Run("Should return error when digest does not match", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		digest := sha256Digest([]byte("tampered"))
+		source := policy.NewTarballSource(server.URL+"/acme/policies/archive/v1.0.tar.gz", digest, server.Client())
+		_, err := source.Load(context.TODO())
+		g.Expect(err).To(MatchError("verifying digest of policies bundle: expected " + digest + ", got " + sha256Digest(tarball)))
+	})
+
+	t.Run("Should return error when tarball is not found", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		source := policy.NewTarballSource(server.URL+"/acme/policies/archive/v2.0.tar.gz", "", server.Client())
+		_, err := source.Load(context.TODO())
+		g.Expect(err).To(MatchError(ContainSubstring("unexpected status code: 404")))
+	})
+}
+
+func TestOCISource(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	// The first layer is a tarball with the policy, whereas the second one
+	// is a single file pushed as with the oras CLI.
+	tarball := newTarball(t, map[string]string{
+		"policy.privileged.rego":  bundleData["policy.privileged.rego"],
+		"policy.privileged.kinds": bundleData["policy.privileged.kinds"],
+	})
+	image, err := mutate.Append(empty.Image,
+		mutate.Addendum{
+			Layer:     static.NewLayer(tarball, "application/vnd.cncf.openpolicyagent.layer.v1.tar+gzip"),
+			MediaType: "application/vnd.cncf.openpolicyagent.layer.v1.tar+gzip",
+		},
+		mutate.Addendum{
+			Layer:       static.NewLayer([]byte(bundleData["library.kubernetes.rego"]), "application/vnd.cncf.openpolicyagent.policy.layer.v1+rego"),
+			MediaType:   "application/vnd.cncf.openpolicyagent.policy.layer.v1+rego",
+			Annotations: map[string]string{"org.opencontainers.image.title": "library.kubernetes.rego"},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	image = mutate.MediaType(image, types.OCIManifestSchema1)
+	ref, err := name.ParseReference(host + "/acme/policies:1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = remote.Write(ref, image); err != nil {
+		t.Fatal(err)
+	}
+	digest, err := image.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should load bundle and verify digest", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		source, err := policy.NewOCISource(host+"/acme/policies:1.0", digest.String())
+		g.Expect(err).ToNot(HaveOccurred())
+		bundle, err := source.Load(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(bundle.Data).To(Equal(bundleData))
+		g.Expect(bundle.Digest).To(Equal(digest.String()))
+	})
+
+	t.Run("Should load bundle by digest reference", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		source, err := policy.NewOCISource(host+"/acme/policies@"+digest.String(), "")
+		g.Expect(err).ToNot(HaveOccurred())
+		bundle, err := source.Load(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(bundle.Data).To(Equal(bundleData))
+	})
+
+	t.Run("Should return error when digest does not match", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		other := v1.Hash{Algorithm: "sha256", Hex: strings.Repeat("0", 64)}
+		source, err := policy.NewOCISource(host+"/acme/policies:1.0", other.String())
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = source.Load(context.TODO())
+		g.Expect(err).To(MatchError("verifying digest of policies bundle: expected " + other.String() + ", got " + digest.String()))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
 	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/history"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/kubebench"
 	"github.com/aquasecurity/starboard/pkg/report/templates"
@@ -94,23 +95,56 @@ func (r *namespaceReporter) RetrieveData(namespace kube.ObjectRef) (templates.Na
 	if err != nil {
 		return templates.NamespaceReport{}, err
 	}
+	var vulnerabilityReports []v1alpha1.VulnerabilityReport
+	for _, report := range vulnerabilityReportList.Items {
+		if _, ok := report.Labels[starboard.LabelVulnerabilityReportAdditional]; ok {
+			continue
+		}
+		vulnerabilityReports = append(vulnerabilityReports, report)
+	}
 
 	var configAuditReportList v1alpha1.ConfigAuditReportList
 	err = r.client.List(context.Background(), &configAuditReportList, client.InNamespace(namespace.Name))
 	if err != nil {
 		return templates.NamespaceReport{}, err
 	}
+	var configAuditReports []v1alpha1.ConfigAuditReport
+	for _, report := range configAuditReportList.Items {
+		if _, ok := report.Labels[starboard.LabelConfigAuditReportAdditional]; ok {
+			continue
+		}
+		configAuditReports = append(configAuditReports, report)
+	}
+
+	historyReader := history.NewReader(r.client)
+	vulnerabilityHistory, err := historyReader.FindByOwner(context.Background(), v1alpha1.VulnerabilityReportKind, namespace)
+	if err != nil {
+		return templates.NamespaceReport{}, err
+	}
+	configAuditHistory, err := historyReader.FindByOwner(context.Background(), v1alpha1.ConfigAuditReportKind, namespace)
+	if err != nil {
+		return templates.NamespaceReport{}, err
+	}
 
-	return templates.NamespaceReport{
+	data := templates.NamespaceReport{
 		Namespace:            namespace,
 		GeneratedAt:          r.clock.Now(),
-		Top5VulnerableImages: r.topNImagesBySeverityCount(vulnerabilityReportList.Items, 5),
-		Top5FailedChecks:     r.topNFailedChecksByAffectedWorkloadsCount(configAuditReportList.Items, 5),
-		Top5Vulnerability:    r.topNVulnerabilitiesByScore(vulnerabilityReportList.Items, 5),
-	}, nil
+		Top5VulnerableImages: TopNImagesBySeverityCount(vulnerabilityReports, 5),
+		Top5FailedChecks:     TopNFailedChecksByAffectedWorkloadsCount(configAuditReports, 5),
+		Top5Vulnerability:    r.topNVulnerabilitiesByScore(vulnerabilityReports, 5),
+	}
+	if vulnerabilityHistory != nil {
+		data.VulnerabilityTrend = vulnerabilityHistory.Report.Entries
+	}
+	if configAuditHistory != nil {
+		data.ConfigAuditTrend = configAuditHistory.Report.Entries
+	}
+	return data, nil
 }
 
-func (r *namespaceReporter) topNImagesBySeverityCount(reports []v1alpha1.VulnerabilityReport, N int) []v1alpha1.VulnerabilityReport {
+// TopNImagesBySeverityCount returns at most N VulnerabilityReports with the
+// highest number of vulnerabilities ordered by severity.
+func TopNImagesBySeverityCount(reports []v1alpha1.VulnerabilityReport, N int) []v1alpha1.VulnerabilityReport {
 	b := append(reports[:0:0], reports...)
 
 	vulnerabilityreport.OrderedBy(vulnerabilityreport.SummaryCount...).
@@ -119,15 +153,32 @@ func (r *namespaceReporter) topNImagesBySeverityCount(reports []v1alpha1.Vulnera
 	return b[:ext.MinInt(N, len(b))]
 }
 
-func (r *namespaceReporter) topNFailedChecksByAffectedWorkloadsCount(reports []v1alpha1.ConfigAuditReport, N int) []templates.CheckWithCount {
+// TopNFailedChecksByAffectedWorkloadsCount returns at most N configuration
+// checks that failed for the highest number of workloads. Each check is
+// counted once per ConfigAuditReport regardless of how many containers of the
+// workload it failed for.
+func TopNFailedChecksByAffectedWorkloadsCount(reports []v1alpha1.ConfigAuditReport, N int) []templates.CheckWithCount {
 	checksMap := make(map[string]templates.CheckWithCount)
 
 	for _, report := range reports {
-		for _, podCheck := range report.Report.PodChecks {
-			if podCheck.Success {
+		checks := append(report.Report.Checks[:0:0], report.Report.Checks...)
+		checks = append(checks, report.Report.PodChecks...)
+		for _, containerChecks := range report.Report.ContainerChecks {
+			checks = append(checks, containerChecks...)
+		}
+
+		alreadyCheckedForWorkload := make(map[string]bool)
+		for _, check := range checks {
+			if check.Success {
+				continue
+			}
+
+			configId := check.ID
+			if alreadyCheckedForWorkload[configId] {
 				continue
 			}
-			configId := podCheck.ID
+
+			alreadyCheckedForWorkload[configId] = true
 			_, ok := checksMap[configId]
 			if ok {
 				config := checksMap[configId]
@@ -135,38 +186,11 @@ func (r *namespaceReporter) topNFailedChecksByAffectedWorkloadsCount(reports []v
 				checksMap[configId] = config
 			} else {
 				checksMap[configId] = templates.CheckWithCount{
-					Check:             podCheck,
+					Check:             check,
 					AffectedWorkloads: 1,
 				}
 			}
 		}
-
-		alreadyCheckedForWorkload := make(map[string]bool)
-		for _, container := range report.Report.ContainerChecks {
-			for _, containerCheck := range container {
-				if containerCheck.Success {
-					continue
-				}
-
-				configId := containerCheck.ID
-				if alreadyCheckedForWorkload[configId] {
-					continue
-				}
-
-				alreadyCheckedForWorkload[configId] = true
-				_, ok := checksMap[configId]
-				if ok {
-					config := checksMap[configId]
-					config.AffectedWorkloads++
-					checksMap[configId] = config
-				} else {
-					checksMap[configId] = templates.CheckWithCount{
-						Check:             containerCheck,
-						AffectedWorkloads: 1,
-					}
-				}
-			}
-		}
 	}
 
 	failedChecks := make([]templates.CheckWithCount, len(checksMap))
@@ -216,3 +216,37 @@ func Test_topNVulnerabilitiesByScore(t *testing.T) {
 		})
 	}
 }
+
+func TestTopNFailedChecksByAffectedWorkloadsCount(t *testing.T) {
+	runAsRoot := v1alpha1.Check{ID: "KSV012", Severity: v1alpha1.SeverityMedium, Category: "Kubernetes Security Check"}
+	privileged := v1alpha1.Check{ID: "KSV017", Severity: v1alpha1.SeverityHigh, Category: "Kubernetes Security Check"}
+	hostNetwork := v1alpha1.Check{ID: "hostNetworkSet", Severity: v1alpha1.SeverityHigh, Category: "Networking"}
+	passed := v1alpha1.Check{ID: "KSV014", Severity: v1alpha1.SeverityLow, Success: true}
+
+	reports := []v1alpha1.ConfigAuditReport{
+		{
+			Report: v1alpha1.ConfigAuditReportData{
+				Checks: []v1alpha1.Check{runAsRoot, privileged, passed},
+			},
+		},
+		{
+			Report: v1alpha1.ConfigAuditReportData{
+				Checks: []v1alpha1.Check{runAsRoot, passed},
+			},
+		},
+		{
+			Report: v1alpha1.ConfigAuditReportData{
+				PodChecks: []v1alpha1.Check{hostNetwork},
+				ContainerChecks: map[string][]v1alpha1.Check{
+					"nginx":   {runAsRoot},
+					"sidecar": {runAsRoot},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []templates.CheckWithCount{
+		{Check: runAsRoot, AffectedWorkloads: 3},
+		{Check: privileged, AffectedWorkloads: 1},
+	}, TopNFailedChecksByAffectedWorkloadsCount(reports, 2))
+}
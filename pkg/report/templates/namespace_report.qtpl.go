@@ -201,6 +201,25 @@ func (p *NamespaceReport) StreamBody(qw422016 *qt422016.Writer) {
     </table>
   </div>
 
+  `)
+//line pkg/report/templates/namespace_report.qtpl:75
+	if len(p.VulnerabilityTrend) > 0 {
+//line pkg/report/templates/namespace_report.qtpl:75
+		qw422016.N().S(`
+  <div class="row">
+    <h3>Vulnerabilities over time</h3>
+    `)
+//line pkg/report/templates/namespace_report.qtpl:78
+		streamtrendChart(qw422016, NewTrendChart(p.VulnerabilityTrend))
+//line pkg/report/templates/namespace_report.qtpl:78
+		qw422016.N().S(`
+  </div>
+  `)
+//line pkg/report/templates/namespace_report.qtpl:80
+	}
+//line pkg/report/templates/namespace_report.qtpl:80
+	qw422016.N().S(`
+
   <div class="row">
     <h3>Top 5 failed workload configs</h3>
     <table class="table table-sm table-bordered">
@@ -214,197 +233,410 @@ func (p *NamespaceReport) StreamBody(qw422016 *qt422016.Writer) {
       </thead>
       <tbody>
       `)
-//line pkg/report/templates/namespace_report.qtpl:87
+//line pkg/report/templates/namespace_report.qtpl:94
 	for _, report := range p.Top5FailedChecks {
-//line pkg/report/templates/namespace_report.qtpl:87
+//line pkg/report/templates/namespace_report.qtpl:94
 		qw422016.N().S(`
       <tr>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:89
+//line pkg/report/templates/namespace_report.qtpl:96
 		qw422016.E().S(report.ID)
-//line pkg/report/templates/namespace_report.qtpl:89
+//line pkg/report/templates/namespace_report.qtpl:96
 		qw422016.N().S(`</td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:90
+//line pkg/report/templates/namespace_report.qtpl:97
 		qw422016.E().V(report.Severity)
-//line pkg/report/templates/namespace_report.qtpl:90
+//line pkg/report/templates/namespace_report.qtpl:97
 		qw422016.N().S(`</td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:91
+//line pkg/report/templates/namespace_report.qtpl:98
 		qw422016.E().S(report.Category)
-//line pkg/report/templates/namespace_report.qtpl:91
+//line pkg/report/templates/namespace_report.qtpl:98
 		qw422016.N().S(`</td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:92
+//line pkg/report/templates/namespace_report.qtpl:99
 		qw422016.N().D(report.AffectedWorkloads)
-//line pkg/report/templates/namespace_report.qtpl:92
+//line pkg/report/templates/namespace_report.qtpl:99
 		qw422016.N().S(`</td>
       </tr>
       `)
-//line pkg/report/templates/namespace_report.qtpl:94
+//line pkg/report/templates/namespace_report.qtpl:101
 	}
-//line pkg/report/templates/namespace_report.qtpl:94
+//line pkg/report/templates/namespace_report.qtpl:101
 	qw422016.N().S(`
       </tbody>
     </table>
   </div>
 
+  `)
+//line pkg/report/templates/namespace_report.qtpl:106
+	if len(p.ConfigAuditTrend) > 0 {
+//line pkg/report/templates/namespace_report.qtpl:106
+		qw422016.N().S(`
+  <div class="row">
+    <h3>Failed workload configs over time</h3>
+    `)
+//line pkg/report/templates/namespace_report.qtpl:109
+		streamtrendChart(qw422016, NewTrendChart(p.ConfigAuditTrend))
+//line pkg/report/templates/namespace_report.qtpl:109
+		qw422016.N().S(`
+  </div>
+  `)
+//line pkg/report/templates/namespace_report.qtpl:111
+	}
+//line pkg/report/templates/namespace_report.qtpl:111
+	qw422016.N().S(`
+
 </div>
 `)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 }
 
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 func (p *NamespaceReport) WriteBody(qq422016 qtio422016.Writer) {
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 	p.StreamBody(qw422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 }
 
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 func (p *NamespaceReport) Body() string {
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 	p.WriteBody(qb422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 	return qs422016
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:114
 }
 
-//line pkg/report/templates/namespace_report.qtpl:102
+//line pkg/report/templates/namespace_report.qtpl:116
+func streamtrendChart(qw422016 *qt422016.Writer, chart TrendChart) {
+//line pkg/report/templates/namespace_report.qtpl:116
+	qw422016.N().S(`
+<svg class="mb-3" width="100%" viewBox="0 0 `)
+//line pkg/report/templates/namespace_report.qtpl:117
+	qw422016.N().D(chart.Width)
+//line pkg/report/templates/namespace_report.qtpl:117
+	qw422016.N().S(` `)
+//line pkg/report/templates/namespace_report.qtpl:117
+	qw422016.N().D(chart.Height)
+//line pkg/report/templates/namespace_report.qtpl:117
+	qw422016.N().S(`" xmlns="http://www.w3.org/2000/svg" font-size="12">
+  <line x1="`)
+//line pkg/report/templates/namespace_report.qtpl:118
+	qw422016.N().D(chart.Left)
+//line pkg/report/templates/namespace_report.qtpl:118
+	qw422016.N().S(`" y1="`)
+//line pkg/report/templates/namespace_report.qtpl:118
+	qw422016.N().D(chart.Bottom)
+//line pkg/report/templates/namespace_report.qtpl:118
+	qw422016.N().S(`" x2="`)
+//line pkg/report/templates/namespace_report.qtpl:118
+	qw422016.N().D(chart.Right)
+//line pkg/report/templates/namespace_report.qtpl:118
+	qw422016.N().S(`" y2="`)
+//line pkg/report/templates/namespace_report.qtpl:118
+	qw422016.N().D(chart.Bottom)
+//line pkg/report/templates/namespace_report.qtpl:118
+	qw422016.N().S(`" stroke="#6c757d" />
+  <line x1="`)
+//line pkg/report/templates/namespace_report.qtpl:119
+	qw422016.N().D(chart.Left)
+//line pkg/report/templates/namespace_report.qtpl:119
+	qw422016.N().S(`" y1="`)
+//line pkg/report/templates/namespace_report.qtpl:119
+	qw422016.N().D(chart.Top)
+//line pkg/report/templates/namespace_report.qtpl:119
+	qw422016.N().S(`" x2="`)
+//line pkg/report/templates/namespace_report.qtpl:119
+	qw422016.N().D(chart.Left)
+//line pkg/report/templates/namespace_report.qtpl:119
+	qw422016.N().S(`" y2="`)
+//line pkg/report/templates/namespace_report.qtpl:119
+	qw422016.N().D(chart.Bottom)
+//line pkg/report/templates/namespace_report.qtpl:119
+	qw422016.N().S(`" stroke="#6c757d" />
+  <text x="`)
+//line pkg/report/templates/namespace_report.qtpl:120
+	qw422016.N().D(chart.Left - 5)
+//line pkg/report/templates/namespace_report.qtpl:120
+	qw422016.N().S(`" y="`)
+//line pkg/report/templates/namespace_report.qtpl:120
+	qw422016.N().D(chart.Top + 4)
+//line pkg/report/templates/namespace_report.qtpl:120
+	qw422016.N().S(`" text-anchor="end">`)
+//line pkg/report/templates/namespace_report.qtpl:120
+	qw422016.N().D(chart.MaxCount)
+//line pkg/report/templates/namespace_report.qtpl:120
+	qw422016.N().S(`</text>
+  <text x="`)
+//line pkg/report/templates/namespace_report.qtpl:121
+	qw422016.N().D(chart.Left - 5)
+//line pkg/report/templates/namespace_report.qtpl:121
+	qw422016.N().S(`" y="`)
+//line pkg/report/templates/namespace_report.qtpl:121
+	qw422016.N().D(chart.Bottom + 4)
+//line pkg/report/templates/namespace_report.qtpl:121
+	qw422016.N().S(`" text-anchor="end">0</text>
+  <text x="`)
+//line pkg/report/templates/namespace_report.qtpl:122
+	qw422016.N().D(chart.Left)
+//line pkg/report/templates/namespace_report.qtpl:122
+	qw422016.N().S(`" y="`)
+//line pkg/report/templates/namespace_report.qtpl:122
+	qw422016.N().D(chart.Bottom + 16)
+//line pkg/report/templates/namespace_report.qtpl:122
+	qw422016.N().S(`" text-anchor="start">`)
+//line pkg/report/templates/namespace_report.qtpl:122
+	qw422016.E().S(chart.From)
+//line pkg/report/templates/namespace_report.qtpl:122
+	qw422016.N().S(`</text>
+  <text x="`)
+//line pkg/report/templates/namespace_report.qtpl:123
+	qw422016.N().D(chart.Right)
+//line pkg/report/templates/namespace_report.qtpl:123
+	qw422016.N().S(`" y="`)
+//line pkg/report/templates/namespace_report.qtpl:123
+	qw422016.N().D(chart.Bottom + 16)
+//line pkg/report/templates/namespace_report.qtpl:123
+	qw422016.N().S(`" text-anchor="end">`)
+//line pkg/report/templates/namespace_report.qtpl:123
+	qw422016.E().S(chart.To)
+//line pkg/report/templates/namespace_report.qtpl:123
+	qw422016.N().S(`</text>
+  `)
+//line pkg/report/templates/namespace_report.qtpl:124
+	for i, series := range chart.Series {
+//line pkg/report/templates/namespace_report.qtpl:124
+		qw422016.N().S(`
+  <polyline points="`)
+//line pkg/report/templates/namespace_report.qtpl:125
+		qw422016.E().S(series.Polyline())
+//line pkg/report/templates/namespace_report.qtpl:125
+		qw422016.N().S(`" fill="none" stroke="`)
+//line pkg/report/templates/namespace_report.qtpl:125
+		qw422016.E().S(series.Color)
+//line pkg/report/templates/namespace_report.qtpl:125
+		qw422016.N().S(`" stroke-width="2" />
+  `)
+//line pkg/report/templates/namespace_report.qtpl:126
+		for _, point := range series.Points {
+//line pkg/report/templates/namespace_report.qtpl:126
+			qw422016.N().S(`
+  <circle cx="`)
+//line pkg/report/templates/namespace_report.qtpl:127
+			qw422016.N().D(point.X)
+//line pkg/report/templates/namespace_report.qtpl:127
+			qw422016.N().S(`" cy="`)
+//line pkg/report/templates/namespace_report.qtpl:127
+			qw422016.N().D(point.Y)
+//line pkg/report/templates/namespace_report.qtpl:127
+			qw422016.N().S(`" r="3" fill="`)
+//line pkg/report/templates/namespace_report.qtpl:127
+			qw422016.E().S(series.Color)
+//line pkg/report/templates/namespace_report.qtpl:127
+			qw422016.N().S(`" />
+  `)
+//line pkg/report/templates/namespace_report.qtpl:128
+		}
+//line pkg/report/templates/namespace_report.qtpl:128
+		qw422016.N().S(`
+  <rect x="`)
+//line pkg/report/templates/namespace_report.qtpl:129
+		qw422016.N().D(chart.Left + i*90)
+//line pkg/report/templates/namespace_report.qtpl:129
+		qw422016.N().S(`" y="`)
+//line pkg/report/templates/namespace_report.qtpl:129
+		qw422016.N().D(chart.Height - 14)
+//line pkg/report/templates/namespace_report.qtpl:129
+		qw422016.N().S(`" width="10" height="10" fill="`)
+//line pkg/report/templates/namespace_report.qtpl:129
+		qw422016.E().S(series.Color)
+//line pkg/report/templates/namespace_report.qtpl:129
+		qw422016.N().S(`" />
+  <text x="`)
+//line pkg/report/templates/namespace_report.qtpl:130
+		qw422016.N().D(chart.Left + i*90 + 14)
+//line pkg/report/templates/namespace_report.qtpl:130
+		qw422016.N().S(`" y="`)
+//line pkg/report/templates/namespace_report.qtpl:130
+		qw422016.N().D(chart.Height - 5)
+//line pkg/report/templates/namespace_report.qtpl:130
+		qw422016.N().S(`">`)
+//line pkg/report/templates/namespace_report.qtpl:130
+		qw422016.E().S(series.Name)
+//line pkg/report/templates/namespace_report.qtpl:130
+		qw422016.N().S(`</text>
+  `)
+//line pkg/report/templates/namespace_report.qtpl:131
+	}
+//line pkg/report/templates/namespace_report.qtpl:131
+	qw422016.N().S(`
+</svg>
+`)
+//line pkg/report/templates/namespace_report.qtpl:133
+}
+
+//line pkg/report/templates/namespace_report.qtpl:133
+func writetrendChart(qq422016 qtio422016.Writer, chart TrendChart) {
+//line pkg/report/templates/namespace_report.qtpl:133
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line pkg/report/templates/namespace_report.qtpl:133
+	streamtrendChart(qw422016, chart)
+//line pkg/report/templates/namespace_report.qtpl:133
+	qt422016.ReleaseWriter(qw422016)
+//line pkg/report/templates/namespace_report.qtpl:133
+}
+
+//line pkg/report/templates/namespace_report.qtpl:133
+func trendChart(chart TrendChart) string {
+//line pkg/report/templates/namespace_report.qtpl:133
+	qb422016 := qt422016.AcquireByteBuffer()
+//line pkg/report/templates/namespace_report.qtpl:133
+	writetrendChart(qb422016, chart)
+//line pkg/report/templates/namespace_report.qtpl:133
+	qs422016 := string(qb422016.B)
+//line pkg/report/templates/namespace_report.qtpl:133
+	qt422016.ReleaseByteBuffer(qb422016)
+//line pkg/report/templates/namespace_report.qtpl:133
+	return qs422016
+//line pkg/report/templates/namespace_report.qtpl:133
+}
+
+//line pkg/report/templates/namespace_report.qtpl:135
 func streamimageReference(qw422016 *qt422016.Writer, registry v1alpha1.Registry, artifact v1alpha1.Artifact) {
-//line pkg/report/templates/namespace_report.qtpl:102
+//line pkg/report/templates/namespace_report.qtpl:135
 	qw422016.N().S(`
   `)
-//line pkg/report/templates/namespace_report.qtpl:103
+//line pkg/report/templates/namespace_report.qtpl:136
 	if artifact.Tag != "" && artifact.Digest != "" {
-//line pkg/report/templates/namespace_report.qtpl:103
+//line pkg/report/templates/namespace_report.qtpl:136
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:137
 		qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:137
 		qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:137
 		qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:137
 		qw422016.N().S(`:`)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:137
 		qw422016.E().S(artifact.Tag)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:137
 		qw422016.N().S(`@`)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:137
 		qw422016.E().S(artifact.Digest)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:137
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:105
+//line pkg/report/templates/namespace_report.qtpl:138
 		return
-//line pkg/report/templates/namespace_report.qtpl:106
+//line pkg/report/templates/namespace_report.qtpl:139
 	}
-//line pkg/report/templates/namespace_report.qtpl:106
+//line pkg/report/templates/namespace_report.qtpl:139
 	qw422016.N().S(`
 
   `)
-//line pkg/report/templates/namespace_report.qtpl:108
+//line pkg/report/templates/namespace_report.qtpl:141
 	if artifact.Tag == "" && artifact.Digest != "" {
-//line pkg/report/templates/namespace_report.qtpl:108
+//line pkg/report/templates/namespace_report.qtpl:141
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:142
 		qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:142
 		qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:142
 		qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:142
 		qw422016.N().S(`@`)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:142
 		qw422016.E().S(artifact.Digest)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:142
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:110
+//line pkg/report/templates/namespace_report.qtpl:143
 		return
-//line pkg/report/templates/namespace_report.qtpl:111
+//line pkg/report/templates/namespace_report.qtpl:144
 	}
-//line pkg/report/templates/namespace_report.qtpl:111
+//line pkg/report/templates/namespace_report.qtpl:144
 	qw422016.N().S(`
 
   `)
-//line pkg/report/templates/namespace_report.qtpl:113
+//line pkg/report/templates/namespace_report.qtpl:146
 	if artifact.Tag != "" && artifact.Digest == "" {
-//line pkg/report/templates/namespace_report.qtpl:113
+//line pkg/report/templates/namespace_report.qtpl:146
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:147
 		qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:147
 		qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:147
 		qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:147
 		qw422016.N().S(`:`)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:147
 		qw422016.E().S(artifact.Tag)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:147
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:115
+//line pkg/report/templates/namespace_report.qtpl:148
 		return
-//line pkg/report/templates/namespace_report.qtpl:116
+//line pkg/report/templates/namespace_report.qtpl:149
 	}
-//line pkg/report/templates/namespace_report.qtpl:116
+//line pkg/report/templates/namespace_report.qtpl:149
 	qw422016.N().S(`
 
   `)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:151
 	qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:151
 	qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:151
 	qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:151
 	qw422016.N().S(`:`)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:151
 	qw422016.E().S(artifact.Tag)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:151
 	qw422016.N().S(`
 `)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 }
 
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 func writeimageReference(qq422016 qtio422016.Writer, registry v1alpha1.Registry, artifact v1alpha1.Artifact) {
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 	streamimageReference(qw422016, registry, artifact)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 }
 
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 func imageReference(registry v1alpha1.Registry, artifact v1alpha1.Artifact) string {
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 	writeimageReference(qb422016, registry, artifact)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 	return qs422016
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:152
 }
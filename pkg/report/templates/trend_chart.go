@@ -0,0 +1,118 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+const (
+	trendChartWidth  = 800
+	trendChartHeight = 240
+	trendChartMargin = 40
+)
+
+// TrendChart holds data to render a line chart of severity counts recorded
+// in a v1alpha1.SummaryHistory as an inline SVG element.
+type TrendChart struct {
+	Width  int
+	Height int
+
+	// Left, Right, Top, and Bottom are coordinates of the plot area.
+	Left   int
+	Right  int
+	Top    int
+	Bottom int
+
+	// MaxCount is the severity count at the top of the plot area.
+	MaxCount int
+
+	// From and To are formatted timestamps of the first and the last entry.
+	From string
+	To   string
+
+	Series []TrendSeries
+}
+
+// TrendSeries is a line of a TrendChart.
+type TrendSeries struct {
+	Name   string
+	Color  string
+	Points []TrendPoint
+}
+
+// TrendPoint is a point of a TrendSeries in SVG coordinates.
+type TrendPoint struct {
+	X int
+	Y int
+}
+
+// Polyline returns points of this series formatted as the value of the
+// points attribute of an SVG polyline element.
+func (s TrendSeries) Polyline() string {
+	points := make([]string, len(s.Points))
+	for i, p := range s.Points {
+		points[i] = fmt.Sprintf("%d,%d", p.X, p.Y)
+	}
+	return strings.Join(points, " ")
+}
+
+// NewTrendChart returns a TrendChart with lines of critical, high, medium,
+// and low severity counts of the given entries.
+func NewTrendChart(entries []v1alpha1.SummaryHistoryEntry) TrendChart {
+	chart := TrendChart{
+		Width:  trendChartWidth,
+		Height: trendChartHeight,
+		Left:   trendChartMargin,
+		Right:  trendChartWidth - trendChartMargin/2,
+		Top:    trendChartMargin / 2,
+		Bottom: trendChartHeight - trendChartMargin,
+	}
+	if len(entries) == 0 {
+		return chart
+	}
+	chart.From = entries[0].Timestamp.Format("2 Jan 2006")
+	chart.To = entries[len(entries)-1].Timestamp.Format("2 Jan 2006")
+
+	series := []struct {
+		name  string
+		color string
+		count func(entry v1alpha1.SummaryHistoryEntry) int
+	}{
+		{name: "Critical", color: "#dc3545", count: func(e v1alpha1.SummaryHistoryEntry) int { return e.CriticalCount }},
+		{name: "High", color: "#fd7e14", count: func(e v1alpha1.SummaryHistoryEntry) int { return e.HighCount }},
+		{name: "Medium", color: "#ffc107", count: func(e v1alpha1.SummaryHistoryEntry) int { return e.MediumCount }},
+		{name: "Low", color: "#17a2b8", count: func(e v1alpha1.SummaryHistoryEntry) int { return e.LowCount }},
+	}
+
+	for _, entry := range entries {
+		for _, s := range series {
+			if count := s.count(entry); count > chart.MaxCount {
+				chart.MaxCount = count
+			}
+		}
+	}
+	maxCount := chart.MaxCount
+	if maxCount == 0 {
+		maxCount = 1
+	}
+
+	for _, s := range series {
+		points := make([]TrendPoint, len(entries))
+		for i, entry := range entries {
+			x := chart.Left
+			if len(entries) > 1 {
+				x += i * (chart.Right - chart.Left) / (len(entries) - 1)
+			}
+			y := chart.Bottom - s.count(entry)*(chart.Bottom-chart.Top)/maxCount
+			points[i] = TrendPoint{X: x, Y: y}
+		}
+		chart.Series = append(chart.Series, TrendSeries{
+			Name:   s.name,
+			Color:  s.color,
+			Points: points,
+		})
+	}
+	return chart
+}
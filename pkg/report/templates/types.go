@@ -27,6 +27,11 @@ type NamespaceReport struct {
 	Top5VulnerableImages []v1alpha1.VulnerabilityReport
 	Top5FailedChecks     []CheckWithCount
 	Top5Vulnerability    []VulnerabilityWithCount
+
+	// VulnerabilityTrend and ConfigAuditTrend are severity counts recorded
+	// over time in v1alpha1.SummaryHistory instances of the namespace.
+	VulnerabilityTrend []v1alpha1.SummaryHistoryEntry
+	ConfigAuditTrend   []v1alpha1.SummaryHistoryEntry
 }
 
 type VulnerabilityWithCount struct {
@@ -0,0 +1,156 @@
+package sbomreport
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// GetClusterReportName returns the name of the v1alpha1.ClusterSbomReport
+// which caches the inventory of the image with the given repo digest.
+func GetClusterReportName(digest string) string {
+	return kube.ComputeHash(digest)
+}
+
+type ReportBuilder struct {
+	scheme     *runtime.Scheme
+	controller client.Object
+	container  string
+	hash       string
+	data       v1alpha1.SbomReportData
+}
+
+func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
+	return &ReportBuilder{
+		scheme: scheme,
+	}
+}
+
+func (b *ReportBuilder) Controller(controller client.Object) *ReportBuilder {
+	b.controller = controller
+	return b
+}
+
+func (b *ReportBuilder) Container(name string) *ReportBuilder {
+	b.container = name
+	return b
+}
+
+func (b *ReportBuilder) PodSpecHash(hash string) *ReportBuilder {
+	b.hash = hash
+	return b
+}
+
+func (b *ReportBuilder) Data(data v1alpha1.SbomReportData) *ReportBuilder {
+	b.data = data
+	return b
+}
+
+func (b *ReportBuilder) reportName() string {
+	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
+	name := b.controller.GetName()
+	reportName := fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), name, b.container)
+	if len(validation.IsValidLabelValue(reportName)) == 0 {
+		return reportName
+	}
+
+	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+b.container))
+}
+
+func (b *ReportBuilder) Get() (v1alpha1.SbomReport, error) {
+	labels := map[string]string{
+		starboard.LabelContainerName: b.container,
+	}
+
+	if b.hash != "" {
+		labels[starboard.LabelResourceSpecHash] = b.hash
+	}
+
+	if spec, err := kube.GetPodSpec(b.controller); err == nil {
+		if containerType, ok := kube.GetContainerType(spec, b.container); ok {
+			labels[starboard.LabelContainerType] = string(containerType)
+		}
+	}
+
+	report := v1alpha1.SbomReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.reportName(),
+			Namespace: b.controller.GetNamespace(),
+			Labels:    labels,
+		},
+		Report: b.data,
+	}
+	err := kube.ObjectToObjectMeta(b.controller, &report.ObjectMeta)
+	if err != nil {
+		return v1alpha1.SbomReport{}, err
+	}
+	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
+	if err != nil {
+		return v1alpha1.SbomReport{}, fmt.Errorf("setting controller reference: %w", err)
+	}
+	// Do not require additional RBAC permissions when the
+	// OwnerReferencesPermissionsEnforcement admission controller is enabled.
+	// See vulnerabilityreport.ReportBuilder for details.
+	report.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
+	return report, nil
+}
+
+type ClusterReportBuilder struct {
+	digest    string
+	data      v1alpha1.SbomReportData
+	reportTTL time.Duration
+}
+
+func NewClusterReportBuilder() *ClusterReportBuilder {
+	return &ClusterReportBuilder{}
+}
+
+func (b *ClusterReportBuilder) ImageDigest(digest string) *ClusterReportBuilder {
+	b.digest = digest
+	return b
+}
+
+func (b *ClusterReportBuilder) Data(data v1alpha1.SbomReportData) *ClusterReportBuilder {
+	b.data = data
+	return b
+}
+
+func (b *ClusterReportBuilder) ReportTTL(ttl time.Duration) *ClusterReportBuilder {
+	b.reportTTL = ttl
+	return b
+}
+
+func (b *ClusterReportBuilder) Get() (v1alpha1.ClusterSbomReport, error) {
+	if b.digest == "" {
+		return v1alpha1.ClusterSbomReport{}, fmt.Errorf("image digest must be set")
+	}
+	data := *b.data.DeepCopy()
+	if data.Artifact.Digest == "" {
+		data.Artifact.Digest = b.digest[strings.LastIndex(b.digest, "@")+1:]
+	}
+	report := v1alpha1.ClusterSbomReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: GetClusterReportName(b.digest),
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+			},
+		},
+		Report: data,
+	}
+	if b.reportTTL > 0 {
+		report.Annotations = map[string]string{
+			v1alpha1.TTLReportAnnotation: b.reportTTL.String(),
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,96 @@
+package sbomreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+)
+
+func TestReportBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := sbomreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-owner",
+				Namespace: "qa",
+			},
+		}).
+		Container("my-container").
+		PodSpecHash("xyz").
+		Data(v1alpha1.SbomReportData{}).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report).To(gomega.Equal(v1alpha1.SbomReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replicaset-some-owner-my-container",
+			Namespace: "qa",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "apps/v1",
+					Kind:               "ReplicaSet",
+					Name:               "some-owner",
+					Controller:         pointer.BoolPtr(true),
+					BlockOwnerDeletion: pointer.BoolPtr(false),
+				},
+			},
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      "some-owner",
+				starboard.LabelResourceNamespace: "qa",
+				starboard.LabelContainerName:     "my-container",
+				starboard.LabelResourceSpecHash:  "xyz",
+			},
+		},
+		Report: v1alpha1.SbomReportData{},
+	}))
+}
+
+func TestClusterReportBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := sbomreport.NewClusterReportBuilder().
+		ImageDigest("nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514").
+		ReportTTL(72 * time.Hour).
+		Data(v1alpha1.SbomReportData{
+			Artifact: v1alpha1.Artifact{
+				Repository: "library/nginx",
+				Tag:        "1.16",
+			},
+		}).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report).To(gomega.Equal(v1alpha1.ClusterSbomReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: sbomreport.GetClusterReportName("nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514"),
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+			},
+			Annotations: map[string]string{
+				v1alpha1.TTLReportAnnotation: "72h0m0s",
+			},
+		},
+		Report: v1alpha1.SbomReportData{
+			Artifact: v1alpha1.Artifact{
+				Repository: "library/nginx",
+				Tag:        "1.16",
+				Digest:     "sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514",
+			},
+		},
+	}))
+
+	_, err = sbomreport.NewClusterReportBuilder().Get()
+	g.Expect(err).To(gomega.MatchError("image digest must be set"))
+}
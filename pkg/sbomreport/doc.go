@@ -0,0 +1,3 @@
+// Package sbomreport provides primitives for working with software bills of
+// materials (SBOM) of container images.
+package sbomreport
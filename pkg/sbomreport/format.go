@@ -0,0 +1,289 @@
+package sbomreport
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Format is an interchange format of SBOM documents.
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx"
+)
+
+const (
+	cycloneDXSpecVersion = "1.4"
+	cycloneDXTarget      = "aquasecurity:starboard:target"
+
+	spdxVersion           = "SPDX-2.2"
+	spdxDocumentNamespace = "https://aquasecurity.github.io/starboard/sbom"
+	spdxNoAssertion       = "NOASSERTION"
+	spdxContainerImageID  = "SPDXRef-ContainerImage"
+)
+
+// CycloneDX is a bill of materials in the CycloneDX JSON format.
+// @see https://cyclonedx.org/docs/1.4/json/
+type CycloneDX struct {
+	BOMFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber,omitempty"`
+	Version      int                  `json:"version"`
+	Metadata     CycloneDXMetadata    `json:"metadata"`
+	Components   []CycloneDXComponent `json:"components"`
+}
+
+type CycloneDXMetadata struct {
+	Timestamp string              `json:"timestamp"`
+	Tools     []CycloneDXTool     `json:"tools,omitempty"`
+	Component *CycloneDXComponent `json:"component,omitempty"`
+}
+
+type CycloneDXTool struct {
+	Vendor  string `json:"vendor,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type CycloneDXComponent struct {
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Type       string              `json:"type"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PackageURL string              `json:"purl,omitempty"`
+	Licenses   []CycloneDXLicense  `json:"licenses,omitempty"`
+	Properties []CycloneDXProperty `json:"properties,omitempty"`
+}
+
+type CycloneDXLicense struct {
+	License struct {
+		Name string `json:"name"`
+	} `json:"license"`
+}
+
+type CycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// NewCycloneDX converts the given report data to a CycloneDX BOM. The UID of
+// the report, if specified, is used as the serial number of the BOM.
+func NewCycloneDX(uid types.UID, data v1alpha1.SbomReportData) CycloneDX {
+	bom := CycloneDX{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: cycloneDXSpecVersion,
+		Version:     1,
+		Metadata: CycloneDXMetadata{
+			Timestamp: data.UpdateTimestamp.UTC().Format(time.RFC3339),
+			Tools: []CycloneDXTool{
+				{
+					Vendor:  data.Scanner.Vendor,
+					Name:    data.Scanner.Name,
+					Version: data.Scanner.Version,
+				},
+			},
+			Component: &CycloneDXComponent{
+				BOMRef:  imageRef(data),
+				Type:    "container",
+				Name:    imageName(data),
+				Version: imageVersion(data),
+			},
+		},
+		Components: make([]CycloneDXComponent, 0, len(data.Components)),
+	}
+	if uid != "" {
+		bom.SerialNumber = "urn:uuid:" + string(uid)
+	}
+
+	refs := map[string]bool{}
+	for i, c := range data.Components {
+		ref := c.PackageURL
+		if ref == "" || refs[ref] {
+			ref = fmt.Sprintf("component-%d", i)
+		}
+		refs[ref] = true
+
+		component := CycloneDXComponent{
+			BOMRef:     ref,
+			Type:       "library",
+			Name:       c.Name,
+			Version:    c.Version,
+			PackageURL: c.PackageURL,
+		}
+		for _, name := range c.Licenses {
+			var license CycloneDXLicense
+			license.License.Name = name
+			component.Licenses = append(component.Licenses, license)
+		}
+		if c.Target != "" {
+			component.Properties = []CycloneDXProperty{{Name: cycloneDXTarget, Value: c.Target}}
+		}
+		bom.Components = append(bom.Components, component)
+	}
+	return bom
+}
+
+// SPDX is a document in the SPDX JSON format.
+// @see https://spdx.github.io/spdx-spec/v2.2.2/
+type SPDX struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      SPDXCreationInfo   `json:"creationInfo"`
+	Packages          []SPDXPackage      `json:"packages"`
+	Relationships     []SPDXRelationship `json:"relationships"`
+}
+
+type SPDXCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type SPDXPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	SourceInfo       string            `json:"sourceInfo,omitempty"`
+	ExternalRefs     []SPDXExternalRef `json:"externalRefs,omitempty"`
+}
+
+type SPDXExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type SPDXRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// NewSPDX converts the given report data to an SPDX document. The UID of the
+// report, if specified, makes the namespace of the document unique.
+func NewSPDX(uid types.UID, data v1alpha1.SbomReportData) SPDX {
+	name := imageRef(data)
+	namespace := fmt.Sprintf("%s/%s", spdxDocumentNamespace, name)
+	if uid != "" {
+		namespace = fmt.Sprintf("%s-%s", namespace, uid)
+	}
+
+	doc := SPDX{
+		SPDXVersion:       spdxVersion,
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              name,
+		DocumentNamespace: namespace,
+		CreationInfo: SPDXCreationInfo{
+			Created: data.UpdateTimestamp.UTC().Format(time.RFC3339),
+			Creators: []string{
+				fmt.Sprintf("Organization: %s", data.Scanner.Vendor),
+				fmt.Sprintf("Tool: %s-%s", data.Scanner.Name, data.Scanner.Version),
+			},
+		},
+		Packages: []SPDXPackage{
+			{
+				SPDXID:           spdxContainerImageID,
+				Name:             imageName(data),
+				VersionInfo:      imageVersion(data),
+				DownloadLocation: spdxNoAssertion,
+				LicenseConcluded: spdxNoAssertion,
+				LicenseDeclared:  spdxNoAssertion,
+				CopyrightText:    spdxNoAssertion,
+			},
+		},
+		Relationships: []SPDXRelationship{
+			{
+				SPDXElementID:      "SPDXRef-DOCUMENT",
+				RelationshipType:   "DESCRIBES",
+				RelatedSPDXElement: spdxContainerImageID,
+			},
+		},
+	}
+
+	for i, c := range data.Components {
+		pkg := SPDXPackage{
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%d", i+1),
+			Name:             c.Name,
+			VersionInfo:      c.Version,
+			DownloadLocation: spdxNoAssertion,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxLicenseExpression(c.Licenses),
+			CopyrightText:    spdxNoAssertion,
+		}
+		if c.Target != "" {
+			pkg.SourceInfo = fmt.Sprintf("found in %s", c.Target)
+		}
+		if c.PackageURL != "" {
+			pkg.ExternalRefs = []SPDXExternalRef{
+				{
+					ReferenceCategory: "PACKAGE-MANAGER",
+					ReferenceType:     "purl",
+					ReferenceLocator:  c.PackageURL,
+				},
+			}
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		doc.Relationships = append(doc.Relationships, SPDXRelationship{
+			SPDXElementID:      spdxContainerImageID,
+			RelationshipType:   "CONTAINS",
+			RelatedSPDXElement: pkg.SPDXID,
+		})
+	}
+	return doc
+}
+
+var spdxLicenseID = regexp.MustCompile(`^[A-Za-z0-9.+-]+$`)
+
+// spdxLicenseExpression joins the given licenses with the AND operator.
+// NOASSERTION is returned if any license is not a valid SPDX identifier,
+// e.g. a license name reported by a package manager.
+func spdxLicenseExpression(licenses []string) string {
+	if len(licenses) == 0 {
+		return spdxNoAssertion
+	}
+	for _, license := range licenses {
+		if !spdxLicenseID.MatchString(license) {
+			return spdxNoAssertion
+		}
+	}
+	return strings.Join(licenses, " AND ")
+}
+
+func imageName(data v1alpha1.SbomReportData) string {
+	if data.Registry.Server == "" {
+		return data.Artifact.Repository
+	}
+	return data.Registry.Server + "/" + data.Artifact.Repository
+}
+
+func imageVersion(data v1alpha1.SbomReportData) string {
+	if data.Artifact.Tag != "" {
+		return data.Artifact.Tag
+	}
+	return data.Artifact.Digest
+}
+
+func imageRef(data v1alpha1.SbomReportData) string {
+	ref := imageName(data)
+	if data.Artifact.Tag != "" {
+		ref += ":" + data.Artifact.Tag
+	}
+	if data.Artifact.Digest != "" {
+		ref += "@" + data.Artifact.Digest
+	}
+	return ref
+}
@@ -0,0 +1,131 @@
+package sbomreport_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var sampleReportData = v1alpha1.SbomReportData{
+	UpdateTimestamp: metav1.NewTime(time.Date(2022, time.June, 1, 10, 30, 0, 0, time.UTC)),
+	Scanner: v1alpha1.Scanner{
+		Name:    "Trivy",
+		Vendor:  "Aqua Security",
+		Version: "0.25.2",
+	},
+	Registry: v1alpha1.Registry{
+		Server: "index.docker.io",
+	},
+	Artifact: v1alpha1.Artifact{
+		Repository: "library/alpine",
+		Tag:        "3.16.2",
+	},
+	Summary: v1alpha1.SbomSummary{
+		ComponentsCount: 3,
+	},
+	Components: []v1alpha1.Component{
+		{
+			Name:       "musl",
+			Version:    "1.2.3-r0",
+			PackageURL: "pkg:apk/alpine/musl@1.2.3-r0?distro=3.16.2",
+			Licenses:   []string{"MIT"},
+			Target:     "alpine:3.16.2 (alpine 3.16.2)",
+		},
+		{
+			Name:       "zlib",
+			Version:    "1.2.12-r1",
+			PackageURL: "pkg:apk/alpine/zlib@1.2.12-r1?distro=3.16.2",
+			Licenses:   []string{"Zlib License"},
+			Target:     "alpine:3.16.2 (alpine 3.16.2)",
+		},
+		{
+			Name:    "app",
+			Version: "1.0.0",
+		},
+	},
+}
+
+func TestNewCycloneDX(t *testing.T) {
+	bom := sbomreport.NewCycloneDX("8aa1a7cb-a319-4b93-850d-5a67827dfbbf", sampleReportData)
+
+	assert.Equal(t, "CycloneDX", bom.BOMFormat)
+	assert.Equal(t, "1.4", bom.SpecVersion)
+	assert.Equal(t, "urn:uuid:8aa1a7cb-a319-4b93-850d-5a67827dfbbf", bom.SerialNumber)
+	assert.Equal(t, 1, bom.Version)
+	assert.Equal(t, "2022-06-01T10:30:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, []sbomreport.CycloneDXTool{{Vendor: "Aqua Security", Name: "Trivy", Version: "0.25.2"}}, bom.Metadata.Tools)
+	assert.Equal(t, &sbomreport.CycloneDXComponent{
+		BOMRef:  "index.docker.io/library/alpine:3.16.2",
+		Type:    "container",
+		Name:    "index.docker.io/library/alpine",
+		Version: "3.16.2",
+	}, bom.Metadata.Component)
+	require.Len(t, bom.Components, 3)
+	assert.Equal(t, "pkg:apk/alpine/musl@1.2.3-r0?distro=3.16.2", bom.Components[0].BOMRef)
+	assert.Equal(t, []sbomreport.CycloneDXProperty{
+		{Name: "aquasecurity:starboard:target", Value: "alpine:3.16.2 (alpine 3.16.2)"},
+	}, bom.Components[0].Properties)
+	assert.Equal(t, "component-2", bom.Components[2].BOMRef)
+	assert.Empty(t, bom.Components[2].Properties)
+
+	content, err := json.Marshal(bom.Components[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "bom-ref": "pkg:apk/alpine/zlib@1.2.12-r1?distro=3.16.2",
+  "type": "library",
+  "name": "zlib",
+  "version": "1.2.12-r1",
+  "purl": "pkg:apk/alpine/zlib@1.2.12-r1?distro=3.16.2",
+  "licenses": [{"license": {"name": "Zlib License"}}],
+  "properties": [{"name": "aquasecurity:starboard:target", "value": "alpine:3.16.2 (alpine 3.16.2)"}]
+}`, string(content))
+}
+
+func TestNewSPDX(t *testing.T) {
+	doc := sbomreport.NewSPDX("8aa1a7cb-a319-4b93-850d-5a67827dfbbf", sampleReportData)
+
+	assert.Equal(t, "SPDX-2.2", doc.SPDXVersion)
+	assert.Equal(t, "CC0-1.0", doc.DataLicense)
+	assert.Equal(t, "SPDXRef-DOCUMENT", doc.SPDXID)
+	assert.Equal(t, "index.docker.io/library/alpine:3.16.2", doc.Name)
+	assert.Equal(t, "https://aquasecurity.github.io/starboard/sbom/index.docker.io/library/alpine:3.16.2-8aa1a7cb-a319-4b93-850d-5a67827dfbbf", doc.DocumentNamespace)
+	assert.Equal(t, sbomreport.SPDXCreationInfo{
+		Created:  "2022-06-01T10:30:00Z",
+		Creators: []string{"Organization: Aqua Security", "Tool: Trivy-0.25.2"},
+	}, doc.CreationInfo)
+
+	require.Len(t, doc.Packages, 4)
+	assert.Equal(t, "SPDXRef-ContainerImage", doc.Packages[0].SPDXID)
+	assert.Equal(t, sbomreport.SPDXPackage{
+		SPDXID:           "SPDXRef-Package-1",
+		Name:             "musl",
+		VersionInfo:      "1.2.3-r0",
+		DownloadLocation: "NOASSERTION",
+		LicenseConcluded: "NOASSERTION",
+		LicenseDeclared:  "MIT",
+		CopyrightText:    "NOASSERTION",
+		SourceInfo:       "found in alpine:3.16.2 (alpine 3.16.2)",
+		ExternalRefs: []sbomreport.SPDXExternalRef{
+			{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  "pkg:apk/alpine/musl@1.2.3-r0?distro=3.16.2",
+			},
+		},
+	}, doc.Packages[1])
+	assert.Equal(t, "NOASSERTION", doc.Packages[2].LicenseDeclared, "license name is not a valid SPDX identifier")
+	assert.Empty(t, doc.Packages[3].ExternalRefs)
+
+	assert.Equal(t, []sbomreport.SPDXRelationship{
+		{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-ContainerImage"},
+		{SPDXElementID: "SPDXRef-ContainerImage", RelationshipType: "CONTAINS", RelatedSPDXElement: "SPDXRef-Package-1"},
+		{SPDXElementID: "SPDXRef-ContainerImage", RelationshipType: "CONTAINS", RelatedSPDXElement: "SPDXRef-Package-2"},
+		{SPDXElementID: "SPDXRef-ContainerImage", RelationshipType: "CONTAINS", RelatedSPDXElement: "SPDXRef-Package-3"},
+	}, doc.Relationships)
+}
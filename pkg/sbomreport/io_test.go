@@ -0,0 +1,111 @@
+package sbomreport_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewReadWriter(t *testing.T) {
+	kubernetesScheme := starboard.NewScheme()
+
+	t.Run("Should create and update SbomReports", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.SbomReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "deployment-app1-container1",
+				Namespace:       "qa",
+				ResourceVersion: "0",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Deployment",
+					starboard.LabelResourceName:      "app1",
+					starboard.LabelResourceNamespace: "qa",
+					starboard.LabelContainerName:     "container1",
+					starboard.LabelResourceSpecHash:  "h1",
+				},
+			},
+			Report: v1alpha1.SbomReportData{
+				Summary: v1alpha1.SbomSummary{ComponentsCount: 5},
+			},
+		}).Build()
+		readWriter := sbomreport.NewReadWriter(client)
+
+		labels := map[string]string{
+			starboard.LabelResourceKind:      "Deployment",
+			starboard.LabelResourceName:      "app1",
+			starboard.LabelResourceNamespace: "qa",
+			starboard.LabelContainerName:     "container1",
+			starboard.LabelResourceSpecHash:  "h2",
+		}
+		err := readWriter.Write(context.TODO(), []v1alpha1.SbomReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container1",
+					Namespace: "qa",
+					Labels:    labels,
+				},
+				Report: v1alpha1.SbomReportData{
+					Summary: v1alpha1.SbomSummary{ComponentsCount: 8},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container2",
+					Namespace: "qa",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Deployment",
+						starboard.LabelResourceName:      "app1",
+						starboard.LabelResourceNamespace: "qa",
+						starboard.LabelContainerName:     "container2",
+						starboard.LabelResourceSpecHash:  "h2",
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		reports, err := readWriter.FindByOwner(context.TODO(), kube.ObjectRef{
+			Kind:      kube.KindDeployment,
+			Name:      "app1",
+			Namespace: "qa",
+		})
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		for _, report := range reports {
+			if report.Name == "deployment-app1-container1" {
+				assert.Equal(t, labels, report.Labels)
+				assert.Equal(t, 8, report.Report.Summary.ComponentsCount)
+			}
+		}
+	})
+
+	t.Run("Should write and find ClusterSbomReport by image digest", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := sbomreport.NewReadWriter(client)
+
+		digest := "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514"
+
+		report, err := readWriter.FindClusterReportByImageDigest(context.TODO(), digest)
+		require.NoError(t, err)
+		assert.Nil(t, report)
+
+		clusterReport, err := sbomreport.NewClusterReportBuilder().
+			ImageDigest(digest).
+			Data(v1alpha1.SbomReportData{Summary: v1alpha1.SbomSummary{ComponentsCount: 3}}).
+			Get()
+		require.NoError(t, err)
+		require.NoError(t, readWriter.WriteClusterReport(context.TODO(), clusterReport))
+
+		report, err = readWriter.FindClusterReportByImageDigest(context.TODO(), digest)
+		require.NoError(t, err)
+		require.NotNil(t, report)
+		assert.Equal(t, 3, report.Report.Summary.ComponentsCount)
+	})
+}
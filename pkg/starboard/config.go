@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	embedded "github.com/aquasecurity/starboard"
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -56,7 +57,19 @@ type Scanner string
 const (
 	keyVulnerabilityReportsScanner       = "vulnerabilityReports.scanner"
 	KeyVulnerabilityScansInSameNamespace = "vulnerabilityReports.scanJobsInSameNamespace"
+	KeyVulnerabilityReportsMergeEnabled  = "vulnerabilityReports.mergeEnabled"
+	KeySbomReportsEnabled                = "sbomReports.enabled"
+	KeyAdmissionMode                     = "admission.mode"
+	KeyAdmissionMaxCriticalVulns         = "admission.vulnerabilities.maxCritical"
+	KeyAdmissionMaxHighVulns             = "admission.vulnerabilities.maxHigh"
+	KeyAdmissionTagFallback              = "admission.vulnerabilities.tagFallback"
+	KeyAdmissionDeniedChecks             = "admission.configAudit.deniedChecks"
+	KeySummaryHistoryEnabled             = "summaryHistory.enabled"
+	keySummaryHistoryMaxEntries          = "summaryHistory.maxEntries"
+	keySummaryHistoryResolution          = "summaryHistory.resolution"
 	keyConfigAuditReportsScanner         = "configAuditReports.scanner"
+	keyPoliciesBundle                    = "configAuditReports.policies.bundle"
+	keyPoliciesBundleDigest              = "configAuditReports.policies.bundleDigest"
 	keyKubeBenchImageRef                 = "kube-bench.imageRef"
 	keyKubeHunterImageRef                = "kube-hunter.imageRef"
 	keyKubeHunterQuick                   = "kube-hunter.quick"
@@ -64,6 +77,8 @@ const (
 	keyScanJobAnnotations                = "scanJob.annotations"
 	keyScanJobPodTemplateLabels          = "scanJob.podTemplateLabels"
 	keyComplianceFailEntriesLimit        = "compliance.failEntriesLimit"
+	keyPluginCommandPrefix               = "plugins."
+	keyPluginCommandSuffix               = ".command"
 )
 
 // ConfigData holds Starboard configuration settings as a set of key-value
@@ -90,13 +105,49 @@ func GetDefaultConfig() ConfigData {
 	}
 }
 
+// GetVulnerabilityReportsScanner returns the primary vulnerability scanner,
+// i.e. the first one of GetVulnerabilityReportsScanners.
 func (c ConfigData) GetVulnerabilityReportsScanner() (Scanner, error) {
+	scanners, err := c.GetVulnerabilityReportsScanners()
+	if err != nil {
+		return "", err
+	}
+	return scanners[0], nil
+}
+
+// GetVulnerabilityReportsScanners returns vulnerability scanners specified as
+// a comma separated list, e.g. Trivy,Grype. Each scanner produces its own
+// VulnerabilityReports. The first scanner is the primary one.
+func (c ConfigData) GetVulnerabilityReportsScanners() ([]Scanner, error) {
 	var ok bool
 	var value string
 	if value, ok = c[keyVulnerabilityReportsScanner]; !ok {
-		return "", fmt.Errorf("property %s not set", keyVulnerabilityReportsScanner)
+		return nil, fmt.Errorf("property %s not set", keyVulnerabilityReportsScanner)
 	}
-	return Scanner(value), nil
+	var scanners []Scanner
+	seen := make(map[Scanner]bool)
+	for _, name := range strings.Split(value, ",") {
+		scanner := Scanner(strings.TrimSpace(name))
+		if scanner == "" {
+			continue
+		}
+		if seen[scanner] {
+			return nil, fmt.Errorf("property %s: duplicate scanner: %s", keyVulnerabilityReportsScanner, scanner)
+		}
+		seen[scanner] = true
+		scanners = append(scanners, scanner)
+	}
+	if len(scanners) == 0 {
+		return nil, fmt.Errorf("property %s not set", keyVulnerabilityReportsScanner)
+	}
+	return scanners, nil
+}
+
+// VulnerabilityReportsMergeEnabled returns true if VulnerabilityReports
+// generated by several vulnerability scanners should be merged into a single
+// VulnerabilityReport per container.
+func (c ConfigData) VulnerabilityReportsMergeEnabled() bool {
+	return c[KeyVulnerabilityReportsMergeEnabled] == "true"
 }
 
 func (c ConfigData) VulnerabilityScanJobsInSameNamespace() bool {
@@ -108,6 +159,13 @@ func (c ConfigData) VulnerabilityScanJobsInSameNamespace() bool {
 	return value == "true"
 }
 
+// SbomReportsEnabled returns true if vulnerability scanners that support it
+// should also record software bills of materials as v1alpha1.SbomReport
+// instances.
+func (c ConfigData) SbomReportsEnabled() bool {
+	return c[KeySbomReportsEnabled] == "true"
+}
+
 func (c ConfigData) GetConfigAuditReportsScanner() (Scanner, error) {
 	var ok bool
 	var value string
@@ -117,6 +175,30 @@ func (c ConfigData) GetConfigAuditReportsScanner() (Scanner, error) {
 	return Scanner(value), nil
 }
 
+// GetPoliciesBundle returns the URL of the bundle of config audit policies,
+// which are loaded in addition to policies of the starboard-policies-config
+// ConfigMap, and the expected digest of the bundle. It returns false if the
+// bundle is not configured.
+func (c ConfigData) GetPoliciesBundle() (string, string, bool) {
+	url := strings.TrimSpace(c[keyPoliciesBundle])
+	if url == "" {
+		return "", "", false
+	}
+	return url, strings.TrimSpace(c[keyPoliciesBundleDigest]), true
+}
+
+// GetPluginCommand returns the command, which runs the out-of-tree plugin for
+// the given scanner, split into the executable name and its arguments. The
+// command is configured with the plugins.<scanner>.command key, e.g.
+// plugins.Acme.command. It returns false if the command is not configured.
+func (c ConfigData) GetPluginCommand(scanner Scanner) ([]string, bool) {
+	command := strings.Fields(c[keyPluginCommandPrefix+string(scanner)+keyPluginCommandSuffix])
+	if len(command) == 0 {
+		return nil, false
+	}
+	return command, true
+}
+
 func (c ConfigData) GetScanJobTolerations() ([]corev1.Toleration, error) {
 	var scanJobTolerations []corev1.Toleration
 	if c[keyScanJobTolerations] == "" {
@@ -226,6 +308,43 @@ func (c ConfigData) ComplianceFailEntriesLimit() int {
 	return intVal
 }
 
+// SummaryHistoryEnabled returns true if severity counts of security reports
+// should be recorded as v1alpha1.SummaryHistory instances.
+func (c ConfigData) SummaryHistoryEnabled() bool {
+	return c[KeySummaryHistoryEnabled] == "true"
+}
+
+// SummaryHistoryMaxEntries returns the maximum number of entries kept in a
+// v1alpha1.SummaryHistory. The oldest entries are removed first.
+func (c ConfigData) SummaryHistoryMaxEntries() int {
+	const defaultValue = 90
+	value, ok := c[keySummaryHistoryMaxEntries]
+	if !ok {
+		return defaultValue
+	}
+	intVal, err := strconv.Atoi(value)
+	if err != nil || intVal < 1 {
+		return defaultValue
+	}
+	return intVal
+}
+
+// SummaryHistoryResolution returns the time span covered by a single entry of
+// a v1alpha1.SummaryHistory. Summaries recorded within the same time span
+// replace each other.
+func (c ConfigData) SummaryHistoryResolution() time.Duration {
+	const defaultValue = 24 * time.Hour
+	value, ok := c[keySummaryHistoryResolution]
+	if !ok {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return defaultValue
+	}
+	return duration
+}
+
 // NewConfigManager constructs a new ConfigManager that is using kubernetes.Interface
 // to manage ConfigData backed by the ConfigMap stored in the specified namespace.
 func NewConfigManager(client kubernetes.Interface, namespace string) ConfigManager {
@@ -3,6 +3,7 @@ package starboard_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/onsi/gomega"
@@ -36,6 +37,13 @@ func TestConfigData_GetVulnerabilityReportsScanner(t *testing.T) {
 			},
 			expectedScanner: "Aqua",
 		},
+		{
+			name: "Should return primary scanner",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Trivy,Grype",
+			},
+			expectedScanner: "Trivy",
+		},
 		{
 			name:          "Should return error when value is not set",
 			configData:    starboard.ConfigData{},
@@ -55,6 +63,62 @@ func TestConfigData_GetVulnerabilityReportsScanner(t *testing.T) {
 	}
 }
 
+func TestConfigData_GetVulnerabilityReportsScanners(t *testing.T) {
+	testCases := []struct {
+		name             string
+		configData       starboard.ConfigData
+		expectedError    string
+		expectedScanners []starboard.Scanner
+	}{
+		{
+			name: "Should return single scanner",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Trivy",
+			},
+			expectedScanners: []starboard.Scanner{"Trivy"},
+		},
+		{
+			name: "Should return scanners in order",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Grype, Trivy,",
+			},
+			expectedScanners: []starboard.Scanner{"Grype", "Trivy"},
+		},
+		{
+			name: "Should return error when scanner is duplicated",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Trivy,Trivy",
+			},
+			expectedError: "property vulnerabilityReports.scanner: duplicate scanner: Trivy",
+		},
+		{
+			name: "Should return error when value is blank",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": " , ",
+			},
+			expectedError: "property vulnerabilityReports.scanner not set",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scanners, err := tc.configData.GetVulnerabilityReportsScanners()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedScanners, scanners)
+			}
+		})
+	}
+}
+
+func TestConfigData_VulnerabilityReportsMergeEnabled(t *testing.T) {
+	assert.False(t, starboard.ConfigData{}.VulnerabilityReportsMergeEnabled())
+	assert.True(t, starboard.ConfigData{
+		"vulnerabilityReports.mergeEnabled": "true",
+	}.VulnerabilityReportsMergeEnabled())
+}
+
 func TestConfigData_GetConfigAuditReportsScanner(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -296,6 +360,136 @@ func TestConfigData_GetComplianceFailEntriesLimit(t *testing.T) {
 	}
 }
 
+func TestConfigData_SbomReportsEnabled(t *testing.T) {
+	testCases := []struct {
+		name       string
+		configData starboard.ConfigData
+		want       bool
+	}{
+		{
+			name:       "Should return false by default",
+			configData: starboard.ConfigData{},
+			want:       false,
+		},
+		{
+			name: "Should return true",
+			configData: starboard.ConfigData{
+				"sbomReports.enabled": "true",
+			},
+			want: true,
+		},
+		{
+			name: "Should return false for invalid value",
+			configData: starboard.ConfigData{
+				"sbomReports.enabled": "yes",
+			},
+			want: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.configData.SbomReportsEnabled())
+		})
+	}
+}
+
+func TestConfigData_SummaryHistory(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configData     starboard.ConfigData
+		wantEnabled    bool
+		wantMaxEntries int
+		wantResolution time.Duration
+	}{
+		{
+			name:           "Should return default values",
+			configData:     starboard.ConfigData{},
+			wantEnabled:    false,
+			wantMaxEntries: 90,
+			wantResolution: 24 * time.Hour,
+		},
+		{
+			name: "Should return values from config data",
+			configData: starboard.ConfigData{
+				"summaryHistory.enabled":    "true",
+				"summaryHistory.maxEntries": "30",
+				"summaryHistory.resolution": "1h",
+			},
+			wantEnabled:    true,
+			wantMaxEntries: 30,
+			wantResolution: time.Hour,
+		},
+		{
+			name: "Should return default values for invalid values",
+			configData: starboard.ConfigData{
+				"summaryHistory.enabled":    "yes",
+				"summaryHistory.maxEntries": "0",
+				"summaryHistory.resolution": "daily",
+			},
+			wantEnabled:    false,
+			wantMaxEntries: 90,
+			wantResolution: 24 * time.Hour,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantEnabled, tc.configData.SummaryHistoryEnabled())
+			assert.Equal(t, tc.wantMaxEntries, tc.configData.SummaryHistoryMaxEntries())
+			assert.Equal(t, tc.wantResolution, tc.configData.SummaryHistoryResolution())
+		})
+	}
+}
+
+func TestConfigData_GetPluginCommand(t *testing.T) {
+	testCases := []struct {
+		name        string
+		configData  starboard.ConfigData
+		wantCommand []string
+		wantOK      bool
+	}{
+		{
+			name:       "Should return false when command is not set",
+			configData: starboard.ConfigData{},
+		},
+		{
+			name: "Should return false when command is blank",
+			configData: starboard.ConfigData{
+				"plugins.Acme.command": "  ",
+			},
+		},
+		{
+			name: "Should split command into path and arguments",
+			configData: starboard.ConfigData{
+				"plugins.Acme.command": "acme  --debug",
+			},
+			wantCommand: []string{"acme", "--debug"},
+			wantOK:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			command, ok := tc.configData.GetPluginCommand("Acme")
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantCommand, command)
+		})
+	}
+}
+
+func TestConfigData_GetPoliciesBundle(t *testing.T) {
+	url, digest, ok := starboard.ConfigData{}.GetPoliciesBundle()
+	assert.False(t, ok)
+	assert.Empty(t, url)
+	assert.Empty(t, digest)
+
+	url, digest, ok = starboard.ConfigData{
+		"configAuditReports.policies.bundle":       "oci://ghcr.io/acme/policies:1.0",
+		"configAuditReports.policies.bundleDigest": "sha256:b1ab9f",
+	}.GetPoliciesBundle()
+	assert.True(t, ok)
+	assert.Equal(t, "oci://ghcr.io/acme/policies:1.0", url)
+	assert.Equal(t, "sha256:b1ab9f", digest)
+}
+
 func TestConfigData_GetKubeBenchImageRef(t *testing.T) {
 	testCases := []struct {
 		name             string
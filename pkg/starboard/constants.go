@@ -34,6 +34,7 @@ const (
 	LabelResourceNameHash  = "starboard.resource.name-hash"
 	LabelResourceNamespace = "starboard.resource.namespace"
 	LabelContainerName     = "starboard.container.name"
+	LabelContainerType     = "starboard.container.type"
 	LabelResourceSpecHash  = "resource-spec-hash"
 	LabelPluginConfigHash  = "plugin-config-hash"
 
@@ -41,10 +42,67 @@ const (
 	LabelVulnerabilityReportScanner = "vulnerabilityReport.scanner"
 	LabelKubeBenchReportScanner     = "kubeBenchReport.scanner"
 
+	// LabelNodeVulnerabilityReportScanner is set on scan jobs of cluster
+	// nodes, which are distinguished from scan jobs of workloads labeled with
+	// LabelVulnerabilityReportScanner.
+	LabelNodeVulnerabilityReportScanner = "nodeVulnerabilityReport.scanner"
+
+	// LabelHarborScanRequest is set on scan jobs created by the Harbor
+	// scanner adapter. Its value is the identifier of the scan request.
+	LabelHarborScanRequest = "harbor.scanRequest.id"
+
+	// LabelVulnerabilityReportAdditional is set to "true" on
+	// VulnerabilityReports generated by an additional vulnerability scanner,
+	// or by any scanner if reports are merged. Such reports are skipped when
+	// vulnerabilities of a workload are counted, because they're also
+	// included in the report of the primary scanner or the merged report.
+	LabelVulnerabilityReportAdditional = "vulnerabilityReport.additional"
+
+	// LabelConfigAuditReportAdditional is set to "true" on ConfigAuditReports
+	// and ClusterConfigAuditReports generated by the built-in configuration
+	// audit scanner if a plugin-based scanner is enabled as well. Such reports
+	// are skipped when the report of a resource is looked up or checks are
+	// counted, so that the report of the primary scanner is used.
+	LabelConfigAuditReportAdditional = "configAuditReport.additional"
+
 	LabelK8SAppManagedBy = "app.kubernetes.io/managed-by"
 	AppStarboard         = "starboard"
 )
 
 const (
-	AnnotationContainerImages = "starboard.container-images"
+	AnnotationContainerImages       = "starboard.container-images"
+	AnnotationContainerImageDigests = "starboard.container-image-digests"
+
+	// AnnotationImageDigest is set on ClusterVulnerabilityReports to the repo
+	// digest of the scanned image, which is not a valid label value.
+	AnnotationImageDigest = "starboard.aquasecurity.github.io/image-digest"
+
+	// AnnotationKubernetesAdvisoriesUpdated is set on ClusterVulnerabilityReports
+	// of the Kubernetes API server and nodes to the date when the static list
+	// of Kubernetes advisories, which versions of components are checked
+	// against, was last updated.
+	AnnotationKubernetesAdvisoriesUpdated = "starboard.aquasecurity.github.io/kubernetes-advisories-updated"
+
+	// AnnotationVulnerabilityRescanInterval is set on a Namespace to override
+	// the interval of periodic vulnerability rescans of its workloads.
+	AnnotationVulnerabilityRescanInterval = "starboard.aquasecurity.github.io/vulnerability-rescan-interval"
+	// AnnotationVulnerabilityRescanSchedule is set on a Namespace to override
+	// the cron schedule of periodic vulnerability rescans of its workloads.
+	AnnotationVulnerabilityRescanSchedule = "starboard.aquasecurity.github.io/vulnerability-rescan-schedule"
+
+	// AnnotationAdmissionMode is set on a Namespace to override the mode of
+	// the admission policy applied to its workloads.
+	AnnotationAdmissionMode = "starboard.aquasecurity.github.io/admission-mode"
+	// AnnotationAdmissionMaxCriticalVulnerabilities is set on a Namespace to
+	// override the maximum number of critical vulnerabilities in a container
+	// image admitted to the namespace.
+	AnnotationAdmissionMaxCriticalVulnerabilities = "starboard.aquasecurity.github.io/admission-max-critical-vulnerabilities"
+	// AnnotationAdmissionMaxHighVulnerabilities is set on a Namespace to
+	// override the maximum number of high vulnerabilities in a container
+	// image admitted to the namespace.
+	AnnotationAdmissionMaxHighVulnerabilities = "starboard.aquasecurity.github.io/admission-max-high-vulnerabilities"
+	// AnnotationAdmissionDeniedChecks is set on a Namespace to override the
+	// comma-separated IDs of configuration checks that must not fail for a
+	// workload to be admitted to the namespace.
+	AnnotationAdmissionDeniedChecks = "starboard.aquasecurity.github.io/admission-denied-checks"
 )
@@ -1,6 +1,8 @@
 package vulnerabilityreport
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"time"
@@ -29,6 +31,9 @@ type ScanJobBuilder struct {
 	tolerations       []corev1.Toleration
 	annotations       map[string]string
 	podTemplateLabels labels.Set
+	imageDigests      kube.ContainerImages
+	scanImageDigests  bool
+	additional        bool
 }
 
 func NewScanJobBuilder() *ScanJobBuilder {
@@ -75,18 +80,61 @@ func (s *ScanJobBuilder) WithCredentials(credentials map[string]docker.Auth) *Sc
 	return s
 }
 
+// WithContainerImageDigests sets repo digests of scanned container images,
+// which are used to cache scan results as v1alpha1.ClusterVulnerabilityReport
+// instances.
+func (s *ScanJobBuilder) WithContainerImageDigests(digests kube.ContainerImages) *ScanJobBuilder {
+	s.imageDigests = digests
+	return s
+}
+
+// WithScanImageDigests tells the builder to scan repo digests set with
+// WithContainerImageDigests instead of images referenced in the pod template
+// of the workload, which might be mutable tags. Containers without a repo
+// digest are scanned by image reference as before.
+func (s *ScanJobBuilder) WithScanImageDigests(enabled bool) *ScanJobBuilder {
+	s.scanImageDigests = enabled
+	return s
+}
+
+// WithAdditionalScanner tells the builder that the plugin is not the primary
+// vulnerability scanner. Names of the scan job and its secrets are suffixed
+// with the name of the scanner, so that they do not collide with the ones of
+// the primary scanner.
+func (s *ScanJobBuilder) WithAdditionalScanner(additional bool) *ScanJobBuilder {
+	s.additional = additional
+	return s
+}
+
 func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 	spec, err := kube.GetPodSpec(s.object)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	templateSpec, secrets, err := s.plugin.GetScanJobSpec(s.pluginContext, s.object, s.credentials)
+	// The plugin gets a copy of the workload with images replaced by repo
+	// digests, whereas annotations and the pod spec hash still refer to images
+	// of the original workload.
+	scannedObject := s.object
+	if s.scanImageDigests && len(s.imageDigests) > 0 {
+		scannedObject, err = kube.WithContainerImages(s.object, s.imageDigests)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	templateSpec, secrets, err := s.plugin.GetScanJobSpec(s.pluginContext, scannedObject, s.credentials)
 	if err != nil {
 		return nil, nil, err
 	}
 	templateSpec.Tolerations = append(templateSpec.Tolerations, s.tolerations...)
 
+	jobName := GetScanJobName(s.object)
+	if s.additional {
+		jobName = GetAdditionalScanJobName(s.object, s.pluginContext.GetName())
+		renameSecrets(&templateSpec, secrets, "-"+strings.ToLower(s.pluginContext.GetName()))
+	}
+
 	containerImagesAsJSON, err := kube.GetContainerImagesFromPodSpec(spec).AsJSON()
 	if err != nil {
 		return nil, nil, err
@@ -109,7 +157,7 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      GetScanJobName(s.object),
+			Name:      jobName,
 			Namespace: s.pluginContext.GetNamespace(),
 			Labels:    labelsSet,
 			Annotations: map[string]string{
@@ -129,6 +177,14 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 			},
 		},
 	}
+	if len(s.imageDigests) > 0 {
+		imageDigestsAsJSON, err := s.imageDigests.AsJSON()
+		if err != nil {
+			return nil, nil, err
+		}
+		job.Annotations[starboard.AnnotationContainerImageDigests] = imageDigestsAsJSON
+	}
+
 	// secrets will be created with scan jobs in same namespace where scan job will run
 	for i, _ := range secrets {
 		secrets[i].Namespace = s.pluginContext.GetNamespace()
@@ -173,17 +229,85 @@ func GetScanJobName(obj client.Object) string {
 	}))
 }
 
+// GetAdditionalScanJobName returns the name of the scan job of the given
+// additional vulnerability scanner.
+func GetAdditionalScanJobName(obj client.Object, scanner string) string {
+	return fmt.Sprintf("%s-%s", GetScanJobName(obj), strings.ToLower(scanner))
+}
+
 func RegistryCredentialsSecretName(obj client.Object) string {
 	return fmt.Sprintf("%s-regcred", GetScanJobName(obj))
 }
 
+// renameSecrets appends the given suffix to names of secrets returned by a
+// plugin and updates references to them in the given pod spec.
+func renameSecrets(spec *corev1.PodSpec, secrets []*corev1.Secret, suffix string) {
+	names := make(map[string]string)
+	for _, secret := range secrets {
+		names[secret.Name] = secret.Name + suffix
+		secret.Name = secret.Name + suffix
+	}
+	rename := func(name *string) {
+		if newName, ok := names[*name]; ok {
+			*name = newName
+		}
+	}
+	for i := range spec.Volumes {
+		if spec.Volumes[i].Secret != nil {
+			rename(&spec.Volumes[i].Secret.SecretName)
+		}
+	}
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for _, container := range containers {
+			for _, env := range container.Env {
+				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+					rename(&env.ValueFrom.SecretKeyRef.Name)
+				}
+			}
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.SecretRef != nil {
+					rename(&envFrom.SecretRef.Name)
+				}
+			}
+		}
+	}
+}
+
+// BuiltInScanner is the name of the built-in vulnerability scanner, which
+// scans container images within the operator's process.
+const BuiltInScanner = "Starboard"
+
+// clusterReportNameLength is the number of hex digits of the SHA256 hash used
+// as the name of a v1alpha1.ClusterVulnerabilityReport. It's short enough to
+// be used as a label value, e.g. of Harbor scan jobs.
+const clusterReportNameLength = 40
+
+// GetClusterReportName returns the name of the v1alpha1.ClusterVulnerabilityReport
+// which caches scan results of the given scanner for the given repo digest. A
+// repo digest is not a valid name for a Kubernetes object, therefore the
+// truncated SHA256 hash of the scanner and the repo digest is used instead.
+// The repo digest and the scanner are also stored as the
+// starboard.AnnotationImageDigest annotation and the
+// starboard.LabelVulnerabilityReportScanner label, which are compared when
+// the report is read.
+func GetClusterReportName(scanner, digest string) string {
+	hash := sha256.New()
+	// Prefix the scanner with its length so that different pairs of scanner
+	// and repo digest never produce the same input.
+	_, _ = fmt.Fprintf(hash, "%d:%s%s", len(scanner), scanner, digest)
+	return hex.EncodeToString(hash.Sum(nil))[:clusterReportNameLength]
+}
+
 type ReportBuilder struct {
-	scheme     *runtime.Scheme
-	controller client.Object
-	container  string
-	hash       string
-	data       v1alpha1.VulnerabilityReportData
-	reportTTL  *time.Duration
+	scheme            *runtime.Scheme
+	controller        client.Object
+	container         string
+	hash              string
+	data              v1alpha1.VulnerabilityReportData
+	reportTTL         *time.Duration
+	clusterReportName string
+	scanner           string
+	additional        bool
 }
 
 func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
@@ -217,15 +341,42 @@ func (b *ReportBuilder) ReportTTL(ttl *time.Duration) *ReportBuilder {
 	return b
 }
 
+// ClusterReportName sets the name of the v1alpha1.ClusterVulnerabilityReport
+// that the report data was copied from.
+func (b *ReportBuilder) ClusterReportName(name string) *ReportBuilder {
+	b.clusterReportName = name
+	return b
+}
+
+// Scanner sets the name of the vulnerability scanner that generated the
+// report data.
+func (b *ReportBuilder) Scanner(name string) *ReportBuilder {
+	b.scanner = name
+	return b
+}
+
+// Additional tells the builder that the report is generated by an additional
+// vulnerability scanner, or that it's merged with reports of other scanners.
+// Such a report is labeled with starboard.LabelVulnerabilityReportAdditional
+// and its name is suffixed with the name of the scanner.
+func (b *ReportBuilder) Additional(additional bool) *ReportBuilder {
+	b.additional = additional
+	return b
+}
+
 func (b *ReportBuilder) reportName() string {
 	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
 	name := b.controller.GetName()
-	reportName := fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), name, b.container)
+	container := b.container
+	if b.additional {
+		container = fmt.Sprintf("%s-%s", container, strings.ToLower(b.scanner))
+	}
+	reportName := fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), name, container)
 	if len(validation.IsValidLabelValue(reportName)) == 0 {
 		return reportName
 	}
 
-	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+b.container))
+	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+container))
 }
 
 func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
@@ -237,6 +388,20 @@ func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
 		labels[starboard.LabelResourceSpecHash] = b.hash
 	}
 
+	if b.scanner != "" {
+		labels[starboard.LabelVulnerabilityReportScanner] = b.scanner
+	}
+
+	if b.additional {
+		labels[starboard.LabelVulnerabilityReportAdditional] = "true"
+	}
+
+	if spec, err := kube.GetPodSpec(b.controller); err == nil {
+		if containerType, ok := kube.GetContainerType(spec, b.container); ok {
+			labels[starboard.LabelContainerType] = string(containerType)
+		}
+	}
+
 	report := v1alpha1.VulnerabilityReport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      b.reportName(),
@@ -251,6 +416,12 @@ func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
 			v1alpha1.TTLReportAnnotation: b.reportTTL.String(),
 		}
 	}
+	if b.clusterReportName != "" {
+		if report.Annotations == nil {
+			report.Annotations = map[string]string{}
+		}
+		report.Annotations[v1alpha1.ClusterVulnerabilityReportNameAnnotation] = b.clusterReportName
+	}
 	err := kube.ObjectToObjectMeta(b.controller, &report.ObjectMeta)
 	if err != nil {
 		return v1alpha1.VulnerabilityReport{}, err
@@ -270,3 +441,128 @@ func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
 	report.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
 	return report, nil
 }
+
+type ClusterReportBuilder struct {
+	scanner   string
+	digest    string
+	data      v1alpha1.VulnerabilityReportData
+	reportTTL time.Duration
+}
+
+func NewClusterReportBuilder() *ClusterReportBuilder {
+	return &ClusterReportBuilder{}
+}
+
+func (b *ClusterReportBuilder) Scanner(name string) *ClusterReportBuilder {
+	b.scanner = name
+	return b
+}
+
+func (b *ClusterReportBuilder) ImageDigest(digest string) *ClusterReportBuilder {
+	b.digest = digest
+	return b
+}
+
+func (b *ClusterReportBuilder) Data(data v1alpha1.VulnerabilityReportData) *ClusterReportBuilder {
+	b.data = data
+	return b
+}
+
+func (b *ClusterReportBuilder) ReportTTL(ttl time.Duration) *ClusterReportBuilder {
+	b.reportTTL = ttl
+	return b
+}
+
+func (b *ClusterReportBuilder) Get() (v1alpha1.ClusterVulnerabilityReport, error) {
+	if b.digest == "" {
+		return v1alpha1.ClusterVulnerabilityReport{}, fmt.Errorf("image digest must be set")
+	}
+	if b.scanner == "" {
+		return v1alpha1.ClusterVulnerabilityReport{}, fmt.Errorf("scanner must be set")
+	}
+	data := *b.data.DeepCopy()
+	if data.Artifact.Digest == "" {
+		data.Artifact.Digest = b.digest[strings.LastIndex(b.digest, "@")+1:]
+	}
+	report := v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: GetClusterReportName(b.scanner, b.digest),
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy:            starboard.AppStarboard,
+				starboard.LabelVulnerabilityReportScanner: b.scanner,
+			},
+			Annotations: map[string]string{
+				starboard.AnnotationImageDigest: b.digest,
+			},
+		},
+		Report: data,
+	}
+	if b.reportTTL > 0 {
+		report.Annotations[v1alpha1.TTLReportAnnotation] = b.reportTTL.String()
+	}
+	return report, nil
+}
+
+// GetNodeScanJobName returns the name of the scan job of the given node.
+func GetNodeScanJobName(node *corev1.Node) string {
+	return "scan-nodevulnerabilityreport-" + kube.ComputeHash(node.Name)
+}
+
+// GetNodeReportName returns the name of the v1alpha1.ClusterVulnerabilityReport
+// generated for the node with the given name.
+func GetNodeReportName(nodeName string) string {
+	return "node-" + nodeName
+}
+
+// GetNodeInfoHash returns the hash of the system information reported by the
+// given node, such as versions of the OS image, kernel, container runtime,
+// and kubelet, which changes when the node is upgraded.
+func GetNodeInfoHash(node *corev1.Node) string {
+	return kube.ComputeHash(node.Status.NodeInfo)
+}
+
+// NodeReportBuilder builds the v1alpha1.ClusterVulnerabilityReport of a
+// cluster node, which is controlled by the corev1.Node.
+type NodeReportBuilder struct {
+	scheme     *runtime.Scheme
+	controller *corev1.Node
+	data       v1alpha1.VulnerabilityReportData
+}
+
+func NewNodeReportBuilder(scheme *runtime.Scheme) *NodeReportBuilder {
+	return &NodeReportBuilder{
+		scheme: scheme,
+	}
+}
+
+func (b *NodeReportBuilder) Controller(node *corev1.Node) *NodeReportBuilder {
+	b.controller = node
+	return b
+}
+
+func (b *NodeReportBuilder) Data(data v1alpha1.VulnerabilityReportData) *NodeReportBuilder {
+	b.data = data
+	return b
+}
+
+func (b *NodeReportBuilder) Get() (v1alpha1.ClusterVulnerabilityReport, error) {
+	report := v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: GetNodeReportName(b.controller.Name),
+			Labels: map[string]string{
+				starboard.LabelResourceKind:     string(kube.KindNode),
+				starboard.LabelResourceName:     b.controller.Name,
+				starboard.LabelResourceSpecHash: GetNodeInfoHash(b.controller),
+				starboard.LabelK8SAppManagedBy:  starboard.AppStarboard,
+			},
+		},
+		Report: b.data,
+	}
+	err := controllerutil.SetControllerReference(b.controller, &report, b.scheme)
+	if err != nil {
+		return v1alpha1.ClusterVulnerabilityReport{}, fmt.Errorf("setting controller reference: %w", err)
+	}
+	// See ReportBuilder.Get for why blockOwnerDeletion is set to false.
+	report.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
+	return report, nil
+}
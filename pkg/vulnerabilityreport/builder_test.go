@@ -7,6 +7,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/onsi/gomega"
@@ -63,6 +64,215 @@ func TestReportBuilder(t *testing.T) {
 	}))
 }
 
+func TestReportBuilder_ClusterReportName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-owner",
+				Namespace: "qa",
+			},
+		}).
+		Container("my-container").
+		PodSpecHash("xyz").
+		ClusterReportName("84bcb5cd46").
+		Data(v1alpha1.VulnerabilityReportData{}).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report.Annotations).To(gomega.Equal(map[string]string{
+		v1alpha1.ClusterVulnerabilityReportNameAnnotation: "84bcb5cd46",
+	}))
+}
+
+func TestReportBuilder_ContainerType(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-owner",
+				Namespace: "qa",
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{
+							{Name: "migrate", Image: "migrate:0.3.1"},
+						},
+						Containers: []corev1.Container{
+							{Name: "nginx", Image: "nginx:1.16"},
+						},
+					},
+				},
+			},
+		}).
+		Container("migrate").
+		PodSpecHash("xyz").
+		Data(v1alpha1.VulnerabilityReportData{}).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report.Name).To(gomega.Equal("replicaset-some-owner-migrate"))
+	g.Expect(report.Labels).To(gomega.HaveKeyWithValue(starboard.LabelContainerName, "migrate"))
+	g.Expect(report.Labels).To(gomega.HaveKeyWithValue(starboard.LabelContainerType, "InitContainer"))
+}
+
+func TestReportBuilder_Scanner(t *testing.T) {
+	owner := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicaSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-owner",
+			Namespace: "qa",
+		},
+	}
+
+	t.Run("Should label report of primary scanner", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+			Controller(owner).
+			Container("my-container").
+			Scanner("Trivy").
+			Data(v1alpha1.VulnerabilityReportData{}).
+			Get()
+
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(report.Name).To(gomega.Equal("replicaset-some-owner-my-container"))
+		g.Expect(report.Labels).To(gomega.HaveKeyWithValue(starboard.LabelVulnerabilityReportScanner, "Trivy"))
+		g.Expect(report.Labels).ToNot(gomega.HaveKey(starboard.LabelVulnerabilityReportAdditional))
+	})
+
+	t.Run("Should suffix name of additional report", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+			Controller(owner).
+			Container("my-container").
+			Scanner("Grype").
+			Additional(true).
+			Data(v1alpha1.VulnerabilityReportData{}).
+			Get()
+
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(report.Name).To(gomega.Equal("replicaset-some-owner-my-container-grype"))
+		g.Expect(report.Labels).To(gomega.HaveKeyWithValue(starboard.LabelVulnerabilityReportScanner, "Grype"))
+		g.Expect(report.Labels).To(gomega.HaveKeyWithValue(starboard.LabelVulnerabilityReportAdditional, "true"))
+	})
+}
+
+func TestClusterReportBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewClusterReportBuilder().
+		Scanner("Trivy").
+		ImageDigest("nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514").
+		ReportTTL(72 * time.Hour).
+		Data(v1alpha1.VulnerabilityReportData{
+			Artifact: v1alpha1.Artifact{
+				Repository: "library/nginx",
+				Tag:        "1.16",
+			},
+		}).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report).To(gomega.Equal(v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "1e61c007d319ea8e474aea8cfc9b7b032d2dcb5d",
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy:            starboard.AppStarboard,
+				starboard.LabelVulnerabilityReportScanner: "Trivy",
+			},
+			Annotations: map[string]string{
+				starboard.AnnotationImageDigest: "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514",
+				v1alpha1.TTLReportAnnotation:    "72h0m0s",
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Artifact: v1alpha1.Artifact{
+				Repository: "library/nginx",
+				Tag:        "1.16",
+				Digest:     "sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514",
+			},
+		},
+	}))
+
+	_, err = vulnerabilityreport.NewClusterReportBuilder().Get()
+	g.Expect(err).To(gomega.MatchError("image digest must be set"))
+
+	_, err = vulnerabilityreport.NewClusterReportBuilder().ImageDigest("nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514").Get()
+	g.Expect(err).To(gomega.MatchError("scanner must be set"))
+}
+
+func TestGetClusterReportName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	name := vulnerabilityreport.GetClusterReportName("Trivy", "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514")
+	g.Expect(name).To(gomega.HaveLen(40))
+	g.Expect(vulnerabilityreport.GetClusterReportName("Grype", "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514")).ToNot(gomega.Equal(name))
+	g.Expect(vulnerabilityreport.GetClusterReportName("Trivy", "nginx@sha256:0000000000000000000000000000000000000000000000000000000000000000")).ToNot(gomega.Equal(name))
+	g.Expect(vulnerabilityreport.GetClusterReportName("Triv", "ynginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514")).ToNot(gomega.Equal(name))
+}
+
+func TestNodeReportBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kind-worker",
+			UID:  "ff1ad3b9-3a26-4a2a-a5ca-d8b5d6f0cc6b",
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{
+				OSImage:        "Ubuntu 21.10",
+				KubeletVersion: "v1.21.1",
+			},
+		},
+	}
+	report, err := vulnerabilityreport.NewNodeReportBuilder(scheme.Scheme).
+		Controller(node).
+		Data(v1alpha1.VulnerabilityReportData{
+			Artifact: v1alpha1.Artifact{
+				Repository: "Ubuntu 21.10",
+			},
+		}).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report).To(gomega.Equal(v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-kind-worker",
+			Labels: map[string]string{
+				starboard.LabelResourceKind:     "Node",
+				starboard.LabelResourceName:     "kind-worker",
+				starboard.LabelResourceSpecHash: vulnerabilityreport.GetNodeInfoHash(node),
+				starboard.LabelK8SAppManagedBy:  starboard.AppStarboard,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "v1",
+					Kind:               "Node",
+					Name:               "kind-worker",
+					UID:                "ff1ad3b9-3a26-4a2a-a5ca-d8b5d6f0cc6b",
+					Controller:         pointer.BoolPtr(true),
+					BlockOwnerDeletion: pointer.BoolPtr(false),
+				},
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Artifact: v1alpha1.Artifact{
+				Repository: "Ubuntu 21.10",
+			},
+		},
+	}))
+}
+
 func TestScanJobBuilder(t *testing.T) {
 	t.Run("Should get scan job with labels", func(t *testing.T) {
 		g := gomega.NewGomegaWithT(t)
@@ -212,6 +422,166 @@ func TestScanJobBuilder(t *testing.T) {
 			},
 		}))
 	})
+
+	t.Run("Should get scan job scanning image digests", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		job, _, err := vulnerabilityreport.NewScanJobBuilder().
+			WithPlugin(&imagesPlugin{}).
+			WithPluginContext(starboard.NewPluginContext().
+				WithName("test-plugin").
+				WithNamespace("starboard-ns").
+				WithServiceAccountName("starboard-sa").
+				Get()).
+			WithTimeout(3 * time.Second).
+			WithObject(&appsv1.ReplicaSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ReplicaSet",
+					APIVersion: "apps/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nginx-6799fc88d8",
+					Namespace: "prod-ns",
+				},
+				Spec: appsv1.ReplicaSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:1.16",
+								},
+								{
+									Name:  "sidecar",
+									Image: "sidecar:latest",
+								},
+							},
+						},
+					},
+					Selector: &metav1.LabelSelector{},
+				},
+			}).
+			WithContainerImageDigests(kube.ContainerImages{
+				"nginx": "nginx@sha256:2963fc49cc50883ba9af25f977a9997ff9af06b45c12d968b7985dc1e9254e4b",
+			}).
+			WithScanImageDigests(true).
+			Get()
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(job.Spec.Template.Spec.Containers).To(gomega.Equal([]corev1.Container{
+			{Name: "nginx", Image: "nginx@sha256:2963fc49cc50883ba9af25f977a9997ff9af06b45c12d968b7985dc1e9254e4b"},
+			{Name: "sidecar", Image: "sidecar:latest"},
+		}))
+		g.Expect(job.Labels[starboard.LabelResourceSpecHash]).To(gomega.Equal("77b89d56b"),
+			"pod spec hash must be computed from images of the workload")
+		g.Expect(job.Annotations).To(gomega.Equal(map[string]string{
+			starboard.AnnotationContainerImages:       `{"nginx":"nginx:1.16","sidecar":"sidecar:latest"}`,
+			starboard.AnnotationContainerImageDigests: `{"nginx":"nginx@sha256:2963fc49cc50883ba9af25f977a9997ff9af06b45c12d968b7985dc1e9254e4b"}`,
+		}))
+	})
+
+	t.Run("Should get scan job of additional scanner", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		job, secrets, err := vulnerabilityreport.NewScanJobBuilder().
+			WithPlugin(&secretsPlugin{}).
+			WithPluginContext(starboard.NewPluginContext().
+				WithName("Grype").
+				WithNamespace("starboard-ns").
+				WithServiceAccountName("starboard-sa").
+				Get()).
+			WithTimeout(3 * time.Second).
+			WithObject(&appsv1.ReplicaSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ReplicaSet",
+					APIVersion: "apps/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nginx-6799fc88d8",
+					Namespace: "prod-ns",
+				},
+				Spec: appsv1.ReplicaSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:1.16",
+								},
+							},
+						},
+					},
+					Selector: &metav1.LabelSelector{},
+				},
+			}).
+			WithAdditionalScanner(true).
+			Get()
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(job.Name).To(gomega.Equal("scan-vulnerabilityreport-64d65c457-grype"))
+		g.Expect(job.Labels).To(gomega.HaveKeyWithValue(starboard.LabelVulnerabilityReportScanner, "Grype"))
+		g.Expect(secrets).To(gomega.HaveLen(1))
+		g.Expect(secrets[0].Name).To(gomega.Equal("scan-vulnerabilityreport-64d65c457-regcred-grype"))
+		g.Expect(job.Spec.Template.Spec.Containers[0].Env[0].ValueFrom.SecretKeyRef.Name).
+			To(gomega.Equal("scan-vulnerabilityreport-64d65c457-regcred-grype"))
+		g.Expect(job.Spec.Template.Spec.Containers[0].EnvFrom[0].SecretRef.Name).
+			To(gomega.Equal("scan-vulnerabilityreport-64d65c457-regcred-grype"))
+		g.Expect(job.Spec.Template.Spec.Volumes[0].Secret.SecretName).
+			To(gomega.Equal("scan-vulnerabilityreport-64d65c457-regcred-grype"))
+	})
+}
+
+// secretsPlugin returns a scan job spec that refers to the secret with
+// registry credentials in all possible ways.
+type secretsPlugin struct {
+	testPlugin
+}
+
+func (p *secretsPlugin) GetScanJobSpec(_ starboard.PluginContext, obj client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	secretName := vulnerabilityreport.RegistryCredentialsSecretName(obj)
+	return corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "scanner",
+				Env: []corev1.EnvVar{
+					{
+						Name: "PASSWORD",
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+								Key:                  "nginx.password",
+							},
+						},
+					},
+				},
+				EnvFrom: []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}}},
+				},
+			},
+		},
+		Volumes: []corev1.Volume{
+			{
+				Name:         "credentials",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}},
+			},
+		},
+	}, []*corev1.Secret{
+		{ObjectMeta: metav1.ObjectMeta{Name: secretName}},
+	}, nil
+}
+
+// imagesPlugin returns a scan job spec with containers named after and
+// running images of containers of the scanned workload.
+type imagesPlugin struct {
+	testPlugin
+}
+
+func (p *imagesPlugin) GetScanJobSpec(_ starboard.PluginContext, obj client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	spec, err := kube.GetPodSpec(obj)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	var containers []corev1.Container
+	for _, c := range spec.Containers {
+		containers = append(containers, corev1.Container{Name: c.Name, Image: c.Image})
+	}
+	return corev1.PodSpec{Containers: containers}, nil, nil
 }
 
 type testPlugin struct {
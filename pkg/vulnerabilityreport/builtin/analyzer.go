@@ -0,0 +1,431 @@
+package builtin
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// OSV ecosystems of application packages found in language lockfiles.
+const (
+	EcosystemNpm       = "npm"
+	EcosystemPyPI      = "PyPI"
+	EcosystemRubyGems  = "RubyGems"
+	EcosystemCratesIO  = "crates.io"
+	EcosystemPackagist = "Packagist"
+)
+
+// maxAnalyzedFileSize is the maximum size of a file read from the image
+// filesystem. Larger package databases and lockfiles are skipped.
+const maxAnalyzedFileSize = 64 << 20
+
+// OS represents the operating system of a container image.
+type OS struct {
+	// ID is the lower-case identifier of the distribution, e.g. debian.
+	ID string
+	// VersionID is the version of the distribution, e.g. 11 or 3.16.2.
+	VersionID string
+}
+
+// Package represents a package installed in a container image.
+type Package struct {
+	Name    string
+	Version string
+	// SrcName is the name of the source package, which is used by Debian
+	// and Alpine security trackers. Defaults to Name.
+	SrcName string
+	// SrcVersion is the version of the source package. Defaults to Version.
+	SrcVersion string
+	// Ecosystem is the OSV ecosystem of the package, e.g. Debian:11 or npm.
+	Ecosystem string
+	// FilePath is the path of the package database or lockfile that lists
+	// the package.
+	FilePath string
+}
+
+// Inventory represents packages found in a container image.
+type Inventory struct {
+	OS       *OS
+	Packages []Package
+}
+
+// fileAnalyzer parses a file of the image filesystem and returns packages
+// listed in it. The ecosystem of OS packages is set once the OS is detected.
+type fileAnalyzer func(filePath string, content []byte) ([]Package, error)
+
+var lockfileAnalyzers = map[string]fileAnalyzer{
+	"package-lock.json": parseNpmLockfile,
+	"requirements.txt":  parseRequirementsFile,
+	"Gemfile.lock":      parseGemfileLock,
+	"Cargo.lock":        parseCargoLock,
+	"composer.lock":     parseComposerLock,
+}
+
+// Analyze reads the flattened filesystem of a container image from the given
+// tar stream and returns the inventory of OS packages and application
+// dependencies declared in language lockfiles.
+func Analyze(r io.Reader) (Inventory, error) {
+	var inventory Inventory
+	var osPackages []Package
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Inventory{}, fmt.Errorf("reading image filesystem: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg || header.Size > maxAnalyzedFileSize {
+			continue
+		}
+		filePath := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+
+		var analyze fileAnalyzer
+		switch {
+		case filePath == "etc/os-release" || filePath == "usr/lib/os-release":
+			content, err := io.ReadAll(tr)
+			if err != nil {
+				return Inventory{}, err
+			}
+			// etc/os-release takes precedence over usr/lib/os-release.
+			if inventory.OS == nil || filePath == "etc/os-release" {
+				inventory.OS = parseOSRelease(content)
+			}
+			continue
+		case filePath == "var/lib/dpkg/status" || strings.HasPrefix(filePath, "var/lib/dpkg/status.d/"):
+			analyze = parseDpkgStatus
+		case filePath == "lib/apk/db/installed":
+			analyze = parseApkInstalled
+		default:
+			analyze = lockfileAnalyzers[path.Base(filePath)]
+		}
+		if analyze == nil {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return Inventory{}, err
+		}
+		packages, err := analyze(filePath, content)
+		if err != nil {
+			// Malformed files, e.g. lockfiles of test fixtures shipped with
+			// a library, must not prevent scanning the rest of the image.
+			continue
+		}
+		for _, pkg := range packages {
+			if pkg.Ecosystem == "" {
+				osPackages = append(osPackages, pkg)
+				continue
+			}
+			inventory.Packages = append(inventory.Packages, pkg)
+		}
+	}
+
+	if ecosystem := inventory.OS.ecosystem(); ecosystem != "" {
+		for _, pkg := range osPackages {
+			pkg.Ecosystem = ecosystem
+			inventory.Packages = append(inventory.Packages, pkg)
+		}
+	}
+	return inventory, nil
+}
+
+// ecosystem returns the OSV ecosystem of OS packages, or an empty string if
+// the distribution is not supported.
+func (o *OS) ecosystem() string {
+	if o == nil || o.VersionID == "" {
+		return ""
+	}
+	switch o.ID {
+	case "debian":
+		return "Debian:" + strings.SplitN(o.VersionID, ".", 2)[0]
+	case "ubuntu":
+		return "Ubuntu:" + o.VersionID
+	case "alpine":
+		parts := strings.SplitN(o.VersionID, ".", 3)
+		if len(parts) < 2 {
+			return ""
+		}
+		return "Alpine:v" + parts[0] + "." + parts[1]
+	}
+	return ""
+}
+
+func parseOSRelease(content []byte) *OS {
+	os := &OS{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, ok := cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch key {
+		case "ID":
+			os.ID = value
+		case "VERSION_ID":
+			os.VersionID = value
+		}
+	}
+	return os
+}
+
+// parseDpkgStatus parses the dpkg status database. Distroless images list
+// packages in separate files in the var/lib/dpkg/status.d directory without
+// the Status field.
+func parseDpkgStatus(filePath string, content []byte) ([]Package, error) {
+	var packages []Package
+	for _, paragraph := range strings.Split(string(content), "\n\n") {
+		fields := map[string]string{}
+		for _, line := range strings.Split(paragraph, "\n") {
+			if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+				continue
+			}
+			if key, value, ok := cut(line, ":"); ok {
+				fields[key] = strings.TrimSpace(value)
+			}
+		}
+		if fields["Package"] == "" || fields["Version"] == "" {
+			continue
+		}
+		if status, ok := fields["Status"]; ok && !strings.HasSuffix(status, " installed") {
+			continue
+		}
+		pkg := Package{
+			Name:       fields["Package"],
+			Version:    fields["Version"],
+			SrcName:    fields["Package"],
+			SrcVersion: fields["Version"],
+			FilePath:   filePath,
+		}
+		// The Source field might include the version of the source package,
+		// e.g. Source: glibc (2.31-13+deb11u3).
+		if source := fields["Source"]; source != "" {
+			name, sourceVersion, _ := cut(source, " ")
+			pkg.SrcName = name
+			if sourceVersion = strings.Trim(sourceVersion, "()"); sourceVersion != "" {
+				pkg.SrcVersion = sourceVersion
+			}
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// parseApkInstalled parses the database of installed Alpine packages.
+func parseApkInstalled(filePath string, content []byte) ([]Package, error) {
+	var packages []Package
+	for _, paragraph := range strings.Split(string(content), "\n\n") {
+		var pkg = Package{FilePath: filePath}
+		for _, line := range strings.Split(paragraph, "\n") {
+			if len(line) < 2 || line[1] != ':' {
+				continue
+			}
+			switch line[0] {
+			case 'P':
+				pkg.Name = line[2:]
+			case 'V':
+				pkg.Version = line[2:]
+			case 'o':
+				pkg.SrcName = line[2:]
+			}
+		}
+		if pkg.Name == "" || pkg.Version == "" {
+			continue
+		}
+		if pkg.SrcName == "" {
+			pkg.SrcName = pkg.Name
+		}
+		pkg.SrcVersion = pkg.Version
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// parseNpmLockfile parses package-lock.json files in both the lockfileVersion
+// 1 format with nested dependencies and the lockfileVersion 2 or 3 format
+// with the flat packages map.
+func parseNpmLockfile(filePath string, content []byte) ([]Package, error) {
+	type dependency struct {
+		Version      string                     `json:"version"`
+		Dependencies map[string]json.RawMessage `json:"dependencies"`
+	}
+	var lockfile struct {
+		Packages map[string]struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			Link    bool   `json:"link"`
+		} `json:"packages"`
+		Dependencies map[string]json.RawMessage `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &lockfile); err != nil {
+		return nil, err
+	}
+
+	seen := map[Package]bool{}
+	var packages []Package
+	add := func(name, version string) {
+		pkg := Package{Name: name, Version: version, Ecosystem: EcosystemNpm, FilePath: filePath}
+		if name == "" || version == "" || seen[pkg] {
+			return
+		}
+		seen[pkg] = true
+		packages = append(packages, pkg)
+	}
+
+	if len(lockfile.Packages) > 0 {
+		for key, p := range lockfile.Packages {
+			if key == "" || p.Link {
+				continue
+			}
+			name := p.Name
+			if name == "" {
+				name = key[strings.LastIndex(key, "node_modules/")+len("node_modules/"):]
+			}
+			add(name, p.Version)
+		}
+		return packages, nil
+	}
+
+	var walk func(deps map[string]json.RawMessage) error
+	walk = func(deps map[string]json.RawMessage) error {
+		for name, raw := range deps {
+			var dep dependency
+			if err := json.Unmarshal(raw, &dep); err != nil {
+				return err
+			}
+			add(name, dep.Version)
+			if err := walk(dep.Dependencies); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return packages, walk(lockfile.Dependencies)
+}
+
+var requirementRegexp = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*===?\s*([^\s;#,]+)`)
+
+// parseRequirementsFile parses pip requirements files. Only requirements
+// pinned to an exact version are reported.
+func parseRequirementsFile(filePath string, content []byte) ([]Package, error) {
+	var packages []Package
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		matches := requirementRegexp.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if matches == nil {
+			continue
+		}
+		packages = append(packages, Package{
+			Name:      matches[1],
+			Version:   matches[3],
+			Ecosystem: EcosystemPyPI,
+			FilePath:  filePath,
+		})
+	}
+	return packages, scanner.Err()
+}
+
+var gemSpecRegexp = regexp.MustCompile(`^    ([^\s(]+) \(([^)-]+)(-[^)]+)?\)$`)
+
+// parseGemfileLock parses Bundler lockfiles. Gems are listed with four
+// spaces of indentation in the specs sections.
+func parseGemfileLock(filePath string, content []byte) ([]Package, error) {
+	var packages []Package
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		matches := gemSpecRegexp.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+		packages = append(packages, Package{
+			Name:      matches[1],
+			Version:   matches[2],
+			Ecosystem: EcosystemRubyGems,
+			FilePath:  filePath,
+		})
+	}
+	return packages, scanner.Err()
+}
+
+// parseCargoLock parses [[package]] tables of Cargo lockfiles.
+func parseCargoLock(filePath string, content []byte) ([]Package, error) {
+	var packages []Package
+	var pkg *Package
+	flush := func() {
+		if pkg != nil && pkg.Name != "" && pkg.Version != "" {
+			packages = append(packages, *pkg)
+		}
+		pkg = nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			flush()
+			if line == "[[package]]" {
+				pkg = &Package{Ecosystem: EcosystemCratesIO, FilePath: filePath}
+			}
+			continue
+		}
+		if pkg == nil {
+			continue
+		}
+		key, value, ok := cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(key) {
+		case "name":
+			pkg.Name = value
+		case "version":
+			pkg.Version = value
+		}
+	}
+	flush()
+	return packages, scanner.Err()
+}
+
+// parseComposerLock parses PHP Composer lockfiles.
+func parseComposerLock(filePath string, content []byte) ([]Package, error) {
+	type composerPackage struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	var lockfile struct {
+		Packages    []composerPackage `json:"packages"`
+		PackagesDev []composerPackage `json:"packages-dev"`
+	}
+	if err := json.Unmarshal(content, &lockfile); err != nil {
+		return nil, err
+	}
+	var packages []Package
+	for _, p := range append(lockfile.Packages, lockfile.PackagesDev...) {
+		packages = append(packages, Package{
+			Name:      p.Name,
+			Version:   strings.TrimPrefix(p.Version, "v"),
+			Ecosystem: EcosystemPackagist,
+			FilePath:  filePath,
+		})
+	}
+	return packages, nil
+}
+
+// cut slices s around the first instance of sep. It is equivalent to
+// strings.Cut, which is not available in Go 1.17.
+func cut(s, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,241 @@
+package builtin_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport/builtin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dpkgStatus = `Package: libc6
+Status: install ok installed
+Architecture: amd64
+Source: glibc
+Version: 2.31-13+deb11u3
+Description: GNU C Library: Shared libraries
+ Contains the standard libraries that are used by nearly all programs on
+ the system.
+
+Package: libssl1.1
+Status: install ok installed
+Source: openssl (1.1.1n-0+deb11u3)
+Version: 1.1.1n-0+deb11u3+b1
+
+Package: removed
+Status: deinstall ok config-files
+Version: 1.0-1
+`
+
+const apkInstalled = `C:Q1
+P:zlib
+V:1.2.12-r1
+o:zlib
+
+P:libcrypto1.1
+V:1.1.1q-r0
+o:openssl
+`
+
+const npmLockfileV1 = `{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "express": {
+      "version": "4.17.1",
+      "dependencies": {
+        "debug": {"version": "2.6.9"}
+      }
+    },
+    "lodash": {"version": "4.17.15"}
+  }
+}`
+
+const npmLockfileV2 = `{
+  "lockfileVersion": 2,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/lodash": {"version": "4.17.21"},
+    "node_modules/@babel/core": {"version": "7.18.0"},
+    "node_modules/local": {"link": true}
+  }
+}`
+
+const requirementsFile = `# comment
+requests==2.25.1
+Django[argon2] == 3.2.4 ; python_version >= "3.6"
+flask>=2.0
+`
+
+const gemfileLock = `GEM
+  remote: https://rubygems.org/
+  specs:
+    nokogiri (1.13.6-x86_64-linux)
+      racc (~> 1.4)
+    racc (1.6.0)
+
+PLATFORMS
+  x86_64-linux
+`
+
+const cargoLock = `version = 3
+
+[[package]]
+name = "regex"
+version = "1.5.4"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+dependencies = [
+ "memchr",
+]
+
+[[package]]
+name = "memchr"
+version = "2.4.1"
+
+[metadata]
+name = "ignored"
+`
+
+const composerLock = `{
+  "packages": [{"name": "guzzlehttp/guzzle", "version": "7.4.4"}],
+  "packages-dev": [{"name": "phpunit/phpunit", "version": "v9.5.20"}]
+}`
+
+func newTar(t *testing.T, files map[string]string) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(content)),
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Run("Should find Debian packages", func(t *testing.T) {
+		inventory, err := builtin.Analyze(newTar(t, map[string]string{
+			"etc/os-release":      "ID=debian\nVERSION_ID=\"11\"\n",
+			"var/lib/dpkg/status": dpkgStatus,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, &builtin.OS{ID: "debian", VersionID: "11"}, inventory.OS)
+		assert.ElementsMatch(t, []builtin.Package{
+			{
+				Name:       "libc6",
+				Version:    "2.31-13+deb11u3",
+				SrcName:    "glibc",
+				SrcVersion: "2.31-13+deb11u3",
+				Ecosystem:  "Debian:11",
+				FilePath:   "var/lib/dpkg/status",
+			},
+			{
+				Name:       "libssl1.1",
+				Version:    "1.1.1n-0+deb11u3+b1",
+				SrcName:    "openssl",
+				SrcVersion: "1.1.1n-0+deb11u3",
+				Ecosystem:  "Debian:11",
+				FilePath:   "var/lib/dpkg/status",
+			},
+		}, inventory.Packages)
+	})
+
+	t.Run("Should find distroless packages", func(t *testing.T) {
+		inventory, err := builtin.Analyze(newTar(t, map[string]string{
+			"etc/os-release":               "ID=\"debian\"\nVERSION_ID=\"11\"\n",
+			"var/lib/dpkg/status.d/base":   "Package: base-files\nVersion: 11.1+deb11u3\n",
+			"var/lib/dpkg/status.d/libc6":  "Package: libc6\nSource: glibc\nVersion: 2.31-13+deb11u3\n",
+			"var/lib/dpkg/status.d/ignore": "Description: no package\n",
+		}))
+		require.NoError(t, err)
+		assert.Len(t, inventory.Packages, 2)
+	})
+
+	t.Run("Should find Alpine packages", func(t *testing.T) {
+		inventory, err := builtin.Analyze(newTar(t, map[string]string{
+			"etc/os-release":       "ID=alpine\nVERSION_ID=3.16.2\n",
+			"lib/apk/db/installed": apkInstalled,
+		}))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []builtin.Package{
+			{
+				Name:       "zlib",
+				Version:    "1.2.12-r1",
+				SrcName:    "zlib",
+				SrcVersion: "1.2.12-r1",
+				Ecosystem:  "Alpine:v3.16",
+				FilePath:   "lib/apk/db/installed",
+			},
+			{
+				Name:       "libcrypto1.1",
+				Version:    "1.1.1q-r0",
+				SrcName:    "openssl",
+				SrcVersion: "1.1.1q-r0",
+				Ecosystem:  "Alpine:v3.16",
+				FilePath:   "lib/apk/db/installed",
+			},
+		}, inventory.Packages)
+	})
+
+	t.Run("Should skip OS packages of unsupported distribution", func(t *testing.T) {
+		inventory, err := builtin.Analyze(newTar(t, map[string]string{
+			"etc/os-release":      "ID=gentoo\nVERSION_ID=2.8\n",
+			"var/lib/dpkg/status": dpkgStatus,
+		}))
+		require.NoError(t, err)
+		assert.Empty(t, inventory.Packages)
+	})
+
+	t.Run("Should find application packages in lockfiles", func(t *testing.T) {
+		inventory, err := builtin.Analyze(newTar(t, map[string]string{
+			"app/package-lock.json":      npmLockfileV1,
+			"srv/web/package-lock.json":  npmLockfileV2,
+			"app/requirements.txt":       requirementsFile,
+			"app/Gemfile.lock":           gemfileLock,
+			"app/Cargo.lock":             cargoLock,
+			"var/www/html/composer.lock": composerLock,
+		}))
+		require.NoError(t, err)
+		assert.Nil(t, inventory.OS)
+
+		type nameVersion struct {
+			ecosystem, name, version string
+		}
+		var actual []nameVersion
+		for _, pkg := range inventory.Packages {
+			actual = append(actual, nameVersion{pkg.Ecosystem, pkg.Name, pkg.Version})
+		}
+		assert.ElementsMatch(t, []nameVersion{
+			{builtin.EcosystemNpm, "express", "4.17.1"},
+			{builtin.EcosystemNpm, "debug", "2.6.9"},
+			{builtin.EcosystemNpm, "lodash", "4.17.15"},
+			{builtin.EcosystemNpm, "lodash", "4.17.21"},
+			{builtin.EcosystemNpm, "@babel/core", "7.18.0"},
+			{builtin.EcosystemPyPI, "requests", "2.25.1"},
+			{builtin.EcosystemPyPI, "Django", "3.2.4"},
+			{builtin.EcosystemRubyGems, "nokogiri", "1.13.6"},
+			{builtin.EcosystemRubyGems, "racc", "1.6.0"},
+			{builtin.EcosystemCratesIO, "regex", "1.5.4"},
+			{builtin.EcosystemCratesIO, "memchr", "2.4.1"},
+			{builtin.EcosystemPackagist, "guzzlehttp/guzzle", "7.4.4"},
+			{builtin.EcosystemPackagist, "phpunit/phpunit", "9.5.20"},
+		}, actual)
+	})
+
+	t.Run("Should skip malformed lockfile", func(t *testing.T) {
+		inventory, err := builtin.Analyze(newTar(t, map[string]string{
+			"app/package-lock.json": "{",
+			"app/Cargo.lock":        cargoLock,
+		}))
+		require.NoError(t, err)
+		assert.Len(t, inventory.Packages, 2)
+	})
+}
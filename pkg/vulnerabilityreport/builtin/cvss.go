@@ -0,0 +1,87 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+var cvssV3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// cvssV3BaseScore computes the base score of the given CVSS v3.x vector
+// string, e.g. CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H.
+func cvssV3BaseScore(vector string) (float64, error) {
+	parts := strings.Split(vector, "/")
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "CVSS:3") {
+		return 0, fmt.Errorf("unsupported CVSS vector: %s", vector)
+	}
+	metrics := map[string]string{}
+	for _, part := range parts[1:] {
+		key, value, ok := cut(part, ":")
+		if !ok {
+			return 0, fmt.Errorf("invalid CVSS metric: %s", part)
+		}
+		metrics[key] = value
+	}
+
+	scopeChanged := metrics["S"] == "C"
+	weights := map[string]float64{}
+	for metric, values := range cvssV3Weights {
+		weight, ok := values[metrics[metric]]
+		if !ok {
+			return 0, fmt.Errorf("invalid CVSS vector: %s: missing or invalid %s", vector, metric)
+		}
+		weights[metric] = weight
+	}
+	var privilegesRequired float64
+	switch metrics["PR"] {
+	case "N":
+		privilegesRequired = 0.85
+	case "L":
+		privilegesRequired = 0.62
+		if scopeChanged {
+			privilegesRequired = 0.68
+		}
+	case "H":
+		privilegesRequired = 0.27
+		if scopeChanged {
+			privilegesRequired = 0.5
+		}
+	default:
+		return 0, fmt.Errorf("invalid CVSS vector: %s: missing or invalid PR", vector)
+	}
+
+	iss := 1 - (1-weights["C"])*(1-weights["I"])*(1-weights["A"])
+	var impact float64
+	if scopeChanged {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	} else {
+		impact = 6.42 * iss
+	}
+	exploitability := 8.22 * weights["AV"] * weights["AC"] * privilegesRequired * weights["UI"]
+
+	if impact <= 0 {
+		return 0, nil
+	}
+	if scopeChanged {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), nil
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), nil
+}
+
+// roundUp returns the smallest number, specified to one decimal place, that
+// is equal to or higher than the given number as defined by CVSS v3.1.
+func roundUp(v float64) float64 {
+	i := int(math.Round(v * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+	return (math.Floor(float64(i)/10000) + 1) / 10
+}
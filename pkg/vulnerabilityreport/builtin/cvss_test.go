@@ -0,0 +1,33 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCVSSV3BaseScore(t *testing.T) {
+	testCases := []struct {
+		vector   string
+		expected float64
+	}{
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", expected: 9.8},
+		{vector: "CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:H/A:H", expected: 7.8},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", expected: 6.1},
+		{vector: "CVSS:3.0/AV:N/AC:H/PR:N/UI:N/S:U/C:N/I:N/A:L", expected: 3.7},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", expected: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.vector, func(t *testing.T) {
+			score, err := cvssV3BaseScore(tc.vector)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, score)
+		})
+	}
+
+	t.Run("Should return error for CVSS v2 vector", func(t *testing.T) {
+		_, err := cvssV3BaseScore("AV:N/AC:L/Au:N/C:P/I:P/A:P")
+		require.EqualError(t, err, "unsupported CVSS vector: AV:N/AC:L/Au:N/C:P/I:P/A:P")
+	})
+}
@@ -0,0 +1,299 @@
+package builtin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// Entry represents a vulnerability in the Open Source Vulnerability (OSV)
+// format. See https://ossf.github.io/osv-schema/ for the complete schema.
+type Entry struct {
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+	Details  string   `json:"details,omitempty"`
+	Severity []struct {
+		Type  string `json:"type"`
+		Score string `json:"score"`
+	} `json:"severity,omitempty"`
+	Affected   []Affected `json:"affected"`
+	References []struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	} `json:"references,omitempty"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity,omitempty"`
+	} `json:"database_specific,omitempty"`
+}
+
+// Affected represents versions of a package affected by a vulnerability.
+type Affected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges   []Range  `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Range represents a range of affected versions as a list of events that
+// introduce or fix a vulnerability.
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event represents a version that introduced, fixed, or was last affected by
+// a vulnerability.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// DB is an in-memory index of OSV entries by ecosystem and package name.
+type DB struct {
+	entries map[string]map[string][]*Entry
+	size    int
+}
+
+// LoadDB loads OSV entries from JSON files in the given directory and its
+// subdirectories. Each file holds a single entry, which is the layout of
+// OSV data dumps published per ecosystem, e.g. Debian/all.zip.
+func LoadDB(dir string) (*DB, error) {
+	db := NewDB()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var entry Entry
+		if err := json.Unmarshal(content, &entry); err != nil {
+			return fmt.Errorf("parsing OSV entry: %s: %w", path, err)
+		}
+		db.Add(&entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// NewDB constructs a new empty DB.
+func NewDB() *DB {
+	return &DB{entries: map[string]map[string][]*Entry{}}
+}
+
+// Add indexes the given entry by ecosystems and names of affected packages.
+func (db *DB) Add(entry *Entry) {
+	indexed := map[string]bool{}
+	for _, affected := range entry.Affected {
+		ecosystem, name := affected.Package.Ecosystem, affected.Package.Name
+		key := ecosystem + "/" + name
+		if indexed[key] {
+			continue
+		}
+		indexed[key] = true
+		if db.entries[ecosystem] == nil {
+			db.entries[ecosystem] = map[string][]*Entry{}
+		}
+		db.entries[ecosystem][name] = append(db.entries[ecosystem][name], entry)
+	}
+	db.size++
+}
+
+// Size returns the number of entries in the DB.
+func (db *DB) Size() int {
+	return db.size
+}
+
+// Match returns vulnerabilities affecting the given packages.
+func (db *DB) Match(packages []Package) []v1alpha1.Vulnerability {
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
+	for _, pkg := range packages {
+		name, version := pkg.Name, pkg.Version
+		if pkg.SrcName != "" {
+			name, version = pkg.SrcName, pkg.SrcVersion
+		}
+		for _, entry := range db.entries[pkg.Ecosystem][name] {
+			for _, affected := range entry.Affected {
+				if affected.Package.Ecosystem != pkg.Ecosystem || affected.Package.Name != name {
+					continue
+				}
+				vulnerable, fixedVersion := affected.matches(pkg.Ecosystem, version)
+				if !vulnerable {
+					continue
+				}
+				vulnerabilities = append(vulnerabilities, entry.toVulnerability(pkg, fixedVersion))
+				break
+			}
+		}
+	}
+	return vulnerabilities
+}
+
+// matches returns true if the given version is affected. The second return
+// value is the lowest version that fixes the vulnerability, if known.
+func (a Affected) matches(ecosystem, version string) (bool, string) {
+	for _, v := range a.Versions {
+		if v == version {
+			return true, a.fixedVersion(ecosystem, version)
+		}
+	}
+	for _, r := range a.Ranges {
+		if r.Type != "ECOSYSTEM" && r.Type != "SEMVER" {
+			continue
+		}
+		// Events are sorted by version within a range. The version is
+		// affected if the last event before it introduced the vulnerability.
+		type event struct {
+			version      string
+			introduced   bool
+			lastAffected bool
+		}
+		var events []event
+		for _, e := range r.Events {
+			switch {
+			case e.Introduced != "":
+				events = append(events, event{version: e.Introduced, introduced: true})
+			case e.Fixed != "":
+				events = append(events, event{version: e.Fixed})
+			case e.LastAffected != "":
+				events = append(events, event{version: e.LastAffected, lastAffected: true})
+			}
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			if events[i].version == "0" || events[j].version == "0" {
+				return events[i].version == "0" && events[j].version != "0"
+			}
+			return compareVersions(ecosystem, events[i].version, events[j].version) < 0
+		})
+		affected := false
+		for _, e := range events {
+			if e.version != "0" {
+				c := compareVersions(ecosystem, version, e.version)
+				if c < 0 || (c == 0 && e.lastAffected) {
+					break
+				}
+			}
+			affected = e.introduced
+		}
+		if affected {
+			return true, a.fixedVersion(ecosystem, version)
+		}
+	}
+	return false, ""
+}
+
+func (a Affected) fixedVersion(ecosystem, version string) string {
+	fixed := ""
+	for _, r := range a.Ranges {
+		for _, e := range r.Events {
+			if e.Fixed == "" || compareVersions(ecosystem, e.Fixed, version) <= 0 {
+				continue
+			}
+			if fixed == "" || compareVersions(ecosystem, e.Fixed, fixed) < 0 {
+				fixed = e.Fixed
+			}
+		}
+	}
+	return fixed
+}
+
+func (e *Entry) toVulnerability(pkg Package, fixedVersion string) v1alpha1.Vulnerability {
+	vulnerabilityID := e.ID
+	if !strings.HasPrefix(vulnerabilityID, "CVE-") {
+		for _, alias := range e.Aliases {
+			if strings.HasPrefix(alias, "CVE-") {
+				vulnerabilityID = alias
+				break
+			}
+		}
+	}
+
+	links := make([]string, 0)
+	primaryLink := ""
+	for _, ref := range e.References {
+		if primaryLink == "" && ref.Type == "ADVISORY" {
+			primaryLink = ref.URL
+		}
+		links = append(links, ref.URL)
+	}
+	if primaryLink == "" {
+		primaryLink = "https://osv.dev/vulnerability/" + e.ID
+	}
+
+	title := e.Summary
+	if title == "" {
+		title = strings.SplitN(e.Details, "\n", 2)[0]
+	}
+
+	severity, score := e.severity()
+	return v1alpha1.Vulnerability{
+		VulnerabilityID:  vulnerabilityID,
+		Resource:         pkg.Name,
+		InstalledVersion: pkg.Version,
+		FixedVersion:     fixedVersion,
+		Severity:         severity,
+		Title:            title,
+		PrimaryLink:      primaryLink,
+		Links:            links,
+		Score:            score,
+	}
+}
+
+// severity returns the severity of the entry. The severity assigned by the
+// database, e.g. GitHub Security Advisories, takes precedence over the
+// severity derived from the CVSS v3 base score.
+func (e *Entry) severity() (v1alpha1.Severity, *float64) {
+	var score *float64
+	for _, s := range e.Severity {
+		if s.Type != "CVSS_V3" {
+			continue
+		}
+		if v, err := cvssV3BaseScore(s.Score); err == nil {
+			score = &v
+			break
+		}
+	}
+
+	switch strings.ToUpper(e.DatabaseSpecific.Severity) {
+	case "CRITICAL":
+		return v1alpha1.SeverityCritical, score
+	case "HIGH":
+		return v1alpha1.SeverityHigh, score
+	case "MODERATE", "MEDIUM":
+		return v1alpha1.SeverityMedium, score
+	case "LOW":
+		return v1alpha1.SeverityLow, score
+	}
+
+	if score == nil {
+		return v1alpha1.SeverityUnknown, nil
+	}
+	switch {
+	case *score >= 9.0:
+		return v1alpha1.SeverityCritical, score
+	case *score >= 7.0:
+		return v1alpha1.SeverityHigh, score
+	case *score >= 4.0:
+		return v1alpha1.SeverityMedium, score
+	case *score > 0:
+		return v1alpha1.SeverityLow, score
+	}
+	return v1alpha1.SeverityUnknown, score
+}
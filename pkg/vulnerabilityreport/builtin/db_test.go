@@ -0,0 +1,100 @@
+package builtin_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport/builtin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+)
+
+func TestLoadDB(t *testing.T) {
+	db, err := builtin.LoadDB("testdata/osv")
+	require.NoError(t, err)
+	assert.Equal(t, 4, db.Size())
+
+	_, err = builtin.LoadDB("testdata/missing")
+	require.Error(t, err)
+}
+
+func TestDB_Match(t *testing.T) {
+	db, err := builtin.LoadDB("testdata/osv")
+	require.NoError(t, err)
+
+	vulnerabilities := db.Match([]builtin.Package{
+		{Name: "libc6", Version: "2.31-13+deb11u3", SrcName: "glibc", SrcVersion: "2.31-13+deb11u3", Ecosystem: "Debian:11"},
+		{Name: "libssl1.1", Version: "1.1.1n-0+deb11u3+b1", SrcName: "openssl", SrcVersion: "1.1.1n-0+deb11u3", Ecosystem: "Debian:11"},
+		{Name: "libc6", Version: "2.28-10", SrcName: "glibc", SrcVersion: "2.28-10", Ecosystem: "Debian:10"},
+		{Name: "zlib", Version: "1.2.12-r1", SrcName: "zlib", SrcVersion: "1.2.12-r1", Ecosystem: "Alpine:v3.16"},
+		{Name: "lodash", Version: "4.17.15", Ecosystem: builtin.EcosystemNpm},
+		{Name: "lodash", Version: "4.17.21", Ecosystem: builtin.EcosystemNpm},
+		{Name: "lodash", Version: "3.6.0", Ecosystem: builtin.EcosystemNpm},
+	})
+
+	assert.Equal(t, []v1alpha1.Vulnerability{
+		{
+			VulnerabilityID:  "CVE-2021-3999",
+			Resource:         "libc6",
+			InstalledVersion: "2.31-13+deb11u3",
+			FixedVersion:     "2.31-13+deb11u4",
+			Severity:         v1alpha1.SeverityHigh,
+			Title:            "glibc - security update",
+			PrimaryLink:      "https://security-tracker.debian.org/tracker/CVE-2021-3999",
+			Links:            []string{"https://security-tracker.debian.org/tracker/CVE-2021-3999"},
+			Score:            pointer.Float64(7.8),
+		},
+		{
+			VulnerabilityID:  "ALPINE-CVE-2022-37434",
+			Resource:         "zlib",
+			InstalledVersion: "1.2.12-r1",
+			FixedVersion:     "1.2.12-r2",
+			Severity:         v1alpha1.SeverityUnknown,
+			Title:            "zlib through 1.2.12 has a heap-based buffer over-read or buffer overflow in inflate.",
+			PrimaryLink:      "https://osv.dev/vulnerability/ALPINE-CVE-2022-37434",
+			Links:            []string{},
+		},
+		{
+			VulnerabilityID:  "CVE-2020-8203",
+			Resource:         "lodash",
+			InstalledVersion: "4.17.15",
+			FixedVersion:     "4.17.19",
+			Severity:         v1alpha1.SeverityHigh,
+			Title:            "Prototype Pollution in lodash",
+			PrimaryLink:      "https://nvd.nist.gov/vuln/detail/CVE-2020-8203",
+			Links: []string{
+				"https://github.com/lodash/lodash/issues/4744",
+				"https://nvd.nist.gov/vuln/detail/CVE-2020-8203",
+			},
+		},
+	}, vulnerabilities)
+}
+
+func TestDB_MatchLastAffectedAndVersions(t *testing.T) {
+	entry := &builtin.Entry{ID: "GHSA-xxxx"}
+	entry.Affected = []builtin.Affected{{
+		Ranges: []builtin.Range{{
+			Type:   "ECOSYSTEM",
+			Events: []builtin.Event{{Introduced: "3.0.0"}, {LastAffected: "3.2.4"}},
+		}},
+		Versions: []string{"2.2.0"},
+	}}
+	entry.Affected[0].Package.Ecosystem = builtin.EcosystemPyPI
+	entry.Affected[0].Package.Name = "django"
+
+	db := builtin.NewDB()
+	db.Add(entry)
+
+	var versions []string
+	for _, v := range db.Match([]builtin.Package{
+		{Name: "django", Version: "2.2.0", Ecosystem: builtin.EcosystemPyPI},
+		{Name: "django", Version: "2.2.1", Ecosystem: builtin.EcosystemPyPI},
+		{Name: "django", Version: "3.0.0", Ecosystem: builtin.EcosystemPyPI},
+		{Name: "django", Version: "3.2.4", Ecosystem: builtin.EcosystemPyPI},
+		{Name: "django", Version: "3.2.5", Ecosystem: builtin.EcosystemPyPI},
+	}) {
+		versions = append(versions, v.InstalledVersion)
+	}
+	assert.Equal(t, []string{"2.2.0", "3.0.0", "3.2.4"}, versions)
+}
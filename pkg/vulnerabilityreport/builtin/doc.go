@@ -0,0 +1,3 @@
+// Package builtin provides the built-in vulnerability scanner, which scans
+// container images in-process instead of creating Kubernetes scan jobs.
+package builtin
@@ -0,0 +1,150 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Scanner scans container images for vulnerabilities within the operator's
+// process. It pulls image layers from container registries, analyzes OS
+// package databases and language lockfiles, and matches installed packages
+// against a local mirror of the OSV database.
+type Scanner struct {
+	clock     ext.Clock
+	buildInfo starboard.BuildInfo
+	dbDir     string
+
+	mu        sync.Mutex
+	db        *DB
+	dbModTime time.Time
+}
+
+// NewScanner constructs a new Scanner, which reads the vulnerability database
+// from the specified directory.
+func NewScanner(clock ext.Clock, buildInfo starboard.BuildInfo, dbDir string) *Scanner {
+	return &Scanner{
+		clock:     clock,
+		buildInfo: buildInfo,
+		dbDir:     dbDir,
+	}
+}
+
+// Scan pulls the specified image and returns vulnerabilities found in it.
+func (s *Scanner) Scan(ctx context.Context, imageRef string, credentials *docker.Auth) (v1alpha1.VulnerabilityReportData, error) {
+	db, err := s.database()
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	auth := authn.Anonymous
+	if credentials != nil {
+		auth = authn.FromConfig(authn.AuthConfig{
+			Username: credentials.Username,
+			Password: credentials.Password,
+		})
+	}
+
+	image, err := remote.Image(ref,
+		remote.WithContext(ctx),
+		remote.WithAuth(auth),
+		remote.WithPlatform(v1.Platform{OS: "linux", Architecture: runtime.GOARCH}),
+	)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, fmt.Errorf("pulling image: %s: %w", imageRef, err)
+	}
+
+	inventory, err := s.analyze(image)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, fmt.Errorf("analyzing image: %s: %w", imageRef, err)
+	}
+
+	return s.newReportData(ref, db.Match(inventory.Packages)), nil
+}
+
+func (s *Scanner) analyze(image v1.Image) (Inventory, error) {
+	rc := mutate.Extract(image)
+	defer func() {
+		_ = rc.Close()
+	}()
+	return Analyze(rc)
+}
+
+func (s *Scanner) newReportData(ref name.Reference, vulnerabilities []v1alpha1.Vulnerability) v1alpha1.VulnerabilityReportData {
+	sort.SliceStable(vulnerabilities, func(i, j int) bool {
+		if vulnerabilities[i].Resource != vulnerabilities[j].Resource {
+			return vulnerabilities[i].Resource < vulnerabilities[j].Resource
+		}
+		return vulnerabilities[i].VulnerabilityID < vulnerabilities[j].VulnerabilityID
+	})
+
+	artifact := v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+	}
+	switch t := ref.(type) {
+	case name.Tag:
+		artifact.Tag = t.TagStr()
+	case name.Digest:
+		artifact.Digest = t.DigestStr()
+	}
+
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(s.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:    vulnerabilityreport.BuiltInScanner,
+			Vendor:  "Aqua Security",
+			Version: s.buildInfo.Version,
+		},
+		Registry: v1alpha1.Registry{
+			Server: ref.Context().RegistryStr(),
+		},
+		Artifact:        artifact,
+		Summary:         v1alpha1.VulnerabilitySummaryFromVulnerabilities(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}
+}
+
+// database returns the vulnerability database, which is reloaded whenever
+// the modification time of the database directory changes. To update the
+// database without restarting the operator, replace the directory
+// atomically, e.g. by swapping a symbolic link.
+func (s *Scanner) database() (*DB, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.dbDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading vulnerability database: %w", err)
+	}
+	if s.db != nil && info.ModTime().Equal(s.dbModTime) {
+		return s.db, nil
+	}
+	db, err := LoadDB(s.dbDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading vulnerability database: %w", err)
+	}
+	s.db = db
+	s.dbModTime = info.ModTime()
+	return s.db, nil
+}
@@ -0,0 +1,79 @@
+package builtin_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport/builtin"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScanner_Scan(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	layerContent := newTar(t, map[string]string{
+		"etc/os-release":        "ID=debian\nVERSION_ID=\"11\"\n",
+		"var/lib/dpkg/status":   dpkgStatus,
+		"app/package-lock.json": npmLockfileV1,
+	}).Bytes()
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(layerContent)), nil
+	})
+	require.NoError(t, err)
+	image, err := mutate.AppendLayers(empty.Image, layer)
+	require.NoError(t, err)
+
+	ref, err := name.ParseReference(host + "/library/app:1.0")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, image))
+
+	now := time.Date(2022, time.June, 1, 10, 30, 0, 0, time.UTC)
+	scanner := builtin.NewScanner(ext.NewFixedClock(now), starboard.BuildInfo{Version: "0.15.6"}, "testdata/osv")
+
+	reportData, err := scanner.Scan(context.Background(), host+"/library/app:1.0", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, metav1.NewTime(now), reportData.UpdateTimestamp)
+	assert.Equal(t, v1alpha1.Scanner{Name: "Starboard", Vendor: "Aqua Security", Version: "0.15.6"}, reportData.Scanner)
+	assert.Equal(t, v1alpha1.Registry{Server: host}, reportData.Registry)
+	assert.Equal(t, v1alpha1.Artifact{Repository: "library/app", Tag: "1.0"}, reportData.Artifact)
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{HighCount: 2}, reportData.Summary)
+
+	var ids []string
+	for _, v := range reportData.Vulnerabilities {
+		ids = append(ids, v.Resource+"/"+v.VulnerabilityID)
+	}
+	assert.Equal(t, []string{"libc6/CVE-2021-3999", "lodash/CVE-2020-8203"}, ids)
+
+	t.Run("Should return error when image does not exist", func(t *testing.T) {
+		_, err := scanner.Scan(context.Background(), host+"/library/missing:1.0", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pulling image: "+host+"/library/missing:1.0")
+	})
+
+	t.Run("Should return error when database does not exist", func(t *testing.T) {
+		scanner := builtin.NewScanner(ext.NewSystemClock(), starboard.BuildInfo{}, "testdata/missing")
+		_, err := scanner.Scan(context.Background(), host+"/library/app:1.0", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reading vulnerability database")
+	})
+}
@@ -0,0 +1,211 @@
+package builtin
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-version"
+)
+
+// compareVersions compares two versions of a package from the given OSV
+// ecosystem. The result is 0 if a == b, negative if a < b, and positive if
+// a > b. Versions that cannot be parsed are compared lexicographically.
+func compareVersions(ecosystem, a, b string) int {
+	switch ecosystemName(ecosystem) {
+	case "Debian", "Ubuntu":
+		return compareDpkgVersions(a, b)
+	case "Alpine":
+		return compareApkVersions(a, b)
+	}
+	va, errA := version.NewVersion(a)
+	vb, errB := version.NewVersion(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return va.Compare(vb)
+}
+
+// ecosystemName returns the name of the given OSV ecosystem without the
+// release suffix, e.g. Debian for Debian:11.
+func ecosystemName(ecosystem string) string {
+	if i := strings.Index(ecosystem, ":"); i >= 0 {
+		return ecosystem[:i]
+	}
+	return ecosystem
+}
+
+// compareDpkgVersions compares Debian package versions in the
+// [epoch:]upstream_version[-debian_revision] format as dpkg does.
+func compareDpkgVersions(a, b string) int {
+	epochA, upstreamA, revisionA := splitDpkgVersion(a)
+	epochB, upstreamB, revisionB := splitDpkgVersion(b)
+	if epochA != epochB {
+		if epochA < epochB {
+			return -1
+		}
+		return 1
+	}
+	if c := compareDpkgFragments(upstreamA, upstreamB); c != 0 {
+		return c
+	}
+	return compareDpkgFragments(revisionA, revisionB)
+}
+
+func splitDpkgVersion(v string) (int, string, string) {
+	epoch := 0
+	if i := strings.Index(v, ":"); i >= 0 {
+		epoch, _ = strconv.Atoi(v[:i])
+		v = v[i+1:]
+	}
+	revision := ""
+	if i := strings.LastIndex(v, "-"); i >= 0 {
+		revision = v[i+1:]
+		v = v[:i]
+	}
+	return epoch, v, revision
+}
+
+// compareDpkgFragments implements the verrevcmp algorithm of dpkg, which
+// alternately compares non-digit and digit parts of two version fragments.
+func compareDpkgFragments(a, b string) int {
+	order := func(s string, i int) int {
+		if i >= len(s) {
+			return 0
+		}
+		c := rune(s[i])
+		switch {
+		case c == '~':
+			return -1
+		case unicode.IsDigit(c):
+			return 0
+		case unicode.IsLetter(c):
+			return int(c)
+		default:
+			return int(c) + 256
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !unicode.IsDigit(rune(a[i]))) || (j < len(b) && !unicode.IsDigit(rune(b[j]))) {
+			ac, bc := order(a, i), order(b, j)
+			if ac != bc {
+				return ac - bc
+			}
+			i++
+			j++
+		}
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		firstDiff := 0
+		for i < len(a) && unicode.IsDigit(rune(a[i])) && j < len(b) && unicode.IsDigit(rune(b[j])) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if i < len(a) && unicode.IsDigit(rune(a[i])) {
+			return 1
+		}
+		if j < len(b) && unicode.IsDigit(rune(b[j])) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+	return 0
+}
+
+// apkSuffixes maps suffixes of Alpine package versions to their order. The
+// pre-release suffixes sort before a version without suffix.
+var apkSuffixes = map[string]int{
+	"alpha": -4,
+	"beta":  -3,
+	"pre":   -2,
+	"rc":    -1,
+	"cvs":   1,
+	"svn":   2,
+	"git":   3,
+	"hg":    4,
+	"p":     5,
+}
+
+// compareApkVersions compares Alpine package versions in the
+// number{.number}...{letter}{_suffix{number}}...{-r#} format.
+func compareApkVersions(a, b string) int {
+	mainA, suffixesA, revisionA := splitApkVersion(a)
+	mainB, suffixesB, revisionB := splitApkVersion(b)
+
+	if c := compareApkMain(mainA, mainB); c != 0 {
+		return c
+	}
+	for i := 0; i < len(suffixesA) || i < len(suffixesB); i++ {
+		var sa, sb apkSuffix
+		if i < len(suffixesA) {
+			sa = suffixesA[i]
+		}
+		if i < len(suffixesB) {
+			sb = suffixesB[i]
+		}
+		if sa.order != sb.order {
+			return sa.order - sb.order
+		}
+		if sa.number != sb.number {
+			return sa.number - sb.number
+		}
+	}
+	return revisionA - revisionB
+}
+
+type apkSuffix struct {
+	order  int
+	number int
+}
+
+func splitApkVersion(v string) (string, []apkSuffix, int) {
+	revision := 0
+	if i := strings.LastIndex(v, "-r"); i >= 0 {
+		revision, _ = strconv.Atoi(v[i+2:])
+		v = v[:i]
+	}
+	parts := strings.Split(v, "_")
+	var suffixes []apkSuffix
+	for _, part := range parts[1:] {
+		name := strings.TrimRightFunc(part, unicode.IsDigit)
+		number, _ := strconv.Atoi(part[len(name):])
+		suffixes = append(suffixes, apkSuffix{order: apkSuffixes[name], number: number})
+	}
+	return parts[0], suffixes, revision
+}
+
+func compareApkMain(a, b string) int {
+	letterA, letterB := "", ""
+	if n := len(a); n > 0 && unicode.IsLetter(rune(a[n-1])) {
+		a, letterA = a[:n-1], a[n-1:]
+	}
+	if n := len(b); n > 0 && unicode.IsLetter(rune(b[n-1])) {
+		b, letterB = b[:n-1], b[n-1:]
+	}
+	numbersA := strings.Split(a, ".")
+	numbersB := strings.Split(b, ".")
+	for i := 0; i < len(numbersA) || i < len(numbersB); i++ {
+		if i >= len(numbersA) {
+			return -1
+		}
+		if i >= len(numbersB) {
+			return 1
+		}
+		na, _ := strconv.Atoi(numbersA[i])
+		nb, _ := strconv.Atoi(numbersB[i])
+		if na != nb {
+			return na - nb
+		}
+	}
+	return strings.Compare(letterA, letterB)
+}
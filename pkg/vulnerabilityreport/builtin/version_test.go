@@ -0,0 +1,44 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	testCases := []struct {
+		ecosystem string
+		a         string
+		b         string
+		expected  int
+	}{
+		{ecosystem: "Debian:11", a: "2.31-13+deb11u3", b: "2.31-13+deb11u4", expected: -1},
+		{ecosystem: "Debian:11", a: "1:1.0-1", b: "2.0-1", expected: 1},
+		{ecosystem: "Debian:11", a: "1.0~rc1-1", b: "1.0-1", expected: -1},
+		{ecosystem: "Debian:11", a: "1.1.1n-0+deb11u1", b: "1.1.1k-1+deb11u1", expected: 1},
+		{ecosystem: "Debian:11", a: "1.0-1", b: "1.0-1", expected: 0},
+		{ecosystem: "Ubuntu:22.04", a: "3.0.2-0ubuntu1.6", b: "3.0.2-0ubuntu1.10", expected: -1},
+		{ecosystem: "Alpine:v3.16", a: "1.2.12-r1", b: "1.2.12-r2", expected: -1},
+		{ecosystem: "Alpine:v3.16", a: "1.2.12_rc1-r0", b: "1.2.12-r0", expected: -1},
+		{ecosystem: "Alpine:v3.16", a: "1.2.12_p1-r0", b: "1.2.12-r0", expected: 1},
+		{ecosystem: "Alpine:v3.16", a: "1.1.1q-r0", b: "1.1.1p-r0", expected: 1},
+		{ecosystem: "Alpine:v3.16", a: "1.10-r0", b: "1.9-r0", expected: 1},
+		{ecosystem: "npm", a: "4.17.15", b: "4.17.19", expected: -1},
+		{ecosystem: "npm", a: "1.0.0-beta.1", b: "1.0.0", expected: -1},
+		{ecosystem: "crates.io", a: "0.10.0", b: "0.9.9", expected: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.ecosystem+" "+tc.a+" "+tc.b, func(t *testing.T) {
+			actual := compareVersions(tc.ecosystem, tc.a, tc.b)
+			switch {
+			case tc.expected < 0:
+				assert.Negative(t, actual)
+			case tc.expected > 0:
+				assert.Positive(t, actual)
+			default:
+				assert.Zero(t, actual)
+			}
+		})
+	}
+}
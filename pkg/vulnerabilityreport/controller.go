@@ -3,15 +3,23 @@ package vulnerabilityreport
 import (
 	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
 
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/operator/controller"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -23,6 +31,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -30,7 +39,8 @@ import (
 
 // WorkloadController watches Kubernetes workloads and generates
 // v1alpha1.VulnerabilityReport instances using vulnerability scanner that that
-// implements the Plugin interface.
+// implements the Plugin interface. If the ImageScanner is set, container
+// images are scanned in-process instead of creating scan jobs.
 type WorkloadController struct {
 	logr.Logger
 	etc.Config
@@ -43,6 +53,34 @@ type WorkloadController struct {
 	starboard.PluginContext
 	ReadWriter
 	starboard.ConfigData
+	ext.Clock
+	ImageScanner
+
+	// ExceptionApplier marks vulnerabilities accepted by
+	// VulnerabilityExceptions as suppressed before reports are written.
+	ExceptionApplier *ExceptionApplier
+
+	// SbomReadWriter writes SbomReports if the Plugin implements SbomPlugin
+	// and SBOM reports are enabled.
+	SbomReadWriter sbomreport.ReadWriter
+
+	// DriftChecker records in existing VulnerabilityReports whether tags of
+	// container images were moved to different digests than the ones run by
+	// the workload. Drift is not checked if DriftChecker is nil.
+	DriftChecker *DriftChecker
+
+	// AdditionalScanner is true if the Plugin is not the primary
+	// vulnerability scanner. Additional scanners do not use the cache of
+	// ClusterVulnerabilityReports and do not generate SbomReports.
+	AdditionalScanner bool
+
+	// MergedScanners are names of all vulnerability scanners in order of
+	// precedence if their reports are merged into a single
+	// VulnerabilityReport per container. Reports are not merged if empty.
+	MergedScanners []string
+
+	// scans limits the number of concurrent in-process scans.
+	scans chan struct{}
 }
 
 func (r *WorkloadController) SetupWithManager(mgr ctrl.Manager) error {
@@ -65,8 +103,27 @@ func (r *WorkloadController) SetupWithManager(mgr ctrl.Manager) error {
 		{kind: kube.KindJob, forObject: &batchv1.Job{}, ownsObject: &v1alpha1.VulnerabilityReport{}},
 	}
 
+	var options crcontroller.Options
+	if r.ImageScanner != nil {
+		// In-process scans block reconciliation, therefore workloads of the
+		// same kind are reconciled concurrently up to the scan jobs limit.
+		limit := r.Config.ConcurrentScanJobsLimit
+		if limit < 1 {
+			limit = 1
+		}
+		r.scans = make(chan struct{}, limit)
+		options.MaxConcurrentReconciles = limit
+	}
+
 	for _, workload := range workloads {
-		err = ctrl.NewControllerManagedBy(mgr).
+		b := ctrl.NewControllerManagedBy(mgr)
+		if r.AdditionalScanner {
+			// Controllers of additional scanners watch the same kinds of
+			// workloads, therefore they're named after the scanner.
+			b = b.Named(strings.ToLower(fmt.Sprintf("%s-%s", workload.kind, r.PluginContext.GetName())))
+		}
+		err = b.
+			WithOptions(options).
 			For(workload.forObject, builder.WithPredicates(
 				Not(ManagedByStarboardOperator),
 				Not(IsBeingTerminated),
@@ -78,16 +135,57 @@ func (r *WorkloadController) SetupWithManager(mgr ctrl.Manager) error {
 			return err
 		}
 	}
+	if r.ImageScanner != nil {
+		return nil
+	}
 	var predicates []predicate.Predicate
 	if !r.ConfigData.VulnerabilityScanJobsInSameNamespace() {
 		predicates = append(predicates, InNamespace(r.Config.Namespace))
 	}
-	predicates = append(predicates, ManagedByStarboardOperator, IsVulnerabilityReportScan, JobHasAnyCondition)
-	return ctrl.NewControllerManagedBy(mgr).
+	predicates = append(predicates, ManagedByStarboardOperator, IsVulnerabilityReportScan,
+		IsVulnerabilityReportScanBy(r.PluginContext.GetName()), JobHasAnyCondition)
+	b := ctrl.NewControllerManagedBy(mgr)
+	if r.AdditionalScanner {
+		b = b.Named(strings.ToLower(fmt.Sprintf("scan-job-%s", r.PluginContext.GetName())))
+	}
+	return b.
 		For(&batchv1.Job{}, builder.WithPredicates(predicates...)).
 		Complete(r.reconcileJobs())
 }
 
+// additionalReports returns true if VulnerabilityReports generated by the
+// Plugin are labeled with starboard.LabelVulnerabilityReportAdditional, i.e.
+// if the Plugin is an additional scanner or reports are merged.
+func (r *WorkloadController) additionalReports() bool {
+	return r.AdditionalScanner || len(r.MergedScanners) > 0
+}
+
+// scannerName returns the name of the Plugin, or an empty string if container
+// images are scanned with the ImageScanner.
+func (r *WorkloadController) scannerName() string {
+	if r.PluginContext == nil {
+		return ""
+	}
+	return r.PluginContext.GetName()
+}
+
+// cacheScannerName returns the name of the scanner that ClusterVulnerabilityReports
+// are cached for, i.e. the name of the Plugin or BuiltInScanner if container
+// images are scanned with the ImageScanner.
+func (r *WorkloadController) cacheScannerName() string {
+	if r.PluginContext == nil {
+		return BuiltInScanner
+	}
+	return r.PluginContext.GetName()
+}
+
+// cacheEnabled returns true if scan results are cached as
+// ClusterVulnerabilityReports, which is supported for the primary scanner
+// only.
+func (r *WorkloadController) cacheEnabled() bool {
+	return r.Config.VulnerabilityScannerCacheEnabled && !r.AdditionalScanner
+}
+
 func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile.Func {
 	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 		log := r.Logger.WithValues("kind", workloadKind, "name", req.NamespacedName)
@@ -145,14 +243,34 @@ func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile
 		log = log.WithValues("podSpecHash", hash)
 
 		// Check if containers of the Pod have corresponding VulnerabilityReports.
-		hasReports, err := r.hasReports(ctx, workloadRef, hash, containerImages)
+		reports, err := r.findReports(ctx, workloadRef, hash)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("getting vulnerability reports: %w", err)
 		}
 
-		if hasReports {
-			log.V(1).Info("VulnerabilityReports already exist")
-			return ctrl.Result{}, nil
+		rescan := false
+		if hasReportsForContainers(reports, containerImages) {
+			driftCheckAfter, err := r.checkDrift(ctx, workloadObj, containerImages, reports)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("checking image drift: %w", err)
+			}
+			rescanAfter, enabled, err := r.rescanAfter(ctx, workloadRef, reports)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("getting rescan time: %w", err)
+			}
+			if !enabled {
+				log.V(1).Info("VulnerabilityReports already exist")
+				return ctrl.Result{RequeueAfter: driftCheckAfter}, nil
+			}
+			if rescanAfter > 0 {
+				log.V(1).Info("VulnerabilityReports already exist", "rescanAfter", rescanAfter)
+				if driftCheckAfter > 0 && driftCheckAfter < rescanAfter {
+					return ctrl.Result{RequeueAfter: driftCheckAfter}, nil
+				}
+				return ctrl.Result{RequeueAfter: rescanAfter}, nil
+			}
+			log.V(1).Info("Rescanning workload with outdated VulnerabilityReports")
+			rescan = true
 		}
 
 		_, job, err := r.hasActiveScanJob(ctx, workloadRef, hash)
@@ -166,6 +284,30 @@ func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile
 			return ctrl.Result{}, nil
 		}
 
+		var imageDigests kube.ContainerImages
+		if r.cacheEnabled() || r.Config.VulnerabilityScannerScanImageDigests {
+			imageDigests, err = r.getContainerImageDigests(ctx, workloadObj)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("getting container image digests: %w", err)
+			}
+		}
+		// Do not copy cached reports on rescan because they might be as old as
+		// the reports to be refreshed.
+		if r.cacheEnabled() && !rescan {
+			copied, err := r.copyCachedReports(ctx, workloadObj, hash, containerImages, imageDigests)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("copying cached vulnerability reports: %w", err)
+			}
+			if copied {
+				log.V(1).Info("Copied VulnerabilityReports from ClusterVulnerabilityReports")
+				return ctrl.Result{}, nil
+			}
+		}
+
+		if r.ImageScanner != nil {
+			return r.scanWorkload(ctx, workloadObj, hash, containerImages, imageDigests)
+		}
+
 		limitExceeded, scanJobsCount, err := r.LimitChecker.Check(ctx)
 		if err != nil {
 			return ctrl.Result{}, err
@@ -173,28 +315,49 @@ func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile
 		log.V(1).Info("Checking scan jobs limit", "count", scanJobsCount, "limit", r.ConcurrentScanJobsLimit)
 
 		if limitExceeded {
+			metrics.ScanJobsThrottled.WithLabelValues(metrics.VulnerabilityReport).Inc()
 			log.V(1).Info("Pushing back scan job", "count", scanJobsCount, "retryAfter", r.ScanJobRetryAfter)
 			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
 		}
 
-		return ctrl.Result{}, r.submitScanJob(ctx, workloadObj)
+		return ctrl.Result{}, r.submitScanJob(ctx, workloadObj, imageDigests)
 	}
 }
 
-func (r *WorkloadController) hasReports(ctx context.Context, owner kube.ObjectRef, hash string, images kube.ContainerImages) (bool, error) {
+// findReports returns VulnerabilityReports of the given owner that were
+// generated by the Plugin for the pod spec with the given hash.
+func (r *WorkloadController) findReports(ctx context.Context, owner kube.ObjectRef, hash string) ([]v1alpha1.VulnerabilityReport, error) {
 	// TODO FindByOwner should accept optional label selector to further narrow down search results
 	list, err := r.FindByOwner(ctx, owner)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	actual := map[string]bool{}
+	var reports []v1alpha1.VulnerabilityReport
 	for _, report := range list {
-		if containerName, ok := report.Labels[starboard.LabelContainerName]; ok {
-			if hash == report.Labels[starboard.LabelResourceSpecHash] {
-				actual[containerName] = true
-			}
+		if _, ok := report.Labels[starboard.LabelContainerName]; !ok {
+			continue
 		}
+		_, additional := report.Labels[starboard.LabelVulnerabilityReportAdditional]
+		if additional != r.additionalReports() {
+			continue
+		}
+		if additional && report.Labels[starboard.LabelVulnerabilityReportScanner] != r.scannerName() {
+			continue
+		}
+		if hash == report.Labels[starboard.LabelResourceSpecHash] {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+// hasReportsForContainers returns true if there is exactly one report for
+// each container image.
+func hasReportsForContainers(reports []v1alpha1.VulnerabilityReport, images kube.ContainerImages) bool {
+	actual := map[string]bool{}
+	for _, report := range reports {
+		actual[report.Labels[starboard.LabelContainerName]] = true
 	}
 
 	expected := map[string]bool{}
@@ -202,11 +365,108 @@ func (r *WorkloadController) hasReports(ctx context.Context, owner kube.ObjectRe
 		expected[containerName] = true
 	}
 
-	return reflect.DeepEqual(actual, expected), nil
+	return reflect.DeepEqual(actual, expected)
+}
+
+// rescanAfter returns the duration to wait before rescanning the given
+// workload according to the RescanPolicy of its namespace. The second return
+// value is false if periodic rescans are disabled.
+func (r *WorkloadController) rescanAfter(ctx context.Context, workload kube.ObjectRef, reports []v1alpha1.VulnerabilityReport) (time.Duration, bool, error) {
+	policy := NewRescanPolicy(r.Config)
+
+	var namespace corev1.Namespace
+	err := r.Client.Get(ctx, client.ObjectKey{Name: workload.Namespace}, &namespace)
+	if err != nil && !k8sapierror.IsNotFound(err) {
+		return 0, false, fmt.Errorf("getting namespace from cache: %w", err)
+	}
+	policy, err = policy.ForNamespace(namespace.Annotations)
+	if err != nil {
+		return 0, false, err
+	}
+	if !policy.Enabled() {
+		return 0, false, nil
+	}
+
+	var lastUpdated time.Time
+	for i, report := range reports {
+		if i == 0 || report.Report.UpdateTimestamp.Time.Before(lastUpdated) {
+			lastUpdated = report.Report.UpdateTimestamp.Time
+		}
+	}
+
+	next, err := policy.NextRescan(workload, lastUpdated)
+	if err != nil {
+		return 0, false, err
+	}
+	return next.Sub(r.Clock.Now()), true, nil
+}
+
+// checkDrift resolves tags of container images of the given workload with the
+// DriftChecker and updates VulnerabilityReports whose drift was checked. It
+// returns the duration to wait before the next check is due, which is zero if
+// drift detection is disabled.
+func (r *WorkloadController) checkDrift(ctx context.Context, owner client.Object, images kube.ContainerImages, reports []v1alpha1.VulnerabilityReport) (time.Duration, error) {
+	if r.DriftChecker == nil {
+		return 0, nil
+	}
+	log := r.Logger.WithValues("kind", owner.GetObjectKind().GroupVersionKind().Kind,
+		"name", owner.GetName(), "namespace", owner.GetNamespace())
+
+	credentials, err := r.CredentialsByWorkload(ctx, owner)
+	if err != nil {
+		return 0, err
+	}
+
+	var checkedReports []v1alpha1.VulnerabilityReport
+	for _, report := range reports {
+		containerName := report.Labels[starboard.LabelContainerName]
+		var auth *docker.Auth
+		if c, ok := credentials[containerName]; ok {
+			auth = &c
+		}
+		checked := report.DeepCopy()
+		ok, err := r.DriftChecker.Check(ctx, checked, images[containerName], auth)
+		if err != nil {
+			// Unavailable registries must not block reconciliation, therefore
+			// the check is retried after the interval.
+			log.Error(err, "Checking image drift", "container", containerName)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if checked.Report.Drift.Drifted {
+			log.Info("Image tag points to different digest than the running one", "container", containerName,
+				"image", images[containerName], "runningDigest", checked.Report.Artifact.Digest,
+				"tagDigest", checked.Report.Drift.Digest)
+		}
+		checkedReports = append(checkedReports, *checked)
+	}
+	if len(checkedReports) > 0 {
+		err = r.ReadWriter.Write(ctx, checkedReports)
+		if err != nil {
+			return 0, err
+		}
+		err = r.writeMergedReports(ctx, owner, checkedReports)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	next := r.DriftChecker.Interval
+	for _, report := range reports {
+		if after := r.DriftChecker.NextCheck(report); after > 0 && after < next {
+			next = after
+		}
+	}
+	return next, nil
 }
 
 func (r *WorkloadController) hasActiveScanJob(ctx context.Context, owner kube.ObjectRef, hash string) (bool, *batchv1.Job, error) {
 	jobName := fmt.Sprintf("scan-vulnerabilityreport-%s", kube.ComputeHash(owner))
+	if r.AdditionalScanner {
+		jobName = fmt.Sprintf("%s-%s", jobName, strings.ToLower(r.PluginContext.GetName()))
+	}
 	job := &batchv1.Job{}
 	err := r.Get(ctx, client.ObjectKey{Namespace: r.Config.Namespace, Name: jobName}, job)
 	if err != nil {
@@ -221,7 +481,302 @@ func (r *WorkloadController) hasActiveScanJob(ctx context.Context, owner kube.Ob
 	return false, nil, nil
 }
 
-func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Object) error {
+// getContainerImageDigests returns repo digests of container images run by
+// active pods of the given workload. An empty map is returned if there are no
+// active pods, e.g. for a CronJob.
+func (r *WorkloadController) getContainerImageDigests(ctx context.Context, workload client.Object) (kube.ContainerImages, error) {
+	pods, err := r.GetActivePodsByWorkload(ctx, workload)
+	if err != nil {
+		if errors.Is(err, kube.ErrReplicaSetNotFound) || errors.Is(err, kube.ErrNoRunningPods) ||
+			errors.Is(err, kube.ErrUnSupportedKind) {
+			return kube.ContainerImages{}, nil
+		}
+		return nil, err
+	}
+	return kube.GetContainerImageDigestsFromPods(pods), nil
+}
+
+// copyCachedReports creates VulnerabilityReports for the given workload by
+// copying ClusterVulnerabilityReports cached for repo digests of its container
+// images. Reports are copied only if all containers have a cached report,
+// otherwise false is returned and a scan job must be submitted. If SBOM
+// reports are enabled, ClusterSbomReports are required and copied as well.
+func (r *WorkloadController) copyCachedReports(ctx context.Context, owner client.Object, hash string, images, digests kube.ContainerImages) (bool, error) {
+	var clusterReports = map[string]*v1alpha1.ClusterVulnerabilityReport{}
+	for containerName := range images {
+		digest, ok := digests[containerName]
+		if !ok {
+			return false, nil
+		}
+		clusterReport, err := r.FindClusterReportByImageDigest(ctx, r.cacheScannerName(), digest)
+		if err != nil {
+			return false, err
+		}
+		if clusterReport == nil {
+			return false, nil
+		}
+		clusterReports[containerName] = clusterReport
+	}
+
+	var sbomReports []v1alpha1.SbomReport
+	if _, ok := r.sbomPlugin(); ok {
+		for containerName := range images {
+			clusterReport, err := r.SbomReadWriter.FindClusterReportByImageDigest(ctx, digests[containerName])
+			if err != nil {
+				return false, err
+			}
+			if clusterReport == nil {
+				return false, nil
+			}
+			report, err := sbomreport.NewReportBuilder(r.Client.Scheme()).
+				Controller(owner).
+				Container(containerName).
+				Data(clusterReport.Report).
+				PodSpecHash(hash).
+				Get()
+			if err != nil {
+				return false, err
+			}
+			sbomReports = append(sbomReports, report)
+		}
+	}
+
+	var vulnerabilityReports []v1alpha1.VulnerabilityReport
+	for containerName, clusterReport := range clusterReports {
+		report, err := r.newReportBuilder(owner, containerName, hash, clusterReport.Report).
+			ClusterReportName(clusterReport.Name).
+			Get()
+		if err != nil {
+			return false, err
+		}
+		vulnerabilityReports = append(vulnerabilityReports, report)
+	}
+
+	if len(sbomReports) > 0 {
+		err := r.SbomReadWriter.Write(ctx, sbomReports)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err := r.writeReports(ctx, owner, vulnerabilityReports)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// scanWorkload scans container images of the given workload with the
+// ImageScanner and writes VulnerabilityReports. The reconcile key is pushed
+// back if the limit of concurrent scans is exceeded.
+func (r *WorkloadController) scanWorkload(ctx context.Context, owner client.Object, hash string, images, digests kube.ContainerImages) (ctrl.Result, error) {
+	log := r.Logger.WithValues("kind", owner.GetObjectKind().GroupVersionKind().Kind,
+		"name", owner.GetName(), "namespace", owner.GetNamespace())
+
+	select {
+	case r.scans <- struct{}{}:
+		defer func() {
+			<-r.scans
+		}()
+	default:
+		metrics.ScanJobsThrottled.WithLabelValues(metrics.VulnerabilityReport).Inc()
+		log.V(1).Info("Pushing back in-process scan", "limit", r.ConcurrentScanJobsLimit, "retryAfter", r.ScanJobRetryAfter)
+		return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+	}
+
+	credentials, err := r.CredentialsByWorkload(ctx, owner)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.Config.ScanJobTimeout)
+	defer cancel()
+
+	var vulnerabilityReports []v1alpha1.VulnerabilityReport
+	for containerName, containerImage := range images {
+		var auth *docker.Auth
+		if c, ok := credentials[containerName]; ok {
+			auth = &c
+		}
+		scannedImage := containerImage
+		digest, hasDigest := digests[containerName]
+		if hasDigest && r.Config.VulnerabilityScannerScanImageDigests {
+			scannedImage = digest
+		}
+		log.V(1).Info("Scanning container image", "container", containerName, "image", scannedImage)
+		reportData, err := r.ImageScanner.Scan(ctx, scannedImage, auth)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("scanning container %s: %w", containerName, err)
+		}
+		if scannedImage != containerImage {
+			err = SetArtifactDigest(&reportData, containerImage, digest)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		report, err := r.newReport(owner, containerName, hash, reportData)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		vulnerabilityReports = append(vulnerabilityReports, report)
+
+		if hasDigest && r.cacheEnabled() {
+			err = r.writeClusterReport(ctx, digest, reportData)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	return ctrl.Result{}, r.writeReports(ctx, owner, vulnerabilityReports)
+}
+
+// newReport builds a VulnerabilityReport generated by the Plugin for the given
+// container of the owner workload.
+func (r *WorkloadController) newReport(owner client.Object, containerName, podSpecHash string, reportData v1alpha1.VulnerabilityReportData) (v1alpha1.VulnerabilityReport, error) {
+	return r.newReportBuilder(owner, containerName, podSpecHash, reportData).Get()
+}
+
+func (r *WorkloadController) newReportBuilder(owner client.Object, containerName, podSpecHash string, reportData v1alpha1.VulnerabilityReportData) *ReportBuilder {
+	reportBuilder := NewReportBuilder(r.Client.Scheme()).
+		Controller(owner).
+		Container(containerName).
+		Data(reportData).
+		PodSpecHash(podSpecHash).
+		Scanner(r.scannerName()).
+		Additional(r.additionalReports())
+
+	if r.Config.VulnerabilityScannerReportTTL != nil {
+		reportBuilder.ReportTTL(r.Config.VulnerabilityScannerReportTTL)
+	}
+
+	return reportBuilder
+}
+
+// writeReports applies VulnerabilityExceptions to the given reports of the
+// owner workload, if the ExceptionApplier is set, and writes them. If reports
+// are merged, the merged reports are written as well.
+func (r *WorkloadController) writeReports(ctx context.Context, owner client.Object, reports []v1alpha1.VulnerabilityReport) error {
+	err := r.applyExceptionsAndWrite(ctx, owner, reports)
+	if err != nil {
+		return err
+	}
+	return r.writeMergedReports(ctx, owner, reports)
+}
+
+func (r *WorkloadController) applyExceptionsAndWrite(ctx context.Context, owner client.Object, reports []v1alpha1.VulnerabilityReport) error {
+	if r.ExceptionApplier != nil {
+		_, err := r.ExceptionApplier.Apply(ctx, owner, reports)
+		if err != nil {
+			return err
+		}
+	}
+	return r.ReadWriter.Write(ctx, reports)
+}
+
+// writeMergedReports merges reports of all MergedScanners for containers of
+// the given reports and writes the merged reports. Reports of scanners that
+// have not completed yet are merged when they're written.
+func (r *WorkloadController) writeMergedReports(ctx context.Context, owner client.Object, reports []v1alpha1.VulnerabilityReport) error {
+	if len(r.MergedScanners) == 0 || len(reports) == 0 {
+		return nil
+	}
+	ownerRef, err := kube.ObjectRefFromObjectMeta(reports[0].ObjectMeta)
+	if err != nil {
+		return fmt.Errorf("getting owner ref from report metadata: %w", err)
+	}
+	hash := reports[0].Labels[starboard.LabelResourceSpecHash]
+	containers := make(map[string]map[string]v1alpha1.VulnerabilityReportData)
+	for _, report := range reports {
+		containers[report.Labels[starboard.LabelContainerName]] = make(map[string]v1alpha1.VulnerabilityReportData)
+	}
+
+	list, err := r.FindByOwner(ctx, ownerRef)
+	if err != nil {
+		return err
+	}
+	for _, report := range list {
+		if _, ok := report.Labels[starboard.LabelVulnerabilityReportAdditional]; !ok {
+			continue
+		}
+		if hash != report.Labels[starboard.LabelResourceSpecHash] {
+			continue
+		}
+		scannerReports, ok := containers[report.Labels[starboard.LabelContainerName]]
+		if !ok {
+			continue
+		}
+		scannerReports[report.Labels[starboard.LabelVulnerabilityReportScanner]] = report.Report
+	}
+
+	var mergedReports []v1alpha1.VulnerabilityReport
+	for containerName, scannerReports := range containers {
+		reportBuilder := NewReportBuilder(r.Client.Scheme()).
+			Controller(owner).
+			Container(containerName).
+			Data(MergeReports(r.MergedScanners, scannerReports)).
+			PodSpecHash(hash)
+		if r.Config.VulnerabilityScannerReportTTL != nil {
+			reportBuilder.ReportTTL(r.Config.VulnerabilityScannerReportTTL)
+		}
+		report, err := reportBuilder.Get()
+		if err != nil {
+			return err
+		}
+		mergedReports = append(mergedReports, report)
+	}
+	return r.applyExceptionsAndWrite(ctx, owner, mergedReports)
+}
+
+// writeClusterReport caches the given report data as a
+// ClusterVulnerabilityReport for the given repo digest.
+func (r *WorkloadController) writeClusterReport(ctx context.Context, digest string, reportData v1alpha1.VulnerabilityReportData) error {
+	clusterReport, err := NewClusterReportBuilder().
+		Scanner(r.cacheScannerName()).
+		ImageDigest(digest).
+		Data(reportData).
+		ReportTTL(r.Config.VulnerabilityScannerCacheReportTTL).
+		Get()
+	if err != nil {
+		return err
+	}
+	err = r.ReadWriter.WriteClusterReport(ctx, clusterReport)
+	if err != nil {
+		return fmt.Errorf("writing cluster vulnerability report: %w", err)
+	}
+	return nil
+}
+
+// sbomPlugin returns the Plugin as SbomPlugin if it implements the interface
+// and SBOM reports are enabled.
+func (r *WorkloadController) sbomPlugin() (SbomPlugin, bool) {
+	if r.SbomReadWriter == nil || !r.ConfigData.SbomReportsEnabled() || r.AdditionalScanner {
+		return nil, false
+	}
+	plugin, ok := r.Plugin.(SbomPlugin)
+	return plugin, ok
+}
+
+// writeClusterSbomReport caches the given report data as a ClusterSbomReport
+// for the given repo digest.
+func (r *WorkloadController) writeClusterSbomReport(ctx context.Context, digest string, reportData v1alpha1.SbomReportData) error {
+	clusterReport, err := sbomreport.NewClusterReportBuilder().
+		ImageDigest(digest).
+		Data(reportData).
+		ReportTTL(r.Config.VulnerabilityScannerCacheReportTTL).
+		Get()
+	if err != nil {
+		return err
+	}
+	err = r.SbomReadWriter.WriteClusterReport(ctx, clusterReport)
+	if err != nil {
+		return fmt.Errorf("writing cluster sbom report: %w", err)
+	}
+	return nil
+}
+
+func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Object, imageDigests kube.ContainerImages) error {
 	log := r.Logger.WithValues("kind", owner.GetObjectKind().GroupVersionKind().Kind,
 		"name", owner.GetName(), "namespace", owner.GetNamespace())
 	credentials, err := r.CredentialsByWorkload(ctx, owner)
@@ -253,6 +808,9 @@ func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Obj
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
 		WithCredentials(credentials).
+		WithContainerImageDigests(imageDigests).
+		WithScanImageDigests(r.Config.VulnerabilityScannerScanImageDigests).
+		WithAdditionalScanner(r.AdditionalScanner).
 		Get()
 
 	if err != nil {
@@ -282,6 +840,7 @@ func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Obj
 		}
 		return fmt.Errorf("creating scan job failed: %s: %w", scanJob.Namespace+"/"+scanJob.Name, err)
 	}
+	metrics.ScanJobsSubmitted.WithLabelValues(metrics.VulnerabilityReport, r.PluginContext.GetName()).Inc()
 
 	for _, secret := range secrets {
 		err = controllerutil.SetOwnerReference(scanJob, secret, r.Client.Scheme())
@@ -316,14 +875,20 @@ func (r *WorkloadController) reconcileJobs() reconcile.Func {
 			return ctrl.Result{}, nil
 		}
 
+		var status string
 		switch jobCondition := job.Status.Conditions[0].Type; jobCondition {
 		case batchv1.JobComplete:
+			status = metrics.StatusComplete
 			err = r.processCompleteScanJob(ctx, job)
 		case batchv1.JobFailed:
+			status = metrics.StatusFailed
 			err = r.processFailedScanJob(ctx, job)
 		default:
 			err = fmt.Errorf("unrecognized scan job condition: %v", jobCondition)
 		}
+		if err == nil {
+			metrics.RecordScanJobFinished(metrics.VulnerabilityReport, job.Labels[starboard.LabelVulnerabilityReportScanner], job, status)
+		}
 
 		return ctrl.Result{}, err
 	}
@@ -352,23 +917,40 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 		return fmt.Errorf("getting container images: %w", err)
 	}
 
+	imageDigests, err := kube.GetContainerImageDigestsFromJob(job)
+	if err != nil {
+		return fmt.Errorf("getting container image digests: %w", err)
+	}
+
 	podSpecHash, ok := job.Labels[starboard.LabelResourceSpecHash]
 	if !ok {
 		return fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
 	}
 
-	hasReports, err := r.hasReports(ctx, ownerRef, podSpecHash, containerImages)
+	reports, err := r.findReports(ctx, ownerRef, podSpecHash)
 	if err != nil {
 		return err
 	}
 
-	if hasReports {
+	// Reports updated before the scan job was created are outdated and
+	// overwritten, e.g. when the workload is rescanned periodically.
+	var upToDateReports []v1alpha1.VulnerabilityReport
+	for _, report := range reports {
+		if !report.Report.UpdateTimestamp.Time.Before(job.CreationTimestamp.Time) {
+			upToDateReports = append(upToDateReports, report)
+		}
+	}
+
+	if hasReportsForContainers(upToDateReports, containerImages) {
 		log.V(1).Info("VulnerabilityReports already exist", "owner", owner)
 		log.V(1).Info("Deleting complete scan job", "owner", owner)
 		return r.deleteJob(ctx, job)
 	}
 
+	sbomPlugin, sbomEnabled := r.sbomPlugin()
+
 	var vulnerabilityReports []v1alpha1.VulnerabilityReport
+	var sbomReports []v1alpha1.SbomReport
 
 	for containerName, containerImage := range containerImages {
 		logsStream, err := r.LogsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
@@ -383,32 +965,78 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 			}
 			return fmt.Errorf("getting logs for pod %q: %w", job.Namespace+"/"+job.Name, err)
 		}
+		// Logs are parsed twice if SBOM reports are enabled, therefore
+		// they're read into memory once.
+		var logs []byte
+		if sbomEnabled {
+			logs, err = io.ReadAll(logsStream)
+			_ = logsStream.Close()
+			if err != nil {
+				return fmt.Errorf("reading logs for pod %q: %w", job.Namespace+"/"+job.Name, err)
+			}
+			logsStream = io.NopCloser(bytes.NewReader(logs))
+		}
 		reportData, err := r.Plugin.ParseVulnerabilityReportData(r.PluginContext, containerImage, logsStream)
 		if err != nil {
 			return err
 		}
 		_ = logsStream.Close()
 
-		reportBuilder := NewReportBuilder(r.Client.Scheme()).
-			Controller(owner).
-			Container(containerName).
-			Data(reportData).
-			PodSpecHash(podSpecHash)
+		if digest, ok := imageDigests[containerName]; ok && r.Config.VulnerabilityScannerScanImageDigests {
+			err = SetArtifactDigest(&reportData, containerImage, digest)
+			if err != nil {
+				return err
+			}
+		}
 
-		if r.Config.VulnerabilityScannerReportTTL != nil {
-			reportBuilder.ReportTTL(r.Config.VulnerabilityScannerReportTTL)
+		report, err := r.newReport(owner, containerName, podSpecHash, reportData)
+		if err != nil {
+			return err
 		}
 
-		report, err := reportBuilder.Get()
+		vulnerabilityReports = append(vulnerabilityReports, report)
+
+		if digest, ok := imageDigests[containerName]; ok && r.cacheEnabled() {
+			err = r.writeClusterReport(ctx, digest, reportData)
+			if err != nil {
+				return err
+			}
+		}
 
+		if !sbomEnabled {
+			continue
+		}
+		sbomData, err := sbomPlugin.ParseSbomReportData(r.PluginContext, containerImage, io.NopCloser(bytes.NewReader(logs)))
 		if err != nil {
 			return err
 		}
+		sbomReport, err := sbomreport.NewReportBuilder(r.Client.Scheme()).
+			Controller(owner).
+			Container(containerName).
+			Data(sbomData).
+			PodSpecHash(podSpecHash).
+			Get()
+		if err != nil {
+			return err
+		}
+		sbomReports = append(sbomReports, sbomReport)
 
-		vulnerabilityReports = append(vulnerabilityReports, report)
+		if digest, ok := imageDigests[containerName]; ok && r.cacheEnabled() {
+			err = r.writeClusterSbomReport(ctx, digest, sbomData)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if sbomEnabled {
+		err = r.SbomReadWriter.Write(ctx, sbomReports)
+		if err != nil {
+			return err
+		}
 	}
 
-	err = r.ReadWriter.Write(ctx, vulnerabilityReports)
+	err = r.writeReports(ctx, owner, vulnerabilityReports)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,93 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/google/go-containerregistry/pkg/name"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TagResolver resolves the digest that a tag of a container image currently
+// points to in its registry.
+type TagResolver interface {
+	ResolveTag(ctx context.Context, imageRef string, credentials *docker.Auth) (string, error)
+}
+
+// DriftChecker detects that the tag of a container image was moved to a
+// different digest than the one run by a workload, e.g. when a new image was
+// pushed as :latest after pods had been started.
+type DriftChecker struct {
+	TagResolver
+	ext.Clock
+
+	// Interval is the minimum duration between subsequent checks of the same
+	// VulnerabilityReport.
+	Interval time.Duration
+}
+
+// NextCheck returns the duration to wait before the tag of the Artifact of the
+// given report should be resolved again.
+func (c *DriftChecker) NextCheck(report v1alpha1.VulnerabilityReport) time.Duration {
+	if report.Report.Drift == nil {
+		return 0
+	}
+	next := report.Report.Drift.CheckTimestamp.Add(c.Interval).Sub(c.Clock.Now())
+	if next < 0 {
+		return 0
+	}
+	return next
+}
+
+// Check resolves the tag of the specified image, which is the image of the
+// container that the given report was generated for, and records the result
+// as v1alpha1.ImageDrift of the report. It returns false if the check was
+// skipped, because it's not due yet, the report does not have the digest of
+// the scanned Artifact, or the image is not referenced by tag.
+func (c *DriftChecker) Check(ctx context.Context, report *v1alpha1.VulnerabilityReport, image string, credentials *docker.Auth) (bool, error) {
+	digest := report.Report.Artifact.Digest
+	if digest == "" || c.NextCheck(*report) > 0 {
+		return false, nil
+	}
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return false, fmt.Errorf("parsing image reference: %s: %w", image, err)
+	}
+	if _, ok := ref.(name.Tag); !ok {
+		return false, nil
+	}
+
+	tagDigest, err := c.TagResolver.ResolveTag(ctx, image, credentials)
+	if err != nil {
+		return false, err
+	}
+	report.Report.Drift = &v1alpha1.ImageDrift{
+		Digest:         tagDigest,
+		Drifted:        tagDigest != digest,
+		CheckTimestamp: metav1.NewTime(c.Clock.Now()),
+	}
+	return true, nil
+}
+
+// SetArtifactDigest records in the given report data that the specified repo
+// digest, e.g. nginx@sha256:..., was scanned for the given container image.
+// The tag of the Artifact is kept, or set from the image if the report data
+// was generated by scanning the repo digest.
+func SetArtifactDigest(data *v1alpha1.VulnerabilityReportData, image, repoDigest string) error {
+	digest, err := name.NewDigest(repoDigest)
+	if err != nil {
+		return fmt.Errorf("parsing repo digest: %s: %w", repoDigest, err)
+	}
+	data.Artifact.Digest = digest.DigestStr()
+	if data.Artifact.Tag != "" {
+		return nil
+	}
+	if tag, err := name.NewTag(image); err == nil {
+		data.Artifact.Tag = tag.TagStr()
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	runningDigest = "sha256:2963fc49cc50883ba9af25f977a9997ff9af06b45c12d968b7985dc1e9254e4b"
+	latestDigest  = "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+)
+
+type fakeTagResolver map[string]string
+
+func (r fakeTagResolver) ResolveTag(_ context.Context, imageRef string, _ *docker.Auth) (string, error) {
+	digest, ok := r[imageRef]
+	if !ok {
+		return "", errors.New("manifest unknown")
+	}
+	return digest, nil
+}
+
+func TestDriftChecker_Check(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+	checker := &vulnerabilityreport.DriftChecker{
+		TagResolver: fakeTagResolver{
+			"nginx:1.16":   runningDigest,
+			"nginx:latest": latestDigest,
+		},
+		Clock:    ext.NewFixedClock(now),
+		Interval: time.Hour,
+	}
+
+	newReport := func(digest string, drift *v1alpha1.ImageDrift) *v1alpha1.VulnerabilityReport {
+		return &v1alpha1.VulnerabilityReport{
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Digest: digest},
+				Drift:    drift,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		report          *v1alpha1.VulnerabilityReport
+		image           string
+		expectedChecked bool
+		expectedDrift   *v1alpha1.ImageDrift
+		expectedError   string
+	}{
+		{
+			name:            "Should record that tag points to running digest",
+			report:          newReport(runningDigest, nil),
+			image:           "nginx:1.16",
+			expectedChecked: true,
+			expectedDrift: &v1alpha1.ImageDrift{
+				Digest:         runningDigest,
+				Drifted:        false,
+				CheckTimestamp: metav1.NewTime(now),
+			},
+		},
+		{
+			name:            "Should record that tag was moved to different digest",
+			report:          newReport(runningDigest, nil),
+			image:           "nginx:latest",
+			expectedChecked: true,
+			expectedDrift: &v1alpha1.ImageDrift{
+				Digest:         latestDigest,
+				Drifted:        true,
+				CheckTimestamp: metav1.NewTime(now),
+			},
+		},
+		{
+			name: "Should check again after interval",
+			report: newReport(runningDigest, &v1alpha1.ImageDrift{
+				Digest:         runningDigest,
+				CheckTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+			}),
+			image:           "nginx:latest",
+			expectedChecked: true,
+			expectedDrift: &v1alpha1.ImageDrift{
+				Digest:         latestDigest,
+				Drifted:        true,
+				CheckTimestamp: metav1.NewTime(now),
+			},
+		},
+		{
+			name: "Should not check before interval elapses",
+			report: newReport(runningDigest, &v1alpha1.ImageDrift{
+				Digest:         runningDigest,
+				CheckTimestamp: metav1.NewTime(now.Add(-30 * time.Minute)),
+			}),
+			image:           "nginx:latest",
+			expectedChecked: false,
+			expectedDrift: &v1alpha1.ImageDrift{
+				Digest:         runningDigest,
+				CheckTimestamp: metav1.NewTime(now.Add(-30 * time.Minute)),
+			},
+		},
+		{
+			name:            "Should not check report without digest",
+			report:          newReport("", nil),
+			image:           "nginx:latest",
+			expectedChecked: false,
+		},
+		{
+			name:            "Should not check image referenced by digest",
+			report:          newReport(runningDigest, nil),
+			image:           "nginx@" + runningDigest,
+			expectedChecked: false,
+		},
+		{
+			name:          "Should return error when tag cannot be resolved",
+			report:        newReport(runningDigest, nil),
+			image:         "nginx:1.17",
+			expectedError: "manifest unknown",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checked, err := checker.Check(context.Background(), tc.report, tc.image, nil)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, tc.report.Report.Drift)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChecked, checked)
+			assert.Equal(t, tc.expectedDrift, tc.report.Report.Drift)
+		})
+	}
+}
+
+func TestDriftChecker_NextCheck(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+	checker := &vulnerabilityreport.DriftChecker{
+		Clock:    ext.NewFixedClock(now),
+		Interval: time.Hour,
+	}
+
+	assert.Equal(t, time.Duration(0), checker.NextCheck(v1alpha1.VulnerabilityReport{}))
+	assert.Equal(t, 45*time.Minute, checker.NextCheck(v1alpha1.VulnerabilityReport{
+		Report: v1alpha1.VulnerabilityReportData{
+			Drift: &v1alpha1.ImageDrift{CheckTimestamp: metav1.NewTime(now.Add(-15 * time.Minute))},
+		},
+	}))
+	assert.Equal(t, time.Duration(0), checker.NextCheck(v1alpha1.VulnerabilityReport{
+		Report: v1alpha1.VulnerabilityReportData{
+			Drift: &v1alpha1.ImageDrift{CheckTimestamp: metav1.NewTime(now.Add(-2 * time.Hour))},
+		},
+	}))
+}
+
+func TestSetArtifactDigest(t *testing.T) {
+	t.Run("Should keep tag of scanned image", func(t *testing.T) {
+		data := v1alpha1.VulnerabilityReportData{
+			Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+		}
+		err := vulnerabilityreport.SetArtifactDigest(&data, "nginx:1.16", "nginx@"+runningDigest)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16", Digest: runningDigest}, data.Artifact)
+	})
+
+	t.Run("Should set tag of image if repo digest was scanned", func(t *testing.T) {
+		data := v1alpha1.VulnerabilityReportData{
+			Artifact: v1alpha1.Artifact{Repository: "library/nginx", Digest: runningDigest},
+		}
+		err := vulnerabilityreport.SetArtifactDigest(&data, "nginx", "docker.io/library/nginx@"+runningDigest)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.Artifact{Repository: "library/nginx", Tag: "latest", Digest: runningDigest}, data.Artifact)
+	})
+
+	t.Run("Should return error when repo digest is invalid", func(t *testing.T) {
+		data := v1alpha1.VulnerabilityReportData{}
+		err := vulnerabilityreport.SetArtifactDigest(&data, "nginx:1.16", "nginx:1.16")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parsing repo digest: nginx:1.16")
+	})
+}
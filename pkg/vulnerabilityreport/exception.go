@@ -0,0 +1,206 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ExceptionTarget describes the workload, which a VulnerabilityReport was
+// generated for, as selected by VulnerabilityExceptions.
+type ExceptionTarget struct {
+	// NamespaceLabels are labels of the workload's namespace.
+	NamespaceLabels map[string]string
+
+	// WorkloadLabels are labels of the workload.
+	WorkloadLabels map[string]string
+}
+
+// ValidateException returns an error if selectors of the given exception
+// cannot be parsed. Invalid exceptions do not match any vulnerability.
+func ValidateException(exception v1alpha1.VulnerabilityException) error {
+	if exception.Spec.VulnerabilityID == "" {
+		return fmt.Errorf("vulnerability ID must be set")
+	}
+	if _, err := path.Match(exception.Spec.Image, ""); err != nil {
+		return fmt.Errorf("parsing image pattern: %w", err)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(exception.Spec.NamespaceSelector); err != nil {
+		return fmt.Errorf("parsing namespace selector: %w", err)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(exception.Spec.Selector); err != nil {
+		return fmt.Errorf("parsing selector: %w", err)
+	}
+	return nil
+}
+
+// ApplyExceptions marks vulnerabilities in the given report data as
+// suppressed if they match any of the given exceptions, which are not expired
+// at the specified time, and updates the summary accordingly. Suppressed
+// vulnerabilities that no longer match any exception re-surface. It returns
+// true if the report data has been modified.
+func ApplyExceptions(data *v1alpha1.VulnerabilityReportData, exceptions []v1alpha1.VulnerabilityException, target ExceptionTarget, now time.Time) bool {
+	var active []v1alpha1.VulnerabilityException
+	for _, exception := range exceptions {
+		if exception.IsExpired(now) || ValidateException(exception) != nil {
+			continue
+		}
+		if !matchesTarget(exception, data, target) {
+			continue
+		}
+		active = append(active, exception)
+	}
+
+	modified := false
+	for i := range data.Vulnerabilities {
+		vulnerability := &data.Vulnerabilities[i]
+
+		suppressedBy := ""
+		for _, exception := range active {
+			if exception.Spec.VulnerabilityID != vulnerability.VulnerabilityID {
+				continue
+			}
+			if exception.Spec.Resource != "" && exception.Spec.Resource != vulnerability.Resource {
+				continue
+			}
+			suppressedBy = exception.Name
+			break
+		}
+
+		suppressed := suppressedBy != ""
+		if vulnerability.Suppressed == suppressed && vulnerability.SuppressedBy == suppressedBy {
+			continue
+		}
+		switch {
+		case suppressed && !vulnerability.Suppressed:
+			updateSummary(&data.Summary, vulnerability.Severity, -1)
+			data.Summary.SuppressedCount++
+		case !suppressed && vulnerability.Suppressed:
+			updateSummary(&data.Summary, vulnerability.Severity, 1)
+			data.Summary.SuppressedCount--
+		}
+		vulnerability.Suppressed = suppressed
+		vulnerability.SuppressedBy = suppressedBy
+		modified = true
+	}
+	return modified
+}
+
+// NextExpiry returns the earliest expiry time of the given exceptions after
+// the specified time. The second return value is false if none of the
+// exceptions expires in the future.
+func NextExpiry(exceptions []v1alpha1.VulnerabilityException, now time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	for _, exception := range exceptions {
+		if exception.Spec.ExpiresAt == nil || exception.IsExpired(now) {
+			continue
+		}
+		if !found || exception.Spec.ExpiresAt.Time.Before(next) {
+			next = exception.Spec.ExpiresAt.Time
+			found = true
+		}
+	}
+	return next, found
+}
+
+func matchesTarget(exception v1alpha1.VulnerabilityException, data *v1alpha1.VulnerabilityReportData, target ExceptionTarget) bool {
+	if exception.Spec.Image != "" && !matchesImage(exception.Spec.Image, data) {
+		return false
+	}
+	if exception.Spec.NamespaceSelector != nil {
+		selector, _ := metav1.LabelSelectorAsSelector(exception.Spec.NamespaceSelector)
+		if !selector.Matches(labels.Set(target.NamespaceLabels)) {
+			return false
+		}
+	}
+	if exception.Spec.Selector != nil {
+		selector, _ := metav1.LabelSelectorAsSelector(exception.Spec.Selector)
+		if !selector.Matches(labels.Set(target.WorkloadLabels)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesImage matches the given glob pattern against the image repository
+// with the registry server and optionally the tag.
+func matchesImage(pattern string, data *v1alpha1.VulnerabilityReportData) bool {
+	name := data.Artifact.Repository
+	if data.Registry.Server != "" {
+		name = data.Registry.Server + "/" + name
+	}
+	if matched, _ := path.Match(pattern, name); matched {
+		return true
+	}
+	if data.Artifact.Tag == "" {
+		return false
+	}
+	matched, _ := path.Match(pattern, name+":"+data.Artifact.Tag)
+	return matched
+}
+
+func updateSummary(summary *v1alpha1.VulnerabilitySummary, severity v1alpha1.Severity, delta int) {
+	switch severity {
+	case v1alpha1.SeverityCritical:
+		summary.CriticalCount += delta
+	case v1alpha1.SeverityHigh:
+		summary.HighCount += delta
+	case v1alpha1.SeverityMedium:
+		summary.MediumCount += delta
+	case v1alpha1.SeverityLow:
+		summary.LowCount += delta
+	default:
+		summary.UnknownCount += delta
+	}
+}
+
+// ExceptionApplier applies VulnerabilityExceptions to VulnerabilityReports of
+// a given workload.
+type ExceptionApplier struct {
+	client.Client
+	ext.Clock
+}
+
+// Apply applies VulnerabilityExceptions to the given reports of the
+// specified workload in place. It returns true if any report was modified.
+func (a *ExceptionApplier) Apply(ctx context.Context, workload client.Object, reports []v1alpha1.VulnerabilityReport) (bool, error) {
+	var exceptions v1alpha1.VulnerabilityExceptionList
+	err := a.Client.List(ctx, &exceptions)
+	if err != nil {
+		return false, fmt.Errorf("listing vulnerability exceptions: %w", err)
+	}
+	target, err := exceptionTarget(ctx, a.Client, workload)
+	if err != nil {
+		return false, err
+	}
+	modified := false
+	for i := range reports {
+		if ApplyExceptions(&reports[i].Report, exceptions.Items, target, a.Clock.Now()) {
+			modified = true
+		}
+	}
+	return modified, nil
+}
+
+// exceptionTarget returns labels of the given workload and its namespace.
+func exceptionTarget(ctx context.Context, c client.Client, workload client.Object) (ExceptionTarget, error) {
+	var namespace corev1.Namespace
+	err := c.Get(ctx, client.ObjectKey{Name: workload.GetNamespace()}, &namespace)
+	if err != nil && !k8sapierror.IsNotFound(err) {
+		return ExceptionTarget{}, fmt.Errorf("getting namespace from cache: %w", err)
+	}
+	return ExceptionTarget{
+		NamespaceLabels: namespace.Labels,
+		WorkloadLabels:  workload.GetLabels(),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/go-logr/logr"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ExceptionController watches v1alpha1.VulnerabilityException instances and
+// applies them to existing VulnerabilityReports. Reports are reconciled again
+// when an exception expires so that suppressed vulnerabilities re-surface.
+type ExceptionController struct {
+	logr.Logger
+	client.Client
+	kube.ObjectResolver
+	ext.Clock
+}
+
+func (r *ExceptionController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.VulnerabilityException{}).
+		Complete(r.reconcileExceptions())
+}
+
+// reconcileExceptions applies all VulnerabilityExceptions to all
+// VulnerabilityReports whenever any exception is created, updated, or
+// deleted, because the spec of a deleted exception is not known.
+func (r *ExceptionController) reconcileExceptions() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("exception", req.Name)
+
+		var exceptions v1alpha1.VulnerabilityExceptionList
+		err := r.Client.List(ctx, &exceptions)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("listing vulnerability exceptions: %w", err)
+		}
+		for _, exception := range exceptions.Items {
+			if exception.Name != req.Name {
+				continue
+			}
+			if err := ValidateException(exception); err != nil {
+				log.Error(err, "Ignoring invalid vulnerability exception")
+			}
+		}
+
+		var reports v1alpha1.VulnerabilityReportList
+		err = r.Client.List(ctx, &reports)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("listing vulnerability reports: %w", err)
+		}
+
+		now := r.Clock.Now()
+		for _, report := range reports.Items {
+			ownerRef, err := kube.ObjectRefFromObjectMeta(report.ObjectMeta)
+			if err != nil {
+				log.V(1).Info("Ignoring report without owner", "report", report.Namespace+"/"+report.Name)
+				continue
+			}
+			owner, err := r.ObjectFromObjectRef(ctx, ownerRef)
+			if err != nil {
+				if k8sapierror.IsNotFound(err) {
+					continue
+				}
+				return ctrl.Result{}, fmt.Errorf("getting report owner from cache: %w", err)
+			}
+			target, err := exceptionTarget(ctx, r.Client, owner)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !ApplyExceptions(&report.Report, exceptions.Items, target, now) {
+				continue
+			}
+			log.V(1).Info("Updating suppressed vulnerabilities", "report", report.Namespace+"/"+report.Name,
+				"suppressedCount", report.Report.Summary.SuppressedCount)
+			err = r.Client.Update(ctx, report.DeepCopy())
+			if err != nil && !k8sapierror.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("updating vulnerability report: %w", err)
+			}
+		}
+
+		if expiresAt, ok := NextExpiry(exceptions.Items, now); ok {
+			return ctrl.Result{RequeueAfter: expiresAt.Sub(now)}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+}
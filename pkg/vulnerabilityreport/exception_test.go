@@ -0,0 +1,220 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newReportDataWithVulnerabilities() v1alpha1.VulnerabilityReportData {
+	vulnerabilities := []v1alpha1.Vulnerability{
+		{VulnerabilityID: "CVE-2021-44228", Resource: "org.apache.logging.log4j:log4j-core", Severity: v1alpha1.SeverityCritical},
+		{VulnerabilityID: "CVE-2022-1271", Resource: "gzip", Severity: v1alpha1.SeverityHigh},
+		{VulnerabilityID: "CVE-2022-1271", Resource: "xz-utils", Severity: v1alpha1.SeverityHigh},
+	}
+	return v1alpha1.VulnerabilityReportData{
+		Registry:        v1alpha1.Registry{Server: "index.docker.io"},
+		Artifact:        v1alpha1.Artifact{Repository: "library/app", Tag: "1.2.3"},
+		Summary:         v1alpha1.VulnerabilitySummaryFromVulnerabilities(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}
+}
+
+func TestApplyExceptions(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+	target := vulnerabilityreport.ExceptionTarget{
+		NamespaceLabels: map[string]string{"team": "payments"},
+		WorkloadLabels:  map[string]string{"app": "checkout"},
+	}
+
+	testCases := []struct {
+		name               string
+		exception          v1alpha1.VulnerabilityExceptionSpec
+		expectedSuppressed []bool
+	}{
+		{
+			name:               "Should suppress vulnerability by ID",
+			exception:          v1alpha1.VulnerabilityExceptionSpec{VulnerabilityID: "CVE-2022-1271"},
+			expectedSuppressed: []bool{false, true, true},
+		},
+		{
+			name:               "Should suppress vulnerability by ID and package",
+			exception:          v1alpha1.VulnerabilityExceptionSpec{VulnerabilityID: "CVE-2022-1271", Resource: "gzip"},
+			expectedSuppressed: []bool{false, true, false},
+		},
+		{
+			name: "Should suppress vulnerability in matching image repository",
+			exception: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID: "CVE-2021-44228",
+				Image:           "index.docker.io/library/app",
+			},
+			expectedSuppressed: []bool{true, false, false},
+		},
+		{
+			name: "Should suppress vulnerability in matching image tag",
+			exception: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID: "CVE-2021-44228",
+				Image:           "*/library/app:1.2.*",
+			},
+			expectedSuppressed: []bool{true, false, false},
+		},
+		{
+			name: "Should not suppress vulnerability in other image",
+			exception: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID: "CVE-2021-44228",
+				Image:           "index.docker.io/library/app:2.*",
+			},
+			expectedSuppressed: []bool{false, false, false},
+		},
+		{
+			name: "Should suppress vulnerability in workload matching selectors",
+			exception: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID:   "CVE-2021-44228",
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+			},
+			expectedSuppressed: []bool{true, false, false},
+		},
+		{
+			name: "Should not suppress vulnerability in namespace not matching selector",
+			exception: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID:   "CVE-2021-44228",
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "shipping"}},
+			},
+			expectedSuppressed: []bool{false, false, false},
+		},
+		{
+			name: "Should not suppress vulnerability when exception expired",
+			exception: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID: "CVE-2021-44228",
+				ExpiresAt:       &metav1.Time{Time: now.Add(-time.Second)},
+			},
+			expectedSuppressed: []bool{false, false, false},
+		},
+		{
+			name: "Should not suppress vulnerability when exception is invalid",
+			exception: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID: "CVE-2021-44228",
+				Image:           "[",
+			},
+			expectedSuppressed: []bool{false, false, false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := newReportDataWithVulnerabilities()
+			exceptions := []v1alpha1.VulnerabilityException{
+				{ObjectMeta: metav1.ObjectMeta{Name: "accepted"}, Spec: tc.exception},
+			}
+
+			vulnerabilityreport.ApplyExceptions(&data, exceptions, target, now)
+
+			var suppressed []bool
+			for _, vulnerability := range data.Vulnerabilities {
+				suppressed = append(suppressed, vulnerability.Suppressed)
+				if vulnerability.Suppressed {
+					assert.Equal(t, "accepted", vulnerability.SuppressedBy)
+				} else {
+					assert.Empty(t, vulnerability.SuppressedBy)
+				}
+			}
+			assert.Equal(t, tc.expectedSuppressed, suppressed)
+			assert.Equal(t, v1alpha1.VulnerabilitySummaryFromVulnerabilities(data.Vulnerabilities), data.Summary)
+		})
+	}
+
+	t.Run("Should re-surface vulnerability when exception expires", func(t *testing.T) {
+		data := newReportDataWithVulnerabilities()
+		exceptions := []v1alpha1.VulnerabilityException{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "log4shell"},
+				Spec: v1alpha1.VulnerabilityExceptionSpec{
+					VulnerabilityID: "CVE-2021-44228",
+					ExpiresAt:       &metav1.Time{Time: now.Add(time.Hour)},
+				},
+			},
+		}
+
+		modified := vulnerabilityreport.ApplyExceptions(&data, exceptions, target, now)
+		assert.True(t, modified)
+		assert.Equal(t, 0, data.Summary.CriticalCount)
+		assert.Equal(t, 1, data.Summary.SuppressedCount)
+
+		modified = vulnerabilityreport.ApplyExceptions(&data, exceptions, target, now.Add(30*time.Minute))
+		assert.False(t, modified)
+
+		modified = vulnerabilityreport.ApplyExceptions(&data, exceptions, target, now.Add(time.Hour))
+		assert.True(t, modified)
+		assert.False(t, data.Vulnerabilities[0].Suppressed)
+		assert.Equal(t, 1, data.Summary.CriticalCount)
+		assert.Equal(t, 0, data.Summary.SuppressedCount)
+	})
+}
+
+func TestNextExpiry(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	_, ok := vulnerabilityreport.NextExpiry([]v1alpha1.VulnerabilityException{
+		{Spec: v1alpha1.VulnerabilityExceptionSpec{VulnerabilityID: "CVE-2021-44228"}},
+	}, now)
+	assert.False(t, ok)
+
+	next, ok := vulnerabilityreport.NextExpiry([]v1alpha1.VulnerabilityException{
+		{Spec: v1alpha1.VulnerabilityExceptionSpec{ExpiresAt: &metav1.Time{Time: now.Add(-time.Hour)}}},
+		{Spec: v1alpha1.VulnerabilityExceptionSpec{ExpiresAt: &metav1.Time{Time: now.Add(2 * time.Hour)}}},
+		{Spec: v1alpha1.VulnerabilityExceptionSpec{ExpiresAt: &metav1.Time{Time: now.Add(time.Hour)}}},
+	}, now)
+	require.True(t, ok)
+	assert.Equal(t, now.Add(time.Hour), next)
+}
+
+func TestExceptionApplier_Apply(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "payments",
+				Labels: map[string]string{"team": "payments"},
+			},
+		},
+		&v1alpha1.VulnerabilityException{
+			ObjectMeta: metav1.ObjectMeta{Name: "log4shell"},
+			Spec: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID:   "CVE-2021-44228",
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				Justification:     "JNDI lookups are disabled",
+			},
+		},
+	).Build()
+	applier := &vulnerabilityreport.ExceptionApplier{
+		Client: client,
+		Clock:  ext.NewFixedClock(now),
+	}
+
+	reports := []v1alpha1.VulnerabilityReport{
+		{Report: newReportDataWithVulnerabilities()},
+	}
+	modified, err := applier.Apply(context.TODO(), &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "checkout-6799fc88d8",
+			Namespace: "payments",
+		},
+	}, reports)
+	require.NoError(t, err)
+	assert.True(t, modified)
+	assert.True(t, reports[0].Report.Vulnerabilities[0].Suppressed)
+	assert.Equal(t, "log4shell", reports[0].Report.Vulnerabilities[0].SuppressedBy)
+	assert.Equal(t, 1, reports[0].Report.Summary.SuppressedCount)
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/notification"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,8 +17,12 @@ import (
 //
 // Write creates or updates the given slice of v1alpha1.VulnerabilityReport
 // instances.
+//
+// WriteClusterReport creates or updates the given v1alpha1.ClusterVulnerabilityReport
+// instance.
 type Writer interface {
 	Write(context.Context, []v1alpha1.VulnerabilityReport) error
+	WriteClusterReport(context.Context, v1alpha1.ClusterVulnerabilityReport) error
 }
 
 // Reader is the interface that wraps methods for finding v1alpha1.VulnerabilityReport objects.
@@ -28,9 +34,18 @@ type Writer interface {
 // v1alpha1.VulnerabilityReport objects owned by related Kubernetes objects.
 // For example, if the given owner is a Deployment, but reports are owned by the
 // active ReplicaSet (current revision) this method will return the reports.
+//
+// FindClusterReportByImageDigest returns the v1alpha1.ClusterVulnerabilityReport
+// cached by the given scanner for the given repo digest or nil if the report
+// is not found.
+//
+// FindClusterReportByNode returns the v1alpha1.ClusterVulnerabilityReport
+// generated for the node with the given name or nil if the report is not found.
 type Reader interface {
 	FindByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
 	FindByOwnerInHierarchy(ctx context.Context, object kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
+	FindClusterReportByImageDigest(ctx context.Context, scanner, digest string) (*v1alpha1.ClusterVulnerabilityReport, error)
+	FindClusterReportByNode(ctx context.Context, nodeName string) (*v1alpha1.ClusterVulnerabilityReport, error)
 }
 
 type ReadWriter interface {
@@ -40,6 +55,7 @@ type ReadWriter interface {
 
 type readWriter struct {
 	*kube.ObjectResolver
+	notifier notification.Notifier
 }
 
 // NewReadWriter constructs a new ReadWriter which is using the client package
@@ -51,6 +67,16 @@ func NewReadWriter(client client.Client) ReadWriter {
 	}
 }
 
+// NewNotifyingReadWriter constructs a new ReadWriter which, in addition to
+// what NewReadWriter does, sends notifications about findings that were not
+// present in the previous version of a written report.
+func NewNotifyingReadWriter(client client.Client, notifier notification.Notifier) ReadWriter {
+	return &readWriter{
+		ObjectResolver: &kube.ObjectResolver{Client: client},
+		notifier:       notifier,
+	}
+}
+
 func (r *readWriter) Write(ctx context.Context, reports []v1alpha1.VulnerabilityReport) error {
 	for _, report := range reports {
 		err := r.createOrUpdate(ctx, report)
@@ -73,6 +99,54 @@ func (r *readWriter) createOrUpdate(ctx context.Context, report v1alpha1.Vulnera
 		copied.Labels = report.Labels
 		copied.Report = report.Report
 
+		err = r.Update(ctx, copied)
+		if err != nil {
+			return err
+		}
+		r.notify(ctx, report, &existing.Report)
+		return nil
+	}
+
+	if errors.IsNotFound(err) {
+		err = r.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		r.notify(ctx, report, nil)
+		return nil
+	}
+
+	return err
+}
+
+// notify sends a notification about findings in the given report that are
+// not present in the previous report data, if the notifier is configured.
+// Reports labeled with starboard.LabelVulnerabilityReportAdditional are
+// skipped to avoid duplicate notifications about the same findings.
+func (r *readWriter) notify(ctx context.Context, report v1alpha1.VulnerabilityReport, previous *v1alpha1.VulnerabilityReportData) {
+	if r.notifier == nil {
+		return
+	}
+	if _, ok := report.Labels[starboard.LabelVulnerabilityReportAdditional]; ok {
+		return
+	}
+	if n, ok := notification.NewVulnerabilityNotification(report, previous); ok {
+		r.notifier.Notify(ctx, n)
+	}
+}
+
+func (r *readWriter) WriteClusterReport(ctx context.Context, report v1alpha1.ClusterVulnerabilityReport) error {
+	var existing v1alpha1.ClusterVulnerabilityReport
+	err := r.Get(ctx, types.NamespacedName{
+		Name: report.Name,
+	}, &existing)
+
+	if err == nil {
+		copied := existing.DeepCopy()
+		copied.Labels = report.Labels
+		copied.Annotations = report.Annotations
+		copied.Report = report.Report
+
 		return r.Update(ctx, copied)
 	}
 
@@ -120,3 +194,35 @@ func (r *readWriter) FindByOwnerInHierarchy(ctx context.Context, owner kube.Obje
 
 	return reports, nil
 }
+
+func (r *readWriter) FindClusterReportByImageDigest(ctx context.Context, scanner, digest string) (*v1alpha1.ClusterVulnerabilityReport, error) {
+	report, err := r.findClusterReport(ctx, GetClusterReportName(scanner, digest))
+	if err != nil || report == nil {
+		return nil, err
+	}
+	// Never return a report cached for another repo digest or scanner, even
+	// if the names collide.
+	if report.Annotations[starboard.AnnotationImageDigest] != digest ||
+		report.Labels[starboard.LabelVulnerabilityReportScanner] != scanner {
+		return nil, nil
+	}
+	return report, nil
+}
+
+func (r *readWriter) FindClusterReportByNode(ctx context.Context, nodeName string) (*v1alpha1.ClusterVulnerabilityReport, error) {
+	return r.findClusterReport(ctx, GetNodeReportName(nodeName))
+}
+
+func (r *readWriter) findClusterReport(ctx context.Context, name string) (*v1alpha1.ClusterVulnerabilityReport, error) {
+	var report v1alpha1.ClusterVulnerabilityReport
+	err := r.Get(ctx, types.NamespacedName{
+		Name: name,
+	}, &report)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return report.DeepCopy(), nil
+}
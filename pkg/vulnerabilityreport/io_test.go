@@ -6,10 +6,12 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/notification"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -257,4 +259,161 @@ func TestNewReadWriter(t *testing.T) {
 		}, reports)
 	})
 
+	t.Run("Should create and find ClusterVulnerabilityReport by image digest", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+
+		found, err := readWriter.FindClusterReportByImageDigest(context.TODO(), "Trivy", "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		report, err := vulnerabilityreport.NewClusterReportBuilder().
+			Scanner("Trivy").
+			ImageDigest("nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514").
+			Data(v1alpha1.VulnerabilityReportData{
+				Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 3},
+			}).
+			Get()
+		require.NoError(t, err)
+		err = readWriter.WriteClusterReport(context.TODO(), report)
+		require.NoError(t, err)
+
+		found, err = readWriter.FindClusterReportByImageDigest(context.TODO(), "Trivy", "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, vulnerabilityreport.GetClusterReportName("Trivy", "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514"), found.Name)
+		assert.Equal(t, 3, found.Report.Summary.CriticalCount)
+		assert.Equal(t, "sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514", found.Report.Artifact.Digest)
+
+		found, err = readWriter.FindClusterReportByImageDigest(context.TODO(), "Grype", "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Should not find ClusterVulnerabilityReport cached for another image digest", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+
+		report, err := vulnerabilityreport.NewClusterReportBuilder().
+			Scanner("Trivy").
+			ImageDigest("nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514").
+			Get()
+		require.NoError(t, err)
+		report.Annotations[starboard.AnnotationImageDigest] = "nginx@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+		err = readWriter.WriteClusterReport(context.TODO(), report)
+		require.NoError(t, err)
+
+		found, err := readWriter.FindClusterReportByImageDigest(context.TODO(), "Trivy", "nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Should create and find ClusterVulnerabilityReport by node", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+
+		found, err := readWriter.FindClusterReportByNode(context.TODO(), "kind-worker")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		report, err := vulnerabilityreport.NewNodeReportBuilder(kubernetesScheme).
+			Controller(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "kind-worker"}}).
+			Data(v1alpha1.VulnerabilityReportData{
+				Summary: v1alpha1.VulnerabilitySummary{HighCount: 2},
+			}).
+			Get()
+		require.NoError(t, err)
+		err = readWriter.WriteClusterReport(context.TODO(), report)
+		require.NoError(t, err)
+
+		found, err = readWriter.FindClusterReportByNode(context.TODO(), "kind-worker")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "node-kind-worker", found.Name)
+		assert.Equal(t, 2, found.Report.Summary.HighCount)
+	})
+
+	t.Run("Should notify about new vulnerabilities", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		notifier := &recordingNotifier{}
+		readWriter := vulnerabilityreport.NewNotifyingReadWriter(client, notifier)
+
+		report := v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment-app-nginx",
+				Namespace: "qa",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Deployment",
+					starboard.LabelResourceName:      "app",
+					starboard.LabelResourceNamespace: "qa",
+					starboard.LabelContainerName:     "nginx",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{VulnerabilityID: "CVE-2022-0001", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+				},
+			},
+		}
+		err := readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{report})
+		require.NoError(t, err)
+
+		// Writing the same report again must not notify about known vulnerabilities.
+		err = readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{report})
+		require.NoError(t, err)
+
+		report.Report.Vulnerabilities = append(report.Report.Vulnerabilities,
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-0002", Resource: "curl", Severity: v1alpha1.SeverityHigh})
+		err = readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{report})
+		require.NoError(t, err)
+
+		require.Len(t, notifier.notifications, 2)
+		assert.Equal(t, []notification.Finding{
+			{ID: "CVE-2022-0001", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+		}, notifier.notifications[0].Findings)
+		assert.Equal(t, []notification.Finding{
+			{ID: "CVE-2022-0002", Resource: "curl", Severity: v1alpha1.SeverityHigh},
+		}, notifier.notifications[1].Findings)
+		assert.Equal(t, kube.ObjectRef{Kind: kube.KindDeployment, Name: "app", Namespace: "qa"},
+			notifier.notifications[1].Owner)
+	})
+
+	t.Run("Should not notify about vulnerabilities in additional reports", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		notifier := &recordingNotifier{}
+		readWriter := vulnerabilityreport.NewNotifyingReadWriter(client, notifier)
+
+		err := readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app-nginx-grype",
+					Namespace: "qa",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:                  "Deployment",
+						starboard.LabelResourceName:                  "app",
+						starboard.LabelResourceNamespace:             "qa",
+						starboard.LabelContainerName:                 "nginx",
+						starboard.LabelVulnerabilityReportScanner:    "Grype",
+						starboard.LabelVulnerabilityReportAdditional: "true",
+					},
+				},
+				Report: v1alpha1.VulnerabilityReportData{
+					Vulnerabilities: []v1alpha1.Vulnerability{
+						{VulnerabilityID: "CVE-2022-0001", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, notifier.notifications)
+	})
+
+}
+
+type recordingNotifier struct {
+	notifications []notification.Notification
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, notification notification.Notification) {
+	n.notifications = append(n.notifications, notification)
 }
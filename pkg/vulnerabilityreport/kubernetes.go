@@ -0,0 +1,94 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kubecve"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+)
+
+// KubernetesReportName is the name of the v1alpha1.ClusterVulnerabilityReport
+// which holds known vulnerabilities of the Kubernetes API server.
+const KubernetesReportName = "kubernetes-apiserver"
+
+// KubernetesVersionChecker checks the version of the Kubernetes API server
+// against the static list of known Kubernetes vulnerabilities and saves
+// results as the v1alpha1.ClusterVulnerabilityReport named
+// KubernetesReportName, which is annotated with the date when the list was
+// last updated.
+//
+// It implements the manager.Runnable interface, so that the version is
+// checked when the manager starts and then periodically with the given
+// Interval, which allows detecting upgrades of the control plane.
+type KubernetesVersionChecker struct {
+	logr.Logger
+	discovery.ServerVersionInterface
+	Writer
+	ext.Clock
+	starboard.BuildInfo
+	Interval time.Duration
+}
+
+// Start checks the version of the API server until the given context is
+// cancelled. Errors are logged and the check is retried after the Interval.
+func (c *KubernetesVersionChecker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Check(ctx); err != nil {
+			c.Logger.Error(err, "Unable to check Kubernetes version")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check checks the current version of the API server and writes the report.
+func (c *KubernetesVersionChecker) Check(ctx context.Context) error {
+	info, err := c.ServerVersion()
+	if err != nil {
+		return fmt.Errorf("getting server version: %w", err)
+	}
+	vulnerabilities, err := kubecve.Check(kubecve.KubeAPIServer, info.GitVersion)
+	if err != nil {
+		return err
+	}
+
+	c.Logger.V(1).Info("Writing Kubernetes vulnerability report", "version", info.GitVersion,
+		"vulnerabilities", len(vulnerabilities))
+	return c.Writer.WriteClusterReport(ctx, v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: KubernetesReportName,
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+			},
+			Annotations: map[string]string{
+				starboard.AnnotationKubernetesAdvisoriesUpdated: kubecve.AdvisoriesUpdated,
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(c.Clock.Now()),
+			Scanner: v1alpha1.Scanner{
+				Name:    "Starboard",
+				Vendor:  "Aqua Security",
+				Version: c.BuildInfo.Version,
+			},
+			Artifact: v1alpha1.Artifact{
+				Repository: "kubernetes",
+				Tag:        info.GitVersion,
+			},
+			Summary:         v1alpha1.VulnerabilitySummaryFromVulnerabilities(vulnerabilities),
+			Vulnerabilities: vulnerabilities,
+		},
+	})
+}
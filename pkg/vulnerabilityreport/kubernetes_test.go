@@ -0,0 +1,70 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kubecve"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeServerVersion string
+
+func (v fakeServerVersion) ServerVersion() (*version.Info, error) {
+	return &version.Info{GitVersion: string(v)}, nil
+}
+
+func TestKubernetesVersionChecker_Check(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	checker := &vulnerabilityreport.KubernetesVersionChecker{
+		Logger:                 logr.Discard(),
+		ServerVersionInterface: fakeServerVersion("v1.20.4-eks-6b7464"),
+		Writer:                 vulnerabilityreport.NewReadWriter(client),
+		Clock:                  ext.NewFixedClock(now),
+		BuildInfo:              starboard.BuildInfo{Version: "0.15.4"},
+		Interval:               time.Hour,
+	}
+
+	err := checker.Check(context.TODO())
+	require.NoError(t, err)
+
+	var report v1alpha1.ClusterVulnerabilityReport
+	err = client.Get(context.TODO(), types.NamespacedName{Name: vulnerabilityreport.KubernetesReportName}, &report)
+	require.NoError(t, err)
+
+	assert.Equal(t, now, report.Report.UpdateTimestamp.UTC())
+	assert.Equal(t, v1alpha1.Scanner{Name: "Starboard", Vendor: "Aqua Security", Version: "0.15.4"}, report.Report.Scanner)
+	assert.Equal(t, v1alpha1.Artifact{Repository: "kubernetes", Tag: "v1.20.4-eks-6b7464"}, report.Report.Artifact)
+	assert.Equal(t, kubecve.AdvisoriesUpdated, report.Annotations[starboard.AnnotationKubernetesAdvisoriesUpdated])
+	var ids []string
+	for _, vulnerability := range report.Report.Vulnerabilities {
+		assert.Equal(t, "kube-apiserver", vulnerability.Resource)
+		ids = append(ids, vulnerability.VulnerabilityID)
+	}
+	assert.Equal(t, []string{"CVE-2020-8554", "CVE-2021-25735", "CVE-2021-25737", "CVE-2021-25740"}, ids)
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{MediumCount: 2, LowCount: 2}, report.Report.Summary)
+
+	t.Run("Should update report when control plane is upgraded", func(t *testing.T) {
+		checker.ServerVersionInterface = fakeServerVersion("v1.21.1")
+		err := checker.Check(context.TODO())
+		require.NoError(t, err)
+
+		err = client.Get(context.TODO(), types.NamespacedName{Name: vulnerabilityreport.KubernetesReportName}, &report)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.21.1", report.Report.Artifact.Tag)
+		require.Len(t, report.Report.Vulnerabilities, 2)
+		assert.Equal(t, "CVE-2020-8554", report.Report.Vulnerabilities[0].VulnerabilityID)
+		assert.Equal(t, "CVE-2021-25740", report.Report.Vulnerabilities[1].VulnerabilityID)
+	})
+}
@@ -0,0 +1,111 @@
+package vulnerabilityreport
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+)
+
+// MergeReports merges report data generated by different vulnerability
+// scanners for the same container image into a single report data.
+//
+// The given scanners determine the precedence of reports, which are keyed by
+// the name of the scanner. Reports of scanners that are not listed are
+// ignored. Vulnerabilities are deduplicated by VulnerabilityID and Resource.
+// Details of a vulnerability are taken from the report of the first scanner
+// that reported it and missing details are filled in from subsequent reports.
+// The highest severity reported by any scanner wins, links are combined, and
+// names of all scanners that reported the vulnerability are recorded. Names,
+// vendors, and versions of the scanners are joined with commas.
+func MergeReports(scanners []string, reports map[string]v1alpha1.VulnerabilityReportData) v1alpha1.VulnerabilityReportData {
+	var merged v1alpha1.VulnerabilityReportData
+	var names, vendors, versions []string
+	var vulnerabilities []v1alpha1.Vulnerability
+	index := make(map[string]int)
+
+	for _, scanner := range scanners {
+		data, ok := reports[scanner]
+		if !ok {
+			continue
+		}
+		if len(names) == 0 {
+			merged.Registry = data.Registry
+			merged.Artifact = data.Artifact
+			merged.Drift = data.Drift.DeepCopy()
+		}
+		if data.UpdateTimestamp.After(merged.UpdateTimestamp.Time) {
+			merged.UpdateTimestamp = data.UpdateTimestamp
+		}
+		names = append(names, scanner)
+		vendors = append(vendors, data.Scanner.Vendor)
+		versions = append(versions, data.Scanner.Version)
+
+		for _, vulnerability := range data.Vulnerabilities {
+			key := vulnerability.VulnerabilityID + "/" + vulnerability.Resource
+			i, ok := index[key]
+			if !ok {
+				added := *vulnerability.DeepCopy()
+				added.Scanners = []string{scanner}
+				index[key] = len(vulnerabilities)
+				vulnerabilities = append(vulnerabilities, added)
+				continue
+			}
+			mergeVulnerability(&vulnerabilities[i], vulnerability, scanner)
+		}
+	}
+
+	merged.Scanner.Name = strings.Join(names, ",")
+	merged.Scanner.Vendor = strings.Join(vendors, ",")
+	merged.Scanner.Version = strings.Join(versions, ",")
+	merged.Vulnerabilities = vulnerabilities
+	merged.Summary = v1alpha1.VulnerabilitySummaryFromVulnerabilities(vulnerabilities)
+	return merged
+}
+
+// mergeVulnerability fills in details of the given vulnerability, which are
+// missing in the merged one, and records that it was reported by the given
+// scanner.
+func mergeVulnerability(merged *v1alpha1.Vulnerability, vulnerability v1alpha1.Vulnerability, scanner string) {
+	merged.Scanners = append(merged.Scanners, scanner)
+	if severityIndex(vulnerability.Severity) < severityIndex(merged.Severity) {
+		merged.Severity = vulnerability.Severity
+	}
+	if merged.InstalledVersion == "" {
+		merged.InstalledVersion = vulnerability.InstalledVersion
+	}
+	if merged.FixedVersion == "" {
+		merged.FixedVersion = vulnerability.FixedVersion
+	}
+	if merged.Title == "" {
+		merged.Title = vulnerability.Title
+	}
+	if merged.Description == "" {
+		merged.Description = vulnerability.Description
+	}
+	if merged.PrimaryLink == "" {
+		merged.PrimaryLink = vulnerability.PrimaryLink
+	}
+	if merged.Score == nil && vulnerability.Score != nil {
+		score := *vulnerability.Score
+		merged.Score = &score
+	}
+	for _, link := range vulnerability.Links {
+		if !ext.SliceContainsString(merged.Links, link) {
+			merged.Links = append(merged.Links, link)
+		}
+	}
+	if vulnerability.Suppressed && !merged.Suppressed {
+		merged.Suppressed = true
+		merged.SuppressedBy = vulnerability.SuppressedBy
+	}
+}
+
+// severityIndex returns the position of the given severity in severityOrder,
+// or the lowest position if the severity is not ordered, e.g. NONE.
+func severityIndex(severity v1alpha1.Severity) int {
+	if i, ok := severityOrder[severity]; ok {
+		return i
+	}
+	return len(severityOrder)
+}
@@ -0,0 +1,138 @@
+package vulnerabilityreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestMergeReports(t *testing.T) {
+	trivyTimestamp := metav1.NewTime(time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC))
+	grypeTimestamp := metav1.NewTime(time.Date(2022, time.June, 1, 11, 0, 0, 0, time.UTC))
+
+	reports := map[string]v1alpha1.VulnerabilityReportData{
+		"Trivy": {
+			UpdateTimestamp: trivyTimestamp,
+			Scanner:         v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.25.2"},
+			Registry:        v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact:        v1alpha1.Artifact{Repository: "library/alpine", Tag: "3.10.2"},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2019-1549",
+					Resource:         "libcrypto1.1",
+					InstalledVersion: "1.1.1c-r0",
+					FixedVersion:     "1.1.1d-r0",
+					Severity:         v1alpha1.SeverityMedium,
+					Title:            "openssl: information disclosure in fork()",
+					Links:            []string{"https://avd.aquasec.com/nvd/cve-2019-1549"},
+				},
+				{
+					VulnerabilityID:  "CVE-2019-1563",
+					Resource:         "libcrypto1.1",
+					InstalledVersion: "1.1.1c-r0",
+					FixedVersion:     "1.1.1d-r0",
+					Severity:         v1alpha1.SeverityLow,
+					Title:            "openssl: information disclosure in PKCS7_dataDecode and CMS_decrypt_set1_pkey",
+				},
+			},
+		},
+		"Grype": {
+			UpdateTimestamp: grypeTimestamp,
+			Scanner:         v1alpha1.Scanner{Name: "Grype", Vendor: "Anchore", Version: "0.38.0"},
+			Registry:        v1alpha1.Registry{Server: "docker.io"},
+			Artifact:        v1alpha1.Artifact{Repository: "library/alpine", Tag: "3.10.2"},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2019-1549",
+					Resource:         "libcrypto1.1",
+					InstalledVersion: "1.1.1c-r0",
+					FixedVersion:     "1.1.1d-r0",
+					Severity:         v1alpha1.SeverityHigh,
+					Title:            "OpenSSL 1.1.1 introduced a rewritten random number generator (RNG).",
+					Score:            pointer.Float64Ptr(5.3),
+					Links:            []string{"https://nvd.nist.gov/vuln/detail/CVE-2019-1549"},
+				},
+				{
+					VulnerabilityID:  "CVE-2019-14697",
+					Resource:         "musl",
+					InstalledVersion: "1.1.22-r3",
+					FixedVersion:     "1.1.22-r4",
+					Severity:         v1alpha1.SeverityCritical,
+					Title:            "musl libc through 1.1.23 has an x87 floating-point stack adjustment imbalance.",
+				},
+			},
+		},
+		"Aqua": {
+			Scanner: v1alpha1.Scanner{Name: "Aqua", Vendor: "Aqua Security", Version: "5.3"},
+		},
+	}
+
+	t.Run("Should merge reports in order of scanners", func(t *testing.T) {
+		merged := vulnerabilityreport.MergeReports([]string{"Trivy", "Grype"}, reports)
+		assert.Equal(t, v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: grypeTimestamp,
+			Scanner:         v1alpha1.Scanner{Name: "Trivy,Grype", Vendor: "Aqua Security,Anchore", Version: "0.25.2,0.38.0"},
+			Registry:        v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact:        v1alpha1.Artifact{Repository: "library/alpine", Tag: "3.10.2"},
+			Summary: v1alpha1.VulnerabilitySummary{
+				CriticalCount: 1,
+				HighCount:     1,
+				LowCount:      1,
+			},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2019-1549",
+					Resource:         "libcrypto1.1",
+					InstalledVersion: "1.1.1c-r0",
+					FixedVersion:     "1.1.1d-r0",
+					Severity:         v1alpha1.SeverityHigh,
+					Title:            "openssl: information disclosure in fork()",
+					Score:            pointer.Float64Ptr(5.3),
+					Links: []string{
+						"https://avd.aquasec.com/nvd/cve-2019-1549",
+						"https://nvd.nist.gov/vuln/detail/CVE-2019-1549",
+					},
+					Scanners: []string{"Trivy", "Grype"},
+				},
+				{
+					VulnerabilityID:  "CVE-2019-1563",
+					Resource:         "libcrypto1.1",
+					InstalledVersion: "1.1.1c-r0",
+					FixedVersion:     "1.1.1d-r0",
+					Severity:         v1alpha1.SeverityLow,
+					Title:            "openssl: information disclosure in PKCS7_dataDecode and CMS_decrypt_set1_pkey",
+					Scanners:         []string{"Trivy"},
+				},
+				{
+					VulnerabilityID:  "CVE-2019-14697",
+					Resource:         "musl",
+					InstalledVersion: "1.1.22-r3",
+					FixedVersion:     "1.1.22-r4",
+					Severity:         v1alpha1.SeverityCritical,
+					Title:            "musl libc through 1.1.23 has an x87 floating-point stack adjustment imbalance.",
+					Scanners:         []string{"Grype"},
+				},
+			},
+		}, merged)
+	})
+
+	t.Run("Should take details from first scanner", func(t *testing.T) {
+		merged := vulnerabilityreport.MergeReports([]string{"Grype", "Trivy"}, reports)
+		assert.Equal(t, "Grype,Trivy", merged.Scanner.Name)
+		assert.Equal(t, v1alpha1.Registry{Server: "docker.io"}, merged.Registry)
+		assert.Equal(t, "OpenSSL 1.1.1 introduced a rewritten random number generator (RNG).", merged.Vulnerabilities[0].Title)
+		assert.Equal(t, []string{"Grype", "Trivy"}, merged.Vulnerabilities[0].Scanners)
+	})
+
+	t.Run("Should merge available reports only", func(t *testing.T) {
+		merged := vulnerabilityreport.MergeReports([]string{"Trivy", "Clair"}, reports)
+		assert.Equal(t, v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.25.2"}, merged.Scanner)
+		assert.Len(t, merged.Vulnerabilities, 2)
+		assert.Equal(t, v1alpha1.VulnerabilitySummary{MediumCount: 1, LowCount: 1}, merged.Summary)
+	})
+}
@@ -0,0 +1,390 @@
+package vulnerabilityreport
+
+import (
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/kubecve"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NodeController reconciles corev1.Node and corev1.Job objects to scan root
+// file systems of cluster nodes for vulnerabilities with the NodePlugin and
+// saves results as v1alpha1.ClusterVulnerabilityReport objects, which are
+// controlled by the corev1.Node for which they were generated. Versions of
+// the kubelet and kube-proxy reported by the node are also checked against
+// known Kubernetes vulnerabilities and added to the report.
+//
+// A node is rescanned when its system information changes, e.g. the kubelet
+// is upgraded, or when its report is older than
+// etc.Config.NodeVulnerabilityScannerRescanInterval.
+type NodeController struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	client.Client
+	controller.LimitChecker
+	kube.LogsReader
+	NodePlugin
+	starboard.PluginContext
+	ReadWriter
+	ext.Clock
+}
+
+func (r *NodeController) SetupWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("nodevulnerabilityreport").
+		For(&corev1.Node{}, builder.WithPredicates(IsLinuxNode)).
+		Owns(&v1alpha1.ClusterVulnerabilityReport{}).
+		Complete(r.reconcileNodes())
+	if err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("nodevulnerabilityreport-job").
+		For(&batchv1.Job{}, builder.WithPredicates(
+			InNamespace(r.Config.Namespace),
+			ManagedByStarboardOperator,
+			IsNodeVulnerabilityReportScan,
+			JobHasAnyCondition,
+		)).
+		Complete(r.reconcileJobs())
+}
+
+func (r *NodeController) reconcileNodes() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("node", req.NamespacedName)
+
+		node := &corev1.Node{}
+
+		log.V(1).Info("Getting node from cache")
+		err := r.Client.Get(ctx, req.NamespacedName, node)
+		if err != nil {
+			if k8sapierror.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached node that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting node from cache: %w", err)
+		}
+
+		log.V(1).Info("Checking whether node vulnerability report exists")
+		report, err := r.ReadWriter.FindClusterReportByNode(ctx, node.Name)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("checking whether report exists: %w", err)
+		}
+
+		if report != nil {
+			requeueAfter, stale := r.isStale(report, node)
+			if !stale {
+				log.V(1).Info("Node vulnerability report exists", "requeueAfter", requeueAfter)
+				return ctrl.Result{RequeueAfter: requeueAfter}, nil
+			}
+			log.V(1).Info("Node vulnerability report is stale")
+		}
+
+		log.V(1).Info("Checking whether node scan has been scheduled")
+		job, err := r.getScanJob(ctx, node)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("checking whether scan job has been scheduled: %w", err)
+		}
+		if job != nil {
+			log.V(1).Info("Node scan has been scheduled",
+				"job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
+			return ctrl.Result{}, nil
+		}
+
+		limitExceeded, jobsCount, err := r.LimitChecker.Check(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.V(1).Info("Checking scan jobs limit", "count", jobsCount, "limit", r.ConcurrentScanJobsLimit)
+
+		if limitExceeded {
+			metrics.ScanJobsThrottled.WithLabelValues(metrics.NodeVulnerabilityReport).Inc()
+			log.V(1).Info("Pushing back scan job", "count", jobsCount, "retryAfter", r.ScanJobRetryAfter)
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		}
+
+		job, err = r.newScanJob(node)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("preparing job: %w", err)
+		}
+
+		log.V(1).Info("Scheduling node scan")
+		err = r.Client.Create(ctx, job)
+		if err != nil {
+			if k8sapierror.IsAlreadyExists(err) {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("creating job: %w", err)
+		}
+		metrics.ScanJobsSubmitted.WithLabelValues(metrics.NodeVulnerabilityReport, r.PluginContext.GetName()).Inc()
+
+		return ctrl.Result{}, nil
+	}
+}
+
+// isStale returns true if the given report was generated for different
+// system information of the node or if it's older than the rescan interval.
+// Otherwise, it returns the duration after which the report becomes stale.
+func (r *NodeController) isStale(report *v1alpha1.ClusterVulnerabilityReport, node *corev1.Node) (time.Duration, bool) {
+	if report.Labels[starboard.LabelResourceSpecHash] != GetNodeInfoHash(node) {
+		return 0, true
+	}
+	age := r.Clock.Now().Sub(report.Report.UpdateTimestamp.Time)
+	if age >= r.Config.NodeVulnerabilityScannerRescanInterval {
+		return 0, true
+	}
+	return r.Config.NodeVulnerabilityScannerRescanInterval - age, false
+}
+
+func (r *NodeController) getScanJob(ctx context.Context, node *corev1.Node) (*batchv1.Job, error) {
+	job := &batchv1.Job{}
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: r.Config.Namespace, Name: GetNodeScanJobName(node)}, job)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting job from cache: %w", err)
+	}
+	return job, nil
+}
+
+func (r *NodeController) newScanJob(node *corev1.Node) (*batchv1.Job, error) {
+	templateSpec, err := r.NodePlugin.GetNodeScanJobSpec(r.PluginContext, *node)
+	if err != nil {
+		return nil, err
+	}
+
+	scanJobTolerations, err := r.ConfigData.GetScanJobTolerations()
+	if err != nil {
+		return nil, err
+	}
+	templateSpec.Tolerations = append(templateSpec.Tolerations, scanJobTolerations...)
+
+	scanJobAnnotations, err := r.ConfigData.GetScanJobAnnotations()
+	if err != nil {
+		return nil, err
+	}
+
+	scanJobPodTemplateLabels, err := r.ConfigData.GetScanJobPodTemplateLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	labelsSet := labels.Set{
+		starboard.LabelResourceKind:                   string(kube.KindNode),
+		starboard.LabelResourceName:                   node.Name,
+		starboard.LabelK8SAppManagedBy:                starboard.AppStarboard,
+		starboard.LabelNodeVulnerabilityReportScanner: r.PluginContext.GetName(),
+	}
+
+	podTemplateLabelsSet := make(labels.Set)
+	for index, element := range labelsSet {
+		podTemplateLabelsSet[index] = element
+	}
+	for index, element := range scanJobPodTemplateLabels {
+		podTemplateLabelsSet[index] = element
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetNodeScanJobName(node),
+			Namespace: r.Config.Namespace,
+			Labels:    labelsSet,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32Ptr(0),
+			Completions:           pointer.Int32Ptr(1),
+			ActiveDeadlineSeconds: kube.GetActiveDeadlineSeconds(r.Config.ScanJobTimeout),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podTemplateLabelsSet,
+					Annotations: scanJobAnnotations,
+				},
+				Spec: templateSpec,
+			},
+		},
+	}, nil
+}
+
+func (r *NodeController) reconcileJobs() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("job", req.NamespacedName)
+
+		job := &batchv1.Job{}
+		log.V(1).Info("Getting job from cache")
+		err := r.Client.Get(ctx, req.NamespacedName, job)
+		if err != nil {
+			if k8sapierror.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached job that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting job from cache: %w", err)
+		}
+
+		if len(job.Status.Conditions) == 0 {
+			log.V(1).Info("Ignoring job without conditions")
+			return ctrl.Result{}, nil
+		}
+
+		var status string
+		switch jobCondition := job.Status.Conditions[0].Type; jobCondition {
+		case batchv1.JobComplete:
+			status = metrics.StatusComplete
+			err = r.processCompleteScanJob(ctx, job)
+		case batchv1.JobFailed:
+			status = metrics.StatusFailed
+			err = r.processFailedScanJob(ctx, job)
+		default:
+			err = fmt.Errorf("unrecognized job condition: %v", jobCondition)
+		}
+		if err == nil {
+			metrics.RecordScanJobFinished(metrics.NodeVulnerabilityReport, r.PluginContext.GetName(), job, status)
+		}
+
+		return ctrl.Result{}, err
+	}
+}
+
+func (r *NodeController) processCompleteScanJob(ctx context.Context, job *batchv1.Job) error {
+	log := r.Logger.WithValues("job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
+
+	nodeRef, err := kube.ObjectRefFromObjectMeta(job.ObjectMeta)
+	if err != nil {
+		return fmt.Errorf("getting owner ref from scan job metadata: %w", err)
+	}
+
+	node := &corev1.Node{}
+	err = r.Client.Get(ctx, client.ObjectKey{Name: nodeRef.Name}, node)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Ignore processing scan job for node that must have been deleted")
+			log.V(1).Info("Deleting complete scan job")
+			return r.deleteJob(ctx, job)
+		}
+		return fmt.Errorf("getting node from cache: %w", err)
+	}
+
+	logsStream, err := r.LogsReader.GetLogsByJobAndContainerName(ctx, job, r.NodePlugin.GetNodeScanContainerName())
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Cached job must have been deleted")
+			return nil
+		}
+		if kube.IsPodControlledByJobNotFound(err) {
+			log.V(1).Info("Pod must have been deleted")
+			return r.deleteJob(ctx, job)
+		}
+		return fmt.Errorf("getting logs: %w", err)
+	}
+	defer func() {
+		_ = logsStream.Close()
+	}()
+
+	data, err := r.NodePlugin.ParseNodeVulnerabilityReportData(r.PluginContext, *node, logsStream)
+	if err != nil {
+		return fmt.Errorf("parsing logs: %w", err)
+	}
+
+	data.Vulnerabilities = append(data.Vulnerabilities, r.checkNodeComponents(log, node)...)
+	data.Summary = v1alpha1.VulnerabilitySummaryFromVulnerabilities(data.Vulnerabilities)
+
+	report, err := NewNodeReportBuilder(r.Client.Scheme()).
+		Controller(node).
+		Data(data).
+		Get()
+	if err != nil {
+		return fmt.Errorf("building report: %w", err)
+	}
+	report.Annotations = map[string]string{
+		starboard.AnnotationKubernetesAdvisoriesUpdated: kubecve.AdvisoriesUpdated,
+	}
+
+	log.V(1).Info("Writing node vulnerability report", "reportName", report.Name)
+	err = r.ReadWriter.WriteClusterReport(ctx, report)
+	if err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	log.V(1).Info("Deleting complete scan job")
+	return r.deleteJob(ctx, job)
+}
+
+// checkNodeComponents returns known Kubernetes vulnerabilities of the kubelet
+// and kube-proxy in versions reported by the given node.
+func (r *NodeController) checkNodeComponents(log logr.Logger, node *corev1.Node) []v1alpha1.Vulnerability {
+	var vulnerabilities []v1alpha1.Vulnerability
+	components := map[string]string{
+		kubecve.Kubelet:   node.Status.NodeInfo.KubeletVersion,
+		kubecve.KubeProxy: node.Status.NodeInfo.KubeProxyVersion,
+	}
+	for _, component := range []string{kubecve.Kubelet, kubecve.KubeProxy} {
+		version := components[component]
+		if version == "" {
+			continue
+		}
+		found, err := kubecve.Check(component, version)
+		if err != nil {
+			log.Error(err, "Unable to check Kubernetes component", "component", component, "version", version)
+			continue
+		}
+		vulnerabilities = append(vulnerabilities, found...)
+	}
+	return vulnerabilities
+}
+
+func (r *NodeController) deleteJob(ctx context.Context, job *batchv1.Job) error {
+	err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("deleting job: %w", err)
+	}
+	return nil
+}
+
+func (r *NodeController) processFailedScanJob(ctx context.Context, job *batchv1.Job) error {
+	log := r.Logger.WithValues("job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
+
+	statuses, err := r.LogsReader.GetTerminatedContainersStatusesByJob(ctx, job)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Cached job must have been deleted")
+			return nil
+		}
+		if kube.IsPodControlledByJobNotFound(err) {
+			log.V(1).Info("Pod must have been deleted")
+			return r.deleteJob(ctx, job)
+		}
+		return err
+	}
+	for container, status := range statuses {
+		if status.ExitCode == 0 {
+			continue
+		}
+		log.Error(nil, "Scan job container", "container", container, "status.reason", status.Reason, "status.message", status.Message)
+	}
+	log.V(1).Info("Deleting failed scan job")
+	return r.deleteJob(ctx, job)
+}
@@ -1,6 +1,7 @@
 package vulnerabilityreport
 
 import (
+	"context"
 	"io"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -32,3 +33,57 @@ type Plugin interface {
 	ParseVulnerabilityReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (
 		v1alpha1.VulnerabilityReportData, error)
 }
+
+// SbomPlugin is implemented by vulnerability scanner plugins that can also
+// inventory all packages installed in container images, including packages
+// without vulnerabilities.
+type SbomPlugin interface {
+
+	// ParseSbomReportData is a callback to parse and convert logs of the pod
+	// controlled by the scan job to v1alpha1.SbomReportData. It's called with
+	// the same logs as Plugin.ParseVulnerabilityReportData if SBOM reports
+	// are enabled with the starboard.KeySbomReportsEnabled setting.
+	ParseSbomReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (
+		v1alpha1.SbomReportData, error)
+}
+
+// ImageScanner defines the interface between Starboard and vulnerability
+// scanners that scan container images in-process, i.e. without creating
+// Kubernetes jobs.
+type ImageScanner interface {
+
+	// Scan pulls the specified image with optional Docker registry
+	// credentials and returns the vulnerabilities found in it.
+	Scan(ctx context.Context, imageRef string, credentials *docker.Auth) (
+		v1alpha1.VulnerabilityReportData, error)
+}
+
+// ImageScannerPlugin is implemented by vulnerability scanner plugins that
+// scan container images without creating Kubernetes jobs, e.g. by calling a
+// remote scanner service. If a plugin implements this interface, the
+// returned ImageScanner is used instead of scan jobs.
+type ImageScannerPlugin interface {
+
+	// GetImageScanner returns the ImageScanner that scans container images
+	// with settings of the plugin in the given context.
+	GetImageScanner(ctx starboard.PluginContext) (ImageScanner, error)
+}
+
+// NodePlugin is implemented by vulnerability scanner plugins that can also
+// scan root file systems of cluster nodes.
+type NodePlugin interface {
+
+	// GetNodeScanJobSpec describes the pod that will be created by Starboard
+	// when it schedules a Kubernetes job to scan the specified node.
+	GetNodeScanJobSpec(ctx starboard.PluginContext, node corev1.Node) (corev1.PodSpec, error)
+
+	// ParseNodeVulnerabilityReportData is a callback to parse and convert
+	// logs of the pod controlled by the node scan job to
+	// v1alpha1.VulnerabilityReportData.
+	ParseNodeVulnerabilityReportData(ctx starboard.PluginContext, node corev1.Node, logsReader io.ReadCloser) (
+		v1alpha1.VulnerabilityReportData, error)
+
+	// GetNodeScanContainerName returns the name of the container, which
+	// writes the output of the node scan to its logs.
+	GetNodeScanContainerName() string
+}
@@ -0,0 +1,104 @@
+package vulnerabilityreport
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/gorhill/cronexpr"
+)
+
+// RescanPolicy determines when a workload that already has VulnerabilityReports
+// is scanned again to find vulnerabilities published since the last scan.
+//
+// Either Interval or Schedule can be set. Rescans are disabled if neither is set.
+type RescanPolicy struct {
+	// Interval is the age of VulnerabilityReports after which the workload
+	// is rescanned.
+	Interval time.Duration
+
+	// Schedule is a cron expression. The workload is rescanned at the first
+	// activation time after VulnerabilityReports were updated.
+	Schedule string
+
+	// Jitter is the upper bound of the delay added to the rescan time of each
+	// workload. It distributes rescans over time so that scan jobs do not
+	// exceed the concurrent scan jobs limit all at once.
+	Jitter time.Duration
+}
+
+// NewRescanPolicy constructs a cluster-wide RescanPolicy from the given
+// operator's config.
+func NewRescanPolicy(config etc.Config) RescanPolicy {
+	policy := RescanPolicy{
+		Schedule: config.VulnerabilityScannerRescanSchedule,
+		Jitter:   config.VulnerabilityScannerRescanJitter,
+	}
+	if config.VulnerabilityScannerRescanInterval != nil {
+		policy.Interval = *config.VulnerabilityScannerRescanInterval
+	}
+	return policy
+}
+
+// Enabled returns true if periodic rescans are enabled by this policy.
+func (p RescanPolicy) Enabled() bool {
+	return p.Interval > 0 || p.Schedule != ""
+}
+
+// ForNamespace returns a copy of this policy overridden with the
+// starboard.AnnotationVulnerabilityRescanInterval or
+// starboard.AnnotationVulnerabilityRescanSchedule annotation of a namespace.
+// Setting the interval annotation to "0" disables rescans in the namespace.
+func (p RescanPolicy) ForNamespace(annotations map[string]string) (RescanPolicy, error) {
+	if value, ok := annotations[starboard.AnnotationVulnerabilityRescanInterval]; ok {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return RescanPolicy{}, fmt.Errorf("parsing annotation: %s: %w", starboard.AnnotationVulnerabilityRescanInterval, err)
+		}
+		p.Interval = interval
+		p.Schedule = ""
+	}
+	if value, ok := annotations[starboard.AnnotationVulnerabilityRescanSchedule]; ok {
+		if _, err := cronexpr.Parse(value); err != nil {
+			return RescanPolicy{}, fmt.Errorf("parsing annotation: %s: %w", starboard.AnnotationVulnerabilityRescanSchedule, err)
+		}
+		p.Schedule = value
+		p.Interval = 0
+	}
+	return p, nil
+}
+
+// NextRescan returns the time at which the given workload should be rescanned
+// provided that its VulnerabilityReports were last updated at the given time.
+func (p RescanPolicy) NextRescan(workload kube.ObjectRef, lastUpdated time.Time) (time.Time, error) {
+	var next time.Time
+	switch {
+	case p.Schedule != "":
+		expr, err := cronexpr.Parse(p.Schedule)
+		if err != nil {
+			return time.Time{}, err
+		}
+		next = expr.Next(lastUpdated)
+	case p.Interval > 0:
+		next = lastUpdated.Add(p.Interval)
+	default:
+		return time.Time{}, fmt.Errorf("rescans are disabled")
+	}
+	return next.Add(p.jitter(workload)), nil
+}
+
+// jitter returns a delay between zero and RescanPolicy.Jitter. The delay is
+// derived from the workload reference so that it is stable across
+// reconciliations of the same workload.
+func (p RescanPolicy) jitter(workload kube.ObjectRef) time.Duration {
+	if p.Jitter <= 0 {
+		return 0
+	}
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(fmt.Sprintf("%s/%s/%s", workload.Kind, workload.Namespace, workload.Name)))
+	return time.Duration(float64(p.Jitter) * float64(hasher.Sum32()) / math.MaxUint32)
+}
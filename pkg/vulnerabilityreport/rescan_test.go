@@ -0,0 +1,145 @@
+package vulnerabilityreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRescanPolicy(t *testing.T) {
+	t.Run("Should disable rescans by default", func(t *testing.T) {
+		policy := vulnerabilityreport.NewRescanPolicy(etc.Config{})
+		assert.False(t, policy.Enabled())
+	})
+
+	t.Run("Should enable rescans with interval", func(t *testing.T) {
+		interval := 24 * time.Hour
+		policy := vulnerabilityreport.NewRescanPolicy(etc.Config{
+			VulnerabilityScannerRescanInterval: &interval,
+			VulnerabilityScannerRescanJitter:   time.Hour,
+		})
+		assert.True(t, policy.Enabled())
+		assert.Equal(t, vulnerabilityreport.RescanPolicy{
+			Interval: 24 * time.Hour,
+			Jitter:   time.Hour,
+		}, policy)
+	})
+
+	t.Run("Should enable rescans with schedule", func(t *testing.T) {
+		policy := vulnerabilityreport.NewRescanPolicy(etc.Config{
+			VulnerabilityScannerRescanSchedule: "0 2 * * *",
+		})
+		assert.True(t, policy.Enabled())
+	})
+}
+
+func TestRescanPolicy_ForNamespace(t *testing.T) {
+	testCases := []struct {
+		name           string
+		policy         vulnerabilityreport.RescanPolicy
+		annotations    map[string]string
+		expectedPolicy vulnerabilityreport.RescanPolicy
+		expectedError  string
+	}{
+		{
+			name:           "Should return cluster-wide policy when annotations are not set",
+			policy:         vulnerabilityreport.RescanPolicy{Interval: 24 * time.Hour},
+			annotations:    nil,
+			expectedPolicy: vulnerabilityreport.RescanPolicy{Interval: 24 * time.Hour},
+		},
+		{
+			name:   "Should override schedule with interval",
+			policy: vulnerabilityreport.RescanPolicy{Schedule: "0 2 * * *", Jitter: time.Hour},
+			annotations: map[string]string{
+				starboard.AnnotationVulnerabilityRescanInterval: "6h",
+			},
+			expectedPolicy: vulnerabilityreport.RescanPolicy{Interval: 6 * time.Hour, Jitter: time.Hour},
+		},
+		{
+			name:   "Should override interval with schedule",
+			policy: vulnerabilityreport.RescanPolicy{Interval: 24 * time.Hour},
+			annotations: map[string]string{
+				starboard.AnnotationVulnerabilityRescanSchedule: "0 4 * * 0",
+			},
+			expectedPolicy: vulnerabilityreport.RescanPolicy{Schedule: "0 4 * * 0"},
+		},
+		{
+			name:   "Should disable rescans",
+			policy: vulnerabilityreport.RescanPolicy{Interval: 24 * time.Hour},
+			annotations: map[string]string{
+				starboard.AnnotationVulnerabilityRescanInterval: "0",
+			},
+			expectedPolicy: vulnerabilityreport.RescanPolicy{},
+		},
+		{
+			name: "Should return error when interval is invalid",
+			annotations: map[string]string{
+				starboard.AnnotationVulnerabilityRescanInterval: "daily",
+			},
+			expectedError: "parsing annotation: starboard.aquasecurity.github.io/vulnerability-rescan-interval: time: invalid duration \"daily\"",
+		},
+		{
+			name: "Should return error when schedule is invalid",
+			annotations: map[string]string{
+				starboard.AnnotationVulnerabilityRescanSchedule: "* *",
+			},
+			expectedError: "parsing annotation: starboard.aquasecurity.github.io/vulnerability-rescan-schedule: missing field(s)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := tc.policy.ForNamespace(tc.annotations)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPolicy, policy)
+		})
+	}
+}
+
+func TestRescanPolicy_NextRescan(t *testing.T) {
+	lastUpdated := time.Date(2022, time.June, 1, 10, 30, 0, 0, time.UTC)
+	workload := kube.ObjectRef{Kind: kube.KindReplicaSet, Name: "nginx-6d4cf56db6", Namespace: "default"}
+
+	t.Run("Should return next rescan by interval", func(t *testing.T) {
+		next, err := vulnerabilityreport.RescanPolicy{Interval: 24 * time.Hour}.NextRescan(workload, lastUpdated)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2022, time.June, 2, 10, 30, 0, 0, time.UTC), next)
+	})
+
+	t.Run("Should return next rescan by schedule", func(t *testing.T) {
+		next, err := vulnerabilityreport.RescanPolicy{Schedule: "0 2 * * *"}.NextRescan(workload, lastUpdated)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2022, time.June, 2, 2, 0, 0, 0, time.UTC), next)
+	})
+
+	t.Run("Should add stable jitter", func(t *testing.T) {
+		policy := vulnerabilityreport.RescanPolicy{Interval: 24 * time.Hour, Jitter: time.Hour}
+		next, err := policy.NextRescan(workload, lastUpdated)
+		require.NoError(t, err)
+		again, err := policy.NextRescan(workload, lastUpdated)
+		require.NoError(t, err)
+		assert.Equal(t, next, again)
+
+		base := time.Date(2022, time.June, 2, 10, 30, 0, 0, time.UTC)
+		assert.False(t, next.Before(base))
+		assert.True(t, next.Before(base.Add(time.Hour)))
+
+		other, err := policy.NextRescan(kube.ObjectRef{Kind: kube.KindReplicaSet, Name: "redis-7c5ddbdf54", Namespace: "default"}, lastUpdated)
+		require.NoError(t, err)
+		assert.NotEqual(t, next, other)
+	})
+
+	t.Run("Should return error when rescans are disabled", func(t *testing.T) {
+		_, err := vulnerabilityreport.RescanPolicy{}.NextRescan(workload, lastUpdated)
+		require.EqualError(t, err, "rescans are disabled")
+	})
+}
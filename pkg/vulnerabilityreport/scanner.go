@@ -2,9 +2,11 @@ package vulnerabilityreport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/runner"
 	"github.com/aquasecurity/starboard/pkg/starboard"
@@ -61,6 +63,9 @@ func NewScanner(
 // or fails. When succeeded it parses container logs and coverts the output
 // to instances of v1alpha1.VulnerabilityReport by delegating such transformation
 // logic also to the Plugin.
+//
+// If the Plugin implements the ImageScannerPlugin interface, container images
+// are scanned with its ImageScanner instead, and no job is created.
 func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error) {
 	klog.V(3).Infof("Getting Pod template for workload: %v", workload)
 
@@ -96,6 +101,15 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 		return nil, err
 	}
 
+	imageDigests, err := s.getContainerImageDigests(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("getting container image digests: %w", err)
+	}
+
+	if imageScannerPlugin, ok := s.plugin.(ImageScannerPlugin); ok {
+		return s.scanImages(ctx, imageScannerPlugin, owner, credentials, imageDigests)
+	}
+
 	job, secrets, err := NewScanJobBuilder().
 		WithPlugin(s.plugin).
 		WithPluginContext(s.pluginContext).
@@ -105,6 +119,8 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 		WithTolerations(scanJobTolerations).
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
+		WithContainerImageDigests(imageDigests).
+		WithScanImageDigests(true).
 		Get()
 
 	if err != nil {
@@ -133,6 +149,79 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 	return s.getVulnerabilityReportsByScanJob(ctx, job, owner)
 }
 
+// scanImages scans container images of the given workload with the
+// ImageScanner of the given plugin. Like scan jobs, it scans repo digests of
+// running images if they're known.
+func (s *Scanner) scanImages(ctx context.Context, plugin ImageScannerPlugin, owner client.Object, credentials map[string]docker.Auth, imageDigests kube.ContainerImages) ([]v1alpha1.VulnerabilityReport, error) {
+	imageScanner, err := plugin.GetImageScanner(s.pluginContext)
+	if err != nil {
+		return nil, fmt.Errorf("getting %s image scanner: %w", s.pluginContext.GetName(), err)
+	}
+
+	spec, err := kube.GetPodSpec(owner)
+	if err != nil {
+		return nil, err
+	}
+	podSpecHash := kube.ComputeHash(spec)
+
+	if s.opts.ScanJobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.ScanJobTimeout)
+		defer cancel()
+	}
+
+	var reports []v1alpha1.VulnerabilityReport
+	for containerName, containerImage := range kube.GetContainerImagesFromPodSpec(spec) {
+		var auth *docker.Auth
+		if c, ok := credentials[containerName]; ok {
+			auth = &c
+		}
+		scannedImage := containerImage
+		digest, hasDigest := imageDigests[containerName]
+		if hasDigest {
+			scannedImage = digest
+		}
+		klog.V(3).Infof("Scanning container image: %s", scannedImage)
+		result, err := imageScanner.Scan(ctx, scannedImage, auth)
+		if err != nil {
+			return nil, fmt.Errorf("scanning container %s: %w", containerName, err)
+		}
+		if hasDigest {
+			err = SetArtifactDigest(&result, containerImage, digest)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		report, err := NewReportBuilder(s.scheme).
+			Controller(owner).
+			Container(containerName).
+			Data(result).
+			PodSpecHash(podSpecHash).
+			Get()
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// getContainerImageDigests returns repo digests of container images run by
+// active pods of the given workload, so that the images actually running are
+// scanned rather than images that mutable tags currently point to.
+func (s *Scanner) getContainerImageDigests(ctx context.Context, workload client.Object) (kube.ContainerImages, error) {
+	pods, err := s.objectResolver.GetActivePodsByWorkload(ctx, workload)
+	if err != nil {
+		if errors.Is(err, kube.ErrReplicaSetNotFound) || errors.Is(err, kube.ErrNoRunningPods) ||
+			errors.Is(err, kube.ErrUnSupportedKind) {
+			return kube.ContainerImages{}, nil
+		}
+		return nil, err
+	}
+	return kube.GetContainerImageDigestsFromPods(pods), nil
+}
+
 // TODO To make this method look the same as the one used by the operator we
 // should resolve the owner based on labels set on the given job instead of
 // passing owner directly. The goal is for CLI and operator to create jobs
@@ -146,6 +235,11 @@ func (s *Scanner) getVulnerabilityReportsByScanJob(ctx context.Context, job *bat
 		return nil, fmt.Errorf("getting container images: %w", err)
 	}
 
+	imageDigests, err := kube.GetContainerImageDigestsFromJob(job)
+	if err != nil {
+		return nil, fmt.Errorf("getting container image digests: %w", err)
+	}
+
 	podSpecHash, ok := job.Labels[starboard.LabelResourceSpecHash]
 	if !ok {
 		return nil, fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
@@ -164,6 +258,13 @@ func (s *Scanner) getVulnerabilityReportsByScanJob(ctx context.Context, job *bat
 
 		_ = logsStream.Close()
 
+		if digest, ok := imageDigests[containerName]; ok {
+			err = SetArtifactDigest(&result, containerImage, digest)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		report, err := NewReportBuilder(s.scheme).
 			Controller(owner).
 			Container(containerName).
@@ -1 +1,115 @@
 package vulnerabilityreport_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// imageScannerPlugin is a plugin that scans images in-process and cannot
+// create scan jobs.
+type imageScannerPlugin struct {
+	scanner *fakeImageScanner
+}
+
+func (p *imageScannerPlugin) Init(_ starboard.PluginContext) error {
+	return nil
+}
+
+func (p *imageScannerPlugin) GetScanJobSpec(_ starboard.PluginContext, _ client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	return corev1.PodSpec{}, nil, errors.New("not supported")
+}
+
+func (p *imageScannerPlugin) ParseVulnerabilityReportData(_ starboard.PluginContext, _ string, _ io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	return v1alpha1.VulnerabilityReportData{}, errors.New("not supported")
+}
+
+func (p *imageScannerPlugin) GetImageScanner(_ starboard.PluginContext) (vulnerabilityreport.ImageScanner, error) {
+	return p.scanner, nil
+}
+
+type fakeImageScanner struct {
+	scanned []string
+}
+
+func (s *fakeImageScanner) Scan(_ context.Context, imageRef string, _ *docker.Auth) (v1alpha1.VulnerabilityReportData, error) {
+	s.scanned = append(s.scanned, imageRef)
+	return v1alpha1.VulnerabilityReportData{
+		Scanner: v1alpha1.Scanner{Name: "Harbor"},
+		Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+	}, nil
+}
+
+func TestScanner_Scan_ImageScannerPlugin(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+			UID:       "1",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "nginx", Image: "nginx:1.16"},
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:    "nginx",
+					ImageID: "docker-pullable://nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514",
+				},
+			},
+		},
+	}
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: "default",
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(pod, serviceAccount).Build()
+	clientset := kubefake.NewSimpleClientset()
+
+	plugin := &imageScannerPlugin{scanner: &fakeImageScanner{}}
+	pluginContext := starboard.NewPluginContext().
+		WithName("Harbor").
+		WithNamespace("starboard").
+		WithClient(c).
+		Get()
+
+	scanner := vulnerabilityreport.NewScanner(clientset, c, plugin, pluginContext, starboard.ConfigData{}, kube.ScannerOpts{})
+	reports, err := scanner.Scan(context.TODO(), kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "default"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"nginx@sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514"}, plugin.scanner.scanned)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "pod-nginx-nginx", reports[0].Name)
+	assert.Equal(t, "default", reports[0].Namespace)
+	assert.Equal(t, "nginx", reports[0].Labels[starboard.LabelContainerName])
+	assert.Equal(t, "Harbor", reports[0].Report.Scanner.Name)
+	assert.Equal(t, 1, reports[0].Report.Summary.CriticalCount)
+	assert.Equal(t, "sha256:2bcabc23b45489fb0885d69a06ba1d648aeda973fae7bb981bafbb884165e514", reports[0].Report.Artifact.Digest)
+	assert.Equal(t, "1.16", reports[0].Report.Artifact.Tag)
+
+	jobs, err := clientset.BatchV1().Jobs("starboard").List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, jobs.Items)
+	var jobList batchv1.JobList
+	require.NoError(t, c.List(context.TODO(), &jobList))
+	assert.Empty(t, jobList.Items)
+}